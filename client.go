@@ -184,6 +184,10 @@ type Client struct {
 	UserAgent string
 	// disable automatic RTCP sender reports.
 	DisableRTCPSenderReports bool
+	// a token used to authenticate with the Bearer method.
+	// It is sent when the server asks for Bearer authentication,
+	// otherwise the credentials of the URL are used.
+	BearerToken string
 	// pointer to a variable that stores received bytes.
 	BytesReceived *uint64
 	// pointer to a variable that stores sent bytes.
@@ -912,17 +916,34 @@ func (c *Client) do(req *base.Request, skipResponse bool, allowFrames bool) (*ba
 	}
 
 	// if required, send request again with authentication
-	if res.StatusCode == base.StatusUnauthorized && req.URL.User != nil && c.sender == nil {
-		pass, _ := req.URL.User.Password()
-		user := req.URL.User.Username()
+	if res.StatusCode == base.StatusUnauthorized && c.sender == nil {
+		// use the Bearer token only if the server asks for it,
+		// otherwise fall back to the credentials of the URL.
+		if c.BearerToken != "" {
+			sender, err := auth.NewBearerSender(res.Header["WWW-Authenticate"], c.BearerToken)
+			if err == nil {
+				c.sender = sender
+
+				return c.do(req, skipResponse, allowFrames)
+			}
 
-		sender, err := auth.NewSender(res.Header["WWW-Authenticate"], user, pass)
-		if err != nil {
-			return nil, fmt.Errorf("unable to setup authentication: %s", err)
+			if req.URL.User == nil {
+				return nil, fmt.Errorf("unable to setup authentication: %s", err)
+			}
 		}
-		c.sender = sender
 
-		return c.do(req, skipResponse, allowFrames)
+		if req.URL.User != nil {
+			pass, _ := req.URL.User.Password()
+			user := req.URL.User.Username()
+
+			sender, err := auth.NewSender(res.Header["WWW-Authenticate"], user, pass)
+			if err != nil {
+				return nil, fmt.Errorf("unable to setup authentication: %s", err)
+			}
+			c.sender = sender
+
+			return c.do(req, skipResponse, allowFrames)
+		}
 	}
 
 	return res, nil
@@ -1053,7 +1074,7 @@ func (c *Client) doDescribe(u *url.URL) (media.Medias, *url.URL, *base.Response,
 	}
 
 	var medias media.Medias
-	err = medias.Unmarshal(sd.MediaDescriptions)
+	err = medias.UnmarshalSessionDescription(&sd)
 	if err != nil {
 		return nil, nil, nil, err
 	}
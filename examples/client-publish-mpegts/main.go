@@ -0,0 +1,64 @@
+package main
+
+import (
+	"log"
+	"net"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2"
+	"github.com/aler9/gortsplib/v2/pkg/media"
+	"github.com/aler9/gortsplib/v2/pkg/mpegts"
+)
+
+// This example shows how to
+// 1. receive a MPEG-TS stream with UDP
+// 2. connect to a RTSP server, announce the medias contained in the MPEG-TS stream
+// 3. convert the content of the MPEG-TS stream into RTP packets and route them to the server
+
+func main() {
+	// open a listener to receive MPEG-TS packets
+	addr, err := net.ResolveUDPAddr("udp", "localhost:9000")
+	if err != nil {
+		panic(err)
+	}
+
+	pc, err := net.ListenUDP("udp", addr)
+	if err != nil {
+		panic(err)
+	}
+	defer pc.Close()
+
+	log.Println("Waiting for a MPEG-TS stream on UDP port 9000 - you can send one with GStreamer:\n" +
+		"gst-launch-1.0 videotestsrc ! video/x-raw,width=1920,height=1080" +
+		" ! x264enc speed-preset=veryfast tune=zerolatency bitrate=600000" +
+		" ! mpegtsmux alignment=7 ! udpsink host=127.0.0.1 port=9000")
+
+	// read the stream until medias are found
+	r, err := mpegts.NewRTPReader(pc)
+	if err != nil {
+		panic(err)
+	}
+	log.Println("stream connected")
+
+	// connect to the server and start recording the medias
+	c := gortsplib.Client{}
+	err = c.StartRecording("rtsp://localhost:8554/mystream", r.Medias())
+	if err != nil {
+		panic(err)
+	}
+	defer c.Close()
+
+	// route RTP packets to the server
+	r.OnPacketRTP(func(medi *media.Media, pkt *rtp.Packet, pts time.Duration) {
+		c.WritePacketRTP(medi, pkt)
+	})
+
+	for {
+		err := r.Read()
+		if err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"io"
+	"log"
+
+	"github.com/aler9/gortsplib/v2"
+	"github.com/aler9/gortsplib/v2/pkg/format"
+	"github.com/aler9/gortsplib/v2/pkg/mpegts"
+	"github.com/aler9/gortsplib/v2/pkg/url"
+	"github.com/pion/rtp"
+)
+
+// This example shows how to
+// 1. connect to a RTSP server
+// 2. check if there's a MPEG-TS media
+// 3. get MPEG-TS packets of that media
+// 4. demux the MPEG-TS stream and print the tracks it contains
+
+func main() {
+	c := gortsplib.Client{}
+
+	// parse URL
+	u, err := url.Parse("rtsp://localhost:8554/mystream")
+	if err != nil {
+		panic(err)
+	}
+
+	// connect to the server
+	err = c.Start(u.Scheme, u.Host)
+	if err != nil {
+		panic(err)
+	}
+	defer c.Close()
+
+	// find published medias
+	medias, baseURL, _, err := c.Describe(u)
+	if err != nil {
+		panic(err)
+	}
+
+	// find the MPEG-TS media and format
+	var forma *format.MPEGTS
+	medi := medias.FindFormat(&forma)
+	if medi == nil {
+		panic("media not found")
+	}
+
+	// create decoder
+	rtpDec := forma.CreateDecoder()
+
+	// MPEG-TS packets are sent to the demuxer through a pipe
+	pr, pw := io.Pipe()
+
+	go func() {
+		r, err := mpegts.NewReader(pr)
+		if err != nil {
+			panic(err)
+		}
+
+		for _, track := range r.Tracks() {
+			log.Printf("found track with PID %d and codec %T\n", track.PID, track.Codec)
+		}
+
+		for {
+			err := r.Read()
+			if err != nil {
+				panic(err)
+			}
+		}
+	}()
+
+	// setup a single media
+	_, err = c.Setup(medi, baseURL, 0, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	// called when a RTP packet arrives
+	c.OnPacketRTP(medi, forma, func(pkt *rtp.Packet) {
+		// extract MPEG-TS packets from RTP packets
+		tsPkts, _, err := rtpDec.Decode(pkt)
+		if err != nil {
+			log.Printf("ERR: %v", err)
+			return
+		}
+
+		for _, tsPkt := range tsPkts {
+			pw.Write(tsPkt)
+		}
+	})
+
+	// start playing
+	_, err = c.Play(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// wait until a fatal error
+	panic(c.Wait())
+}
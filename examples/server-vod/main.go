@@ -0,0 +1,33 @@
+package main
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/aler9/gortsplib/v2"
+)
+
+// This example shows how to
+// 1. create a RTSP server that serves MP4 files of a directory on demand
+// 2. allow multiple clients to read, pause and seek files independently, with TCP or UDP
+
+func main() {
+	// configure the server
+	s := &gortsplib.Server{
+		Handler: &gortsplib.ServerVOD{
+			// rtsp://localhost:8554/myfile.mp4 is mapped into ./myfile.mp4
+			OpenFile: func(path string) (io.ReadSeekCloser, error) {
+				return os.Open(filepath.Join(".", filepath.Clean("/"+path)))
+			},
+		},
+		RTSPAddress:    ":8554",
+		UDPRTPAddress:  ":8000",
+		UDPRTCPAddress: ":8001",
+	}
+
+	// start server and wait until a fatal error
+	log.Printf("server is ready")
+	panic(s.StartAndWait())
+}
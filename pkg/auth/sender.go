@@ -15,6 +15,7 @@ type Sender struct {
 	method headers.AuthMethod
 	realm  string
 	nonce  string
+	token  string
 }
 
 // NewSender allocates a Sender with the WWW-Authenticate header provided by
@@ -81,6 +82,21 @@ func NewSender(v base.HeaderValue, user string, pass string) (*Sender, error) {
 	return nil, fmt.Errorf("no authentication methods available")
 }
 
+// NewBearerSender allocates a Sender with the WWW-Authenticate header provided by
+// a server and a token.
+func NewBearerSender(v base.HeaderValue, token string) (*Sender, error) {
+	for _, vi := range v {
+		if strings.HasPrefix(vi, "Bearer") {
+			return &Sender{
+				method: headers.AuthBearer,
+				token:  token,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("bearer authentication method not available")
+}
+
 // AddAuthorization adds the Authorization header to a Request.
 func (se *Sender) AddAuthorization(req *base.Request) {
 	urStr := req.URL.CloneWithoutCredentials().String()
@@ -94,6 +110,9 @@ func (se *Sender) AddAuthorization(req *base.Request) {
 		h.BasicUser = se.user
 		h.BasicPass = se.pass
 
+	case headers.AuthBearer:
+		h.BearerToken = se.token
+
 	default: // headers.AuthDigest
 		response := md5Hex(md5Hex(se.user+":"+se.realm+":"+se.pass) + ":" +
 			se.nonce + ":" + md5Hex(string(req.Method)+":"+urStr))
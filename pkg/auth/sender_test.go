@@ -51,3 +51,18 @@ func TestSenderErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestBearerSender(t *testing.T) {
+	se, err := NewBearerSender(base.HeaderValue{`Bearer realm="IPCAM"`}, "mytoken")
+	require.NoError(t, err)
+
+	req := &base.Request{
+		Method: base.Describe,
+		URL:    mustParseURL("rtsp://myhost/mypath"),
+	}
+	se.AddAuthorization(req)
+	require.Equal(t, base.HeaderValue{"Bearer mytoken"}, req.Header["Authorization"])
+
+	_, err = NewBearerSender(base.HeaderValue{`Basic realm="IPCAM"`}, "mytoken")
+	require.EqualError(t, err, "bearer authentication method not available")
+}
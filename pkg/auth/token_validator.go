@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+)
+
+// TokenClaims are the claims contained in a token.
+type TokenClaims map[string]interface{}
+
+func (c TokenClaims) checkTime(now time.Time) error {
+	if v, ok := c["exp"]; ok {
+		exp, ok := v.(float64)
+		if !ok {
+			return fmt.Errorf("invalid exp claim")
+		}
+
+		if now.Unix() >= int64(exp) {
+			return fmt.Errorf("token is expired")
+		}
+	}
+
+	if v, ok := c["nbf"]; ok {
+		nbf, ok := v.(float64)
+		if !ok {
+			return fmt.Errorf("invalid nbf claim")
+		}
+
+		if now.Unix() < int64(nbf) {
+			return fmt.Errorf("token is not valid yet")
+		}
+	}
+
+	return nil
+}
+
+// TokenValidator allows to validate JSON Web Tokens (JWT) signed with HMAC
+// (HS256, HS384 or HS512).
+type TokenValidator struct {
+	key []byte
+	now func() time.Time
+}
+
+// NewTokenValidator allocates a TokenValidator.
+// key is the secret shared with the token issuer.
+func NewTokenValidator(key []byte) *TokenValidator {
+	return &TokenValidator{
+		key: key,
+		now: time.Now,
+	}
+}
+
+// ValidateToken validates a token and returns its claims.
+func (va *TokenValidator) ValidateToken(token string) (TokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	byts, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid token header")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	err = json.Unmarshal(byts, &header)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token header")
+	}
+
+	var hashFunc func() hash.Hash
+	switch header.Alg {
+	case "HS256":
+		hashFunc = sha256.New
+
+	case "HS384":
+		hashFunc = sha512.New384
+
+	case "HS512":
+		hashFunc = sha512.New
+
+	default:
+		return nil, fmt.Errorf("unsupported algorithm (%s)", header.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid token signature")
+	}
+
+	h := hmac.New(hashFunc, va.key)
+	h.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, h.Sum(nil)) {
+		return nil, fmt.Errorf("wrong signature")
+	}
+
+	byts, err = base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid token payload")
+	}
+
+	var claims TokenClaims
+	err = json.Unmarshal(byts, &claims)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token payload")
+	}
+
+	err = claims.checkTime(va.now())
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
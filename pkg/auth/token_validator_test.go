@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func signTestToken(key []byte, header string, payload string) string {
+	s := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(payload))
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(s))
+	return s + "." + base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+func TestTokenValidator(t *testing.T) {
+	va := NewTokenValidator([]byte("mykey"))
+	va.now = func() time.Time {
+		return time.Unix(1600000000, 0)
+	}
+
+	claims, err := va.ValidateToken(signTestToken([]byte("mykey"),
+		`{"alg":"HS256","typ":"JWT"}`,
+		`{"sub":"myuser","exp":1600000100,"nbf":1599999900}`))
+	require.NoError(t, err)
+	require.Equal(t, TokenClaims{
+		"sub": "myuser",
+		"exp": float64(1600000100),
+		"nbf": float64(1599999900),
+	}, claims)
+}
+
+func TestTokenValidatorErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name  string
+		token string
+		err   string
+	}{
+		{
+			"invalid",
+			"abc",
+			"invalid token",
+		},
+		{
+			"unsupported algorithm",
+			signTestToken([]byte("mykey"), `{"alg":"none"}`, `{}`),
+			"unsupported algorithm (none)",
+		},
+		{
+			"wrong signature",
+			signTestToken([]byte("otherkey"), `{"alg":"HS256"}`, `{}`),
+			"wrong signature",
+		},
+		{
+			"expired",
+			signTestToken([]byte("mykey"), `{"alg":"HS256"}`, `{"exp":1599999999}`),
+			"token is expired",
+		},
+		{
+			"not valid yet",
+			signTestToken([]byte("mykey"), `{"alg":"HS256"}`, `{"nbf":1600000001}`),
+			"token is not valid yet",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			va := NewTokenValidator([]byte("mykey"))
+			va.now = func() time.Time {
+				return time.Unix(1600000000, 0)
+			}
+			_, err := va.ValidateToken(ca.token)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
@@ -26,7 +26,7 @@ func TestBodyRead(t *testing.T) {
 	for _, ca := range casesBody {
 		t.Run(ca.name, func(t *testing.T) {
 			var p body
-			err := p.read(ca.h, bufio.NewReader(bytes.NewReader(ca.byts)))
+			err := p.read(ca.h, bufio.NewReader(bytes.NewReader(ca.byts)), rtspMaxContentLength)
 			require.NoError(t, err)
 			require.Equal(t, ca.byts, []byte(p))
 		})
@@ -67,7 +67,7 @@ func TestBodyReadErrors(t *testing.T) {
 	} {
 		t.Run(ca.name, func(t *testing.T) {
 			var p body
-			err := p.read(ca.h, bufio.NewReader(bytes.NewReader(ca.byts)))
+			err := p.read(ca.h, bufio.NewReader(bytes.NewReader(ca.byts)), rtspMaxContentLength)
 			require.EqualError(t, err, ca.err)
 		})
 	}
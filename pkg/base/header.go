@@ -9,7 +9,6 @@ import (
 )
 
 const (
-	headerMaxEntryCount  = 255
 	headerMaxKeyLength   = 512
 	headerMaxValueLength = 2048
 )
@@ -34,9 +33,10 @@ type HeaderValue []string
 // Header is a RTSP reader, present in both Requests and Responses.
 type Header map[string]HeaderValue
 
-func (h *Header) read(rb *bufio.Reader) error {
+func (h *Header) read(rb *bufio.Reader, limits ReadLimits) error {
 	*h = make(Header)
 	count := 0
+	size := 0
 
 	for {
 		byt, err := rb.ReadByte()
@@ -53,8 +53,9 @@ func (h *Header) read(rb *bufio.Reader) error {
 			break
 		}
 
-		if count >= headerMaxEntryCount {
-			return fmt.Errorf("headers count exceeds %d", headerMaxEntryCount)
+		if count >= limits.MaxHeaderCount {
+			return newErrReadLimitExceeded(StatusRequestHeaderFieldsTooLarge,
+				"headers count exceeds %d", limits.MaxHeaderCount)
 		}
 
 		key := string([]byte{byt})
@@ -90,6 +91,12 @@ func (h *Header) read(rb *bufio.Reader) error {
 			return err
 		}
 
+		size += len(key) + len(val) + 4
+		if limits.MaxHeaderSize != 0 && size > limits.MaxHeaderSize {
+			return newErrReadLimitExceeded(StatusRequestHeaderFieldsTooLarge,
+				"headers size exceeds %d", limits.MaxHeaderSize)
+		}
+
 		(*h)[key] = append((*h)[key], val)
 		count++
 	}
@@ -109,7 +109,7 @@ func TestHeaderRead(t *testing.T) {
 	for _, ca := range cases {
 		t.Run(ca.name, func(t *testing.T) {
 			h := make(Header)
-			err := h.read(bufio.NewReader(bytes.NewBuffer(ca.dec)))
+			err := h.read(bufio.NewReader(bytes.NewBuffer(ca.dec)), ReadLimits{}.withDefaults())
 			require.NoError(t, err)
 			require.Equal(t, ca.header, h)
 		})
@@ -167,7 +167,7 @@ func TestHeaderReadErrors(t *testing.T) {
 	} {
 		t.Run(ca.name, func(t *testing.T) {
 			h := make(Header)
-			err := h.read(bufio.NewReader(bytes.NewBuffer(ca.dec)))
+			err := h.read(bufio.NewReader(bytes.NewBuffer(ca.dec)), ReadLimits{}.withDefaults())
 			require.EqualError(t, err, ca.err)
 		})
 	}
@@ -0,0 +1,53 @@
+package base
+
+import (
+	"fmt"
+)
+
+const (
+	headerMaxEntryCount  = 255
+	rtspMaxContentLength = 128 * 1024
+)
+
+// ReadLimits contains the limits applied when reading requests and responses.
+type ReadLimits struct {
+	// maximum number of header entries.
+	// It defaults to 255.
+	MaxHeaderCount int
+	// maximum size of the header, in bytes.
+	// It defaults to 0, that means that only single entries are limited.
+	MaxHeaderSize int
+	// maximum size of the body, in bytes.
+	// It defaults to 128 KiB.
+	MaxBodySize int
+}
+
+func (l ReadLimits) withDefaults() ReadLimits {
+	if l.MaxHeaderCount == 0 {
+		l.MaxHeaderCount = headerMaxEntryCount
+	}
+	if l.MaxBodySize == 0 {
+		l.MaxBodySize = rtspMaxContentLength
+	}
+	return l
+}
+
+// ErrReadLimitExceeded is returned when a request or response exceeds a limit.
+type ErrReadLimitExceeded struct {
+	// status code that is returned to the author of a request
+	// that exceeds the limit.
+	StatusCode StatusCode
+	Msg        string
+}
+
+// Error implements the error interface.
+func (e ErrReadLimitExceeded) Error() string {
+	return e.Msg
+}
+
+func newErrReadLimitExceeded(statusCode StatusCode, format string, args ...interface{}) error {
+	return ErrReadLimitExceeded{
+		StatusCode: statusCode,
+		Msg:        fmt.Sprintf(format, args...),
+	}
+}
@@ -28,6 +28,7 @@ const (
 	Pause        Method = "PAUSE"
 	Play         Method = "PLAY"
 	Record       Method = "RECORD"
+	Redirect     Method = "REDIRECT"
 	Setup        Method = "SETUP"
 	SetParameter Method = "SET_PARAMETER"
 	Teardown     Method = "TEARDOWN"
@@ -50,6 +51,13 @@ type Request struct {
 
 // Read reads a request.
 func (req *Request) Read(rb *bufio.Reader) error {
+	return req.ReadLimited(rb, ReadLimits{})
+}
+
+// ReadLimited reads a request and applies the given limits.
+func (req *Request) ReadLimited(rb *bufio.Reader, limits ReadLimits) error {
+	limits = limits.withDefaults()
+
 	byts, err := readBytesLimited(rb, ' ', requestMaxMethodLength)
 	if err != nil {
 		return err
@@ -87,12 +95,12 @@ func (req *Request) Read(rb *bufio.Reader) error {
 		return err
 	}
 
-	err = req.Header.read(rb)
+	err = req.Header.read(rb, limits)
 	if err != nil {
 		return err
 	}
 
-	err = (*body)(&req.Body).read(req.Header, rb)
+	err = (*body)(&req.Body).read(req.Header, rb, limits.MaxBodySize)
 	if err != nil {
 		return err
 	}
@@ -228,6 +228,48 @@ func TestRequestReadErrors(t *testing.T) {
 	}
 }
 
+func TestRequestReadLimitedErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name       string
+		byts       []byte
+		limits     ReadLimits
+		err        string
+		statusCode StatusCode
+	}{
+		{
+			"header count",
+			[]byte("GET rtsp://testing123 RTSP/1.0\r\nA: 1\r\nB: 2\r\n\r\n"),
+			ReadLimits{MaxHeaderCount: 1},
+			"headers count exceeds 1",
+			StatusRequestHeaderFieldsTooLarge,
+		},
+		{
+			"header size",
+			[]byte("GET rtsp://testing123 RTSP/1.0\r\nA: 1234\r\nB: 5678\r\n\r\n"),
+			ReadLimits{MaxHeaderSize: 12},
+			"headers size exceeds 12",
+			StatusRequestHeaderFieldsTooLarge,
+		},
+		{
+			"body size",
+			[]byte("GET rtsp://testing123 RTSP/1.0\r\nContent-Length: 4\r\n\r\n1234"),
+			ReadLimits{MaxBodySize: 3},
+			"Content-Length exceeds 3 (it's 4)",
+			StatusRequestEntityTooLarge,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var req Request
+			err := req.ReadLimited(bufio.NewReader(bytes.NewBuffer(ca.byts)), ca.limits)
+			require.EqualError(t, err, ca.err)
+
+			var lerr ErrReadLimitExceeded
+			require.ErrorAs(t, err, &lerr)
+			require.Equal(t, ca.statusCode, lerr.StatusCode)
+		})
+	}
+}
+
 func TestRequestMarshal(t *testing.T) {
 	for _, ca := range casesRequest {
 		t.Run(ca.name, func(t *testing.T) {
@@ -32,6 +32,7 @@ const (
 	StatusRequestEntityTooLarge              StatusCode = 413
 	StatusRequestURITooLong                  StatusCode = 414
 	StatusUnsupportedMediaType               StatusCode = 415
+	StatusRequestHeaderFieldsTooLarge        StatusCode = 431
 	StatusParameterNotUnderstood             StatusCode = 451
 	StatusNotEnoughBandwidth                 StatusCode = 453
 	StatusSessionNotFound                    StatusCode = 454
@@ -88,6 +89,7 @@ var statusMessages = map[StatusCode]string{
 	StatusRequestEntityTooLarge:              "Request Entity Too Large",
 	StatusRequestURITooLong:                  "Request URI Too Long",
 	StatusUnsupportedMediaType:               "Unsupported Media Type",
+	StatusRequestHeaderFieldsTooLarge:        "Request Header Fields Too Large",
 	StatusParameterNotUnderstood:             "Parameter Not Understood",
 	StatusNotEnoughBandwidth:                 "Not Enough Bandwidth",
 	StatusSessionNotFound:                    "Session Not Found",
@@ -134,6 +136,13 @@ type Response struct {
 
 // Read reads a response.
 func (res *Response) Read(rb *bufio.Reader) error {
+	return res.ReadLimited(rb, ReadLimits{})
+}
+
+// ReadLimited reads a response and applies the given limits.
+func (res *Response) ReadLimited(rb *bufio.Reader, limits ReadLimits) error {
+	limits = limits.withDefaults()
+
 	byts, err := readBytesLimited(rb, ' ', 255)
 	if err != nil {
 		return err
@@ -171,12 +180,12 @@ func (res *Response) Read(rb *bufio.Reader) error {
 		return err
 	}
 
-	err = res.Header.read(rb)
+	err = res.Header.read(rb, limits)
 	if err != nil {
 		return err
 	}
 
-	err = (*body)(&res.Body).read(res.Header, rb)
+	err = (*body)(&res.Body).read(res.Header, rb, limits.MaxBodySize)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,19 @@
+// Package ac3 contains utilities to work with the AC-3 and E-AC-3 codecs.
+package ac3
+
+const (
+	syncWord = 0x0B77
+)
+
+// number of full-bandwidth channels of every audio coding mode.
+var acmodChannelCounts = []int{
+	2, 1, 2, 3, 3, 4, 4, 5,
+}
+
+func channelCount(acmod uint8, lfeOn bool) int {
+	n := acmodChannelCounts[acmod]
+	if lfeOn {
+		n++
+	}
+	return n
+}
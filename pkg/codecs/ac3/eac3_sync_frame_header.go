@@ -0,0 +1,85 @@
+package ac3
+
+import (
+	"fmt"
+)
+
+var eac3ReducedSampleRates = []int{24000, 22050, 16000}
+
+var eac3BlockCounts = []int{1, 2, 3, 6}
+
+// EAC3SyncFrameHeader is the header of an E-AC-3 syncframe,
+// that is made of the synchronization information and of the first fields
+// of the bit stream information.
+// Specification: ATSC A/52, E.1.2.1 and E.1.2.2
+type EAC3SyncFrameHeader struct {
+	StreamType  uint8
+	SubstreamID uint8
+	// syncframe size in 16-bit words, minus one.
+	FrameSize  uint16
+	SampleRate int
+	BlockCount int
+	ACMod      uint8
+	LFEOn      bool
+	BSID       uint8
+}
+
+// Unmarshal decodes an EAC3SyncFrameHeader.
+func (h *EAC3SyncFrameHeader) Unmarshal(buf []byte) error {
+	if len(buf) < 6 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	sw := uint16(buf[0])<<8 | uint16(buf[1])
+	if sw != syncWord {
+		return fmt.Errorf("invalid sync word: %x", sw)
+	}
+
+	h.StreamType = buf[2] >> 6
+	if h.StreamType == 3 {
+		return fmt.Errorf("invalid strmtyp (%d)", h.StreamType)
+	}
+
+	h.SubstreamID = (buf[2] >> 3) & 0x07
+	h.FrameSize = uint16(buf[2]&0x07)<<8 | uint16(buf[3])
+
+	fscod := buf[4] >> 6
+
+	if fscod == 3 {
+		fscod2 := (buf[4] >> 4) & 0x03
+		if fscod2 == 3 {
+			return fmt.Errorf("invalid fscod2 (%d)", fscod2)
+		}
+
+		h.SampleRate = eac3ReducedSampleRates[fscod2]
+		h.BlockCount = 6
+	} else {
+		h.SampleRate = sampleRates[fscod]
+		h.BlockCount = eac3BlockCounts[(buf[4]>>4)&0x03]
+	}
+
+	h.ACMod = (buf[4] >> 1) & 0x07
+	h.LFEOn = (buf[4] & 0x01) != 0
+
+	h.BSID = buf[5] >> 3
+	if h.BSID <= 10 || h.BSID > 16 {
+		return fmt.Errorf("unsupported bsid (%d)", h.BSID)
+	}
+
+	return nil
+}
+
+// FrameLen returns the length of the syncframe.
+func (h EAC3SyncFrameHeader) FrameLen() int {
+	return (int(h.FrameSize) + 1) * 2
+}
+
+// ChannelCount returns the channel count.
+func (h EAC3SyncFrameHeader) ChannelCount() int {
+	return channelCount(h.ACMod, h.LFEOn)
+}
+
+// SampleCount returns the number of samples per channel contained in the syncframe.
+func (h EAC3SyncFrameHeader) SampleCount() int {
+	return h.BlockCount * 256
+}
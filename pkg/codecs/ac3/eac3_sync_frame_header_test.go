@@ -0,0 +1,109 @@
+package ac3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEAC3SyncFrameHeaderUnmarshal(t *testing.T) {
+	for _, ca := range []struct {
+		name         string
+		byts         []byte
+		h            EAC3SyncFrameHeader
+		frameLen     int
+		channelCount int
+		sampleCount  int
+	}{
+		{
+			"48khz stereo",
+			[]byte{0x0b, 0x77, 0x01, 0x7f, 0x34, 0x80},
+			EAC3SyncFrameHeader{
+				FrameSize:  383,
+				SampleRate: 48000,
+				BlockCount: 6,
+				ACMod:      2,
+				BSID:       16,
+			},
+			768,
+			2,
+			1536,
+		},
+		{
+			"24khz 5.1 dependent substream",
+			[]byte{0x0b, 0x77, 0x48, 0xff, 0xcf, 0x80},
+			EAC3SyncFrameHeader{
+				StreamType:  1,
+				SubstreamID: 1,
+				FrameSize:   255,
+				SampleRate:  24000,
+				BlockCount:  6,
+				ACMod:       7,
+				LFEOn:       true,
+				BSID:        16,
+			},
+			512,
+			6,
+			1536,
+		},
+		{
+			"48khz mono 1 block",
+			[]byte{0x0b, 0x77, 0x00, 0x3f, 0x02, 0x80},
+			EAC3SyncFrameHeader{
+				FrameSize:  63,
+				SampleRate: 48000,
+				BlockCount: 1,
+				ACMod:      1,
+				BSID:       16,
+			},
+			128,
+			1,
+			256,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var h EAC3SyncFrameHeader
+			err := h.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.h, h)
+			require.Equal(t, ca.frameLen, h.FrameLen())
+			require.Equal(t, ca.channelCount, h.ChannelCount())
+			require.Equal(t, ca.sampleCount, h.SampleCount())
+		})
+	}
+}
+
+func TestEAC3SyncFrameHeaderUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"not enough bytes",
+			[]byte{0x0b, 0x77},
+			"not enough bytes",
+		},
+		{
+			"invalid sync word",
+			[]byte{0x0b, 0x78, 0x01, 0x7f, 0x34, 0x80},
+			"invalid sync word: b78",
+		},
+		{
+			"invalid fscod2",
+			[]byte{0x0b, 0x77, 0x01, 0x7f, 0xf4, 0x80},
+			"invalid fscod2 (3)",
+		},
+		{
+			"ac-3",
+			[]byte{0x0b, 0x77, 0x01, 0x7f, 0x34, 0x40},
+			"unsupported bsid (8)",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var h EAC3SyncFrameHeader
+			err := h.Unmarshal(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
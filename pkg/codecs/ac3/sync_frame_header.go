@@ -0,0 +1,113 @@
+package ac3
+
+import (
+	"fmt"
+
+	"github.com/aler9/gortsplib/v2/pkg/bits"
+)
+
+var sampleRates = []int{48000, 44100, 32000}
+
+var bitrates = []int{
+	32000, 40000, 48000, 56000, 64000, 80000, 96000, 112000, 128000, 160000,
+	192000, 224000, 256000, 320000, 384000, 448000, 512000, 576000, 640000,
+}
+
+// SyncFrameHeader is the header of an AC-3 syncframe,
+// that is made of the synchronization information and of the first fields
+// of the bit stream information.
+// Specification: ATSC A/52, 5.3.1 and 5.3.2
+type SyncFrameHeader struct {
+	SampleRate    int
+	FrameSizeCode uint8
+	BSID          uint8
+	BSMod         uint8
+	ACMod         uint8
+	LFEOn         bool
+}
+
+// Unmarshal decodes a SyncFrameHeader.
+func (h *SyncFrameHeader) Unmarshal(buf []byte) error {
+	if len(buf) < 7 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	sw := uint16(buf[0])<<8 | uint16(buf[1])
+	if sw != syncWord {
+		return fmt.Errorf("invalid sync word: %x", sw)
+	}
+
+	fscod := buf[4] >> 6
+	if fscod >= 3 {
+		return fmt.Errorf("invalid fscod (%d)", fscod)
+	}
+	h.SampleRate = sampleRates[fscod]
+
+	h.FrameSizeCode = buf[4] & 0x3F
+	if h.FrameSizeCode > 37 {
+		return fmt.Errorf("invalid frmsizecod (%d)", h.FrameSizeCode)
+	}
+
+	h.BSID = buf[5] >> 3
+	if h.BSID > 8 {
+		return fmt.Errorf("unsupported bsid (%d)", h.BSID)
+	}
+
+	h.BSMod = buf[5] & 0x07
+
+	pos := 0
+	tmp := buf[6:]
+
+	h.ACMod = uint8(bits.ReadBitsUnsafe(tmp, &pos, 3))
+
+	if (h.ACMod&0x01) != 0 && h.ACMod != 1 {
+		pos += 2 // cmixlev
+	}
+
+	if (h.ACMod & 0x04) != 0 {
+		pos += 2 // surmixlev
+	}
+
+	if h.ACMod == 2 {
+		pos += 2 // dsurmod
+	}
+
+	lfeOn, err := bits.ReadFlag(tmp, &pos)
+	if err != nil {
+		return err
+	}
+	h.LFEOn = lfeOn
+
+	return nil
+}
+
+// Bitrate returns the bitrate.
+func (h SyncFrameHeader) Bitrate() int {
+	return bitrates[h.FrameSizeCode/2]
+}
+
+// FrameLen returns the length of the syncframe.
+func (h SyncFrameHeader) FrameLen() int {
+	br := h.Bitrate() / 1000
+
+	switch h.SampleRate {
+	case 48000:
+		return br * 4
+
+	case 44100:
+		return (br*1536*1000/(44100*16) + int(h.FrameSizeCode&0x01)) * 2
+
+	default: // 32000
+		return br * 6
+	}
+}
+
+// ChannelCount returns the channel count.
+func (h SyncFrameHeader) ChannelCount() int {
+	return channelCount(h.ACMod, h.LFEOn)
+}
+
+// SampleCount returns the number of samples per channel contained in the syncframe.
+func (h SyncFrameHeader) SampleCount() int {
+	return 1536
+}
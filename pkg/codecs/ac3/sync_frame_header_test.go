@@ -0,0 +1,123 @@
+package ac3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncFrameHeaderUnmarshal(t *testing.T) {
+	for _, ca := range []struct {
+		name         string
+		byts         []byte
+		h            SyncFrameHeader
+		bitrate      int
+		frameLen     int
+		channelCount int
+	}{
+		{
+			"48khz 5.1",
+			[]byte{0x0b, 0x77, 0x00, 0x00, 0x0e, 0x40, 0xe1},
+			SyncFrameHeader{
+				SampleRate:    48000,
+				FrameSizeCode: 14,
+				BSID:          8,
+				ACMod:         7,
+				LFEOn:         true,
+			},
+			112000,
+			448,
+			6,
+		},
+		{
+			"48khz stereo",
+			[]byte{0x0b, 0x77, 0x00, 0x00, 0x14, 0x40, 0x40},
+			SyncFrameHeader{
+				SampleRate:    48000,
+				FrameSizeCode: 20,
+				BSID:          8,
+				ACMod:         2,
+			},
+			192000,
+			768,
+			2,
+		},
+		{
+			"44.1khz stereo",
+			[]byte{0x0b, 0x77, 0x00, 0x00, 0x55, 0x40, 0x40},
+			SyncFrameHeader{
+				SampleRate:    44100,
+				FrameSizeCode: 21,
+				BSID:          8,
+				ACMod:         2,
+			},
+			192000,
+			836,
+			2,
+		},
+		{
+			"32khz mono",
+			[]byte{0x0b, 0x77, 0x00, 0x00, 0x88, 0x40, 0x20},
+			SyncFrameHeader{
+				SampleRate:    32000,
+				FrameSizeCode: 8,
+				BSID:          8,
+				ACMod:         1,
+			},
+			64000,
+			384,
+			1,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var h SyncFrameHeader
+			err := h.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.h, h)
+			require.Equal(t, ca.bitrate, h.Bitrate())
+			require.Equal(t, ca.frameLen, h.FrameLen())
+			require.Equal(t, ca.channelCount, h.ChannelCount())
+			require.Equal(t, 1536, h.SampleCount())
+		})
+	}
+}
+
+func TestSyncFrameHeaderUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"not enough bytes",
+			[]byte{0x0b, 0x77},
+			"not enough bytes",
+		},
+		{
+			"invalid sync word",
+			[]byte{0x0b, 0x78, 0x00, 0x00, 0x14, 0x40, 0x40},
+			"invalid sync word: b78",
+		},
+		{
+			"invalid fscod",
+			[]byte{0x0b, 0x77, 0x00, 0x00, 0xd4, 0x40, 0x40},
+			"invalid fscod (3)",
+		},
+		{
+			"invalid frmsizecod",
+			[]byte{0x0b, 0x77, 0x00, 0x00, 0x3f, 0x40, 0x40},
+			"invalid frmsizecod (63)",
+		},
+		{
+			"e-ac-3",
+			[]byte{0x0b, 0x77, 0x00, 0x00, 0x14, 0x80, 0x40},
+			"unsupported bsid (16)",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var h SyncFrameHeader
+			err := h.Unmarshal(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
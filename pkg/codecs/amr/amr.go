@@ -0,0 +1,70 @@
+// Package amr contains utilities to work with the AMR and AMR-WB codecs.
+package amr
+
+import (
+	"fmt"
+)
+
+const (
+	// SamplesPerFrame is the number of samples contained in an AMR frame.
+	SamplesPerFrame = 160
+
+	// SamplesPerFrameWB is the number of samples contained in an AMR-WB frame.
+	SamplesPerFrameWB = 320
+
+	// FrameTypeNoData is the frame type of frames that don't contain speech data.
+	FrameTypeNoData = 15
+)
+
+// MagicNumber is the header of files that contain AMR frames in storage format.
+// Specification: RFC4867, 5.1
+var MagicNumber = []byte("#!AMR\n")
+
+// MagicNumberWB is the header of files that contain AMR-WB frames in storage format.
+// Specification: RFC4867, 5.1
+var MagicNumberWB = []byte("#!AMR-WB\n")
+
+// number of speech bits of each frame type.
+// Specification: 3GPP TS 26.101, Table 1a and 3GPP TS 26.201, Table 2
+var (
+	frameBits = [16]int{
+		95, 103, 118, 134, 148, 159, 204, 244,
+		39, 43, 38, 37, -1, -1, -1, 0,
+	}
+	frameBitsWB = [16]int{
+		132, 177, 253, 285, 317, 365, 397, 461,
+		477, 40, -1, -1, -1, -1, 0, 0,
+	}
+)
+
+// FrameBits returns the number of speech bits contained in a frame with the given type.
+func FrameBits(wideband bool, frameType uint8) (int, error) {
+	if frameType > 15 {
+		return 0, fmt.Errorf("invalid frame type (%d)", frameType)
+	}
+
+	var n int
+	if wideband {
+		n = frameBitsWB[frameType]
+	} else {
+		n = frameBits[frameType]
+	}
+
+	if n < 0 {
+		return 0, fmt.Errorf("reserved frame type (%d)", frameType)
+	}
+
+	return n, nil
+}
+
+// StorageFrameSize returns the size of a frame in storage format,
+// that is made of a one-byte header followed by speech bits padded to the byte boundary.
+// Specification: RFC4867, 5.3
+func StorageFrameSize(wideband bool, frameType uint8) (int, error) {
+	n, err := FrameBits(wideband, frameType)
+	if err != nil {
+		return 0, err
+	}
+
+	return 1 + (n+7)/8, nil
+}
@@ -0,0 +1,74 @@
+package amr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameBits(t *testing.T) {
+	for _, ca := range []struct {
+		name      string
+		wideband  bool
+		frameType uint8
+		bits      int
+		size      int
+	}{
+		{
+			"amr 12.2",
+			false,
+			7,
+			244,
+			32,
+		},
+		{
+			"amr sid",
+			false,
+			8,
+			39,
+			6,
+		},
+		{
+			"amr no data",
+			false,
+			15,
+			0,
+			1,
+		},
+		{
+			"amr-wb 23.85",
+			true,
+			8,
+			477,
+			61,
+		},
+		{
+			"amr-wb speech lost",
+			true,
+			14,
+			0,
+			1,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			bits, err := FrameBits(ca.wideband, ca.frameType)
+			require.NoError(t, err)
+			require.Equal(t, ca.bits, bits)
+
+			size, err := StorageFrameSize(ca.wideband, ca.frameType)
+			require.NoError(t, err)
+			require.Equal(t, ca.size, size)
+		})
+	}
+}
+
+func TestFrameBitsErrors(t *testing.T) {
+	_, err := FrameBits(false, 12)
+	require.EqualError(t, err, "reserved frame type (12)")
+
+	_, err = FrameBits(true, 10)
+	require.EqualError(t, err, "reserved frame type (10)")
+
+	_, err = FrameBits(true, 16)
+	require.EqualError(t, err, "invalid frame type (16)")
+}
@@ -0,0 +1,90 @@
+package av1
+
+import (
+	"fmt"
+)
+
+// BitstreamUnmarshal extracts OBUs from a temporal unit in the low overhead bitstream format,
+// in which every OBU has a size field.
+// The size field of returned OBUs is removed, as required by RTP/AV1.
+// Specification: AV1 Bitstream & Decoding Process, 5.2
+func BitstreamUnmarshal(bs []byte) ([][]byte, error) {
+	var ret [][]byte
+
+	for len(bs) > 0 {
+		var h OBUHeader
+		err := h.Unmarshal(bs)
+		if err != nil {
+			return nil, err
+		}
+
+		if !h.HasSize {
+			return nil, fmt.Errorf("OBU size not present")
+		}
+
+		hs := h.Size()
+
+		size, n, err := LEB128Unmarshal(bs[hs:])
+		if err != nil {
+			return nil, err
+		}
+
+		if size > uint(len(bs)-hs-n) {
+			return nil, fmt.Errorf("not enough bytes")
+		}
+
+		obu := make([]byte, hs+int(size))
+		copy(obu, bs[:hs])
+		obu[0] &^= 0x02 // obu_has_size_field
+		copy(obu[hs:], bs[hs+n:hs+n+int(size)])
+		ret = append(ret, obu)
+
+		if len(ret) > MaxOBUsPerTemporalUnit {
+			return nil, fmt.Errorf("OBU count exceeds maximum allowed (%d)", MaxOBUsPerTemporalUnit)
+		}
+
+		bs = bs[hs+n+int(size):]
+	}
+
+	return ret, nil
+}
+
+// BitstreamMarshal encodes OBUs without size field into a temporal unit
+// in the low overhead bitstream format.
+// Specification: AV1 Bitstream & Decoding Process, 5.2
+func BitstreamMarshal(obus [][]byte) ([]byte, error) {
+	n := 0
+
+	for _, obu := range obus {
+		var h OBUHeader
+		err := h.Unmarshal(obu)
+		if err != nil {
+			return nil, err
+		}
+
+		if h.HasSize {
+			return nil, fmt.Errorf("OBU size already present")
+		}
+
+		n += len(obu) + LEB128MarshalSize(uint(len(obu)-h.Size()))
+	}
+
+	buf := make([]byte, n)
+	pos := 0
+
+	for _, obu := range obus {
+		hs := 1
+		if ((obu[0] >> 2) & 0x01) != 0 {
+			hs = 2
+		}
+
+		copy(buf[pos:], obu[:hs])
+		buf[pos] |= 0x02 // obu_has_size_field
+		pos += hs
+
+		pos += LEB128MarshalTo(uint(len(obu)-hs), buf[pos:])
+		pos += copy(buf[pos:], obu[hs:])
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,81 @@
+package av1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesBitstream = []struct {
+	name string
+	enc  []byte
+	dec  [][]byte
+}{
+	{
+		"standard",
+		[]byte{
+			0x12, 0x00, 0x0a, 0x0b, 0x00, 0x00, 0x00, 0x42,
+			0xab, 0xbf, 0xc3, 0x71, 0xab, 0xe6, 0x01, 0x32,
+			0x03, 0x10, 0x01, 0x02,
+		},
+		[][]byte{
+			{0x10},
+			{
+				0x08, 0x00, 0x00, 0x00, 0x42, 0xab, 0xbf, 0xc3,
+				0x71, 0xab, 0xe6, 0x01,
+			},
+			{0x30, 0x10, 0x01, 0x02},
+		},
+	},
+	{
+		"extension",
+		[]byte{0x36, 0x68, 0x02, 0x01, 0x02},
+		[][]byte{
+			{0x34, 0x68, 0x01, 0x02},
+		},
+	},
+}
+
+func TestBitstreamUnmarshal(t *testing.T) {
+	for _, ca := range casesBitstream {
+		t.Run(ca.name, func(t *testing.T) {
+			dec, err := BitstreamUnmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestBitstreamMarshal(t *testing.T) {
+	for _, ca := range casesBitstream {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := BitstreamMarshal(ca.dec)
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func TestBitstreamUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"missing size",
+			[]byte{0x30, 0x01},
+			"OBU size not present",
+		},
+		{
+			"invalid size",
+			[]byte{0x32, 0x05, 0x01},
+			"not enough bytes",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := BitstreamUnmarshal(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package av1
+
+// ContainsKeyFrame checks whether OBUs of a temporal unit contain a key frame,
+// that is always preceded by a sequence header.
+func ContainsKeyFrame(obus [][]byte) bool {
+	for _, obu := range obus {
+		if len(obu) == 0 {
+			continue
+		}
+
+		typ := OBUType((obu[0] >> 3) & 0x0F)
+		if typ == OBUTypeSequenceHeader {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+package av1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainsKeyFrame(t *testing.T) {
+	require.Equal(t, true, ContainsKeyFrame([][]byte{
+		{0x08, 0x00, 0x00, 0x00, 0x42, 0xab, 0xbf, 0xc3, 0x71, 0xab, 0xe6, 0x01},
+		{0x30, 0x10, 0x01, 0x02},
+	}))
+	require.Equal(t, false, ContainsKeyFrame([][]byte{
+		{0x30, 0x10, 0x01, 0x02},
+	}))
+}
@@ -0,0 +1,60 @@
+package av1
+
+import (
+	"fmt"
+)
+
+// LEB128Unmarshal decodes an unsigned integer from the LEB128 format.
+// It returns the value and the number of consumed bytes.
+// Specification: AV1 Bitstream & Decoding Process, 4.10.5
+func LEB128Unmarshal(buf []byte) (uint, int, error) {
+	v := uint(0)
+
+	for i := 0; i < 8; i++ {
+		if len(buf) <= i {
+			return 0, 0, fmt.Errorf("not enough bytes")
+		}
+
+		b := buf[i]
+
+		v |= (uint(b&0x7F) << (i * 7))
+
+		if (b & 0x80) == 0 {
+			return v, i + 1, nil
+		}
+	}
+
+	return 0, 0, fmt.Errorf("LEB128 value is too long")
+}
+
+// LEB128MarshalSize returns the size of an unsigned integer in the LEB128 format.
+func LEB128MarshalSize(v uint) int {
+	n := 1
+
+	for v >= 0x80 {
+		v >>= 7
+		n++
+	}
+
+	return n
+}
+
+// LEB128MarshalTo encodes an unsigned integer with the LEB128 format.
+// It returns the number of written bytes.
+func LEB128MarshalTo(v uint, buf []byte) int {
+	n := 0
+
+	for {
+		b := byte(v & 0x7F)
+		v >>= 7
+
+		if v != 0 {
+			buf[n] = b | 0x80
+			n++
+		} else {
+			buf[n] = b
+			n++
+			return n
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package av1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOBUHeaderUnmarshal(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		h    OBUHeader
+	}{
+		{
+			"sequence header",
+			[]byte{0x0a, 0x0b},
+			OBUHeader{
+				Type:    OBUTypeSequenceHeader,
+				HasSize: true,
+			},
+		},
+		{
+			"frame with extension",
+			[]byte{0x34, 0x68},
+			OBUHeader{
+				Type:         OBUTypeFrame,
+				HasExtension: true,
+				TemporalID:   3,
+				SpatialID:    1,
+			},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var h OBUHeader
+			err := h.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.h, h)
+		})
+	}
+}
+
+func TestOBUHeaderUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"not enough bytes",
+		},
+		{
+			"forbidden bit",
+			[]byte{0x8a},
+			"forbidden bit is set",
+		},
+		{
+			"missing extension",
+			[]byte{0x34},
+			"not enough bytes",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var h OBUHeader
+			err := h.Unmarshal(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
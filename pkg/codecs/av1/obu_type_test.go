@@ -0,0 +1,13 @@
+package av1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOBUType(t *testing.T) {
+	require.NotEqual(t, true, strings.HasPrefix(OBUType(1).String(), "unknown"))
+	require.Equal(t, true, strings.HasPrefix(OBUType(10).String(), "unknown"))
+}
@@ -0,0 +1,283 @@
+package av1
+
+import (
+	"fmt"
+
+	"github.com/aler9/gortsplib/v2/pkg/bits"
+)
+
+// SequenceHeaderTimingInfo is the timing info of a sequence header.
+type SequenceHeaderTimingInfo struct {
+	NumUnitsInDisplayTick    uint32
+	TimeScale                uint32
+	EqualPictureInterval     bool
+	NumTicksPerPictureMinus1 uint32
+}
+
+// SequenceHeaderDecoderModelInfo is the decoder model info of a sequence header.
+type SequenceHeaderDecoderModelInfo struct {
+	BufferDelayLengthMinus1           uint8
+	NumUnitsInDecodingTick            uint32
+	BufferRemovalTimeLengthMinus1     uint8
+	FramePresentationTimeLengthMinus1 uint8
+}
+
+// SequenceHeaderOperatingPoint is an operating point of a sequence header.
+type SequenceHeaderOperatingPoint struct {
+	IDC                        uint16
+	SeqLevelIdx                uint8
+	SeqTier                    bool
+	DecoderModelPresent        bool
+	DecoderBufferDelay         uint32
+	EncoderBufferDelay         uint32
+	LowDelayModeFlag           bool
+	InitialDisplayDelayPresent bool
+	InitialDisplayDelayMinus1  uint8
+}
+
+// SequenceHeader is a AV1 sequence header.
+// Only the fields up to the maximum frame size are decoded.
+// Specification: AV1 Bitstream & Decoding Process, 5.5
+type SequenceHeader struct {
+	SeqProfile                uint8
+	StillPicture              bool
+	ReducedStillPictureHeader bool
+
+	TimingInfoPresentFlag bool
+	TimingInfo            SequenceHeaderTimingInfo
+
+	DecoderModelInfoPresentFlag bool
+	DecoderModelInfo            SequenceHeaderDecoderModelInfo
+
+	InitialDisplayDelayPresentFlag bool
+	OperatingPoints                []SequenceHeaderOperatingPoint
+
+	FrameWidthBitsMinus1  uint8
+	FrameHeightBitsMinus1 uint8
+	MaxFrameWidthMinus1   uint32
+	MaxFrameHeightMinus1  uint32
+}
+
+// Unmarshal decodes a SequenceHeader from an OBU, with or without size field.
+func (h *SequenceHeader) Unmarshal(obu []byte) error {
+	*h = SequenceHeader{}
+
+	var oh OBUHeader
+	err := oh.Unmarshal(obu)
+	if err != nil {
+		return err
+	}
+
+	if oh.Type != OBUTypeSequenceHeader {
+		return fmt.Errorf("not a sequence header")
+	}
+
+	buf := obu[oh.Size():]
+
+	if oh.HasSize {
+		size, n, err := LEB128Unmarshal(buf)
+		if err != nil {
+			return err
+		}
+
+		if size > uint(len(buf)-n) {
+			return fmt.Errorf("not enough bytes")
+		}
+
+		buf = buf[n : n+int(size)]
+	}
+
+	pos := 0
+
+	err = bits.HasSpace(buf, pos, 5)
+	if err != nil {
+		return err
+	}
+
+	h.SeqProfile = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+	h.StillPicture = bits.ReadFlagUnsafe(buf, &pos)
+	h.ReducedStillPictureHeader = bits.ReadFlagUnsafe(buf, &pos)
+
+	if h.ReducedStillPictureHeader {
+		tmp, err := bits.ReadBits(buf, &pos, 5)
+		if err != nil {
+			return err
+		}
+
+		h.OperatingPoints = []SequenceHeaderOperatingPoint{{
+			SeqLevelIdx: uint8(tmp),
+		}}
+	} else {
+		h.TimingInfoPresentFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if h.TimingInfoPresentFlag {
+			err = h.unmarshalTimingInfo(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			h.DecoderModelInfoPresentFlag, err = bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return err
+			}
+
+			if h.DecoderModelInfoPresentFlag {
+				err = h.unmarshalDecoderModelInfo(buf, &pos)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		err = bits.HasSpace(buf, pos, 6)
+		if err != nil {
+			return err
+		}
+
+		h.InitialDisplayDelayPresentFlag = bits.ReadFlagUnsafe(buf, &pos)
+		operatingPointsCntMinus1 := int(bits.ReadBitsUnsafe(buf, &pos, 5))
+
+		h.OperatingPoints = make([]SequenceHeaderOperatingPoint, operatingPointsCntMinus1+1)
+
+		for i := range h.OperatingPoints {
+			err = h.unmarshalOperatingPoint(&h.OperatingPoints[i], buf, &pos)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = bits.HasSpace(buf, pos, 8)
+	if err != nil {
+		return err
+	}
+
+	h.FrameWidthBitsMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	h.FrameHeightBitsMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+
+	n1 := int(h.FrameWidthBitsMinus1) + 1
+	n2 := int(h.FrameHeightBitsMinus1) + 1
+
+	err = bits.HasSpace(buf, pos, n1+n2)
+	if err != nil {
+		return err
+	}
+
+	h.MaxFrameWidthMinus1 = uint32(bits.ReadBitsUnsafe(buf, &pos, n1))
+	h.MaxFrameHeightMinus1 = uint32(bits.ReadBitsUnsafe(buf, &pos, n2))
+
+	return nil
+}
+
+func (h *SequenceHeader) unmarshalTimingInfo(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 65)
+	if err != nil {
+		return err
+	}
+
+	h.TimingInfo.NumUnitsInDisplayTick = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+	h.TimingInfo.TimeScale = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+	h.TimingInfo.EqualPictureInterval = bits.ReadFlagUnsafe(buf, pos)
+
+	if h.TimingInfo.EqualPictureInterval {
+		// uvlc() is equivalent to an unsigned Exp-Golomb code
+		h.TimingInfo.NumTicksPerPictureMinus1, err = bits.ReadGolombUnsigned(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *SequenceHeader) unmarshalDecoderModelInfo(buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 47)
+	if err != nil {
+		return err
+	}
+
+	h.DecoderModelInfo.BufferDelayLengthMinus1 = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+	h.DecoderModelInfo.NumUnitsInDecodingTick = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+	h.DecoderModelInfo.BufferRemovalTimeLengthMinus1 = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+	h.DecoderModelInfo.FramePresentationTimeLengthMinus1 = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+
+	return nil
+}
+
+func (h *SequenceHeader) unmarshalOperatingPoint(op *SequenceHeaderOperatingPoint, buf []byte, pos *int) error {
+	err := bits.HasSpace(buf, *pos, 17)
+	if err != nil {
+		return err
+	}
+
+	op.IDC = uint16(bits.ReadBitsUnsafe(buf, pos, 12))
+	op.SeqLevelIdx = uint8(bits.ReadBitsUnsafe(buf, pos, 5))
+
+	if op.SeqLevelIdx > 7 {
+		op.SeqTier, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	if h.DecoderModelInfoPresentFlag {
+		op.DecoderModelPresent, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if op.DecoderModelPresent {
+			n := int(h.DecoderModelInfo.BufferDelayLengthMinus1) + 1
+
+			err := bits.HasSpace(buf, *pos, 2*n+1)
+			if err != nil {
+				return err
+			}
+
+			op.DecoderBufferDelay = uint32(bits.ReadBitsUnsafe(buf, pos, n))
+			op.EncoderBufferDelay = uint32(bits.ReadBitsUnsafe(buf, pos, n))
+			op.LowDelayModeFlag = bits.ReadFlagUnsafe(buf, pos)
+		}
+	}
+
+	if h.InitialDisplayDelayPresentFlag {
+		op.InitialDisplayDelayPresent, err = bits.ReadFlag(buf, pos)
+		if err != nil {
+			return err
+		}
+
+		if op.InitialDisplayDelayPresent {
+			tmp, err := bits.ReadBits(buf, pos, 4)
+			if err != nil {
+				return err
+			}
+			op.InitialDisplayDelayMinus1 = uint8(tmp)
+		}
+	}
+
+	return nil
+}
+
+// Width returns the maximum frame width.
+func (h SequenceHeader) Width() int {
+	return int(h.MaxFrameWidthMinus1) + 1
+}
+
+// Height returns the maximum frame height.
+func (h SequenceHeader) Height() int {
+	return int(h.MaxFrameHeightMinus1) + 1
+}
+
+// FrameRate returns the frame rate, or zero if it is not fixed or not available.
+func (h SequenceHeader) FrameRate() float64 {
+	if !h.TimingInfoPresentFlag || !h.TimingInfo.EqualPictureInterval ||
+		h.TimingInfo.NumUnitsInDisplayTick == 0 {
+		return 0
+	}
+
+	return float64(h.TimingInfo.TimeScale) /
+		(float64(h.TimingInfo.NumUnitsInDisplayTick) * (float64(h.TimingInfo.NumTicksPerPictureMinus1) + 1))
+}
@@ -0,0 +1,104 @@
+package av1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceHeaderUnmarshal(t *testing.T) {
+	for _, ca := range []struct {
+		name      string
+		byts      []byte
+		sh        SequenceHeader
+		width     int
+		height    int
+		frameRate float64
+	}{
+		{
+			"1920x1080",
+			[]byte{
+				0x0a, 0x0b, 0x00, 0x00, 0x00, 0x42, 0xab, 0xbf,
+				0xc3, 0x71, 0xab, 0xe6, 0x01,
+			},
+			SequenceHeader{
+				OperatingPoints: []SequenceHeaderOperatingPoint{{
+					SeqLevelIdx: 8,
+				}},
+				FrameWidthBitsMinus1:  10,
+				FrameHeightBitsMinus1: 10,
+				MaxFrameWidthMinus1:   1919,
+				MaxFrameHeightMinus1:  1079,
+			},
+			1920,
+			1080,
+			0,
+		},
+		{
+			"3840x2160 with timing info",
+			[]byte{
+				0x08, 0x04, 0x00, 0x00, 0x0f, 0xa4, 0x00, 0x01,
+				0xd4, 0xc3, 0x00, 0x00, 0x04, 0xbb, 0xef, 0xf8,
+				0x6f,
+			},
+			SequenceHeader{
+				TimingInfoPresentFlag: true,
+				TimingInfo: SequenceHeaderTimingInfo{
+					NumUnitsInDisplayTick: 1001,
+					TimeScale:             30000,
+					EqualPictureInterval:  true,
+				},
+				OperatingPoints: []SequenceHeaderOperatingPoint{{
+					SeqLevelIdx: 4,
+				}},
+				FrameWidthBitsMinus1:  11,
+				FrameHeightBitsMinus1: 11,
+				MaxFrameWidthMinus1:   3839,
+				MaxFrameHeightMinus1:  2159,
+			},
+			3840,
+			2160,
+			29.97002997002997,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var sh SequenceHeader
+			err := sh.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.sh, sh)
+			require.Equal(t, ca.width, sh.Width())
+			require.Equal(t, ca.height, sh.Height())
+			require.Equal(t, ca.frameRate, sh.FrameRate())
+		})
+	}
+}
+
+func TestSequenceHeaderUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"not a sequence header",
+			[]byte{0x30, 0x00},
+			"not a sequence header",
+		},
+		{
+			"invalid size",
+			[]byte{0x0a, 0x0b, 0x00},
+			"not enough bytes",
+		},
+		{
+			"truncated",
+			[]byte{0x08, 0x00, 0x00, 0x00, 0x42},
+			"not enough bits",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var sh SequenceHeader
+			err := sh.Unmarshal(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
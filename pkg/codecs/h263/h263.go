@@ -0,0 +1,18 @@
+// Package h263 contains utilities to work with the H263 codec.
+package h263
+
+// IsPictureStart checks whether a buffer begins with a picture start code.
+func IsPictureStart(buf []byte) bool {
+	return len(buf) >= 3 && buf[0] == 0 && buf[1] == 0 && (buf[2]&0xFC) == 0x80
+}
+
+// FindStartCode returns the position of the first byte-aligned start code
+// (picture, GOB or slice start code) that is found at or after the given position, or -1.
+func FindStartCode(buf []byte, pos int) int {
+	for ; pos+3 <= len(buf); pos++ {
+		if buf[pos] == 0 && buf[pos+1] == 0 && (buf[pos+2]&0x80) != 0 {
+			return pos
+		}
+	}
+	return -1
+}
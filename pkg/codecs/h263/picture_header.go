@@ -0,0 +1,132 @@
+package h263
+
+import (
+	"fmt"
+
+	"github.com/aler9/gortsplib/v2/pkg/bits"
+)
+
+// SourceFormat is a source format.
+type SourceFormat uint8
+
+// source formats.
+const (
+	SourceFormatSubQCIF  SourceFormat = 1
+	SourceFormatQCIF     SourceFormat = 2
+	SourceFormatCIF      SourceFormat = 3
+	SourceFormat4CIF     SourceFormat = 4
+	SourceFormat16CIF    SourceFormat = 5
+	SourceFormatExtended SourceFormat = 7
+)
+
+// PictureHeader is a picture header.
+// When the source format is extended (H263+), the picture type is stored
+// into PLUSPTYPE, that is not decoded.
+// Specification: ITU-T Rec. H.263, 5.1
+type PictureHeader struct {
+	TemporalReference           uint8
+	SplitScreen                 bool
+	DocumentCamera              bool
+	FreezePictureRelease        bool
+	SourceFormat                SourceFormat
+	Inter                       bool
+	UnrestrictedMotionVector    bool
+	SyntaxBasedArithmeticCoding bool
+	AdvancedPrediction          bool
+	PBFrames                    bool
+}
+
+// Unmarshal decodes a PictureHeader.
+func (h *PictureHeader) Unmarshal(buf []byte) error {
+	if !IsPictureStart(buf) {
+		return fmt.Errorf("invalid picture start code")
+	}
+
+	pos := 22
+
+	err := bits.HasSpace(buf, pos, 16)
+	if err != nil {
+		return err
+	}
+
+	h.TemporalReference = uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
+
+	if bits.ReadBitsUnsafe(buf, &pos, 2) != 0b10 {
+		return fmt.Errorf("invalid PTYPE")
+	}
+
+	h.SplitScreen = bits.ReadFlagUnsafe(buf, &pos)
+	h.DocumentCamera = bits.ReadFlagUnsafe(buf, &pos)
+	h.FreezePictureRelease = bits.ReadFlagUnsafe(buf, &pos)
+	h.SourceFormat = SourceFormat(bits.ReadBitsUnsafe(buf, &pos, 3))
+
+	switch h.SourceFormat {
+	case SourceFormatSubQCIF, SourceFormatQCIF, SourceFormatCIF,
+		SourceFormat4CIF, SourceFormat16CIF:
+
+	case SourceFormatExtended:
+		return nil
+
+	default:
+		return fmt.Errorf("invalid source format (%d)", h.SourceFormat)
+	}
+
+	err = bits.HasSpace(buf, pos, 5)
+	if err != nil {
+		return err
+	}
+
+	h.Inter = bits.ReadFlagUnsafe(buf, &pos)
+	h.UnrestrictedMotionVector = bits.ReadFlagUnsafe(buf, &pos)
+	h.SyntaxBasedArithmeticCoding = bits.ReadFlagUnsafe(buf, &pos)
+	h.AdvancedPrediction = bits.ReadFlagUnsafe(buf, &pos)
+	h.PBFrames = bits.ReadFlagUnsafe(buf, &pos)
+
+	return nil
+}
+
+// Width returns the picture width.
+// It returns zero when the source format is extended.
+func (h PictureHeader) Width() int {
+	switch h.SourceFormat {
+	case SourceFormatSubQCIF:
+		return 128
+
+	case SourceFormatQCIF:
+		return 176
+
+	case SourceFormatCIF:
+		return 352
+
+	case SourceFormat4CIF:
+		return 704
+
+	case SourceFormat16CIF:
+		return 1408
+	}
+
+	return 0
+}
+
+// Height returns the picture height.
+// It returns zero when the source format is extended.
+func (h PictureHeader) Height() int {
+	switch h.SourceFormat {
+	case SourceFormatSubQCIF:
+		return 96
+
+	case SourceFormatQCIF:
+		return 144
+
+	case SourceFormatCIF:
+		return 288
+
+	case SourceFormat4CIF:
+		return 576
+
+	case SourceFormat16CIF:
+		return 1152
+	}
+
+	return 0
+}
@@ -0,0 +1,74 @@
+//go:build go1.18
+// +build go1.18
+
+package h263
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPictureHeaderUnmarshal(t *testing.T) {
+	for _, ca := range []struct {
+		name   string
+		byts   []byte
+		h      PictureHeader
+		width  int
+		height int
+	}{
+		{
+			"qcif inter",
+			[]byte{0x00, 0x00, 0x80, 0x16, 0x0a, 0x0a},
+			PictureHeader{
+				TemporalReference: 5,
+				SourceFormat:      SourceFormatQCIF,
+				Inter:             true,
+			},
+			176,
+			144,
+		},
+		{
+			"cif intra",
+			[]byte{0x00, 0x00, 0x81, 0x02, 0x0c, 0x08},
+			PictureHeader{
+				TemporalReference: 64,
+				SourceFormat:      SourceFormatCIF,
+			},
+			352,
+			288,
+		},
+		{
+			"extended",
+			[]byte{0x00, 0x00, 0x80, 0x02, 0x1c},
+			PictureHeader{
+				SourceFormat: SourceFormatExtended,
+			},
+			0,
+			0,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var h PictureHeader
+			err := h.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.h, h)
+			require.Equal(t, ca.width, h.Width())
+			require.Equal(t, ca.height, h.Height())
+		})
+	}
+}
+
+func TestFindStartCode(t *testing.T) {
+	buf := []byte{0x00, 0x00, 0x80, 0x16, 0x00, 0x00, 0x01, 0x00, 0x00, 0x88, 0x12}
+	require.Equal(t, 0, FindStartCode(buf, 0))
+	require.Equal(t, 7, FindStartCode(buf, 1))
+	require.Equal(t, -1, FindStartCode(buf, 8))
+}
+
+func FuzzPictureHeaderUnmarshal(f *testing.F) {
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var h PictureHeader
+		h.Unmarshal(b)
+	})
+}
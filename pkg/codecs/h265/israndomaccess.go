@@ -0,0 +1,18 @@
+package h265
+
+// IsRandomAccess checks whether the access unit can be randomly accessed,
+// i.e. whether it contains an IDR or CRA NALU.
+func IsRandomAccess(au [][]byte) bool {
+	for _, nalu := range au {
+		if len(nalu) == 0 {
+			continue
+		}
+
+		typ := NALUType((nalu[0] >> 1) & 0b111111)
+		switch typ {
+		case NALUType_IDR_W_RADL, NALUType_IDR_N_LP, NALUType_CRA_NUT:
+			return true
+		}
+	}
+	return false
+}
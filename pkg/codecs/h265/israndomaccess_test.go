@@ -0,0 +1,21 @@
+package h265
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRandomAccess(t *testing.T) {
+	require.Equal(t, true, IsRandomAccess([][]byte{
+		{0x40, 0x01},
+		{0x26, 0x01},
+	}))
+	require.Equal(t, true, IsRandomAccess([][]byte{
+		{0x2a, 0x01},
+	}))
+	require.Equal(t, false, IsRandomAccess([][]byte{
+		{0x02, 0x01},
+		{},
+	}))
+}
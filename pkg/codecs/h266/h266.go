@@ -0,0 +1,10 @@
+// Package h266 contains utilities to work with the H266 codec.
+package h266
+
+const (
+	// MaxNALUSize is the maximum size of a NALU.
+	MaxNALUSize = 3 * 1024 * 1024
+
+	// MaxNALUsPerGroup is the maximum number of NALUs per group.
+	MaxNALUsPerGroup = 20
+)
@@ -0,0 +1,290 @@
+package h266
+
+import (
+	"fmt"
+
+	"github.com/aler9/gortsplib/v2/pkg/bits"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h264"
+)
+
+var subWidthC = []uint32{
+	1,
+	2,
+	2,
+	1,
+}
+
+var subHeightC = []uint32{
+	1,
+	2,
+	1,
+	1,
+}
+
+func skipAlignment(buf []byte, pos *int) error {
+	n := (8 - (*pos % 8)) % 8
+
+	err := bits.HasSpace(buf, *pos, n)
+	if err != nil {
+		return err
+	}
+
+	*pos += n
+	return nil
+}
+
+// SPS_ProfileTierLevel is a profile level tier of a SPS.
+type SPS_ProfileTierLevel struct { //nolint:revive
+	GeneralProfileIdc        uint8
+	GeneralTierFlag          bool
+	GeneralLevelIdc          uint8
+	FrameOnlyConstraintFlag  bool
+	MultilayerEnabledFlag    bool
+	SubLayerLevelPresentFlag []bool
+	SubLayerLevelIdc         []uint8
+	GeneralSubProfileIdc     []uint32
+}
+
+func (p *SPS_ProfileTierLevel) unmarshal(buf []byte, pos *int, maxSubLayersMinus1 uint8) error {
+	err := bits.HasSpace(buf, *pos, 8+8+2+1)
+	if err != nil {
+		return err
+	}
+
+	p.GeneralProfileIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 7))
+	p.GeneralTierFlag = bits.ReadFlagUnsafe(buf, pos)
+	p.GeneralLevelIdc = uint8(bits.ReadBitsUnsafe(buf, pos, 8))
+	p.FrameOnlyConstraintFlag = bits.ReadFlagUnsafe(buf, pos)
+	p.MultilayerEnabledFlag = bits.ReadFlagUnsafe(buf, pos)
+
+	// general_constraints_info()
+	gciPresentFlag := bits.ReadFlagUnsafe(buf, pos)
+	if gciPresentFlag {
+		err := bits.HasSpace(buf, *pos, 71+8)
+		if err != nil {
+			return err
+		}
+
+		*pos += 71
+		gciNumAdditionalBits := int(bits.ReadBitsUnsafe(buf, pos, 8))
+
+		err = bits.HasSpace(buf, *pos, gciNumAdditionalBits)
+		if err != nil {
+			return err
+		}
+
+		*pos += gciNumAdditionalBits
+	}
+
+	err = skipAlignment(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if maxSubLayersMinus1 > 0 {
+		p.SubLayerLevelPresentFlag = make([]bool, maxSubLayersMinus1)
+		p.SubLayerLevelIdc = make([]uint8, maxSubLayersMinus1)
+
+		err := bits.HasSpace(buf, *pos, int(maxSubLayersMinus1))
+		if err != nil {
+			return err
+		}
+
+		for i := int(maxSubLayersMinus1) - 1; i >= 0; i-- {
+			p.SubLayerLevelPresentFlag[i] = bits.ReadFlagUnsafe(buf, pos)
+		}
+	}
+
+	err = skipAlignment(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	for i := int(maxSubLayersMinus1) - 1; i >= 0; i-- {
+		if p.SubLayerLevelPresentFlag[i] {
+			tmp, err := bits.ReadBits(buf, pos, 8)
+			if err != nil {
+				return err
+			}
+			p.SubLayerLevelIdc[i] = uint8(tmp)
+		}
+	}
+
+	numSubProfiles, err := bits.ReadBits(buf, pos, 8)
+	if err != nil {
+		return err
+	}
+
+	if numSubProfiles > 0 {
+		err := bits.HasSpace(buf, *pos, int(numSubProfiles)*32)
+		if err != nil {
+			return err
+		}
+
+		p.GeneralSubProfileIdc = make([]uint32, numSubProfiles)
+
+		for i := range p.GeneralSubProfileIdc {
+			p.GeneralSubProfileIdc[i] = uint32(bits.ReadBitsUnsafe(buf, pos, 32))
+		}
+	}
+
+	return nil
+}
+
+// SPS_ConformanceWindow is a conformance window of a SPS.
+type SPS_ConformanceWindow struct { //nolint:revive
+	LeftOffset   uint32
+	RightOffset  uint32
+	TopOffset    uint32
+	BottomOffset uint32
+}
+
+func (c *SPS_ConformanceWindow) unmarshal(buf []byte, pos *int) error {
+	var err error
+	c.LeftOffset, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	c.RightOffset, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	c.TopOffset, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	c.BottomOffset, err = bits.ReadGolombUnsigned(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SPS is a H266 sequence parameter set.
+// Only the fields up to the conformance window are decoded.
+// Specification: ITU-T Rec. H.266, 7.3.2.4
+type SPS struct {
+	ID                          uint8
+	VPSID                       uint8
+	MaxSubLayersMinus1          uint8
+	ChromaFormatIdc             uint8
+	Log2CTUSizeMinus5           uint8
+	ProfileTierLevel            *SPS_ProfileTierLevel
+	GDREnabledFlag              bool
+	RefPicResamplingEnabledFlag bool
+	ResChangeInCLVSAllowedFlag  bool
+	PicWidthMaxInLumaSamples    uint32
+	PicHeightMaxInLumaSamples   uint32
+	ConformanceWindow           *SPS_ConformanceWindow
+}
+
+// Unmarshal decodes a SPS from bytes.
+func (s *SPS) Unmarshal(buf []byte) error {
+	*s = SPS{}
+
+	buf = h264.EmulationPreventionRemove(buf)
+
+	if len(buf) < 2 {
+		return fmt.Errorf("not enough bits")
+	}
+
+	typ := NALUType(buf[1] >> 3)
+	if typ != NALUType_SPS_NUT {
+		return fmt.Errorf("not a SPS")
+	}
+
+	buf = buf[2:]
+	pos := 0
+
+	err := bits.HasSpace(buf, pos, 16)
+	if err != nil {
+		return err
+	}
+
+	s.ID = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	s.VPSID = uint8(bits.ReadBitsUnsafe(buf, &pos, 4))
+	s.MaxSubLayersMinus1 = uint8(bits.ReadBitsUnsafe(buf, &pos, 3))
+	s.ChromaFormatIdc = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	s.Log2CTUSizeMinus5 = uint8(bits.ReadBitsUnsafe(buf, &pos, 2))
+	ptlDpbHrdParamsPresentFlag := bits.ReadFlagUnsafe(buf, &pos)
+
+	if s.MaxSubLayersMinus1 > 6 {
+		return fmt.Errorf("invalid sps_max_sublayers_minus1 (%d)", s.MaxSubLayersMinus1)
+	}
+
+	if ptlDpbHrdParamsPresentFlag {
+		s.ProfileTierLevel = &SPS_ProfileTierLevel{}
+		err := s.ProfileTierLevel.unmarshal(buf, &pos, s.MaxSubLayersMinus1)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = bits.HasSpace(buf, pos, 2)
+	if err != nil {
+		return err
+	}
+
+	s.GDREnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+	s.RefPicResamplingEnabledFlag = bits.ReadFlagUnsafe(buf, &pos)
+
+	if s.RefPicResamplingEnabledFlag {
+		s.ResChangeInCLVSAllowedFlag, err = bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.PicWidthMaxInLumaSamples, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	s.PicHeightMaxInLumaSamples, err = bits.ReadGolombUnsigned(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	conformanceWindowFlag, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if conformanceWindowFlag {
+		s.ConformanceWindow = &SPS_ConformanceWindow{}
+		err := s.ConformanceWindow.unmarshal(buf, &pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Width returns the video width.
+func (s SPS) Width() int {
+	width := s.PicWidthMaxInLumaSamples
+
+	if s.ConformanceWindow != nil {
+		cropUnitX := subWidthC[s.ChromaFormatIdc]
+		width -= (s.ConformanceWindow.LeftOffset + s.ConformanceWindow.RightOffset) * cropUnitX
+	}
+
+	return int(width)
+}
+
+// Height returns the video height.
+func (s SPS) Height() int {
+	height := s.PicHeightMaxInLumaSamples
+
+	if s.ConformanceWindow != nil {
+		cropUnitY := subHeightC[s.ChromaFormatIdc]
+		height -= (s.ConformanceWindow.TopOffset + s.ConformanceWindow.BottomOffset) * cropUnitY
+	}
+
+	return int(height)
+}
@@ -0,0 +1,88 @@
+//go:build go1.18
+// +build go1.18
+
+package h266
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSPSUnmarshal(t *testing.T) {
+	for _, ca := range []struct {
+		name   string
+		byts   []byte
+		sps    SPS
+		width  int
+		height int
+	}{
+		{
+			"1920x1080",
+			[]byte{
+				0x00, 0x79, 0x00, 0x0d, 0x02, 0x43, 0x80, 0x00,
+				0x00, 0x0f, 0x02, 0x00, 0x44, 0x1f, 0x2c,
+			},
+			SPS{
+				ChromaFormatIdc:   1,
+				Log2CTUSizeMinus5: 2,
+				ProfileTierLevel: &SPS_ProfileTierLevel{
+					GeneralProfileIdc:       1,
+					GeneralLevelIdc:         67,
+					FrameOnlyConstraintFlag: true,
+				},
+				PicWidthMaxInLumaSamples:  1920,
+				PicHeightMaxInLumaSamples: 1088,
+				ConformanceWindow: &SPS_ConformanceWindow{
+					BottomOffset: 4,
+				},
+			},
+			1920,
+			1080,
+		},
+		{
+			"3840x2160 with constraints and sublayers",
+			[]byte{
+				0x00, 0x79, 0x00, 0x2d, 0x03, 0x53, 0xa0, 0x00,
+				0x00, 0x03, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
+				0x00, 0x00, 0x03, 0x00, 0x00, 0x80, 0x50, 0x01,
+				0x12, 0x34, 0x56, 0x78, 0x00, 0x07, 0x80, 0x80,
+				0x08, 0x71, 0x40,
+			},
+			SPS{
+				MaxSubLayersMinus1: 1,
+				ChromaFormatIdc:    1,
+				Log2CTUSizeMinus5:  2,
+				ProfileTierLevel: &SPS_ProfileTierLevel{
+					GeneralProfileIdc:        1,
+					GeneralTierFlag:          true,
+					GeneralLevelIdc:          83,
+					FrameOnlyConstraintFlag:  true,
+					SubLayerLevelPresentFlag: []bool{true},
+					SubLayerLevelIdc:         []uint8{80},
+					GeneralSubProfileIdc:     []uint32{0x12345678},
+				},
+				PicWidthMaxInLumaSamples:  3840,
+				PicHeightMaxInLumaSamples: 2160,
+			},
+			3840,
+			2160,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var sps SPS
+			err := sps.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.sps, sps)
+			require.Equal(t, ca.width, sps.Width())
+			require.Equal(t, ca.height, sps.Height())
+		})
+	}
+}
+
+func FuzzSPSUnmarshal(f *testing.F) {
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var sps SPS
+		sps.Unmarshal(b)
+	})
+}
@@ -0,0 +1,143 @@
+package mpeg1audio
+
+import (
+	"fmt"
+)
+
+// http://www.mp3-tech.org/programmer/frame_header.html
+
+var bitrates = map[bool]map[int][]int{
+	// MPEG-1
+	false: {
+		1: {
+			32000, 64000, 96000, 128000, 160000, 192000, 224000,
+			256000, 288000, 320000, 352000, 384000, 416000, 448000,
+		},
+		2: {
+			32000, 48000, 56000, 64000, 80000, 96000, 112000,
+			128000, 160000, 192000, 224000, 256000, 320000, 384000,
+		},
+		3: {
+			32000, 40000, 48000, 56000, 64000, 80000, 96000,
+			112000, 128000, 160000, 192000, 224000, 256000, 320000,
+		},
+	},
+	// MPEG-2
+	true: {
+		1: {
+			32000, 48000, 56000, 64000, 80000, 96000, 112000,
+			128000, 144000, 160000, 176000, 192000, 224000, 256000,
+		},
+		2: {
+			8000, 16000, 24000, 32000, 40000, 48000, 56000,
+			64000, 80000, 96000, 112000, 128000, 144000, 160000,
+		},
+		3: {
+			8000, 16000, 24000, 32000, 40000, 48000, 56000,
+			64000, 80000, 96000, 112000, 128000, 144000, 160000,
+		},
+	},
+}
+
+var sampleRates = map[bool][]int{
+	// MPEG-1
+	false: {44100, 48000, 32000},
+	// MPEG-2
+	true: {22050, 24000, 16000},
+}
+
+// ChannelMode is a channel mode of a MPEG-1/2 audio frame.
+type ChannelMode int
+
+// standard channel modes.
+const (
+	ChannelModeStereo      ChannelMode = 0
+	ChannelModeJointStereo ChannelMode = 1
+	ChannelModeDualChannel ChannelMode = 2
+	ChannelModeMono        ChannelMode = 3
+)
+
+// FrameHeader is the header of a MPEG-1/2 audio frame.
+type FrameHeader struct {
+	MPEG2       bool
+	Layer       uint8
+	Bitrate     int
+	SampleRate  int
+	Padding     bool
+	ChannelMode ChannelMode
+}
+
+// Unmarshal decodes a FrameHeader.
+func (h *FrameHeader) Unmarshal(buf []byte) error {
+	if len(buf) < 4 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	syncWord := uint16(buf[0])<<4 | uint16(buf[1])>>4
+	if syncWord != 0x0FFF {
+		return fmt.Errorf("sync word not found: %x", syncWord)
+	}
+
+	switch (buf[1] >> 3) & 0x01 {
+	case 1:
+		h.MPEG2 = false
+
+	default:
+		h.MPEG2 = true
+	}
+
+	h.Layer = 4 - ((buf[1] >> 1) & 0x03)
+	if h.Layer < 1 || h.Layer > 3 {
+		return fmt.Errorf("unsupported MPEG layer: %d", h.Layer)
+	}
+
+	bitrateIndex := int(buf[2] >> 4)
+	if bitrateIndex == 0 || bitrateIndex == 15 {
+		return fmt.Errorf("unsupported bitrate index: %d", bitrateIndex)
+	}
+	h.Bitrate = bitrates[h.MPEG2][int(h.Layer)][bitrateIndex-1]
+
+	sampleRateIndex := int((buf[2] >> 2) & 0x03)
+	if sampleRateIndex == 3 {
+		return fmt.Errorf("invalid sample rate index: %d", sampleRateIndex)
+	}
+	h.SampleRate = sampleRates[h.MPEG2][sampleRateIndex]
+
+	h.Padding = ((buf[2] >> 1) & 0x01) != 0
+	h.ChannelMode = ChannelMode(buf[3] >> 6)
+
+	return nil
+}
+
+// FrameLen returns the length of the frame associated with the header.
+func (h FrameHeader) FrameLen() int {
+	padding := 0
+	if h.Padding {
+		padding = 1
+	}
+
+	switch {
+	case h.Layer == 1:
+		return (12*h.Bitrate/h.SampleRate + padding) * 4
+
+	case h.Layer == 2 || !h.MPEG2:
+		return 144*h.Bitrate/h.SampleRate + padding
+
+	default: // MPEG-2 layer 3
+		return 72*h.Bitrate/h.SampleRate + padding
+	}
+}
+
+// SampleCount returns the number of samples contained in the frame associated with the header.
+func (h FrameHeader) SampleCount() int {
+	switch {
+	case h.Layer == 1:
+		return 384
+
+	case h.Layer == 2 || !h.MPEG2:
+		return 1152
+
+	default: // MPEG-2 layer 3
+		return 576
+	}
+}
@@ -0,0 +1,134 @@
+package mpeg1audio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameHeaderUnmarshal(t *testing.T) {
+	for _, ca := range []struct {
+		name        string
+		byts        []byte
+		h           FrameHeader
+		frameLen    int
+		sampleCount int
+	}{
+		{
+			"mpeg-1 layer 3",
+			[]byte{0xff, 0xfb, 0x90, 0x64},
+			FrameHeader{
+				MPEG2:       false,
+				Layer:       3,
+				Bitrate:     128000,
+				SampleRate:  44100,
+				Padding:     false,
+				ChannelMode: ChannelModeJointStereo,
+			},
+			417,
+			1152,
+		},
+		{
+			"mpeg-1 layer 3 with padding",
+			[]byte{0xff, 0xfb, 0x92, 0x64},
+			FrameHeader{
+				MPEG2:       false,
+				Layer:       3,
+				Bitrate:     128000,
+				SampleRate:  44100,
+				Padding:     true,
+				ChannelMode: ChannelModeJointStereo,
+			},
+			418,
+			1152,
+		},
+		{
+			"mpeg-1 layer 1",
+			[]byte{0xff, 0xff, 0x14, 0x00},
+			FrameHeader{
+				MPEG2:       false,
+				Layer:       1,
+				Bitrate:     32000,
+				SampleRate:  48000,
+				ChannelMode: ChannelModeStereo,
+			},
+			32,
+			384,
+		},
+		{
+			"mpeg-2 layer 2",
+			[]byte{0xff, 0xf5, 0x80, 0xc0},
+			FrameHeader{
+				MPEG2:       true,
+				Layer:       2,
+				Bitrate:     64000,
+				SampleRate:  22050,
+				ChannelMode: ChannelModeMono,
+			},
+			417,
+			1152,
+		},
+		{
+			"mpeg-2 layer 3",
+			[]byte{0xff, 0xf3, 0x88, 0x80},
+			FrameHeader{
+				MPEG2:       true,
+				Layer:       3,
+				Bitrate:     64000,
+				SampleRate:  16000,
+				ChannelMode: ChannelModeDualChannel,
+			},
+			288,
+			576,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var h FrameHeader
+			err := h.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.h, h)
+			require.Equal(t, ca.frameLen, h.FrameLen())
+			require.Equal(t, ca.sampleCount, h.SampleCount())
+		})
+	}
+}
+
+func TestFrameHeaderUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"not enough bytes",
+			[]byte{0xff, 0xfb, 0x90},
+			"not enough bytes",
+		},
+		{
+			"invalid sync word",
+			[]byte{0xff, 0xeb, 0x90, 0x64},
+			"sync word not found: ffe",
+		},
+		{
+			"invalid layer",
+			[]byte{0xff, 0xf9, 0x90, 0x64},
+			"unsupported MPEG layer: 4",
+		},
+		{
+			"free bitrate",
+			[]byte{0xff, 0xfb, 0x00, 0x64},
+			"unsupported bitrate index: 0",
+		},
+		{
+			"invalid sample rate",
+			[]byte{0xff, 0xfb, 0x9c, 0x64},
+			"invalid sample rate index: 3",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var h FrameHeader
+			err := h.Unmarshal(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
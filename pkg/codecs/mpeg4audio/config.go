@@ -25,8 +25,11 @@ type Config struct {
 // Unmarshal decodes a Config.
 func (c *Config) Unmarshal(buf []byte) error {
 	pos := 0
+	return c.unmarshalBits(buf, &pos)
+}
 
-	tmp, err := bits.ReadBits(buf, &pos, 5)
+func (c *Config) unmarshalBits(buf []byte, pos *int) error {
+	tmp, err := bits.ReadBits(buf, pos, 5)
 	if err != nil {
 		return err
 	}
@@ -40,7 +43,7 @@ func (c *Config) Unmarshal(buf []byte) error {
 		return fmt.Errorf("unsupported object type: %d", c.Type)
 	}
 
-	sampleRateIndex, err := bits.ReadBits(buf, &pos, 4)
+	sampleRateIndex, err := bits.ReadBits(buf, pos, 4)
 	if err != nil {
 		return err
 	}
@@ -50,7 +53,7 @@ func (c *Config) Unmarshal(buf []byte) error {
 		c.SampleRate = sampleRates[sampleRateIndex]
 
 	case sampleRateIndex == 0x0F:
-		tmp, err := bits.ReadBits(buf, &pos, 24)
+		tmp, err := bits.ReadBits(buf, pos, 24)
 		if err != nil {
 			return err
 		}
@@ -60,7 +63,7 @@ func (c *Config) Unmarshal(buf []byte) error {
 		return fmt.Errorf("invalid sample rate index (%d)", sampleRateIndex)
 	}
 
-	channelConfig, err := bits.ReadBits(buf, &pos, 4)
+	channelConfig, err := bits.ReadBits(buf, pos, 4)
 	if err != nil {
 		return err
 	}
@@ -81,7 +84,7 @@ func (c *Config) Unmarshal(buf []byte) error {
 
 	if c.Type == ObjectTypeSBR || c.Type == ObjectTypePS {
 		c.ExtensionType = c.Type
-		extensionSamplingFrequencyIndex, err := bits.ReadBits(buf, &pos, 4)
+		extensionSamplingFrequencyIndex, err := bits.ReadBits(buf, pos, 4)
 		if err != nil {
 			return err
 		}
@@ -91,7 +94,7 @@ func (c *Config) Unmarshal(buf []byte) error {
 			c.ExtensionSampleRate = sampleRates[extensionSamplingFrequencyIndex]
 
 		case extensionSamplingFrequencyIndex == 0x0F:
-			tmp, err := bits.ReadBits(buf, &pos, 24)
+			tmp, err := bits.ReadBits(buf, pos, 24)
 			if err != nil {
 				return err
 			}
@@ -101,7 +104,7 @@ func (c *Config) Unmarshal(buf []byte) error {
 			return fmt.Errorf("invalid extension sample rate index (%d)", extensionSamplingFrequencyIndex)
 		}
 
-		tmp, err = bits.ReadBits(buf, &pos, 5)
+		tmp, err = bits.ReadBits(buf, pos, 5)
 		if err != nil {
 			return err
 		}
@@ -112,25 +115,25 @@ func (c *Config) Unmarshal(buf []byte) error {
 		}
 	}
 
-	c.FrameLengthFlag, err = bits.ReadFlag(buf, &pos)
+	c.FrameLengthFlag, err = bits.ReadFlag(buf, pos)
 	if err != nil {
 		return err
 	}
 
-	c.DependsOnCoreCoder, err = bits.ReadFlag(buf, &pos)
+	c.DependsOnCoreCoder, err = bits.ReadFlag(buf, pos)
 	if err != nil {
 		return err
 	}
 
 	if c.DependsOnCoreCoder {
-		tmp, err := bits.ReadBits(buf, &pos, 14)
+		tmp, err := bits.ReadBits(buf, pos, 14)
 		if err != nil {
 			return err
 		}
 		c.CoreCoderDelay = uint16(tmp)
 	}
 
-	extensionFlag, err := bits.ReadFlag(buf, &pos)
+	extensionFlag, err := bits.ReadFlag(buf, pos)
 	if err != nil {
 		return err
 	}
@@ -142,7 +145,7 @@ func (c *Config) Unmarshal(buf []byte) error {
 	return nil
 }
 
-func (c Config) marshalSize() int {
+func (c Config) marshalSizeBits() int {
 	n := 5 + 4 + 3
 
 	_, ok := reverseSampleRates[c.SampleRate]
@@ -164,6 +167,12 @@ func (c Config) marshalSize() int {
 		n += 14
 	}
 
+	return n
+}
+
+func (c Config) marshalSize() int {
+	n := c.marshalSizeBits()
+
 	ret := n / 8
 	if (n % 8) != 0 {
 		ret++
@@ -177,18 +186,29 @@ func (c Config) Marshal() ([]byte, error) {
 	buf := make([]byte, c.marshalSize())
 	pos := 0
 
+	err := c.marshalTo(buf, &pos)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+func (c Config) marshalTo(buf []byte, pos *int) error {
+	start := *pos
+
 	if c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS {
-		bits.WriteBits(buf, &pos, uint64(c.ExtensionType), 5)
+		bits.WriteBits(buf, pos, uint64(c.ExtensionType), 5)
 	} else {
-		bits.WriteBits(buf, &pos, uint64(c.Type), 5)
+		bits.WriteBits(buf, pos, uint64(c.Type), 5)
 	}
 
 	sampleRateIndex, ok := reverseSampleRates[c.SampleRate]
 	if !ok {
-		bits.WriteBits(buf, &pos, uint64(15), 4)
-		bits.WriteBits(buf, &pos, uint64(c.SampleRate), 24)
+		bits.WriteBits(buf, pos, uint64(15), 4)
+		bits.WriteBits(buf, pos, uint64(c.SampleRate), 24)
 	} else {
-		bits.WriteBits(buf, &pos, uint64(sampleRateIndex), 4)
+		bits.WriteBits(buf, pos, uint64(sampleRateIndex), 4)
 	}
 
 	var channelConfig int
@@ -200,36 +220,39 @@ func (c Config) Marshal() ([]byte, error) {
 		channelConfig = 7
 
 	default:
-		return nil, fmt.Errorf("invalid channel count (%d)", c.ChannelCount)
+		return fmt.Errorf("invalid channel count (%d)", c.ChannelCount)
 	}
-	bits.WriteBits(buf, &pos, uint64(channelConfig), 4)
+	bits.WriteBits(buf, pos, uint64(channelConfig), 4)
 
 	if c.ExtensionType == ObjectTypeSBR || c.ExtensionType == ObjectTypePS {
 		sampleRateIndex, ok := reverseSampleRates[c.ExtensionSampleRate]
 		if !ok {
-			bits.WriteBits(buf, &pos, uint64(0x0F), 4)
-			bits.WriteBits(buf, &pos, uint64(c.ExtensionSampleRate), 24)
+			bits.WriteBits(buf, pos, uint64(0x0F), 4)
+			bits.WriteBits(buf, pos, uint64(c.ExtensionSampleRate), 24)
 		} else {
-			bits.WriteBits(buf, &pos, uint64(sampleRateIndex), 4)
+			bits.WriteBits(buf, pos, uint64(sampleRateIndex), 4)
 		}
-		bits.WriteBits(buf, &pos, uint64(c.Type), 5)
+		bits.WriteBits(buf, pos, uint64(c.Type), 5)
 	} else {
 		if c.FrameLengthFlag {
-			bits.WriteBits(buf, &pos, 1, 1)
+			bits.WriteBits(buf, pos, 1, 1)
 		} else {
-			bits.WriteBits(buf, &pos, 0, 1)
+			bits.WriteBits(buf, pos, 0, 1)
 		}
 
 		if c.DependsOnCoreCoder {
-			bits.WriteBits(buf, &pos, 1, 1)
+			bits.WriteBits(buf, pos, 1, 1)
 		} else {
-			bits.WriteBits(buf, &pos, 0, 1)
+			bits.WriteBits(buf, pos, 0, 1)
 		}
 
 		if c.DependsOnCoreCoder {
-			bits.WriteBits(buf, &pos, uint64(c.CoreCoderDelay), 14)
+			bits.WriteBits(buf, pos, uint64(c.CoreCoderDelay), 14)
 		}
 	}
 
-	return buf, nil
+	// fields that are implicitly set to zero
+	*pos = start + c.marshalSizeBits()
+
+	return nil
 }
@@ -0,0 +1,343 @@
+package mpeg4audio
+
+import (
+	"fmt"
+
+	"github.com/aler9/gortsplib/v2/pkg/bits"
+)
+
+// StreamMuxConfigLayer is a layer of a StreamMuxConfig.
+type StreamMuxConfigLayer struct {
+	AudioSpecificConfig       *Config
+	FrameLengthType           uint
+	LatmBufferFullness        uint
+	FrameLength               uint
+	CELPframeLengthTableIndex uint
+	HVXCframeLengthTableIndex bool
+}
+
+// StreamMuxConfigProgram is a program of a StreamMuxConfig.
+type StreamMuxConfigProgram struct {
+	Layers []*StreamMuxConfigLayer
+}
+
+// StreamMuxConfig is a MPEG-4 Audio LATM configuration.
+// Specification: ISO 14496-3, Table 1.42
+type StreamMuxConfig struct {
+	NumSubFrames     uint
+	Programs         []*StreamMuxConfigProgram
+	OtherDataPresent bool
+	OtherDataLenBits uint32
+	CRCCheckPresent  bool
+	CRCCheckSum      uint8
+}
+
+// Unmarshal decodes a StreamMuxConfig.
+func (c *StreamMuxConfig) Unmarshal(buf []byte) error {
+	pos := 0
+	return c.UnmarshalBits(buf, &pos)
+}
+
+// UnmarshalBits decodes a StreamMuxConfig that starts at the given bit position,
+// as it happens when the StreamMuxConfig is embedded into an AudioMuxElement.
+func (c *StreamMuxConfig) UnmarshalBits(buf []byte, pos *int) error {
+	*c = StreamMuxConfig{}
+
+	err := bits.HasSpace(buf, *pos, 12)
+	if err != nil {
+		return err
+	}
+
+	audioMuxVersion := bits.ReadFlagUnsafe(buf, pos)
+	if audioMuxVersion {
+		return fmt.Errorf("audioMuxVersion = 1 is not supported")
+	}
+
+	allStreamsSameTimeFraming := bits.ReadFlagUnsafe(buf, pos)
+	if !allStreamsSameTimeFraming {
+		return fmt.Errorf("allStreamsSameTimeFraming = 0 is not supported")
+	}
+
+	c.NumSubFrames = uint(bits.ReadBitsUnsafe(buf, pos, 6))
+	numProgram := int(bits.ReadBitsUnsafe(buf, pos, 4))
+
+	c.Programs = make([]*StreamMuxConfigProgram, numProgram+1)
+
+	var prevConfig *Config
+
+	for prog := 0; prog <= numProgram; prog++ {
+		p := &StreamMuxConfigProgram{}
+		c.Programs[prog] = p
+
+		numLayer, err := bits.ReadBits(buf, pos, 3)
+		if err != nil {
+			return err
+		}
+
+		p.Layers = make([]*StreamMuxConfigLayer, numLayer+1)
+
+		for lay := 0; lay <= int(numLayer); lay++ {
+			l := &StreamMuxConfigLayer{}
+			p.Layers[lay] = l
+
+			useSameConfig := false
+
+			if prog != 0 || lay != 0 {
+				useSameConfig, err = bits.ReadFlag(buf, pos)
+				if err != nil {
+					return err
+				}
+			}
+
+			if useSameConfig {
+				l.AudioSpecificConfig = prevConfig
+			} else {
+				l.AudioSpecificConfig = &Config{}
+				err = l.AudioSpecificConfig.unmarshalBits(buf, pos)
+				if err != nil {
+					return err
+				}
+				prevConfig = l.AudioSpecificConfig
+			}
+
+			tmp, err := bits.ReadBits(buf, pos, 3)
+			if err != nil {
+				return err
+			}
+			l.FrameLengthType = uint(tmp)
+
+			switch l.FrameLengthType {
+			case 0:
+				tmp, err = bits.ReadBits(buf, pos, 8)
+				if err != nil {
+					return err
+				}
+				l.LatmBufferFullness = uint(tmp)
+
+			case 1:
+				tmp, err = bits.ReadBits(buf, pos, 9)
+				if err != nil {
+					return err
+				}
+				l.FrameLength = uint(tmp)
+
+			case 3, 4, 5:
+				tmp, err = bits.ReadBits(buf, pos, 6)
+				if err != nil {
+					return err
+				}
+				l.CELPframeLengthTableIndex = uint(tmp)
+
+			case 6, 7:
+				l.HVXCframeLengthTableIndex, err = bits.ReadFlag(buf, pos)
+				if err != nil {
+					return err
+				}
+
+			default:
+				return fmt.Errorf("invalid frameLengthType (%d)", l.FrameLengthType)
+			}
+		}
+	}
+
+	c.OtherDataPresent, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if c.OtherDataPresent {
+		for {
+			c.OtherDataLenBits *= 256
+
+			err := bits.HasSpace(buf, *pos, 9)
+			if err != nil {
+				return err
+			}
+
+			otherDataLenEsc := bits.ReadFlagUnsafe(buf, pos)
+			otherDataLenTmp := uint32(bits.ReadBitsUnsafe(buf, pos, 8))
+			c.OtherDataLenBits += otherDataLenTmp
+
+			if !otherDataLenEsc {
+				break
+			}
+		}
+	}
+
+	c.CRCCheckPresent, err = bits.ReadFlag(buf, pos)
+	if err != nil {
+		return err
+	}
+
+	if c.CRCCheckPresent {
+		tmp, err := bits.ReadBits(buf, pos, 8)
+		if err != nil {
+			return err
+		}
+		c.CRCCheckSum = uint8(tmp)
+	}
+
+	return nil
+}
+
+func (c StreamMuxConfig) marshalSizeBits() int {
+	n := 12
+
+	for prog, p := range c.Programs {
+		n += 3
+
+		for lay, l := range p.Layers {
+			if prog != 0 || lay != 0 {
+				n++
+			}
+
+			n += l.AudioSpecificConfig.marshalSizeBits()
+			n += 3
+
+			switch l.FrameLengthType {
+			case 0:
+				n += 8
+
+			case 1:
+				n += 9
+
+			case 3, 4, 5:
+				n += 6
+
+			case 6, 7:
+				n++
+			}
+		}
+	}
+
+	n++
+
+	if c.OtherDataPresent {
+		tmp := c.OtherDataLenBits
+		for {
+			n += 9
+			tmp >>= 8
+			if tmp == 0 {
+				break
+			}
+		}
+	}
+
+	n++
+
+	if c.CRCCheckPresent {
+		n += 8
+	}
+
+	return n
+}
+
+func (c StreamMuxConfig) marshalSize() int {
+	n := c.marshalSizeBits()
+
+	ret := n / 8
+	if (n % 8) != 0 {
+		ret++
+	}
+
+	return ret
+}
+
+// Marshal encodes a StreamMuxConfig.
+func (c StreamMuxConfig) Marshal() ([]byte, error) {
+	if len(c.Programs) == 0 || len(c.Programs) > 16 {
+		return nil, fmt.Errorf("invalid program count (%d)", len(c.Programs))
+	}
+
+	for _, p := range c.Programs {
+		if len(p.Layers) == 0 || len(p.Layers) > 8 {
+			return nil, fmt.Errorf("invalid layer count (%d)", len(p.Layers))
+		}
+
+		for _, l := range p.Layers {
+			if l.AudioSpecificConfig == nil {
+				return nil, fmt.Errorf("AudioSpecificConfig is missing")
+			}
+		}
+	}
+
+	buf := make([]byte, c.marshalSize())
+	pos := 0
+
+	bits.WriteBits(buf, &pos, 0, 1) // audioMuxVersion
+	bits.WriteBits(buf, &pos, 1, 1) // allStreamsSameTimeFraming
+	bits.WriteBits(buf, &pos, uint64(c.NumSubFrames), 6)
+	bits.WriteBits(buf, &pos, uint64(len(c.Programs)-1), 4)
+
+	for prog, p := range c.Programs {
+		bits.WriteBits(buf, &pos, uint64(len(p.Layers)-1), 3)
+
+		for lay, l := range p.Layers {
+			if prog != 0 || lay != 0 {
+				bits.WriteBits(buf, &pos, 0, 1) // useSameConfig
+			}
+
+			err := l.AudioSpecificConfig.marshalTo(buf, &pos)
+			if err != nil {
+				return nil, err
+			}
+
+			bits.WriteBits(buf, &pos, uint64(l.FrameLengthType), 3)
+
+			switch l.FrameLengthType {
+			case 0:
+				bits.WriteBits(buf, &pos, uint64(l.LatmBufferFullness), 8)
+
+			case 1:
+				bits.WriteBits(buf, &pos, uint64(l.FrameLength), 9)
+
+			case 3, 4, 5:
+				bits.WriteBits(buf, &pos, uint64(l.CELPframeLengthTableIndex), 6)
+
+			case 6, 7:
+				if l.HVXCframeLengthTableIndex {
+					bits.WriteBits(buf, &pos, 1, 1)
+				} else {
+					bits.WriteBits(buf, &pos, 0, 1)
+				}
+
+			default:
+				return nil, fmt.Errorf("invalid frameLengthType (%d)", l.FrameLengthType)
+			}
+		}
+	}
+
+	if c.OtherDataPresent {
+		bits.WriteBits(buf, &pos, 1, 1)
+
+		var lenBytes []uint8
+		tmp := c.OtherDataLenBits
+		for {
+			lenBytes = append([]uint8{uint8(tmp)}, lenBytes...)
+			tmp >>= 8
+			if tmp == 0 {
+				break
+			}
+		}
+
+		for i, b := range lenBytes {
+			if i != (len(lenBytes) - 1) {
+				bits.WriteBits(buf, &pos, 1, 1)
+			} else {
+				bits.WriteBits(buf, &pos, 0, 1)
+			}
+			bits.WriteBits(buf, &pos, uint64(b), 8)
+		}
+	} else {
+		bits.WriteBits(buf, &pos, 0, 1)
+	}
+
+	if c.CRCCheckPresent {
+		bits.WriteBits(buf, &pos, 1, 1)
+		bits.WriteBits(buf, &pos, uint64(c.CRCCheckSum), 8)
+	} else {
+		bits.WriteBits(buf, &pos, 0, 1)
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,81 @@
+//go:build go1.18
+// +build go1.18
+
+package mpeg4audio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var streamMuxConfigCases = []struct {
+	name string
+	enc  []byte
+	dec  StreamMuxConfig
+}{
+	{
+		"aac-lc 24khz stereo",
+		[]byte{0x40, 0x00, 0x26, 0x20, 0x3f, 0xc0},
+		StreamMuxConfig{
+			Programs: []*StreamMuxConfigProgram{{
+				Layers: []*StreamMuxConfigLayer{{
+					AudioSpecificConfig: &Config{
+						Type:         ObjectTypeAACLC,
+						SampleRate:   24000,
+						ChannelCount: 2,
+					},
+					LatmBufferFullness: 255,
+				}},
+			}},
+		},
+	},
+	{
+		"aac-lc 48khz mono, other data, crc",
+		[]byte{0x40, 0x00, 0x23, 0x10, 0x3f, 0xf0, 0x10, 0x15, 0xfc},
+		StreamMuxConfig{
+			Programs: []*StreamMuxConfigProgram{{
+				Layers: []*StreamMuxConfigLayer{{
+					AudioSpecificConfig: &Config{
+						Type:         ObjectTypeAACLC,
+						SampleRate:   48000,
+						ChannelCount: 1,
+					},
+					LatmBufferFullness: 255,
+				}},
+			}},
+			OtherDataPresent: true,
+			OtherDataLenBits: 0x0102,
+			CRCCheckPresent:  true,
+			CRCCheckSum:      0x7f,
+		},
+	},
+}
+
+func TestStreamMuxConfigUnmarshal(t *testing.T) {
+	for _, ca := range streamMuxConfigCases {
+		t.Run(ca.name, func(t *testing.T) {
+			var dec StreamMuxConfig
+			err := dec.Unmarshal(ca.enc)
+			require.NoError(t, err)
+			require.Equal(t, ca.dec, dec)
+		})
+	}
+}
+
+func TestStreamMuxConfigMarshal(t *testing.T) {
+	for _, ca := range streamMuxConfigCases {
+		t.Run(ca.name, func(t *testing.T) {
+			enc, err := ca.dec.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.enc, enc)
+		})
+	}
+}
+
+func FuzzStreamMuxConfigUnmarshal(f *testing.F) {
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var conf StreamMuxConfig
+		conf.Unmarshal(b)
+	})
+}
@@ -0,0 +1,205 @@
+package mpeg4video
+
+import (
+	"fmt"
+
+	"github.com/aler9/gortsplib/v2/pkg/bits"
+)
+
+// video object layer shapes.
+const (
+	shapeRectangular = 0
+	shapeGrayscale   = 3
+)
+
+// Config is a MPEG-4 video configuration, that is made of
+// a visual object sequence header, a visual object header
+// and a video object layer header.
+// Specification: ISO 14496-2, 6.2.2 and 6.2.3
+type Config struct {
+	// visual object sequence
+	ProfileLevelIndication uint8
+
+	// video object layer
+	VideoObjectTypeIndication  uint8
+	Width                      int
+	Height                     int
+	VOPTimeIncrementResolution uint16
+	// zero if the frame rate is not fixed.
+	FixedVOPTimeIncrement uint16
+}
+
+// Unmarshal decodes a Config.
+func (c *Config) Unmarshal(buf []byte) error {
+	*c = Config{}
+	volFound := false
+
+	pos := FindStartCode(buf, 0)
+	if pos != 0 {
+		return fmt.Errorf("start code not found")
+	}
+
+	for pos >= 0 {
+		next := FindStartCode(buf, pos+3)
+		end := next
+		if end < 0 {
+			end = len(buf)
+		}
+
+		code := StartCode(buf[pos+3])
+		body := buf[pos+4 : end]
+
+		switch {
+		case code == StartCodeVisualObjectSequenceStart:
+			if len(body) < 1 {
+				return fmt.Errorf("invalid visual object sequence header")
+			}
+			c.ProfileLevelIndication = body[0]
+
+		case code >= StartCodeVideoObjectLayerFirst && code <= StartCodeVideoObjectLayerLast:
+			err := c.unmarshalVOL(body)
+			if err != nil {
+				return err
+			}
+			volFound = true
+		}
+
+		pos = next
+	}
+
+	if !volFound {
+		return fmt.Errorf("video object layer header not found")
+	}
+
+	return nil
+}
+
+func (c *Config) unmarshalVOL(buf []byte) error {
+	pos := 0
+
+	err := bits.HasSpace(buf, pos, 9)
+	if err != nil {
+		return err
+	}
+
+	pos++ // random_accessible_vol
+	c.VideoObjectTypeIndication = uint8(bits.ReadBitsUnsafe(buf, &pos, 8))
+
+	isObjectLayerIdentifier, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	verID := uint64(1)
+
+	if isObjectLayerIdentifier {
+		verID, err = bits.ReadBits(buf, &pos, 4)
+		if err != nil {
+			return err
+		}
+		pos += 3 // video_object_layer_priority
+	}
+
+	aspectRatioInfo, err := bits.ReadBits(buf, &pos, 4)
+	if err != nil {
+		return err
+	}
+
+	if aspectRatioInfo == 0x0F { // extended PAR
+		pos += 16
+	}
+
+	volControlParameters, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if volControlParameters {
+		pos += 3 // chroma_format, low_delay
+
+		vbvParameters, err := bits.ReadFlag(buf, &pos)
+		if err != nil {
+			return err
+		}
+
+		if vbvParameters {
+			pos += 79
+		}
+	}
+
+	shape, err := bits.ReadBits(buf, &pos, 2)
+	if err != nil {
+		return err
+	}
+
+	if shape == shapeGrayscale && verID != 1 {
+		pos += 4 // video_object_layer_shape_extension
+	}
+
+	pos++ // marker_bit
+
+	tmp, err := bits.ReadBits(buf, &pos, 16)
+	if err != nil {
+		return err
+	}
+	c.VOPTimeIncrementResolution = uint16(tmp)
+
+	if c.VOPTimeIncrementResolution == 0 {
+		return fmt.Errorf("invalid vop_time_increment_resolution")
+	}
+
+	pos++ // marker_bit
+
+	fixedVOPRate, err := bits.ReadFlag(buf, &pos)
+	if err != nil {
+		return err
+	}
+
+	if fixedVOPRate {
+		tmp, err := bits.ReadBits(buf, &pos, vopTimeIncrementBits(c.VOPTimeIncrementResolution))
+		if err != nil {
+			return err
+		}
+		c.FixedVOPTimeIncrement = uint16(tmp)
+	}
+
+	if shape != shapeRectangular {
+		return fmt.Errorf("unsupported video object layer shape: %d", shape)
+	}
+
+	pos++ // marker_bit
+
+	tmp, err = bits.ReadBits(buf, &pos, 13)
+	if err != nil {
+		return err
+	}
+	c.Width = int(tmp)
+
+	pos++ // marker_bit
+
+	tmp, err = bits.ReadBits(buf, &pos, 13)
+	if err != nil {
+		return err
+	}
+	c.Height = int(tmp)
+
+	return nil
+}
+
+// vopTimeIncrementBits returns the number of bits that are needed
+// to represent vop_time_increment.
+func vopTimeIncrementBits(resolution uint16) int {
+	n := 1
+	for (uint32(1) << n) < uint32(resolution) {
+		n++
+	}
+	return n
+}
+
+// FrameRate returns the frame rate, or zero if the frame rate is not fixed.
+func (c Config) FrameRate() float64 {
+	if c.FixedVOPTimeIncrement == 0 {
+		return 0
+	}
+	return float64(c.VOPTimeIncrementResolution) / float64(c.FixedVOPTimeIncrement)
+}
@@ -0,0 +1,111 @@
+package mpeg4video
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigUnmarshal(t *testing.T) {
+	for _, ca := range []struct {
+		name      string
+		byts      []byte
+		conf      Config
+		frameRate float64
+	}{
+		{
+			"176x144",
+			[]byte{
+				0x00, 0x00, 0x01, 0xb0, 0x08, 0x00, 0x00, 0x01,
+				0xb5, 0x09, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00,
+				0x01, 0x20, 0x00, 0x84, 0x40, 0xfa, 0x28, 0x2c,
+				0x20, 0x90, 0xa2, 0x1f,
+			},
+			Config{
+				ProfileLevelIndication:     8,
+				VideoObjectTypeIndication:  1,
+				Width:                      176,
+				Height:                     144,
+				VOPTimeIncrementResolution: 1000,
+			},
+			0,
+		},
+		{
+			"1600x900",
+			[]byte{
+				0x00, 0x00, 0x01, 0xb0, 0x01, 0x00, 0x00, 0x01,
+				0xb5, 0x89, 0x13, 0x00, 0x00, 0x01, 0x00, 0x00,
+				0x00, 0x01, 0x20, 0x00, 0xc4, 0x8d, 0x88, 0x00,
+				0xcd, 0x32, 0x04, 0x70, 0x94, 0x43, 0x00, 0x00,
+				0x01, 0xb2, 0x4c, 0x61, 0x76, 0x63, 0x35, 0x38,
+				0x2e, 0x35, 0x34, 0x2e, 0x31, 0x30, 0x30,
+			},
+			Config{
+				ProfileLevelIndication:     1,
+				VideoObjectTypeIndication:  1,
+				Width:                      1600,
+				Height:                     900,
+				VOPTimeIncrementResolution: 25,
+			},
+			0,
+		},
+		{
+			"640x480 fixed vop rate",
+			[]byte{
+				0x00, 0x00, 0x01, 0x20, 0x00, 0x84, 0x5d, 0x4c,
+				0x30, 0x7d, 0x31, 0x40, 0x43, 0xc1, 0x40,
+			},
+			Config{
+				VideoObjectTypeIndication:  1,
+				Width:                      640,
+				Height:                     480,
+				VOPTimeIncrementResolution: 30000,
+				FixedVOPTimeIncrement:      1001,
+			},
+			29.97002997002997,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var conf Config
+			err := conf.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.conf, conf)
+			require.Equal(t, ca.frameRate, conf.FrameRate())
+		})
+	}
+}
+
+func TestConfigUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"start code not found",
+		},
+		{
+			"missing vol",
+			[]byte{0x00, 0x00, 0x01, 0xb0, 0x08, 0x00, 0x00, 0x01, 0xb5, 0x09},
+			"video object layer header not found",
+		},
+		{
+			"truncated vol",
+			[]byte{0x00, 0x00, 0x01, 0x20, 0x00, 0x84, 0x5d},
+			"not enough bits",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var conf Config
+			err := conf.Unmarshal(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func TestFindStartCode(t *testing.T) {
+	require.Equal(t, 2, FindStartCode([]byte{0x01, 0x02, 0x00, 0x00, 0x01, 0xb6}, 0))
+	require.Equal(t, -1, FindStartCode([]byte{0x01, 0x02, 0x00, 0x00, 0x01, 0xb6}, 3))
+}
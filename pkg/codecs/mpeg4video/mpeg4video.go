@@ -0,0 +1,30 @@
+// Package mpeg4video contains utilities to work with MPEG-4 part 2 video codecs.
+package mpeg4video
+
+// StartCode is a MPEG-4 video start code.
+type StartCode uint8
+
+// start codes.
+const (
+	StartCodeVideoObjectFirst          StartCode = 0x00
+	StartCodeVideoObjectLast           StartCode = 0x1F
+	StartCodeVideoObjectLayerFirst     StartCode = 0x20
+	StartCodeVideoObjectLayerLast      StartCode = 0x2F
+	StartCodeVisualObjectSequenceStart StartCode = 0xB0
+	StartCodeVisualObjectSequenceEnd   StartCode = 0xB1
+	StartCodeUserData                  StartCode = 0xB2
+	StartCodeGroupOfVideoObjectPlane   StartCode = 0xB3
+	StartCodeVisualObject              StartCode = 0xB5
+	StartCodeVideoObjectPlane          StartCode = 0xB6
+)
+
+// FindStartCode returns the position of the first start code prefix (0x000001)
+// that is found at or after the given position, or -1.
+func FindStartCode(buf []byte, pos int) int {
+	for ; pos+3 < len(buf); pos++ {
+		if buf[pos] == 0 && buf[pos+1] == 0 && buf[pos+2] == 1 {
+			return pos
+		}
+	}
+	return -1
+}
@@ -0,0 +1,58 @@
+package opus
+
+import (
+	"time"
+)
+
+// PacketDuration returns the duration of an Opus packet.
+// Specification: RFC 6716, 3.1
+func PacketDuration(packet []byte) time.Duration {
+	if len(packet) == 0 {
+		return 0
+	}
+
+	config := packet[0] >> 3
+	var frameDuration time.Duration
+
+	switch {
+	case config < 12: // SILK-only
+		frameDuration = []time.Duration{
+			10 * time.Millisecond,
+			20 * time.Millisecond,
+			40 * time.Millisecond,
+			60 * time.Millisecond,
+		}[config%4]
+
+	case config < 16: // hybrid
+		frameDuration = []time.Duration{
+			10 * time.Millisecond,
+			20 * time.Millisecond,
+		}[config%2]
+
+	default: // CELT-only
+		frameDuration = []time.Duration{
+			2500 * time.Microsecond,
+			5 * time.Millisecond,
+			10 * time.Millisecond,
+			20 * time.Millisecond,
+		}[config%4]
+	}
+
+	var frameCount time.Duration
+
+	switch packet[0] & 0x03 {
+	case 0:
+		frameCount = 1
+
+	case 1, 2:
+		frameCount = 2
+
+	default:
+		if len(packet) < 2 {
+			return 0
+		}
+		frameCount = time.Duration(packet[1] & 0x3F)
+	}
+
+	return frameDuration * frameCount
+}
@@ -0,0 +1,46 @@
+package opus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketDuration(t *testing.T) {
+	for _, ca := range []struct {
+		name   string
+		packet []byte
+		dur    time.Duration
+	}{
+		{
+			"silk 20ms",
+			[]byte{0x08, 0x01},
+			20 * time.Millisecond,
+		},
+		{
+			"hybrid 10ms two frames",
+			[]byte{0x61, 0x01, 0x02},
+			20 * time.Millisecond,
+		},
+		{
+			"celt 20ms",
+			[]byte{0xf8, 0x01},
+			20 * time.Millisecond,
+		},
+		{
+			"celt 2.5ms arbitrary frames",
+			[]byte{0x83, 0x04, 0x01},
+			10 * time.Millisecond,
+		},
+		{
+			"empty",
+			[]byte{},
+			0,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			require.Equal(t, ca.dur, PacketDuration(ca.packet))
+		})
+	}
+}
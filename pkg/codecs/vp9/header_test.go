@@ -0,0 +1,106 @@
+package vp9
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderUnmarshal(t *testing.T) {
+	for _, ca := range []struct {
+		name              string
+		byts              []byte
+		sh                Header
+		width             int
+		height            int
+		chromaSubsampling uint8
+	}{
+		{
+			"1920x804",
+			[]byte{
+				0x82, 0x49, 0x83, 0x42, 0x00, 0x77, 0xf0, 0x32,
+				0x34, 0x30, 0x38, 0x24, 0x1c, 0x19, 0x40, 0x18,
+				0x03, 0x40, 0x5f, 0xb4,
+			},
+			Header{
+				ShowFrame: true,
+				ColorConfig: &Header_ColorConfig{
+					BitDepth:     8,
+					SubsamplingX: true,
+					SubsamplingY: true,
+				},
+				FrameSize: &Header_FrameSize{
+					FrameWidthMinus1:  1919,
+					FrameHeightMinus1: 803,
+				},
+			},
+			1920,
+			804,
+			1,
+		},
+		{
+			"vp9 profile 1",
+			[]byte{
+				0xa2, 0x49, 0x83, 0x42, 0x00, 0x0e, 0xfe, 0x08,
+				0x6e,
+			},
+			Header{
+				Profile:   1,
+				ShowFrame: true,
+				ColorConfig: &Header_ColorConfig{
+					BitDepth:     8,
+					SubsamplingX: false,
+					SubsamplingY: false,
+					ColorSpace:   0,
+				},
+				FrameSize: &Header_FrameSize{
+					FrameWidthMinus1:  1919,
+					FrameHeightMinus1: 1079,
+				},
+			},
+			1920,
+			1080,
+			3,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var sh Header
+			err := sh.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.sh, sh)
+			require.Equal(t, ca.width, sh.Width())
+			require.Equal(t, ca.height, sh.Height())
+			require.Equal(t, ca.chromaSubsampling, sh.ChromaSubsampling())
+		})
+	}
+}
+
+func TestHeaderUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"not enough bits",
+		},
+		{
+			"invalid frame marker",
+			[]byte{0x02, 0x49, 0x83, 0x42},
+			"invalid frame marker",
+		},
+		{
+			"invalid sync code",
+			[]byte{0x82, 0x49, 0x83, 0x43, 0x00},
+			"invalid frame sync code",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var sh Header
+			err := sh.Unmarshal(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
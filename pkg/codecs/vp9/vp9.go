@@ -0,0 +1,2 @@
+// Package vp9 contains utilities to work with the VP9 codec.
+package vp9
@@ -0,0 +1,156 @@
+package xiph
+
+import (
+	"fmt"
+)
+
+func base128Unmarshal(buf []byte) (uint32, int, error) {
+	var v uint32
+
+	for i := 0; i < len(buf) && i < 5; i++ {
+		v = (v << 7) | uint32(buf[i]&0x7F)
+
+		if (buf[i] & 0x80) == 0 {
+			return v, i + 1, nil
+		}
+	}
+
+	return 0, 0, fmt.Errorf("invalid base128 value")
+}
+
+func base128MarshalSize(v uint32) int {
+	n := 1
+	for v >= 0x80 {
+		v >>= 7
+		n++
+	}
+	return n
+}
+
+func base128MarshalTo(buf []byte, v uint32) int {
+	n := base128MarshalSize(v)
+
+	for i := n - 1; i >= 0; i-- {
+		buf[i] = byte(v & 0x7F)
+		if i != (n - 1) {
+			buf[i] |= 0x80
+		}
+		v >>= 7
+	}
+
+	return n
+}
+
+// Config is a Xiph packed configuration,
+// that contains the headers needed to decode a Vorbis or Theora stream.
+// Specification: RFC5215, 3.2.1
+type Config struct {
+	// used to associate the configuration to RTP packets.
+	Ident uint32
+
+	IdentificationHeader []byte
+	CommentHeader        []byte
+	SetupHeader          []byte
+}
+
+// Unmarshal decodes a Config.
+// Only the first packed header is decoded.
+func (c *Config) Unmarshal(buf []byte) error {
+	if len(buf) < 4 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	count := uint32(buf[0])<<24 | uint32(buf[1])<<16 | uint32(buf[2])<<8 | uint32(buf[3])
+	if count == 0 {
+		return fmt.Errorf("no packed headers")
+	}
+	buf = buf[4:]
+
+	if len(buf) < 5 {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	c.Ident = uint32(buf[0])<<16 | uint32(buf[1])<<8 | uint32(buf[2])
+	le := int(uint16(buf[3])<<8 | uint16(buf[4]))
+	buf = buf[5:]
+
+	headerCount, n, err := base128Unmarshal(buf)
+	if err != nil {
+		return err
+	}
+	buf = buf[n:]
+
+	// the field contains the header count minus one
+	if headerCount != 2 {
+		return fmt.Errorf("unsupported header count (%d)", headerCount+1)
+	}
+
+	var lens [3]int
+
+	for i := 0; i < 2; i++ {
+		v, n, err := base128Unmarshal(buf)
+		if err != nil {
+			return err
+		}
+		buf = buf[n:]
+		lens[i] = int(v)
+	}
+
+	if le > len(buf) {
+		return fmt.Errorf("not enough bytes")
+	}
+
+	if (lens[0] + lens[1]) > le {
+		return fmt.Errorf("invalid header lengths")
+	}
+
+	// the length of the last header is implicit
+	lens[2] = le - lens[0] - lens[1]
+
+	c.IdentificationHeader = buf[:lens[0]]
+	buf = buf[lens[0]:]
+	c.CommentHeader = buf[:lens[1]]
+	buf = buf[lens[1]:]
+	c.SetupHeader = buf[:lens[2]]
+
+	return nil
+}
+
+func (c Config) marshalSize() int {
+	return 4 + 5 + 1 +
+		base128MarshalSize(uint32(len(c.IdentificationHeader))) +
+		base128MarshalSize(uint32(len(c.CommentHeader))) +
+		len(c.IdentificationHeader) + len(c.CommentHeader) + len(c.SetupHeader)
+}
+
+// Marshal encodes a Config.
+func (c Config) Marshal() ([]byte, error) {
+	if c.Ident > 0xFFFFFF {
+		return nil, fmt.Errorf("invalid ident (%d)", c.Ident)
+	}
+
+	le := len(c.IdentificationHeader) + len(c.CommentHeader) + len(c.SetupHeader)
+	if le > 0xFFFF {
+		return nil, fmt.Errorf("headers are too big")
+	}
+
+	buf := make([]byte, c.marshalSize())
+
+	buf[3] = 1 // packed header count
+
+	buf[4] = byte(c.Ident >> 16)
+	buf[5] = byte(c.Ident >> 8)
+	buf[6] = byte(c.Ident)
+	buf[7] = byte(le >> 8)
+	buf[8] = byte(le)
+	buf[9] = 2 // header count minus one
+	pos := 10
+
+	pos += base128MarshalTo(buf[pos:], uint32(len(c.IdentificationHeader)))
+	pos += base128MarshalTo(buf[pos:], uint32(len(c.CommentHeader)))
+	pos += copy(buf[pos:], c.IdentificationHeader)
+	pos += copy(buf[pos:], c.CommentHeader)
+	copy(buf[pos:], c.SetupHeader)
+
+	return buf, nil
+}
@@ -0,0 +1,121 @@
+//go:build go1.18
+// +build go1.18
+
+package xiph
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var casesConfig = []struct {
+	name string
+	byts []byte
+	conf Config
+}{
+	{
+		"short headers",
+		[]byte{
+			0x00, 0x00, 0x00, 0x01, 0xaa, 0xbb, 0xcc, 0x00,
+			0x07, 0x02, 0x03, 0x01, 0x01, 0x02, 0x03, 0x04,
+			0x05, 0x06, 0x07,
+		},
+		Config{
+			Ident:                0xaabbcc,
+			IdentificationHeader: []byte{0x01, 0x02, 0x03},
+			CommentHeader:        []byte{0x04},
+			SetupHeader:          []byte{0x05, 0x06, 0x07},
+		},
+	},
+	{
+		"long headers",
+		append([]byte{
+			0x00, 0x00, 0x00, 0x01, 0xfe, 0xcd, 0xba, 0x00,
+			0xa3, 0x02, 0x1e, 0x81, 0x02,
+		}, append(append(
+			bytes.Repeat([]byte{0x01}, 30),
+			bytes.Repeat([]byte{0x03}, 130)...),
+			0x05, 0x05, 0x05)...),
+		Config{
+			Ident:                0xfecdba,
+			IdentificationHeader: bytes.Repeat([]byte{0x01}, 30),
+			CommentHeader:        bytes.Repeat([]byte{0x03}, 130),
+			SetupHeader:          []byte{0x05, 0x05, 0x05},
+		},
+	},
+}
+
+func TestConfigUnmarshal(t *testing.T) {
+	for _, ca := range casesConfig {
+		t.Run(ca.name, func(t *testing.T) {
+			var conf Config
+			err := conf.Unmarshal(ca.byts)
+			require.NoError(t, err)
+			require.Equal(t, ca.conf, conf)
+		})
+	}
+}
+
+func TestConfigMarshal(t *testing.T) {
+	for _, ca := range casesConfig {
+		t.Run(ca.name, func(t *testing.T) {
+			byts, err := ca.conf.Marshal()
+			require.NoError(t, err)
+			require.Equal(t, ca.byts, byts)
+		})
+	}
+}
+
+func TestConfigUnmarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"not enough bytes",
+		},
+		{
+			"no packed headers",
+			[]byte{0x00, 0x00, 0x00, 0x00},
+			"no packed headers",
+		},
+		{
+			"unsupported header count",
+			[]byte{0x00, 0x00, 0x00, 0x01, 0xaa, 0xbb, 0xcc, 0x00, 0x00, 0x01},
+			"unsupported header count (2)",
+		},
+		{
+			"invalid base128",
+			[]byte{0x00, 0x00, 0x00, 0x01, 0xaa, 0xbb, 0xcc, 0x00, 0x00, 0x02, 0x81},
+			"invalid base128 value",
+		},
+		{
+			"truncated",
+			[]byte{0x00, 0x00, 0x00, 0x01, 0xaa, 0xbb, 0xcc, 0x00, 0x07, 0x02, 0x03, 0x01, 0x01},
+			"not enough bytes",
+		},
+		{
+			"invalid lengths",
+			[]byte{0x00, 0x00, 0x00, 0x01, 0xaa, 0xbb, 0xcc, 0x00, 0x02, 0x02, 0x03, 0x01, 0x01, 0x02},
+			"invalid header lengths",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			var conf Config
+			err := conf.Unmarshal(ca.byts)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzConfigUnmarshal(f *testing.F) {
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var conf Config
+		conf.Unmarshal(b) //nolint:errcheck
+	})
+}
@@ -0,0 +1,7 @@
+// Package xiph contains utilities to work with the Xiph codecs (Vorbis and Theora).
+package xiph
+
+const (
+	// MaxPacketSize is the maximum size of a Vorbis or Theora packet.
+	MaxPacketSize = 3 * 1024 * 1024
+)
@@ -10,15 +10,19 @@ import (
 
 const (
 	readBufferSize = 4096
+
+	// responses start with the protocol, requests with the method.
+	rtspProtocolPrefix = "RTSP/"
 )
 
 // Conn is a RTSP connection.
 type Conn struct {
-	w   io.Writer
-	br  *bufio.Reader
-	req base.Request
-	res base.Response
-	fr  base.InterleavedFrame
+	w      io.Writer
+	br     *bufio.Reader
+	limits base.ReadLimits
+	req    base.Request
+	res    base.Response
+	fr     base.InterleavedFrame
 }
 
 // NewConn allocates a Conn.
@@ -29,15 +33,20 @@ func NewConn(rw io.ReadWriter) *Conn {
 	}
 }
 
+// SetReadLimits sets the limits applied when reading requests and responses.
+func (c *Conn) SetReadLimits(limits base.ReadLimits) {
+	c.limits = limits
+}
+
 // ReadRequest reads a Request.
 func (c *Conn) ReadRequest() (*base.Request, error) {
-	err := c.req.Read(c.br)
+	err := c.req.ReadLimited(c.br, c.limits)
 	return &c.req, err
 }
 
 // ReadResponse reads a Response.
 func (c *Conn) ReadResponse() (*base.Response, error) {
-	err := c.res.Read(c.br)
+	err := c.res.ReadLimited(c.br, c.limits)
 	return &c.res, err
 }
 
@@ -77,6 +86,30 @@ func (c *Conn) ReadInterleavedFrameOrResponse() (interface{}, error) {
 	return c.ReadResponse()
 }
 
+// ReadInterleavedFrameOrRequestOrResponse reads an InterleavedFrame, a Request or a Response.
+// Responses can be received by servers in reply to requests initiated by them.
+func (c *Conn) ReadInterleavedFrameOrRequestOrResponse() (interface{}, error) {
+	b, err := c.br.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if b[0] == base.InterleavedFrameMagicByte {
+		return c.ReadInterleavedFrame()
+	}
+
+	b, err = c.br.Peek(len(rtspProtocolPrefix))
+	if err != nil {
+		return nil, err
+	}
+
+	if string(b) == rtspProtocolPrefix {
+		return c.ReadResponse()
+	}
+
+	return c.ReadRequest()
+}
+
 // ReadRequestIgnoreFrames reads a Request and ignores frames in between.
 func (c *Conn) ReadRequestIgnoreFrames() (*base.Request, error) {
 	for {
@@ -138,6 +138,49 @@ func TestReadInterleavedFrameOrResponseErrors(t *testing.T) {
 	}
 }
 
+func TestReadInterleavedFrameOrRequestOrResponse(t *testing.T) {
+	byts := []byte("RTSP/1.0 200 OK\r\n" +
+		"CSeq: 1\r\n" +
+		"\r\n")
+	byts = append(byts, []byte("OPTIONS rtsp://example.com/media.mp4 RTSP/1.0\r\n"+
+		"CSeq: 2\r\n"+
+		"\r\n")...)
+	byts = append(byts, []byte{0x24, 0x6, 0x0, 0x0}...)
+
+	conn := NewConn(bytes.NewBuffer(byts))
+
+	out, err := conn.ReadInterleavedFrameOrRequestOrResponse()
+	require.NoError(t, err)
+	require.Equal(t, &base.Response{
+		StatusCode:    200,
+		StatusMessage: "OK",
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"1"},
+		},
+	}, out)
+
+	out, err = conn.ReadInterleavedFrameOrRequestOrResponse()
+	require.NoError(t, err)
+	require.Equal(t, &base.Request{
+		Method: base.Options,
+		URL: &url.URL{
+			Scheme: "rtsp",
+			Host:   "example.com",
+			Path:   "/media.mp4",
+		},
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"2"},
+		},
+	}, out)
+
+	out, err = conn.ReadInterleavedFrameOrRequestOrResponse()
+	require.NoError(t, err)
+	require.Equal(t, &base.InterleavedFrame{
+		Channel: 6,
+		Payload: []byte{},
+	}, out)
+}
+
 func TestReadRequestIgnoreFrames(t *testing.T) {
 	byts := []byte{0x24, 0x6, 0x0, 0x4, 0x1, 0x2, 0x3, 0x4}
 	byts = append(byts, []byte("OPTIONS rtsp://example.com/media.mp4 RTSP/1.0\r\n"+
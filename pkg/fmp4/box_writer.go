@@ -0,0 +1,70 @@
+package fmp4
+
+import (
+	"encoding/binary"
+)
+
+// boxWriter writes ISO-BMFF boxes into a buffer.
+type boxWriter struct {
+	buf []byte
+}
+
+// beginBox writes the header of a box and returns its position.
+func (w *boxWriter) beginBox(typ string) int {
+	pos := len(w.buf)
+	w.buf = append(w.buf, 0, 0, 0, 0)
+	w.buf = append(w.buf, typ...)
+	return pos
+}
+
+// beginFullBox writes the header of a full box and returns its position.
+func (w *boxWriter) beginFullBox(typ string, version uint8, flags uint32) int {
+	pos := w.beginBox(typ)
+	w.writeUint32(uint32(version)<<24 | flags)
+	return pos
+}
+
+// endBox fills the size of the box that starts at the given position.
+func (w *boxWriter) endBox(pos int) {
+	binary.BigEndian.PutUint32(w.buf[pos:], uint32(len(w.buf)-pos))
+}
+
+func (w *boxWriter) writeUint8(v uint8) {
+	w.buf = append(w.buf, v)
+}
+
+func (w *boxWriter) writeUint16(v uint16) {
+	w.buf = append(w.buf, byte(v>>8), byte(v))
+}
+
+func (w *boxWriter) writeUint32(v uint32) {
+	w.buf = append(w.buf, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func (w *boxWriter) writeUint64(v uint64) {
+	w.writeUint32(uint32(v >> 32))
+	w.writeUint32(uint32(v))
+}
+
+func (w *boxWriter) writeBytes(v []byte) {
+	w.buf = append(w.buf, v...)
+}
+
+func (w *boxWriter) writeZeros(n int) {
+	for i := 0; i < n; i++ {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+// writeMatrix writes an unity matrix.
+func (w *boxWriter) writeMatrix() {
+	w.writeUint32(0x00010000)
+	w.writeUint32(0)
+	w.writeUint32(0)
+	w.writeUint32(0)
+	w.writeUint32(0x00010000)
+	w.writeUint32(0)
+	w.writeUint32(0)
+	w.writeUint32(0)
+	w.writeUint32(0x40000000)
+}
@@ -0,0 +1,82 @@
+package fmp4
+
+import (
+	"github.com/aler9/gortsplib/v2/pkg/codecs/ac3"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+)
+
+// Codec is a fMP4 codec.
+type Codec interface {
+	isVideo() bool
+}
+
+// CodecH264 is a H264 codec.
+type CodecH264 struct {
+	SPS []byte
+	PPS []byte
+}
+
+func (c *CodecH264) isVideo() bool {
+	return true
+}
+
+// CodecH265 is a H265 codec.
+type CodecH265 struct {
+	VPS []byte
+	SPS []byte
+	PPS []byte
+}
+
+func (c *CodecH265) isVideo() bool {
+	return true
+}
+
+// CodecVP9 is a VP9 codec.
+type CodecVP9 struct {
+	Width             int
+	Height            int
+	Profile           uint8
+	BitDepth          uint8
+	ChromaSubsampling uint8
+	ColorRange        bool
+}
+
+func (c *CodecVP9) isVideo() bool {
+	return true
+}
+
+// CodecMPEG4Audio is a MPEG-4 Audio codec.
+type CodecMPEG4Audio struct {
+	Config mpeg4audio.Config
+}
+
+func (c *CodecMPEG4Audio) isVideo() bool {
+	return false
+}
+
+// CodecOpus is a Opus codec.
+type CodecOpus struct {
+	ChannelCount int
+}
+
+func (c *CodecOpus) isVideo() bool {
+	return false
+}
+
+// CodecAC3 is an AC-3 codec.
+type CodecAC3 struct {
+	Header ac3.SyncFrameHeader
+}
+
+func (c *CodecAC3) isVideo() bool {
+	return false
+}
+
+// CodecEAC3 is an E-AC-3 codec.
+type CodecEAC3 struct {
+	Header ac3.EAC3SyncFrameHeader
+}
+
+func (c *CodecEAC3) isVideo() bool {
+	return false
+}
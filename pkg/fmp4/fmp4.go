@@ -0,0 +1,15 @@
+// Package fmp4 contains a fragmented MP4 (CMAF) muxer.
+package fmp4
+
+import (
+	"time"
+)
+
+// convert a duration into a timestamp expressed in the given timescale.
+// the result is rounded, since durations are often truncated (i.e. 1024 samples at 44100Hz).
+func durationGoToMP4(v time.Duration, timeScale uint32) int64 {
+	timeScale64 := int64(timeScale)
+	secs := int64(v / time.Second)
+	dec := int64(v % time.Second)
+	return secs*timeScale64 + (dec*timeScale64+int64(time.Second)/2)/int64(time.Second)
+}
@@ -0,0 +1,108 @@
+package fmp4
+
+import (
+	"encoding/binary"
+)
+
+const (
+	sampleFlagsSync    = 0x02000000 // sample_depends_on = 2
+	sampleFlagsNonSync = 0x01010000 // sample_depends_on = 1, sample_is_non_sync_sample = 1
+)
+
+// Sample is a sample of a fragment.
+type Sample struct {
+	Duration        uint32
+	PTSOffset       int32
+	IsNonSyncSample bool
+	Payload         []byte
+}
+
+// FragmentTrack is a track of a fragment.
+type FragmentTrack struct {
+	ID       int
+	BaseTime uint64
+	Samples  []*Sample
+}
+
+// Fragment is a fMP4 fragment.
+type Fragment struct {
+	SequenceNumber uint32
+	Tracks         []*FragmentTrack
+}
+
+// Marshal encodes a fragment.
+func (f *Fragment) Marshal() ([]byte, error) {
+	w := &boxWriter{}
+
+	/*
+	   - moof
+	     - mfhd
+	     - traf
+	       - tfhd
+	       - tfdt
+	       - trun
+	     - traf
+	     - ...
+	   - mdat
+	*/
+
+	moof := w.beginBox("moof")
+
+	mfhd := w.beginFullBox("mfhd", 0, 0)
+	w.writeUint32(f.SequenceNumber)
+	w.endBox(mfhd)
+
+	dataOffsetPositions := make([]int, len(f.Tracks))
+
+	for i, track := range f.Tracks {
+		traf := w.beginBox("traf")
+
+		tfhd := w.beginFullBox("tfhd", 0, 0x020000) // default base is moof
+		w.writeUint32(uint32(track.ID))
+		w.endBox(tfhd)
+
+		tfdt := w.beginFullBox("tfdt", 1, 0)
+		w.writeUint64(track.BaseTime)
+		w.endBox(tfdt)
+
+		// data offset, sample duration, size, flags and composition time offset are present
+		trun := w.beginFullBox("trun", 1, 0x000f01)
+		w.writeUint32(uint32(len(track.Samples)))
+		dataOffsetPositions[i] = len(w.buf)
+		w.writeUint32(0) // data offset, filled later
+		for _, sample := range track.Samples {
+			w.writeUint32(sample.Duration)
+			w.writeUint32(uint32(len(sample.Payload)))
+			if sample.IsNonSyncSample {
+				w.writeUint32(sampleFlagsNonSync)
+			} else {
+				w.writeUint32(sampleFlagsSync)
+			}
+			w.writeUint32(uint32(sample.PTSOffset))
+		}
+		w.endBox(trun)
+
+		w.endBox(traf)
+	}
+
+	w.endBox(moof)
+
+	// data offsets are relative to the beginning of moof
+	dataOffset := len(w.buf) + 8
+	for i, track := range f.Tracks {
+		binary.BigEndian.PutUint32(w.buf[dataOffsetPositions[i]:], uint32(dataOffset))
+		for _, sample := range track.Samples {
+			dataOffset += len(sample.Payload)
+		}
+	}
+
+	mdat := w.beginBox("mdat")
+	for _, track := range f.Tracks {
+		for _, sample := range track.Samples {
+			w.writeBytes(sample.Payload)
+		}
+	}
+	w.endBox(mdat)
+
+	return w.buf, nil
+}
@@ -0,0 +1,55 @@
+package fmp4
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFragmentMarshal(t *testing.T) {
+	frag := &Fragment{
+		SequenceNumber: 3,
+		Tracks: []*FragmentTrack{
+			{
+				ID:       1,
+				BaseTime: 90000,
+				Samples: []*Sample{
+					{
+						Duration:  3000,
+						PTSOffset: 6000,
+						Payload:   []byte{1, 2},
+					},
+					{
+						Duration:        3000,
+						PTSOffset:       -3000,
+						IsNonSyncSample: true,
+						Payload:         []byte{3},
+					},
+				},
+			},
+		},
+	}
+
+	byts, err := frag.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, []byte{
+		0x00, 0x00, 0x00, 0x78, 'm', 'o', 'o', 'f',
+		0x00, 0x00, 0x00, 0x10, 'm', 'f', 'h', 'd',
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03,
+		0x00, 0x00, 0x00, 0x60, 't', 'r', 'a', 'f',
+		0x00, 0x00, 0x00, 0x10, 't', 'f', 'h', 'd',
+		0x00, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x14, 't', 'f', 'd', 't',
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x01, 0x5f, 0x90,
+		0x00, 0x00, 0x00, 0x34, 't', 'r', 'u', 'n',
+		0x01, 0x00, 0x0f, 0x01, 0x00, 0x00, 0x00, 0x02,
+		0x00, 0x00, 0x00, 0x80,
+		0x00, 0x00, 0x0b, 0xb8, 0x00, 0x00, 0x00, 0x02,
+		0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x17, 0x70,
+		0x00, 0x00, 0x0b, 0xb8, 0x00, 0x00, 0x00, 0x01,
+		0x01, 0x01, 0x00, 0x00, 0xff, 0xff, 0xf4, 0x48,
+		0x00, 0x00, 0x00, 0x0b, 'm', 'd', 'a', 't',
+		0x01, 0x02, 0x03,
+	}, byts)
+}
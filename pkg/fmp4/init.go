@@ -0,0 +1,475 @@
+package fmp4
+
+import (
+	"fmt"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h264"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h265"
+)
+
+// InitTrack is a track of an initialization segment.
+type InitTrack struct {
+	ID        int
+	TimeScale uint32
+	Codec     Codec
+}
+
+// Init is a fMP4 initialization segment.
+type Init struct {
+	Tracks []*InitTrack
+}
+
+// Marshal encodes an initialization segment.
+func (i *Init) Marshal() ([]byte, error) {
+	w := &boxWriter{}
+
+	/*
+	   - ftyp
+	   - moov
+	     - mvhd
+	     - trak
+	     - trak
+	     - ...
+	     - mvex
+	       - trex
+	       - trex
+	       - ...
+	*/
+
+	ftyp := w.beginBox("ftyp")
+	w.writeBytes([]byte("iso5")) // major brand
+	w.writeUint32(512)           // minor version
+	w.writeBytes([]byte("iso5"))
+	w.writeBytes([]byte("iso6"))
+	w.writeBytes([]byte("mp41"))
+	w.writeBytes([]byte("cmfc"))
+	w.endBox(ftyp)
+
+	moov := w.beginBox("moov")
+
+	mvhd := w.beginFullBox("mvhd", 0, 0)
+	w.writeUint32(0)          // creation time
+	w.writeUint32(0)          // modification time
+	w.writeUint32(1000)       // timescale
+	w.writeUint32(0)          // duration
+	w.writeUint32(0x00010000) // rate
+	w.writeUint16(0x0100)     // volume
+	w.writeZeros(10)          // reserved
+	w.writeMatrix()
+	w.writeZeros(24) // pre-defined
+	w.writeUint32(uint32(len(i.Tracks) + 1))
+	w.endBox(mvhd)
+
+	for _, track := range i.Tracks {
+		err := track.marshal(w)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	mvex := w.beginBox("mvex")
+	for _, track := range i.Tracks {
+		trex := w.beginFullBox("trex", 0, 0)
+		w.writeUint32(uint32(track.ID))
+		w.writeUint32(1) // default sample description index
+		w.writeUint32(0) // default sample duration
+		w.writeUint32(0) // default sample size
+		w.writeUint32(0) // default sample flags
+		w.endBox(trex)
+	}
+	w.endBox(mvex)
+
+	w.endBox(moov)
+
+	return w.buf, nil
+}
+
+func (track *InitTrack) marshal(w *boxWriter) error {
+	/*
+	   - trak
+	     - tkhd
+	     - mdia
+	       - mdhd
+	       - hdlr
+	       - minf
+	         - vmhd / smhd
+	         - dinf
+	           - dref
+	             - url
+	         - stbl
+	           - stsd
+	             - avc1 / hvc1 / vp09 / mp4a / Opus / ac-3 / ec-3
+	           - stts
+	           - stsc
+	           - stsz
+	           - stco
+	*/
+
+	width, height, err := track.videoSize()
+	if err != nil {
+		return err
+	}
+
+	trak := w.beginBox("trak")
+
+	tkhd := w.beginFullBox("tkhd", 0, 3)
+	w.writeUint32(0) // creation time
+	w.writeUint32(0) // modification time
+	w.writeUint32(uint32(track.ID))
+	w.writeUint32(0) // reserved
+	w.writeUint32(0) // duration
+	w.writeZeros(8)  // reserved
+	w.writeUint16(0) // layer
+	w.writeUint16(0) // alternate group
+	if track.Codec.isVideo() {
+		w.writeUint16(0) // volume
+	} else {
+		w.writeUint16(0x0100) // volume
+	}
+	w.writeUint16(0) // reserved
+	w.writeMatrix()
+	w.writeUint32(uint32(width) << 16)
+	w.writeUint32(uint32(height) << 16)
+	w.endBox(tkhd)
+
+	mdia := w.beginBox("mdia")
+
+	mdhd := w.beginFullBox("mdhd", 0, 0)
+	w.writeUint32(0) // creation time
+	w.writeUint32(0) // modification time
+	w.writeUint32(track.TimeScale)
+	w.writeUint32(0)      // duration
+	w.writeUint16(0x55C4) // language (und)
+	w.writeUint16(0)      // pre-defined
+	w.endBox(mdhd)
+
+	hdlr := w.beginFullBox("hdlr", 0, 0)
+	w.writeUint32(0) // pre-defined
+	if track.Codec.isVideo() {
+		w.writeBytes([]byte("vide"))
+	} else {
+		w.writeBytes([]byte("soun"))
+	}
+	w.writeZeros(12) // reserved
+	if track.Codec.isVideo() {
+		w.writeBytes([]byte("VideoHandler\x00"))
+	} else {
+		w.writeBytes([]byte("SoundHandler\x00"))
+	}
+	w.endBox(hdlr)
+
+	minf := w.beginBox("minf")
+
+	if track.Codec.isVideo() {
+		vmhd := w.beginFullBox("vmhd", 0, 1)
+		w.writeUint16(0) // graphics mode
+		w.writeZeros(6)  // opcolor
+		w.endBox(vmhd)
+	} else {
+		smhd := w.beginFullBox("smhd", 0, 0)
+		w.writeUint16(0) // balance
+		w.writeUint16(0) // reserved
+		w.endBox(smhd)
+	}
+
+	dinf := w.beginBox("dinf")
+	dref := w.beginFullBox("dref", 0, 0)
+	w.writeUint32(1) // entry count
+	url := w.beginFullBox("url ", 0, 1)
+	w.endBox(url)
+	w.endBox(dref)
+	w.endBox(dinf)
+
+	stbl := w.beginBox("stbl")
+
+	stsd := w.beginFullBox("stsd", 0, 0)
+	w.writeUint32(1) // entry count
+	err = track.marshalSampleEntry(w, width, height)
+	if err != nil {
+		return err
+	}
+	w.endBox(stsd)
+
+	for _, typ := range []string{"stts", "stsc", "stco"} {
+		box := w.beginFullBox(typ, 0, 0)
+		w.writeUint32(0) // entry count
+		w.endBox(box)
+	}
+
+	stsz := w.beginFullBox("stsz", 0, 0)
+	w.writeUint32(0) // sample size
+	w.writeUint32(0) // sample count
+	w.endBox(stsz)
+
+	w.endBox(stbl)
+	w.endBox(minf)
+	w.endBox(mdia)
+	w.endBox(trak)
+
+	return nil
+}
+
+func (track *InitTrack) videoSize() (int, int, error) {
+	switch codec := track.Codec.(type) {
+	case *CodecH264:
+		if codec.SPS == nil || codec.PPS == nil {
+			return 0, 0, fmt.Errorf("H264 parameters not provided")
+		}
+
+		var sps h264.SPS
+		err := sps.Unmarshal(codec.SPS)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		return sps.Width(), sps.Height(), nil
+
+	case *CodecH265:
+		if codec.VPS == nil || codec.SPS == nil || codec.PPS == nil {
+			return 0, 0, fmt.Errorf("H265 parameters not provided")
+		}
+
+		var sps h265.SPS
+		err := sps.Unmarshal(codec.SPS)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		return sps.Width(), sps.Height(), nil
+
+	case *CodecVP9:
+		return codec.Width, codec.Height, nil
+	}
+
+	return 0, 0, nil
+}
+
+func marshalVisualSampleEntryHeader(w *boxWriter, width int, height int) {
+	w.writeZeros(6)               // reserved
+	w.writeUint16(1)              // data reference index
+	w.writeZeros(16)              // pre-defined + reserved
+	w.writeUint16(uint16(width))  // width
+	w.writeUint16(uint16(height)) // height
+	w.writeUint32(0x00480000)     // horizontal resolution
+	w.writeUint32(0x00480000)     // vertical resolution
+	w.writeUint32(0)              // reserved
+	w.writeUint16(1)              // frame count
+	w.writeZeros(32)              // compressor name
+	w.writeUint16(0x0018)         // depth
+	w.writeUint16(0xFFFF)         // pre-defined
+}
+
+func marshalAudioSampleEntryHeader(w *boxWriter, channelCount int, sampleRate int) {
+	w.writeZeros(6)                         // reserved
+	w.writeUint16(1)                        // data reference index
+	w.writeZeros(8)                         // reserved
+	w.writeUint16(uint16(channelCount))     // channel count
+	w.writeUint16(16)                       // sample size
+	w.writeUint16(0)                        // pre-defined
+	w.writeUint16(0)                        // reserved
+	w.writeUint32(uint32(sampleRate) << 16) // sample rate
+}
+
+func (track *InitTrack) marshalSampleEntry(w *boxWriter, width int, height int) error {
+	switch codec := track.Codec.(type) {
+	case *CodecH264:
+		avc1 := w.beginBox("avc1")
+		marshalVisualSampleEntryHeader(w, width, height)
+
+		avcc := w.beginBox("avcC")
+		w.writeUint8(1)            // configuration version
+		w.writeUint8(codec.SPS[1]) // profile
+		w.writeUint8(codec.SPS[2]) // profile compatibility
+		w.writeUint8(codec.SPS[3]) // level
+		w.writeUint8(0xFF)         // reserved + length size minus one
+		w.writeUint8(0xE1)         // reserved + number of SPS
+		w.writeUint16(uint16(len(codec.SPS)))
+		w.writeBytes(codec.SPS)
+		w.writeUint8(1) // number of PPS
+		w.writeUint16(uint16(len(codec.PPS)))
+		w.writeBytes(codec.PPS)
+		w.endBox(avcc)
+
+		w.endBox(avc1)
+
+	case *CodecH265:
+		var sps h265.SPS
+		err := sps.Unmarshal(codec.SPS)
+		if err != nil {
+			return err
+		}
+
+		// profile_tier_level is copied as is from the SPS
+		rbsp := h264.EmulationPreventionRemove(codec.SPS)
+		if len(rbsp) < 15 {
+			return fmt.Errorf("invalid SPS")
+		}
+
+		hvc1 := w.beginBox("hvc1")
+		marshalVisualSampleEntryHeader(w, width, height)
+
+		hvcc := w.beginBox("hvcC")
+		w.writeUint8(1)          // configuration version
+		w.writeBytes(rbsp[3:15]) // profile space, tier, profile, compatibility flags, constraint flags, level
+		w.writeUint16(0xF000)    // reserved + min spatial segmentation
+		w.writeUint8(0xFC)       // reserved + parallelism type
+		w.writeUint8(0xFC | uint8(sps.ChromaFormatIdc))
+		w.writeUint8(0xF8 | uint8(sps.BitDepthLumaMinus8))
+		w.writeUint8(0xF8 | uint8(sps.BitDepthChromaMinus8))
+		w.writeUint16(0) // average frame rate
+		temporalIDNested := uint8(0)
+		if sps.TemporalIDNestingFlag {
+			temporalIDNested = 1
+		}
+		w.writeUint8((sps.MaxSubLayersMinus1+1)<<3 | temporalIDNested<<2 | 0x03)
+		w.writeUint8(3) // number of arrays
+
+		for _, nalu := range [][]byte{codec.VPS, codec.SPS, codec.PPS} {
+			w.writeUint8(0x80 | (nalu[0]>>1)&0b111111) // array completeness + NALU type
+			w.writeUint16(1)                           // number of NALUs
+			w.writeUint16(uint16(len(nalu)))
+			w.writeBytes(nalu)
+		}
+		w.endBox(hvcc)
+
+		w.endBox(hvc1)
+
+	case *CodecVP9:
+		vp09 := w.beginBox("vp09")
+		marshalVisualSampleEntryHeader(w, width, height)
+
+		vpcc := w.beginFullBox("vpcC", 1, 0)
+		w.writeUint8(codec.Profile)
+		w.writeUint8(10) // level
+		colorRange := uint8(0)
+		if codec.ColorRange {
+			colorRange = 1
+		}
+		w.writeUint8(codec.BitDepth<<4 | codec.ChromaSubsampling<<1 | colorRange)
+		w.writeUint8(2)  // color primaries (unspecified)
+		w.writeUint8(2)  // transfer characteristics (unspecified)
+		w.writeUint8(2)  // matrix coefficients (unspecified)
+		w.writeUint16(0) // codec initialization data size
+		w.endBox(vpcc)
+
+		w.endBox(vp09)
+
+	case *CodecMPEG4Audio:
+		enc, err := codec.Config.Marshal()
+		if err != nil {
+			return err
+		}
+
+		mp4a := w.beginBox("mp4a")
+		marshalAudioSampleEntryHeader(w, codec.Config.ChannelCount, codec.Config.SampleRate)
+
+		esds := w.beginFullBox("esds", 0, 0)
+
+		w.writeUint8(0x03) // ES descriptor
+		w.writeUint8(uint8(3 + 2 + 13 + 2 + len(enc) + 3))
+		w.writeUint16(uint16(track.ID)) // ES ID
+		w.writeUint8(0)                 // flags
+
+		w.writeUint8(0x04) // decoder config descriptor
+		w.writeUint8(uint8(13 + 2 + len(enc)))
+		w.writeUint8(0x40) // object type indication (MPEG-4 Audio)
+		w.writeUint8(0x15) // stream type (audio) + upstream + reserved
+		w.writeZeros(3)    // buffer size
+		w.writeUint32(0)   // max bitrate
+		w.writeUint32(0)   // average bitrate
+
+		w.writeUint8(0x05) // decoder specific info
+		w.writeUint8(uint8(len(enc)))
+		w.writeBytes(enc)
+
+		w.writeUint8(0x06) // SL config descriptor
+		w.writeUint8(1)
+		w.writeUint8(2) // pre-defined
+
+		w.endBox(esds)
+
+		w.endBox(mp4a)
+
+	case *CodecOpus:
+		if codec.ChannelCount > 2 {
+			return fmt.Errorf("Opus streams with more than 2 channels are not supported")
+		}
+
+		opus := w.beginBox("Opus")
+		marshalAudioSampleEntryHeader(w, codec.ChannelCount, 48000)
+
+		dops := w.beginBox("dOps")
+		w.writeUint8(0) // version
+		w.writeUint8(uint8(codec.ChannelCount))
+		w.writeUint16(312)   // pre-skip
+		w.writeUint32(48000) // input sample rate
+		w.writeUint16(0)     // output gain
+		w.writeUint8(0)      // channel mapping family
+		w.endBox(dops)
+
+		w.endBox(opus)
+
+	case *CodecAC3:
+		ac3 := w.beginBox("ac-3")
+		marshalAudioSampleEntryHeader(w, codec.Header.ChannelCount(), codec.Header.SampleRate)
+
+		dac3 := w.beginBox("dac3")
+		v := ac3Fscod(codec.Header.SampleRate)<<22 |
+			uint32(codec.Header.BSID)<<17 |
+			uint32(codec.Header.BSMod)<<14 |
+			uint32(codec.Header.ACMod)<<11 |
+			boolToUint32(codec.Header.LFEOn)<<10 |
+			uint32(codec.Header.FrameSizeCode/2)<<5 // bit rate code
+		w.writeUint8(uint8(v >> 16))
+		w.writeUint16(uint16(v))
+		w.endBox(dac3)
+
+		w.endBox(ac3)
+
+	case *CodecEAC3:
+		ec3 := w.beginBox("ec-3")
+		marshalAudioSampleEntryHeader(w, codec.Header.ChannelCount(), codec.Header.SampleRate)
+
+		dec3 := w.beginBox("dec3")
+		dataRate := codec.Header.FrameLen() * 8 * codec.Header.SampleRate /
+			codec.Header.SampleCount() / 1000
+		w.writeUint16(uint16(dataRate) << 3) // data rate, one independent substream
+		v := ac3Fscod(codec.Header.SampleRate)<<22 |
+			uint32(codec.Header.BSID)<<17 |
+			uint32(codec.Header.ACMod)<<9 |
+			boolToUint32(codec.Header.LFEOn)<<8 // bsmod is main audio service, no dependent substreams
+		w.writeUint8(uint8(v >> 16))
+		w.writeUint16(uint16(v))
+		w.endBox(dec3)
+
+		w.endBox(ec3)
+
+	default:
+		return fmt.Errorf("unsupported codec: %T", track.Codec)
+	}
+
+	return nil
+}
+
+func ac3Fscod(sampleRate int) uint32 {
+	switch sampleRate {
+	case 48000:
+		return 0
+
+	case 44100:
+		return 1
+
+	case 32000:
+		return 2
+	}
+
+	// reduced sample rates of E-AC-3
+	return 3
+}
+
+func boolToUint32(v bool) uint32 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,293 @@
+package fmp4
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/ac3"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+)
+
+var testH264SPS = []byte{
+	0x67, 0x64, 0x00, 0x28, 0xac, 0xd9, 0x40, 0x78,
+	0x02, 0x27, 0xe5, 0x84, 0x00, 0x00, 0x03, 0x00,
+	0x04, 0x00, 0x00, 0x03, 0x00, 0xf0, 0x3c, 0x60,
+	0xc6, 0x58,
+}
+
+var testH264PPS = []byte{0x68, 0xee, 0x3c, 0x80}
+
+var testH265VPS = []byte{
+	0x40, 0x01, 0x0c, 0x01, 0xff, 0xff, 0x01, 0x60,
+	0x00, 0x00, 0x03, 0x00, 0x90, 0x00, 0x00, 0x03,
+	0x00, 0x00, 0x03, 0x00, 0x78, 0x99, 0x98, 0x09,
+}
+
+var testH265SPS = []byte{
+	0x42, 0x01, 0x01, 0x01, 0x60, 0x00, 0x00, 0x03,
+	0x00, 0x90, 0x00, 0x00, 0x03, 0x00, 0x00, 0x03,
+	0x00, 0x78, 0xa0, 0x03, 0xc0, 0x80, 0x10, 0xe5,
+	0x96, 0x66, 0x69, 0x24, 0xca, 0xe0, 0x10, 0x00,
+	0x00, 0x03, 0x00, 0x10, 0x00, 0x00, 0x03, 0x01,
+	0xe0, 0x80,
+}
+
+var testH265PPS = []byte{0x44, 0x01, 0xc1, 0x72, 0xb4, 0x62, 0x40}
+
+// size of the fields that precede child boxes.
+var boxHeaderSizes = map[string]int{
+	"stsd": 8,
+	"dref": 8,
+	"avc1": 78,
+	"hvc1": 78,
+	"vp09": 78,
+	"mp4a": 28,
+	"Opus": 28,
+	"ac-3": 28,
+	"ec-3": 28,
+}
+
+// findBox returns the content of the first box that matches the given path.
+func findBox(t *testing.T, buf []byte, path ...string) []byte {
+	for len(buf) >= 8 {
+		size := int(binary.BigEndian.Uint32(buf))
+		require.GreaterOrEqual(t, size, 8)
+		require.LessOrEqual(t, size, len(buf))
+		typ := string(buf[4:8])
+
+		if typ == path[0] {
+			content := buf[8:size]
+			if len(path) == 1 {
+				return content
+			}
+			return findBox(t, content[boxHeaderSizes[typ]:], path[1:]...)
+		}
+
+		buf = buf[size:]
+	}
+
+	t.Errorf("box not found: %v", path)
+	return nil
+}
+
+func TestInitMarshal(t *testing.T) {
+	for _, ca := range []struct {
+		name    string
+		codec   Codec
+		handler string
+		width   uint32
+		height  uint32
+		entry   string
+		config  string
+		content []byte
+	}{
+		{
+			"h264",
+			&CodecH264{
+				SPS: testH264SPS,
+				PPS: testH264PPS,
+			},
+			"vide",
+			1920,
+			1080,
+			"avc1",
+			"avcC",
+			append(append([]byte{
+				0x01, 0x64, 0x00, 0x28, 0xff, 0xe1, 0x00, 0x1a,
+			}, testH264SPS...), append([]byte{
+				0x01, 0x00, 0x04,
+			}, testH264PPS...)...),
+		},
+		{
+			"h265",
+			&CodecH265{
+				VPS: testH265VPS,
+				SPS: testH265SPS,
+				PPS: testH265PPS,
+			},
+			"vide",
+			1920,
+			1080,
+			"hvc1",
+			"hvcC",
+			append(append(append([]byte{
+				0x01, 0x01, 0x60, 0x00, 0x00, 0x00, 0x90, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x78, 0xf0, 0x00, 0xfc,
+				0xfd, 0xf8, 0xf8, 0x00, 0x00, 0x0f, 0x03,
+				0xa0, 0x00, 0x01, 0x00, 0x18,
+			}, testH265VPS...), append([]byte{
+				0xa1, 0x00, 0x01, 0x00, 0x2a,
+			}, testH265SPS...)...), append([]byte{
+				0xa2, 0x00, 0x01, 0x00, 0x07,
+			}, testH265PPS...)...),
+		},
+		{
+			"vp9",
+			&CodecVP9{
+				Width:             1920,
+				Height:            1080,
+				Profile:           1,
+				BitDepth:          8,
+				ChromaSubsampling: 1,
+				ColorRange:        false,
+			},
+			"vide",
+			1920,
+			1080,
+			"vp09",
+			"vpcC",
+			[]byte{
+				0x01, 0x00, 0x00, 0x00, 0x01, 0x0a, 0x82, 0x02,
+				0x02, 0x02, 0x00, 0x00,
+			},
+		},
+		{
+			"mpeg4audio",
+			&CodecMPEG4Audio{
+				Config: mpeg4audio.Config{
+					Type:         mpeg4audio.ObjectTypeAACLC,
+					SampleRate:   44100,
+					ChannelCount: 2,
+				},
+			},
+			"soun",
+			0,
+			0,
+			"mp4a",
+			"esds",
+			[]byte{
+				0x00, 0x00, 0x00, 0x00,
+				0x03, 0x19, 0x00, 0x01, 0x00,
+				0x04, 0x11, 0x40, 0x15, 0x00, 0x00, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+				0x05, 0x02, 0x12, 0x10,
+				0x06, 0x01, 0x02,
+			},
+		},
+		{
+			"opus",
+			&CodecOpus{
+				ChannelCount: 2,
+			},
+			"soun",
+			0,
+			0,
+			"Opus",
+			"dOps",
+			[]byte{
+				0x00, 0x02, 0x01, 0x38, 0x00, 0x00, 0xbb, 0x80,
+				0x00, 0x00, 0x00,
+			},
+		},
+		{
+			"ac-3",
+			&CodecAC3{
+				Header: ac3.SyncFrameHeader{
+					SampleRate:    48000,
+					FrameSizeCode: 14,
+					BSID:          8,
+					ACMod:         2,
+				},
+			},
+			"soun",
+			0,
+			0,
+			"ac-3",
+			"dac3",
+			[]byte{0x10, 0x10, 0xe0},
+		},
+		{
+			"e-ac-3",
+			&CodecEAC3{
+				Header: ac3.EAC3SyncFrameHeader{
+					FrameSize:  383,
+					SampleRate: 48000,
+					BlockCount: 6,
+					ACMod:      2,
+					BSID:       16,
+				},
+			},
+			"soun",
+			0,
+			0,
+			"ec-3",
+			"dec3",
+			[]byte{0x06, 0x00, 0x20, 0x04, 0x00},
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			init := &Init{
+				Tracks: []*InitTrack{{
+					ID:        1,
+					TimeScale: 90000,
+					Codec:     ca.codec,
+				}},
+			}
+
+			byts, err := init.Marshal()
+			require.NoError(t, err)
+
+			require.Equal(t, []byte{
+				'i', 's', 'o', '5', 0x00, 0x00, 0x02, 0x00,
+				'i', 's', 'o', '5', 'i', 's', 'o', '6',
+				'm', 'p', '4', '1', 'c', 'm', 'f', 'c',
+			}, findBox(t, byts, "ftyp"))
+
+			tkhd := findBox(t, byts, "moov", "trak", "tkhd")
+			require.Equal(t, uint32(1), binary.BigEndian.Uint32(tkhd[12:]))
+			require.Equal(t, ca.width<<16, binary.BigEndian.Uint32(tkhd[76:]))
+			require.Equal(t, ca.height<<16, binary.BigEndian.Uint32(tkhd[80:]))
+
+			mdhd := findBox(t, byts, "moov", "trak", "mdia", "mdhd")
+			require.Equal(t, uint32(90000), binary.BigEndian.Uint32(mdhd[12:]))
+
+			hdlr := findBox(t, byts, "moov", "trak", "mdia", "hdlr")
+			require.Equal(t, ca.handler, string(hdlr[8:12]))
+
+			require.Equal(t, ca.content, findBox(t, byts, "moov", "trak", "mdia", "minf", "stbl",
+				"stsd", ca.entry, ca.config))
+
+			trex := findBox(t, byts, "moov", "mvex", "trex")
+			require.Equal(t, uint32(1), binary.BigEndian.Uint32(trex[4:]))
+		})
+	}
+}
+
+func TestInitMarshalErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name  string
+		codec Codec
+		err   string
+	}{
+		{
+			"h264 without parameters",
+			&CodecH264{},
+			"H264 parameters not provided",
+		},
+		{
+			"h265 without parameters",
+			&CodecH265{},
+			"H265 parameters not provided",
+		},
+		{
+			"opus with too many channels",
+			&CodecOpus{ChannelCount: 6},
+			"Opus streams with more than 2 channels are not supported",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			init := &Init{
+				Tracks: []*InitTrack{{
+					ID:        1,
+					TimeScale: 90000,
+					Codec:     ca.codec,
+				}},
+			}
+
+			_, err := init.Marshal()
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
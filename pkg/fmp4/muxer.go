@@ -0,0 +1,676 @@
+package fmp4
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/ac3"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h264"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h265"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/opus"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/vp9"
+	"github.com/aler9/gortsplib/v2/pkg/format"
+)
+
+// Track is a track of a Muxer.
+type Track struct {
+	// track ID. If zero, it is assigned automatically.
+	ID int
+
+	// format of the track.
+	// Supported formats are H264, H265, VP9, MPEG4Audio, Opus, AC3 and EAC3.
+	Format format.Format
+}
+
+func isVideoFormat(forma format.Format) bool {
+	switch forma.(type) {
+	case *format.H264, *format.H265, *format.VP9:
+		return true
+	}
+	return false
+}
+
+type muxerTrack struct {
+	timeScale uint32
+
+	// H264 / H265 specific
+	vps              []byte
+	sps              []byte
+	pps              []byte
+	h264DTSExtractor *h264.DTSExtractor
+	h265DTSExtractor *h265.DTSExtractor
+
+	// VP9 specific
+	vp9Codec *CodecVP9
+
+	// AC-3 / E-AC-3 specific
+	ac3Codec  *CodecAC3
+	eac3Codec *CodecEAC3
+
+	randomAccessRecv bool
+	pending          *Sample
+	pendingDTS       time.Duration
+	lastDuration     uint32
+	baseTime         uint64
+	samples          []*Sample
+}
+
+func (mt *muxerTrack) appendPending(duration uint32) {
+	if mt.pending == nil {
+		return
+	}
+
+	mt.pending.Duration = duration
+	mt.lastDuration = duration
+
+	if len(mt.samples) == 0 {
+		mt.baseTime = uint64(durationGoToMP4(mt.pendingDTS, mt.timeScale))
+	}
+	mt.samples = append(mt.samples, mt.pending)
+	mt.pending = nil
+}
+
+// Muxer is a fragmented MP4 muxer.
+// It writes an initialization segment, followed by fragments.
+type Muxer struct {
+	w                io.Writer
+	fragmentDuration time.Duration
+	tracks           []*Track
+	states           map[*Track]*muxerTrack
+	leadingTrack     *Track
+
+	startDTSSet    bool
+	startDTS       time.Duration
+	fragmentStart  time.Duration
+	initWritten    bool
+	sequenceNumber uint32
+}
+
+// NewMuxer allocates a Muxer that writes fragmented MP4 into w.
+// A fragment is written every time the leading track (the first video track, or
+// the first track) receives a random access point and the fragment duration is exceeded.
+// If fragmentDuration is zero, it defaults to one second.
+// Tracks with a zero ID are assigned an ID automatically.
+func NewMuxer(w io.Writer, fragmentDuration time.Duration, tracks []*Track) (*Muxer, error) {
+	if fragmentDuration == 0 {
+		fragmentDuration = 1 * time.Second
+	}
+
+	m := &Muxer{
+		w:                w,
+		fragmentDuration: fragmentDuration,
+		tracks:           tracks,
+		states:           make(map[*Track]*muxerTrack),
+	}
+
+	for i, track := range tracks {
+		if track.ID == 0 {
+			track.ID = i + 1
+		}
+
+		mt := &muxerTrack{}
+
+		switch forma := track.Format.(type) {
+		case *format.H264:
+			mt.timeScale = 90000
+			mt.sps = forma.SafeSPS()
+			mt.pps = forma.SafePPS()
+
+		case *format.H265:
+			mt.timeScale = 90000
+			mt.vps = forma.SafeVPS()
+			mt.sps = forma.SafeSPS()
+			mt.pps = forma.SafePPS()
+
+		case *format.VP9:
+			mt.timeScale = 90000
+
+		case *format.MPEG4Audio:
+			if forma.Config == nil {
+				return nil, fmt.Errorf("MPEG-4 Audio configuration is missing")
+			}
+			mt.timeScale = uint32(forma.Config.SampleRate)
+
+		case *format.Opus:
+			mt.timeScale = 48000
+
+		case *format.AC3:
+			mt.timeScale = uint32(forma.SampleRate)
+
+		case *format.EAC3:
+			mt.timeScale = uint32(forma.SampleRate)
+
+		default:
+			return nil, fmt.Errorf("unsupported format: %s", track.Format)
+		}
+
+		m.states[track] = mt
+	}
+
+	// use the first video track as leading track, or the first track.
+	for _, track := range tracks {
+		if isVideoFormat(track.Format) {
+			m.leadingTrack = track
+			break
+		}
+	}
+	if m.leadingTrack == nil && len(tracks) != 0 {
+		m.leadingTrack = tracks[0]
+	}
+
+	return m, nil
+}
+
+func (m *Muxer) state(track *Track) (*muxerTrack, error) {
+	mt, ok := m.states[track]
+	if !ok {
+		return nil, fmt.Errorf("track not found")
+	}
+	return mt, nil
+}
+
+// WriteH264 writes a H264 access unit.
+// Access units are discarded until one containing an IDR is received.
+func (m *Muxer) WriteH264(track *Track, pts time.Duration, au [][]byte) error {
+	mt, err := m.state(track)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := track.Format.(*format.H264); !ok {
+		return fmt.Errorf("track is not a H264 track")
+	}
+
+	filteredAU := make([][]byte, 0, len(au))
+	idrPresent := false
+
+	for _, nalu := range au {
+		typ := h264.NALUType(nalu[0] & 0x1F)
+		switch typ {
+		case h264.NALUTypeSPS:
+			// parameters are stored into the initialization segment
+			if mt.sps == nil {
+				mt.sps = append([]byte(nil), nalu...)
+			}
+			continue
+
+		case h264.NALUTypePPS:
+			if mt.pps == nil {
+				mt.pps = append([]byte(nil), nalu...)
+			}
+			continue
+
+		case h264.NALUTypeAccessUnitDelimiter:
+			continue
+
+		case h264.NALUTypeIDR:
+			idrPresent = true
+		}
+
+		filteredAU = append(filteredAU, nalu)
+	}
+
+	if len(filteredAU) == 0 {
+		return nil
+	}
+
+	if !mt.randomAccessRecv {
+		// skip access units silently until we find one with a IDR
+		if !idrPresent {
+			return nil
+		}
+
+		mt.randomAccessRecv = true
+		mt.h264DTSExtractor = h264.NewDTSExtractor()
+	}
+
+	// the DTS extractor needs the SPS
+	extractorAU := filteredAU
+	if idrPresent {
+		if mt.sps == nil || mt.pps == nil {
+			return fmt.Errorf("SPS or PPS not received yet")
+		}
+		extractorAU = append([][]byte{mt.sps, mt.pps}, filteredAU...)
+	}
+
+	dts, err := mt.h264DTSExtractor.Extract(extractorAU, pts)
+	if err != nil {
+		return err
+	}
+
+	payload, err := h264.AVCCMarshal(filteredAU)
+	if err != nil {
+		return err
+	}
+
+	return m.writeSample(track, mt, pts, dts, idrPresent, payload)
+}
+
+// WriteH265 writes a H265 access unit.
+// Access units are discarded until one containing a random access point is received.
+func (m *Muxer) WriteH265(track *Track, pts time.Duration, au [][]byte) error {
+	mt, err := m.state(track)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := track.Format.(*format.H265); !ok {
+		return fmt.Errorf("track is not a H265 track")
+	}
+
+	filteredAU := make([][]byte, 0, len(au))
+	randomAccess := false
+
+	for _, nalu := range au {
+		typ := h265.NALUType((nalu[0] >> 1) & 0b111111)
+		switch typ {
+		case h265.NALUType_VPS_NUT:
+			// parameters are stored into the initialization segment
+			if mt.vps == nil {
+				mt.vps = append([]byte(nil), nalu...)
+			}
+			continue
+
+		case h265.NALUType_SPS_NUT:
+			if mt.sps == nil {
+				mt.sps = append([]byte(nil), nalu...)
+			}
+			continue
+
+		case h265.NALUType_PPS_NUT:
+			if mt.pps == nil {
+				mt.pps = append([]byte(nil), nalu...)
+			}
+			continue
+
+		case h265.NALUType_AUD_NUT:
+			continue
+
+		case h265.NALUType_IDR_W_RADL, h265.NALUType_IDR_N_LP, h265.NALUType_CRA_NUT:
+			randomAccess = true
+		}
+
+		filteredAU = append(filteredAU, nalu)
+	}
+
+	if len(filteredAU) == 0 {
+		return nil
+	}
+
+	if !mt.randomAccessRecv {
+		// skip access units silently until we find a random access point
+		if !randomAccess {
+			return nil
+		}
+
+		mt.randomAccessRecv = true
+		mt.h265DTSExtractor = h265.NewDTSExtractor()
+	}
+
+	// the DTS extractor needs the VPS, SPS and PPS
+	extractorAU := filteredAU
+	if randomAccess {
+		if mt.vps == nil || mt.sps == nil || mt.pps == nil {
+			return fmt.Errorf("VPS, SPS or PPS not received yet")
+		}
+		extractorAU = append([][]byte{mt.vps, mt.sps, mt.pps}, filteredAU...)
+	}
+
+	dts, err := mt.h265DTSExtractor.Extract(extractorAU, pts)
+	if err != nil {
+		return err
+	}
+
+	// H265 uses the same AVCC format of H264
+	payload, err := h264.AVCCMarshal(filteredAU)
+	if err != nil {
+		return err
+	}
+
+	return m.writeSample(track, mt, pts, dts, randomAccess, payload)
+}
+
+// WriteVP9 writes a VP9 frame.
+// Frames are discarded until a key frame is received.
+func (m *Muxer) WriteVP9(track *Track, pts time.Duration, frame []byte) error {
+	mt, err := m.state(track)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := track.Format.(*format.VP9); !ok {
+		return fmt.Errorf("track is not a VP9 track")
+	}
+
+	var h vp9.Header
+	err = h.Unmarshal(frame)
+	if err != nil {
+		return err
+	}
+
+	keyFrame := !h.ShowExistingFrame && h.FrameType == vp9.FrameTypeKeyFrame
+
+	if keyFrame && mt.vp9Codec == nil {
+		mt.vp9Codec = &CodecVP9{
+			Width:             h.Width(),
+			Height:            h.Height(),
+			Profile:           h.Profile,
+			BitDepth:          h.ColorConfig.BitDepth,
+			ChromaSubsampling: h.ChromaSubsampling(),
+			ColorRange:        h.ColorConfig.ColorRange,
+		}
+	}
+
+	if !mt.randomAccessRecv {
+		// skip frames silently until we find a key frame
+		if !keyFrame {
+			return nil
+		}
+
+		mt.randomAccessRecv = true
+	}
+
+	return m.writeSample(track, mt, pts, pts, keyFrame, frame)
+}
+
+// WriteMPEG4Audio writes MPEG-4 Audio access units.
+// The first access unit has the given PTS.
+func (m *Muxer) WriteMPEG4Audio(track *Track, pts time.Duration, aus [][]byte) error {
+	mt, err := m.state(track)
+	if err != nil {
+		return err
+	}
+
+	forma, ok := track.Format.(*format.MPEG4Audio)
+	if !ok {
+		return fmt.Errorf("track is not a MPEG-4 Audio track")
+	}
+
+	for i, au := range aus {
+		auPTS := pts + time.Duration(i)*mpeg4audio.SamplesPerAccessUnit*time.Second/
+			time.Duration(forma.Config.SampleRate)
+
+		err := m.writeSample(track, mt, auPTS, auPTS, true, au)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteOpus writes Opus packets.
+// The first packet has the given PTS.
+func (m *Muxer) WriteOpus(track *Track, pts time.Duration, packets [][]byte) error {
+	mt, err := m.state(track)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := track.Format.(*format.Opus); !ok {
+		return fmt.Errorf("track is not a Opus track")
+	}
+
+	for _, packet := range packets {
+		err := m.writeSample(track, mt, pts, pts, true, packet)
+		if err != nil {
+			return err
+		}
+
+		pts += opus.PacketDuration(packet)
+	}
+
+	return nil
+}
+
+// WriteAC3 writes AC-3 syncframes.
+// The first syncframe has the given PTS.
+func (m *Muxer) WriteAC3(track *Track, pts time.Duration, frames [][]byte) error {
+	mt, err := m.state(track)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := track.Format.(*format.AC3); !ok {
+		return fmt.Errorf("track is not an AC-3 track")
+	}
+
+	for _, frame := range frames {
+		var h ac3.SyncFrameHeader
+		err := h.Unmarshal(frame)
+		if err != nil {
+			return err
+		}
+
+		// parameters are stored into the initialization segment
+		if mt.ac3Codec == nil {
+			mt.ac3Codec = &CodecAC3{
+				Header: h,
+			}
+		}
+
+		err = m.writeSample(track, mt, pts, pts, true, frame)
+		if err != nil {
+			return err
+		}
+
+		pts += time.Duration(h.SampleCount()) * time.Second / time.Duration(h.SampleRate)
+	}
+
+	return nil
+}
+
+// WriteEAC3 writes E-AC-3 syncframes.
+// The first syncframe has the given PTS.
+// Streams with dependent substreams are not supported.
+func (m *Muxer) WriteEAC3(track *Track, pts time.Duration, frames [][]byte) error {
+	mt, err := m.state(track)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := track.Format.(*format.EAC3); !ok {
+		return fmt.Errorf("track is not an E-AC-3 track")
+	}
+
+	for _, frame := range frames {
+		var h ac3.EAC3SyncFrameHeader
+		err := h.Unmarshal(frame)
+		if err != nil {
+			return err
+		}
+
+		if h.StreamType == 1 || h.SubstreamID != 0 {
+			return fmt.Errorf("E-AC-3 dependent or additional substreams are not supported")
+		}
+
+		// parameters are stored into the initialization segment
+		if mt.eac3Codec == nil {
+			mt.eac3Codec = &CodecEAC3{
+				Header: h,
+			}
+		}
+
+		err = m.writeSample(track, mt, pts, pts, true, frame)
+		if err != nil {
+			return err
+		}
+
+		pts += time.Duration(h.SampleCount()) * time.Second / time.Duration(h.SampleRate)
+	}
+
+	return nil
+}
+
+// Flush writes pending samples into a final fragment.
+// It must be called when the stream is over.
+func (m *Muxer) Flush() error {
+	for _, track := range m.tracks {
+		// the duration of the last sample is unknown, use the one of the previous sample
+		mt := m.states[track]
+		mt.appendPending(mt.lastDuration)
+	}
+
+	return m.writeFragment()
+}
+
+func (m *Muxer) writeSample(
+	track *Track,
+	mt *muxerTrack,
+	pts time.Duration,
+	dts time.Duration,
+	isSync bool,
+	payload []byte,
+) error {
+	// the stream starts with the first random access point of the leading track
+	if !m.startDTSSet {
+		if track != m.leadingTrack || !isSync {
+			return nil
+		}
+
+		m.startDTSSet = true
+		m.startDTS = dts
+	}
+
+	pts -= m.startDTS
+	dts -= m.startDTS
+
+	// discard samples that precede the beginning of the stream
+	if dts < 0 {
+		return nil
+	}
+
+	// the duration of the previous sample is known now
+	mt.appendPending(uint32(durationGoToMP4(dts, mt.timeScale) -
+		durationGoToMP4(mt.pendingDTS, mt.timeScale)))
+
+	if track == m.leadingTrack && isSync && (dts-m.fragmentStart) >= m.fragmentDuration {
+		err := m.writeFragment()
+		if err != nil {
+			return err
+		}
+		m.fragmentStart = dts
+	}
+
+	mt.pending = &Sample{
+		PTSOffset: int32(durationGoToMP4(pts, mt.timeScale) -
+			durationGoToMP4(dts, mt.timeScale)),
+		IsNonSyncSample: !isSync,
+		Payload:         payload,
+	}
+	mt.pendingDTS = dts
+
+	return nil
+}
+
+func (m *Muxer) writeInit() error {
+	init := &Init{
+		Tracks: make([]*InitTrack, len(m.tracks)),
+	}
+
+	for i, track := range m.tracks {
+		mt := m.states[track]
+
+		var codec Codec
+
+		switch forma := track.Format.(type) {
+		case *format.H264:
+			codec = &CodecH264{
+				SPS: mt.sps,
+				PPS: mt.pps,
+			}
+
+		case *format.H265:
+			codec = &CodecH265{
+				VPS: mt.vps,
+				SPS: mt.sps,
+				PPS: mt.pps,
+			}
+
+		case *format.VP9:
+			if mt.vp9Codec == nil {
+				return fmt.Errorf("VP9 key frame not received yet")
+			}
+			codec = mt.vp9Codec
+
+		case *format.MPEG4Audio:
+			codec = &CodecMPEG4Audio{
+				Config: *forma.Config,
+			}
+
+		case *format.Opus:
+			codec = &CodecOpus{
+				ChannelCount: forma.ChannelCount,
+			}
+
+		case *format.AC3:
+			if mt.ac3Codec == nil {
+				return fmt.Errorf("AC-3 syncframe not received yet")
+			}
+			codec = mt.ac3Codec
+
+		case *format.EAC3:
+			if mt.eac3Codec == nil {
+				return fmt.Errorf("E-AC-3 syncframe not received yet")
+			}
+			codec = mt.eac3Codec
+		}
+
+		init.Tracks[i] = &InitTrack{
+			ID:        track.ID,
+			TimeScale: mt.timeScale,
+			Codec:     codec,
+		}
+	}
+
+	byts, err := init.Marshal()
+	if err != nil {
+		return err
+	}
+
+	_, err = m.w.Write(byts)
+	return err
+}
+
+func (m *Muxer) writeFragment() error {
+	frag := &Fragment{}
+
+	for _, track := range m.tracks {
+		mt := m.states[track]
+		if len(mt.samples) == 0 {
+			continue
+		}
+
+		frag.Tracks = append(frag.Tracks, &FragmentTrack{
+			ID:       track.ID,
+			BaseTime: mt.baseTime,
+			Samples:  mt.samples,
+		})
+		mt.samples = nil
+	}
+
+	if len(frag.Tracks) == 0 {
+		return nil
+	}
+
+	if !m.initWritten {
+		err := m.writeInit()
+		if err != nil {
+			return err
+		}
+		m.initWritten = true
+	}
+
+	m.sequenceNumber++
+	frag.SequenceNumber = m.sequenceNumber
+
+	byts, err := frag.Marshal()
+	if err != nil {
+		return err
+	}
+
+	_, err = m.w.Write(byts)
+	return err
+}
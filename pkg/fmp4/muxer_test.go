@@ -0,0 +1,287 @@
+package fmp4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+	"github.com/aler9/gortsplib/v2/pkg/format"
+)
+
+func splitBoxes(t *testing.T, buf []byte) ([]string, [][]byte) {
+	var types []string
+	var boxes [][]byte
+
+	for len(buf) > 0 {
+		require.GreaterOrEqual(t, len(buf), 8)
+		size := int(binary.BigEndian.Uint32(buf))
+		require.LessOrEqual(t, size, len(buf))
+		types = append(types, string(buf[4:8]))
+		boxes = append(boxes, buf[:size])
+		buf = buf[size:]
+	}
+
+	return types, boxes
+}
+
+type testTrun struct {
+	sampleCount uint32
+	durations   []uint32
+	flags       []uint32
+}
+
+func parseTrun(t *testing.T, buf []byte) testTrun {
+	var ret testTrun
+	ret.sampleCount = binary.BigEndian.Uint32(buf[4:])
+	for i := 0; i < int(ret.sampleCount); i++ {
+		entry := buf[12+i*16:]
+		ret.durations = append(ret.durations, binary.BigEndian.Uint32(entry))
+		ret.flags = append(ret.flags, binary.BigEndian.Uint32(entry[8:]))
+	}
+	return ret
+}
+
+func TestMuxer(t *testing.T) {
+	videoTrack := &Track{
+		Format: &format.H264{
+			PayloadTyp:        96,
+			PacketizationMode: 1,
+		},
+	}
+
+	audioTrack := &Track{
+		ID: 5,
+		Format: &format.MPEG4Audio{
+			PayloadTyp: 97,
+			Config: &mpeg4audio.Config{
+				Type:         mpeg4audio.ObjectTypeAACLC,
+				SampleRate:   44100,
+				ChannelCount: 2,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	m, err := NewMuxer(&buf, 1*time.Second, []*Track{videoTrack, audioTrack})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, videoTrack.ID)
+	require.Equal(t, 5, audioTrack.ID)
+
+	// before the first IDR, discarded
+	err = m.WriteMPEG4Audio(audioTrack, 1900*time.Millisecond, [][]byte{{1, 2}})
+	require.NoError(t, err)
+
+	err = m.WriteH264(videoTrack, 1900*time.Millisecond, [][]byte{{0x41, 0x9a, 0x21}})
+	require.NoError(t, err)
+
+	err = m.WriteH264(videoTrack, 2*time.Second, [][]byte{
+		{0x09, 0xf0}, // AUD
+		testH264SPS,
+		testH264PPS,
+		{0x65, 0x88, 0x84, 0x00, 0x33, 0xff},
+	})
+	require.NoError(t, err)
+
+	err = m.WriteMPEG4Audio(audioTrack, 2*time.Second, [][]byte{{3, 4}, {5, 6}})
+	require.NoError(t, err)
+
+	err = m.WriteH264(videoTrack, 2500*time.Millisecond, [][]byte{{0x41, 0x9a, 0x21, 0x6c, 0x45, 0xff}})
+	require.NoError(t, err)
+
+	require.Equal(t, 0, buf.Len())
+
+	err = m.WriteH264(videoTrack, 3200*time.Millisecond, [][]byte{
+		testH264SPS,
+		testH264PPS,
+		{0x65, 0x88, 0x84, 0x00, 0x33, 0xff},
+	})
+	require.NoError(t, err)
+
+	err = m.Flush()
+	require.NoError(t, err)
+
+	err = m.WriteOpus(videoTrack, 0, [][]byte{{1}})
+	require.EqualError(t, err, "track is not a Opus track")
+
+	err = m.WriteH264(&Track{Format: &format.H264{}}, 0, [][]byte{{0x65}})
+	require.EqualError(t, err, "track not found")
+
+	types, boxes := splitBoxes(t, buf.Bytes())
+	require.Equal(t, []string{"ftyp", "moov", "moof", "mdat", "moof", "mdat"}, types)
+
+	require.Equal(t, []byte{'a', 'v', 'c', '1'}, findBox(t, boxes[1], "moov", "trak", "mdia",
+		"minf", "stbl", "stsd")[12:16])
+
+	// first fragment
+	moof := boxes[2]
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 1}, findBox(t, moof, "moof", "mfhd"))
+
+	tfdt := findBox(t, moof, "moof", "traf", "tfdt")
+	require.Equal(t, uint64(0), binary.BigEndian.Uint64(tfdt[4:]))
+
+	require.Equal(t, testTrun{
+		sampleCount: 2,
+		durations:   []uint32{45000, 63000},
+		flags:       []uint32{sampleFlagsSync, sampleFlagsNonSync},
+	}, parseTrun(t, findBox(t, moof, "moof", "traf", "trun")))
+
+	_, trafs := splitBoxes(t, findBox(t, moof, "moof")[16:])
+	require.Equal(t, 2, len(trafs))
+
+	require.Equal(t, testTrun{
+		sampleCount: 1,
+		durations:   []uint32{1024},
+		flags:       []uint32{sampleFlagsSync},
+	}, parseTrun(t, findBox(t, trafs[1], "traf", "trun")))
+
+	require.Equal(t, []byte{
+		0x00, 0x00, 0x00, 0x06, 0x65, 0x88, 0x84, 0x00, 0x33, 0xff,
+		0x00, 0x00, 0x00, 0x06, 0x41, 0x9a, 0x21, 0x6c, 0x45, 0xff,
+		0x03, 0x04,
+	}, boxes[3][8:])
+
+	// second fragment
+	moof = boxes[4]
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 2}, findBox(t, moof, "moof", "mfhd"))
+
+	tfdt = findBox(t, moof, "moof", "traf", "tfdt")
+	require.Equal(t, uint64(108000), binary.BigEndian.Uint64(tfdt[4:]))
+
+	require.Equal(t, testTrun{
+		sampleCount: 1,
+		durations:   []uint32{63000},
+		flags:       []uint32{sampleFlagsSync},
+	}, parseTrun(t, findBox(t, moof, "moof", "traf", "trun")))
+
+	_, trafs = splitBoxes(t, findBox(t, moof, "moof")[16:])
+	require.Equal(t, 2, len(trafs))
+
+	tfdt = findBox(t, trafs[1], "traf", "tfdt")
+	require.Equal(t, uint64(1024), binary.BigEndian.Uint64(tfdt[4:]))
+
+	require.Equal(t, []byte{
+		0x00, 0x00, 0x00, 0x06, 0x65, 0x88, 0x84, 0x00, 0x33, 0xff,
+		0x05, 0x06,
+	}, boxes[5][8:])
+}
+
+func TestMuxerErrors(t *testing.T) {
+	_, err := NewMuxer(&bytes.Buffer{}, 0, []*Track{{Format: &format.VP8{}}})
+	require.EqualError(t, err, "unsupported format: VP8")
+
+	_, err = NewMuxer(&bytes.Buffer{}, 0, []*Track{{Format: &format.MPEG4Audio{}}})
+	require.EqualError(t, err, "MPEG-4 Audio configuration is missing")
+}
+
+func TestMuxerVP9Opus(t *testing.T) {
+	videoTrack := &Track{
+		Format: &format.VP9{
+			PayloadTyp: 96,
+		},
+	}
+
+	audioTrack := &Track{
+		Format: &format.Opus{
+			PayloadTyp:   97,
+			SampleRate:   48000,
+			ChannelCount: 2,
+		},
+	}
+
+	var buf bytes.Buffer
+	m, err := NewMuxer(&buf, 0, []*Track{videoTrack, audioTrack})
+	require.NoError(t, err)
+
+	keyFrame := []byte{
+		0x82, 0x49, 0x83, 0x42, 0x00, 0x77, 0xf0, 0x32,
+		0x34, 0x30, 0x38, 0x24, 0x1c, 0x19, 0x40, 0x18,
+		0x03, 0x40, 0x5f, 0xb4,
+	}
+
+	// non-key frame, discarded
+	err = m.WriteVP9(videoTrack, 0, []byte{0x86, 0x00, 0x40, 0x92})
+	require.NoError(t, err)
+
+	err = m.WriteVP9(videoTrack, 1*time.Second, keyFrame)
+	require.NoError(t, err)
+
+	err = m.WriteOpus(audioTrack, 1*time.Second, [][]byte{{0xf8, 0x01}, {0xf8, 0x02}})
+	require.NoError(t, err)
+
+	err = m.WriteVP9(videoTrack, 1100*time.Millisecond, []byte{0x86, 0x00, 0x40, 0x92})
+	require.NoError(t, err)
+
+	err = m.Flush()
+	require.NoError(t, err)
+
+	types, boxes := splitBoxes(t, buf.Bytes())
+	require.Equal(t, []string{"ftyp", "moov", "moof", "mdat"}, types)
+
+	_, traks := splitBoxes(t, findBox(t, boxes[1], "moov")[108:])
+	require.Equal(t, 3, len(traks))
+
+	tkhd := findBox(t, traks[0], "trak", "tkhd")
+	require.Equal(t, uint32(1920)<<16, binary.BigEndian.Uint32(tkhd[76:]))
+	require.Equal(t, uint32(804)<<16, binary.BigEndian.Uint32(tkhd[80:]))
+
+	moof := boxes[2]
+	require.Equal(t, testTrun{
+		sampleCount: 2,
+		durations:   []uint32{9000, 9000},
+		flags:       []uint32{sampleFlagsSync, sampleFlagsNonSync},
+	}, parseTrun(t, findBox(t, moof, "moof", "traf", "trun")))
+
+	_, trafs := splitBoxes(t, findBox(t, moof, "moof")[16:])
+	require.Equal(t, 2, len(trafs))
+
+	require.Equal(t, testTrun{
+		sampleCount: 2,
+		durations:   []uint32{960, 960},
+		flags:       []uint32{sampleFlagsSync, sampleFlagsSync},
+	}, parseTrun(t, findBox(t, trafs[1], "traf", "trun")))
+}
+
+func TestMuxerAC3(t *testing.T) {
+	track := &Track{
+		Format: &format.AC3{
+			PayloadTyp:   96,
+			SampleRate:   48000,
+			ChannelCount: 2,
+		},
+	}
+
+	var buf bytes.Buffer
+	m, err := NewMuxer(&buf, 0, []*Track{track})
+	require.NoError(t, err)
+
+	frame := []byte{0x0b, 0x77, 0x00, 0x00, 0x0e, 0x40, 0x40, 0x00}
+
+	err = m.WriteAC3(track, 0, [][]byte{frame, frame, frame})
+	require.NoError(t, err)
+
+	err = m.WriteAC3(track, 0, [][]byte{{0x01, 0x02}})
+	require.Error(t, err)
+
+	err = m.WriteEAC3(track, 0, [][]byte{frame})
+	require.EqualError(t, err, "track is not an E-AC-3 track")
+
+	err = m.Flush()
+	require.NoError(t, err)
+
+	types, boxes := splitBoxes(t, buf.Bytes())
+	require.Equal(t, []string{"ftyp", "moov", "moof", "mdat"}, types)
+
+	require.Equal(t, []byte{0x10, 0x10, 0xe0}, findBox(t, boxes[1], "moov", "trak", "mdia", "minf", "stbl",
+		"stsd", "ac-3", "dac3"))
+
+	require.Equal(t, testTrun{
+		sampleCount: 3,
+		durations:   []uint32{1536, 1536, 1536},
+		flags:       []uint32{sampleFlagsSync, sampleFlagsSync, sampleFlagsSync},
+	}, parseTrun(t, findBox(t, boxes[2], "moof", "traf", "trun")))
+}
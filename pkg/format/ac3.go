@@ -0,0 +1,86 @@
+package format
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpac3"
+)
+
+// AC3 is a AC-3 format.
+type AC3 struct {
+	PayloadTyp   uint8
+	SampleRate   int
+	ChannelCount int
+}
+
+// String implements Format.
+func (t *AC3) String() string {
+	return "AC-3"
+}
+
+// ClockRate implements Format.
+func (t *AC3) ClockRate() int {
+	return t.SampleRate
+}
+
+// PayloadType implements Format.
+func (t *AC3) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *AC3) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+
+	tmp := strings.SplitN(clock, "/", 2)
+
+	sampleRate, err := strconv.ParseInt(tmp[0], 10, 64)
+	if err != nil {
+		return err
+	}
+	t.SampleRate = int(sampleRate)
+
+	if len(tmp) == 2 {
+		channelCount, err := strconv.ParseInt(tmp[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		t.ChannelCount = int(channelCount)
+	} else {
+		t.ChannelCount = 1
+	}
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *AC3) Marshal() (string, string) {
+	return "AC3/" + strconv.FormatInt(int64(t.SampleRate), 10) +
+		"/" + strconv.FormatInt(int64(t.ChannelCount), 10), ""
+}
+
+// PTSEqualsDTS implements Format.
+func (t *AC3) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *AC3) CreateDecoder() *rtpac3.Decoder {
+	d := &rtpac3.Decoder{
+		SampleRate: t.SampleRate,
+	}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *AC3) CreateEncoder() *rtpac3.Encoder {
+	e := &rtpac3.Encoder{
+		PayloadType: t.PayloadTyp,
+		SampleRate:  t.SampleRate,
+	}
+	e.Init()
+	return e
+}
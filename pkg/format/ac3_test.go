@@ -0,0 +1,58 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAC3Attributes(t *testing.T) {
+	format := &AC3{
+		PayloadTyp:   97,
+		SampleRate:   48000,
+		ChannelCount: 2,
+	}
+	require.Equal(t, "AC-3", format.String())
+	require.Equal(t, 48000, format.ClockRate())
+	require.Equal(t, uint8(97), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestAC3MediaDescription(t *testing.T) {
+	format := &AC3{
+		PayloadTyp:   97,
+		SampleRate:   48000,
+		ChannelCount: 6,
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "AC3/48000/6", rtpmap)
+	require.Equal(t, "", fmtp)
+}
+
+func TestAC3DecEncoder(t *testing.T) {
+	format := &AC3{
+		PayloadTyp:   97,
+		SampleRate:   48000,
+		ChannelCount: 2,
+	}
+
+	frames := [][]byte{
+		append(
+			[]byte{0x0b, 0x77, 0x00, 0x00, 0x00, 0x40, 0x40},
+			bytes.Repeat([]byte{0x01}, 121)...,
+		),
+	}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode(frames, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	byts, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, frames, byts)
+}
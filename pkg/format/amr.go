@@ -0,0 +1,203 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpamr"
+)
+
+// AMR is an AMR or AMR-WB format.
+type AMR struct {
+	// payload type of packets.
+	PayloadTyp uint8
+
+	// whether the format is AMR-WB.
+	Wideband bool
+
+	// number of audio channels.
+	ChannelCount int
+
+	// whether the octet-aligned mode is in use.
+	// It is implied by CRC, Interleaving and RobustSorting.
+	OctetAlign bool
+
+	// allowed codec modes.
+	ModeSet []int
+
+	// maximum number of frames in an interleaving group.
+	Interleaving *int
+
+	// whether speech frames are protected by CRCs.
+	CRC bool
+
+	// whether robust sorting is in use.
+	RobustSorting bool
+}
+
+// String implements Format.
+func (t *AMR) String() string {
+	if t.Wideband {
+		return "AMR-WB"
+	}
+	return "AMR"
+}
+
+// ClockRate implements Format.
+func (t *AMR) ClockRate() int {
+	if t.Wideband {
+		return 16000
+	}
+	return 8000
+}
+
+// PayloadType implements Format.
+func (t *AMR) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *AMR) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+	t.Wideband = (codec == "amr-wb")
+
+	tmp := strings.SplitN(clock, "/", 2)
+
+	if len(tmp) == 2 {
+		channelCount, err := strconv.ParseInt(tmp[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		t.ChannelCount = int(channelCount)
+	} else {
+		t.ChannelCount = 1
+	}
+
+	if fmtp != "" {
+		for _, kv := range strings.Split(fmtp, ";") {
+			kv = strings.Trim(kv, " ")
+
+			if len(kv) == 0 {
+				continue
+			}
+
+			tmp := strings.SplitN(kv, "=", 2)
+			if len(tmp) != 2 {
+				return fmt.Errorf("invalid fmtp (%v)", fmtp)
+			}
+
+			switch strings.ToLower(tmp[0]) {
+			case "octet-align":
+				t.OctetAlign = (tmp[1] == "1")
+
+			case "mode-set":
+				for _, m := range strings.Split(tmp[1], ",") {
+					val, err := strconv.ParseUint(strings.Trim(m, " "), 10, 64)
+					if err != nil || val > 8 {
+						return fmt.Errorf("invalid mode-set (%v)", tmp[1])
+					}
+					t.ModeSet = append(t.ModeSet, int(val))
+				}
+
+			case "interleaving":
+				val, err := strconv.ParseUint(tmp[1], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid interleaving (%v)", tmp[1])
+				}
+				v := int(val)
+				t.Interleaving = &v
+
+			case "crc":
+				t.CRC = (tmp[1] == "1")
+
+			case "robust-sorting":
+				t.RobustSorting = (tmp[1] == "1")
+			}
+		}
+	}
+
+	// interleaving, crc and robust-sorting imply octet-align
+	// (RFC 4867, section 8.1)
+	if t.Interleaving != nil || t.CRC || t.RobustSorting {
+		t.OctetAlign = true
+	}
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *AMR) Marshal() (string, string) {
+	var tmp []string
+
+	if t.OctetAlign {
+		tmp = append(tmp, "octet-align=1")
+	}
+
+	if len(t.ModeSet) != 0 {
+		tmp2 := make([]string, len(t.ModeSet))
+		for i, m := range t.ModeSet {
+			tmp2[i] = strconv.FormatInt(int64(m), 10)
+		}
+		tmp = append(tmp, "mode-set="+strings.Join(tmp2, ","))
+	}
+
+	if t.Interleaving != nil {
+		tmp = append(tmp, "interleaving="+strconv.FormatInt(int64(*t.Interleaving), 10))
+	}
+
+	if t.CRC {
+		tmp = append(tmp, "crc=1")
+	}
+
+	if t.RobustSorting {
+		tmp = append(tmp, "robust-sorting=1")
+	}
+
+	var fmtp string
+	if tmp != nil {
+		fmtp = strings.Join(tmp, "; ")
+	}
+
+	codec := "AMR"
+	if t.Wideband {
+		codec = "AMR-WB"
+	}
+
+	return codec + "/" + strconv.FormatInt(int64(t.ClockRate()), 10) +
+		"/" + strconv.FormatInt(int64(t.ChannelCount), 10), fmtp
+}
+
+// PTSEqualsDTS implements Format.
+func (t *AMR) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+// The decoder doesn't support interleaving and robust sorting;
+// packets of interleaved streams are rejected.
+func (t *AMR) CreateDecoder() *rtpamr.Decoder {
+	d := &rtpamr.Decoder{
+		Wideband:     t.Wideband,
+		OctetAligned: t.OctetAlign,
+		CRC:          t.CRC,
+		Interleaved:  t.Interleaving != nil,
+	}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+// The encoder doesn't support interleaving, robust sorting and CRCs,
+// therefore the format must have them disabled.
+func (t *AMR) CreateEncoder() *rtpamr.Encoder {
+	e := &rtpamr.Encoder{
+		PayloadType:  t.PayloadTyp,
+		Wideband:     t.Wideband,
+		OctetAligned: t.OctetAlign,
+		ChannelCount: t.ChannelCount,
+	}
+	e.Init()
+	return e
+}
@@ -0,0 +1,71 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAMRAttributes(t *testing.T) {
+	format := &AMR{
+		PayloadTyp:   96,
+		ChannelCount: 1,
+	}
+	require.Equal(t, "AMR", format.String())
+	require.Equal(t, 8000, format.ClockRate())
+	require.Equal(t, uint8(96), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+
+	format = &AMR{
+		PayloadTyp:   96,
+		Wideband:     true,
+		ChannelCount: 1,
+	}
+	require.Equal(t, "AMR-WB", format.String())
+	require.Equal(t, 16000, format.ClockRate())
+}
+
+func TestAMRMediaDescription(t *testing.T) {
+	v := 10
+
+	format := &AMR{
+		PayloadTyp:    96,
+		Wideband:      true,
+		ChannelCount:  1,
+		OctetAlign:    true,
+		ModeSet:       []int{0, 2, 8},
+		Interleaving:  &v,
+		CRC:           true,
+		RobustSorting: true,
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "AMR-WB/16000/1", rtpmap)
+	require.Equal(t, "octet-align=1; mode-set=0,2,8; interleaving=10; crc=1; robust-sorting=1", fmtp)
+}
+
+func TestAMRDecEncoder(t *testing.T) {
+	for _, octetAlign := range []bool{false, true} {
+		format := &AMR{
+			PayloadTyp:   96,
+			ChannelCount: 1,
+			OctetAlign:   octetAlign,
+		}
+
+		frames := [][]byte{
+			append(append([]byte{0x3c}, bytes.Repeat([]byte{0x01}, 30)...), 0x10),
+		}
+
+		enc := format.CreateEncoder()
+		pkts, err := enc.Encode(frames, 0)
+		require.NoError(t, err)
+		require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+		dec := format.CreateDecoder()
+		byts, _, err := dec.Decode(pkts[0])
+		require.NoError(t, err)
+		require.Equal(t, frames, byts)
+	}
+}
@@ -0,0 +1,157 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/av1"
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpav1"
+)
+
+func rtpAV1ContainsKeyFrame(pkt *rtp.Packet) bool {
+	if len(pkt.Payload) < 2 {
+		return false
+	}
+
+	// N bit: the packet is the first packet of a coded video sequence
+	if (pkt.Payload[0] & 0x08) != 0 {
+		return true
+	}
+
+	// Z bit: the first element is the continuation of a fragmented OBU
+	if (pkt.Payload[0] & 0x80) != 0 {
+		return false
+	}
+
+	w := (pkt.Payload[0] >> 4) & 0x03
+	payload := pkt.Payload[1:]
+
+	if w != 1 {
+		_, n, err := av1.LEB128Unmarshal(payload)
+		if err != nil {
+			return false
+		}
+		payload = payload[n:]
+	}
+
+	if len(payload) == 0 {
+		return false
+	}
+
+	typ := av1.OBUType((payload[0] >> 3) & 0x0F)
+	return typ == av1.OBUTypeSequenceHeader
+}
+
+// AV1 is a AV1 format.
+type AV1 struct {
+	PayloadTyp uint8
+	LevelIdx   *int
+	Profile    *int
+	Tier       *int
+}
+
+// String implements Format.
+func (t *AV1) String() string {
+	return "AV1"
+}
+
+// ClockRate implements Format.
+func (t *AV1) ClockRate() int {
+	return 90000
+}
+
+// PayloadType implements Format.
+func (t *AV1) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *AV1) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+
+	if fmtp != "" {
+		for _, kv := range strings.Split(fmtp, ";") {
+			kv = strings.Trim(kv, " ")
+
+			if len(kv) == 0 {
+				continue
+			}
+
+			tmp := strings.SplitN(kv, "=", 2)
+			if len(tmp) != 2 {
+				return fmt.Errorf("invalid fmtp attribute (%v)", fmtp)
+			}
+
+			switch tmp[0] {
+			case "level-idx":
+				val, err := strconv.ParseUint(tmp[1], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid level-idx (%v)", tmp[1])
+				}
+				v2 := int(val)
+				t.LevelIdx = &v2
+
+			case "profile":
+				val, err := strconv.ParseUint(tmp[1], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid profile (%v)", tmp[1])
+				}
+				v2 := int(val)
+				t.Profile = &v2
+
+			case "tier":
+				val, err := strconv.ParseUint(tmp[1], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid tier (%v)", tmp[1])
+				}
+				v2 := int(val)
+				t.Tier = &v2
+			}
+		}
+	}
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *AV1) Marshal() (string, string) {
+	var tmp []string
+	if t.LevelIdx != nil {
+		tmp = append(tmp, "level-idx="+strconv.FormatInt(int64(*t.LevelIdx), 10))
+	}
+	if t.Profile != nil {
+		tmp = append(tmp, "profile="+strconv.FormatInt(int64(*t.Profile), 10))
+	}
+	if t.Tier != nil {
+		tmp = append(tmp, "tier="+strconv.FormatInt(int64(*t.Tier), 10))
+	}
+	var fmtp string
+	if tmp != nil {
+		fmtp = strings.Join(tmp, ";")
+	}
+
+	return "AV1/90000", fmtp
+}
+
+// PTSEqualsDTS implements Format.
+func (t *AV1) PTSEqualsDTS(pkt *rtp.Packet) bool {
+	return rtpAV1ContainsKeyFrame(pkt)
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *AV1) CreateDecoder() *rtpav1.Decoder {
+	d := &rtpav1.Decoder{}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *AV1) CreateEncoder() *rtpav1.Encoder {
+	e := &rtpav1.Encoder{
+		PayloadType: t.PayloadTyp,
+	}
+	e.Init()
+	return e
+}
@@ -0,0 +1,67 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAV1Attributes(t *testing.T) {
+	format := &AV1{
+		PayloadTyp: 100,
+	}
+	require.Equal(t, "AV1", format.String())
+	require.Equal(t, 90000, format.ClockRate())
+	require.Equal(t, uint8(100), format.PayloadType())
+}
+
+func TestAV1PTSEqualsDTS(t *testing.T) {
+	format := &AV1{
+		PayloadTyp: 100,
+	}
+
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{
+		Payload: []byte{0x08, 0x02, 0x30, 0x10},
+	}))
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{
+		Payload: []byte{0x00, 0x02, 0x08, 0x00},
+	}))
+	require.Equal(t, false, format.PTSEqualsDTS(&rtp.Packet{
+		Payload: []byte{0x00, 0x02, 0x30, 0x10},
+	}))
+	require.Equal(t, false, format.PTSEqualsDTS(&rtp.Packet{
+		Payload: []byte{0x80, 0x02, 0x08, 0x00},
+	}))
+}
+
+func TestAV1MediaDescription(t *testing.T) {
+	levelIdx := 8
+	profile := 0
+	tier := 0
+
+	format := &AV1{
+		PayloadTyp: 100,
+		LevelIdx:   &levelIdx,
+		Profile:    &profile,
+		Tier:       &tier,
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "AV1/90000", rtpmap)
+	require.Equal(t, "level-idx=8;profile=0;tier=0", fmtp)
+}
+
+func TestAV1DecEncoder(t *testing.T) {
+	format := &AV1{}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode([][]byte{{0x30, 0x10, 0x01, 0x02}}, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	obus, _, err := dec.DecodeUntilMarker(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x30, 0x10, 0x01, 0x02}}, obus)
+}
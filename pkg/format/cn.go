@@ -0,0 +1,81 @@
+package format
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpcn"
+)
+
+// CN is a comfort noise format.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3389
+type CN struct {
+	PayloadTyp uint8
+	SampleRate int
+}
+
+// String implements Format.
+func (t *CN) String() string {
+	return "CN"
+}
+
+// ClockRate implements Format.
+func (t *CN) ClockRate() int {
+	return t.SampleRate
+}
+
+// PayloadType implements Format.
+func (t *CN) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *CN) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+
+	if clock == "" {
+		// static payload type 13
+		t.SampleRate = 8000
+		return nil
+	}
+
+	tmp := strings.SplitN(clock, "/", 2)
+
+	sampleRate, err := strconv.ParseInt(tmp[0], 10, 64)
+	if err != nil {
+		return err
+	}
+	t.SampleRate = int(sampleRate)
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *CN) Marshal() (string, string) {
+	return "CN/" + strconv.FormatInt(int64(t.SampleRate), 10), ""
+}
+
+// PTSEqualsDTS implements Format.
+func (t *CN) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *CN) CreateDecoder() *rtpcn.Decoder {
+	d := &rtpcn.Decoder{
+		ClockRate: t.SampleRate,
+	}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *CN) CreateEncoder() *rtpcn.Encoder {
+	e := &rtpcn.Encoder{
+		PayloadType: t.PayloadTyp,
+		ClockRate:   t.SampleRate,
+	}
+	e.Init()
+	return e
+}
@@ -0,0 +1,49 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpcn"
+)
+
+func TestCNAttributes(t *testing.T) {
+	format := &CN{
+		PayloadTyp: 13,
+		SampleRate: 8000,
+	}
+	require.Equal(t, "CN", format.String())
+	require.Equal(t, 8000, format.ClockRate())
+	require.Equal(t, uint8(13), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestCNMediaDescription(t *testing.T) {
+	format := &CN{
+		PayloadTyp: 13,
+		SampleRate: 8000,
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "CN/8000", rtpmap)
+	require.Equal(t, "", fmtp)
+}
+
+func TestCNDecEncoder(t *testing.T) {
+	format := &CN{
+		PayloadTyp: 13,
+		SampleRate: 8000,
+	}
+
+	enc := format.CreateEncoder()
+	pkt, err := enc.Encode(&rtpcn.Parameters{NoiseLevel: 64}, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkt.PayloadType)
+
+	dec := format.CreateDecoder()
+	params, _, err := dec.Decode(pkt)
+	require.NoError(t, err)
+	require.Equal(t, &rtpcn.Parameters{NoiseLevel: 64}, params)
+}
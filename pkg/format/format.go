@@ -52,6 +52,17 @@ type Format interface {
 	PTSEqualsDTS(*rtp.Packet) bool
 }
 
+// MediaAttributesMarshaler is implemented by formats that
+// need to write media-level attributes.
+type MediaAttributesMarshaler interface {
+	// MarshalMediaAttributes returns the media-level attributes of the format.
+	MarshalMediaAttributes() []psdp.Attribute
+}
+
+type mediaAttributesUnmarshaler interface {
+	unmarshalMediaAttributes(attributes []psdp.Attribute)
+}
+
 // Unmarshal decodes a format from a media description.
 func Unmarshal(md *psdp.MediaDescription, payloadTypeStr string) (Format, error) {
 	if payloadTypeStr == "smart/1/90000" {
@@ -85,17 +96,41 @@ func Unmarshal(md *psdp.MediaDescription, payloadTypeStr string) (Format, error)
 			case payloadType == 32:
 				return &MPEG2Video{}
 
+			case payloadType == 33, codec == "mp2t" && clock == "90000":
+				return &MPEGTS{}
+
+			case payloadType == 34, codec == "h263" && clock == "90000":
+				return &H263{}
+
+			case (codec == "h263-1998" || codec == "h263-2000") && clock == "90000":
+				return &H263Plus{}
+
 			case codec == "h264" && clock == "90000":
 				return &H264{}
 
 			case codec == "h265" && clock == "90000":
 				return &H265{}
 
+			case codec == "h266" && clock == "90000":
+				return &H266{}
+
+			case codec == "mp4v-es" && clock == "90000":
+				return &MPEG4Video{}
+
 			case codec == "vp8" && clock == "90000":
 				return &VP8{}
 
 			case codec == "vp9" && clock == "90000":
 				return &VP9{}
+
+			case codec == "av1" && clock == "90000":
+				return &AV1{}
+
+			case codec == "theora" && clock == "90000":
+				return &Theora{}
+
+			case codec == "raw" && clock == "90000":
+				return &RawVideo{}
 			}
 
 		case md.MediaName.Media == "audio":
@@ -103,23 +138,56 @@ func Unmarshal(md *psdp.MediaDescription, payloadTypeStr string) (Format, error)
 			case payloadType == 0, payloadType == 8:
 				return &G711{}
 
+			case payloadType == 2, strings.HasPrefix(codec, "g726-"), strings.HasPrefix(codec, "aal2-g726-"):
+				return &G726{}
+
+			case payloadType == 3, codec == "gsm":
+				return &GSM{}
+
 			case payloadType == 9:
 				return &G722{}
 
+			case payloadType == 13, codec == "cn":
+				return &CN{}
+
 			case payloadType == 14:
 				return &MPEG2Audio{}
 
+			case payloadType == 18, codec == "g729":
+				return &G729{}
+
+			case codec == "speex":
+				return &Speex{}
+
+			case codec == "ilbc":
+				return &ILBC{}
+
 			case codec == "l8", codec == "l16", codec == "l24":
 				return &LPCM{}
 
 			case codec == "mpeg4-generic":
 				return &MPEG4Audio{}
 
+			case codec == "mp4a-latm":
+				return &MPEG4AudioLATM{}
+
 			case codec == "vorbis":
 				return &Vorbis{}
 
 			case codec == "opus":
 				return &Opus{}
+
+			case codec == "ac3":
+				return &AC3{}
+
+			case codec == "eac3":
+				return &EAC3{}
+
+			case codec == "amr", codec == "amr-wb":
+				return &AMR{}
+
+			case codec == "telephone-event":
+				return &TelephoneEvent{}
 			}
 		}
 
@@ -131,5 +199,9 @@ func Unmarshal(md *psdp.MediaDescription, payloadTypeStr string) (Format, error)
 		return nil, err
 	}
 
+	if fma, ok := format.(mediaAttributesUnmarshaler); ok {
+		fma.unmarshalMediaAttributes(md.Attributes)
+	}
+
 	return format, nil
 }
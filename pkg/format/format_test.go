@@ -2,6 +2,7 @@ package format
 
 import (
 	"testing"
+	"time"
 
 	psdp "github.com/pion/sdp/v3"
 	"github.com/stretchr/testify/require"
@@ -50,6 +51,222 @@ func TestNewFromMediaDescription(t *testing.T) {
 			},
 			&G722{},
 		},
+		{
+			"audio g726 static",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"2"},
+				},
+			},
+			&G726{
+				PayloadTyp: 2,
+				BitRate:    32,
+			},
+		},
+		{
+			"audio g726 aal2",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 AAL2-G726-24/8000",
+					},
+				},
+			},
+			&G726{
+				PayloadTyp: 96,
+				BitRate:    24,
+				BigEndian:  true,
+			},
+		},
+		{
+			"audio g729",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"18"},
+				},
+			},
+			&G729{
+				PayloadTyp: 18,
+				AnnexB:     true,
+			},
+		},
+		{
+			"audio g729 without annexb",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"18"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "18 G729/8000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "18 annexb=no",
+					},
+				},
+			},
+			&G729{
+				PayloadTyp: 18,
+			},
+		},
+		{
+			"audio gsm",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"3"},
+				},
+			},
+			&GSM{
+				PayloadTyp: 3,
+			},
+		},
+		{
+			"audio speex",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"97"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "97 speex/16000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "97 mode=\"1,any\";vbr=on",
+					},
+				},
+			},
+			&Speex{
+				PayloadTyp: 97,
+				SampleRate: 16000,
+				VBR:        true,
+				Mode:       "1,any",
+			},
+		},
+		{
+			"audio ilbc",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"98"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "98 iLBC/8000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "98 mode=20",
+					},
+				},
+			},
+			&ILBC{
+				PayloadTyp: 98,
+				Mode:       20,
+			},
+		},
+		{
+			"audio ilbc quoted mode",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"98"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "98 iLBC/8000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "98 mode=\"30\"",
+					},
+				},
+			},
+			&ILBC{
+				PayloadTyp: 98,
+				Mode:       30,
+			},
+		},
+		{
+			"audio lpcm malformed fmtp",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"97"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "97 L16/44100/2",
+					},
+					{
+						Key:   "fmtp",
+						Value: "97 emphasis; channel-order=SMPTE2110.(ST); unknown=1",
+					},
+				},
+			},
+			&LPCM{
+				PayloadTyp:   97,
+				BitDepth:     16,
+				SampleRate:   44100,
+				ChannelCount: 2,
+				ChannelOrder: "SMPTE2110.(ST)",
+			},
+		},
+		{
+			"audio lpcm malformed ptime",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"97"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "97 L24/48000/2",
+					},
+					{
+						Key:   "ptime",
+						Value: "aa",
+					},
+					{
+						Key:   "maxptime",
+						Value: "-1",
+					},
+				},
+			},
+			&LPCM{
+				PayloadTyp:   97,
+				BitDepth:     24,
+				SampleRate:   48000,
+				ChannelCount: 2,
+			},
+		},
 		{
 			"audio lpcm 8",
 			&psdp.MediaDescription{
@@ -138,6 +355,53 @@ func TestNewFromMediaDescription(t *testing.T) {
 				ChannelCount: 4,
 			},
 		},
+		{
+			"audio lpcm aes67",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"97"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "97 L24/48000/2",
+					},
+					{
+						Key:   "fmtp",
+						Value: "97 channel-order=SMPTE2110.(ST)",
+					},
+					{
+						Key:   "ptime",
+						Value: "0.125",
+					},
+					{
+						Key:   "maxptime",
+						Value: "1",
+					},
+					{
+						Key:   "ts-refclk",
+						Value: "ptp=IEEE1588-2008:39-A7-94-FF-FE-07-CB-D0:0",
+					},
+					{
+						Key:   "mediaclk",
+						Value: "direct=963214424",
+					},
+				},
+			},
+			&LPCM{
+				PayloadTyp:      97,
+				BitDepth:        24,
+				SampleRate:      48000,
+				ChannelCount:    2,
+				PacketTime:      125 * time.Microsecond,
+				MaxPacketTime:   1 * time.Millisecond,
+				ChannelOrder:    "SMPTE2110.(ST)",
+				ReferenceClocks: []string{"ptp=IEEE1588-2008:39-A7-94-FF-FE-07-CB-D0:0"},
+				MediaClock:      "direct=963214424",
+			},
+		},
 		{
 			"audio mpeg2 audio",
 			&psdp.MediaDescription{
@@ -250,6 +514,331 @@ func TestNewFromMediaDescription(t *testing.T) {
 				IndexDeltaLength: 0,
 			},
 		},
+		{
+			"audio mpeg4 audio latm",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 MP4A-LATM/24000/2",
+					},
+					{
+						Key:   "fmtp",
+						Value: "96 profile-level-id=15; object=2; cpresent=0; config=400026203fc0",
+					},
+				},
+			},
+			&MPEG4AudioLATM{
+				PayloadTyp:     96,
+				SampleRate:     24000,
+				ChannelCount:   2,
+				ProfileLevelID: 15,
+				Object:         2,
+				CPresent:       false,
+				Config: &mpeg4audio.StreamMuxConfig{
+					Programs: []*mpeg4audio.StreamMuxConfigProgram{{
+						Layers: []*mpeg4audio.StreamMuxConfigLayer{{
+							AudioSpecificConfig: &mpeg4audio.Config{
+								Type:         mpeg4audio.ObjectTypeAACLC,
+								SampleRate:   24000,
+								ChannelCount: 2,
+							},
+							LatmBufferFullness: 255,
+						}},
+					}},
+				},
+			},
+		},
+		{
+			"audio mpeg4 audio latm in-band config",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 MP4A-LATM/90000",
+					},
+				},
+			},
+			&MPEG4AudioLATM{
+				PayloadTyp:     96,
+				SampleRate:     90000,
+				ChannelCount:   1,
+				ProfileLevelID: 30,
+				CPresent:       true,
+			},
+		},
+		{
+			"video theora",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 THEORA/90000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "96 sampling=YCbCr-4:2:0; width=1280; height=720; delivery-method=inline; configuration=AQIDBA==",
+					},
+				},
+			},
+			&Theora{
+				PayloadTyp:    96,
+				Sampling:      "YCbCr-4:2:0",
+				Width:         1280,
+				Height:        720,
+				Configuration: []byte{0x01, 0x02, 0x03, 0x04},
+			},
+		},
+		{
+			"video raw",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 raw/90000",
+					},
+					{
+						Key: "fmtp",
+						Value: "96 sampling=YCbCr-4:2:2; width=1920; height=1080; exactframerate=25; depth=10; " +
+							"TCS=SDR; colorimetry=BT709; PM=2110GPM; SSN=ST2110-20:2017; interlace",
+					},
+				},
+			},
+			&RawVideo{
+				PayloadTyp:     96,
+				Sampling:       "YCbCr-4:2:2",
+				Width:          1920,
+				Height:         1080,
+				Depth:          10,
+				Colorimetry:    "BT709",
+				Interlace:      true,
+				ExactFrameRate: "25",
+			},
+		},
+		{
+			"video h263",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"34"},
+				},
+			},
+			&H263{
+				PayloadTyp: 34,
+			},
+		},
+		{
+			"video h263-1998",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 H263-1998/90000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "96 CIF=1;QCIF=1",
+					},
+				},
+			},
+			&H263Plus{
+				PayloadTyp: 96,
+			},
+		},
+		{
+			"video h263-2000",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"97"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "97 H263-2000/90000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "97 profile=3;level=10",
+					},
+				},
+			},
+			&H263Plus{
+				PayloadTyp:  97,
+				Version2000: true,
+				Profile: func() *int {
+					v := 3
+					return &v
+				}(),
+				Level: func() *int {
+					v := 10
+					return &v
+				}(),
+			},
+		},
+		{
+			"audio amr",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 AMR/8000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "96 octet-align=1; mode-set=0,2,5,7; mode-change-period=2",
+					},
+				},
+			},
+			&AMR{
+				PayloadTyp:   96,
+				ChannelCount: 1,
+				OctetAlign:   true,
+				ModeSet:      []int{0, 2, 5, 7},
+			},
+		},
+		{
+			"audio amr-wb",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"97"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "97 AMR-WB/16000/2",
+					},
+				},
+			},
+			&AMR{
+				PayloadTyp:   97,
+				Wideband:     true,
+				ChannelCount: 2,
+			},
+		},
+		{
+			"audio amr crc without octet-align",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 AMR/8000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "96 crc=1; interleaving=4",
+					},
+				},
+			},
+			&AMR{
+				PayloadTyp:   96,
+				ChannelCount: 1,
+				OctetAlign:   true,
+				Interleaving: func() *int {
+					v := 4
+					return &v
+				}(),
+				CRC: true,
+			},
+		},
+		{
+			"audio cn static",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"13"},
+				},
+			},
+			&CN{
+				PayloadTyp: 13,
+				SampleRate: 8000,
+			},
+		},
+		{
+			"audio cn dynamic",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"98"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "98 CN/16000",
+					},
+				},
+			},
+			&CN{
+				PayloadTyp: 98,
+				SampleRate: 16000,
+			},
+		},
+		{
+			"audio telephone-event",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"101"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "101 telephone-event/8000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "101 0-3,16",
+					},
+				},
+			},
+			&TelephoneEvent{
+				PayloadTyp: 101,
+				SampleRate: 8000,
+				Events:     []uint8{0, 1, 2, 3, 16},
+			},
+		},
 		{
 			"audio vorbis",
 			&psdp.MediaDescription{
@@ -295,33 +884,107 @@ func TestNewFromMediaDescription(t *testing.T) {
 					},
 				},
 			},
-			&Opus{
-				PayloadTyp:   96,
-				SampleRate:   48000,
-				ChannelCount: 2,
-			},
+			&Opus{
+				PayloadTyp:   96,
+				SampleRate:   48000,
+				ChannelCount: 2,
+			},
+		},
+		{
+			"audio ac-3",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"97"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "97 AC3/48000/6",
+					},
+				},
+			},
+			&AC3{
+				PayloadTyp:   97,
+				SampleRate:   48000,
+				ChannelCount: 6,
+			},
+		},
+		{
+			"audio e-ac-3",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"97"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "97 eac3/32000",
+					},
+				},
+			},
+			&EAC3{
+				PayloadTyp:   97,
+				SampleRate:   32000,
+				ChannelCount: 1,
+			},
+		},
+		{
+			"video jpeg",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"26"},
+				},
+			},
+			&MJPEG{},
+		},
+		{
+			"video mpeg2 video",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"32"},
+				},
+			},
+			&MPEG2Video{},
 		},
 		{
-			"video jpeg",
+			"video mpeg-ts",
 			&psdp.MediaDescription{
 				MediaName: psdp.MediaName{
 					Media:   "video",
 					Protos:  []string{"RTP", "AVP"},
-					Formats: []string{"26"},
+					Formats: []string{"33"},
 				},
 			},
-			&MJPEG{},
+			&MPEGTS{
+				PayloadTyp: 33,
+			},
 		},
 		{
-			"video mpeg2 video",
+			"video mpeg-ts dynamic",
 			&psdp.MediaDescription{
 				MediaName: psdp.MediaName{
 					Media:   "video",
 					Protos:  []string{"RTP", "AVP"},
-					Formats: []string{"32"},
+					Formats: []string{"98"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "98 MP2T/90000",
+					},
 				},
 			},
-			&MPEG2Video{},
+			&MPEGTS{
+				PayloadTyp: 98,
+			},
 		},
 		{
 			"video h264",
@@ -509,6 +1172,68 @@ func TestNewFromMediaDescription(t *testing.T) {
 				MaxDONDiff: 2,
 			},
 		},
+		{
+			"video h266",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 H266/90000",
+					},
+					{
+						Key: "fmtp",
+						Value: "96 sprop-vps=AHEBAg==; sprop-sps=AHkADQJDgAAADwIARB8s; " +
+							"sprop-pps=AIEDBA==",
+					},
+				},
+			},
+			&H266{
+				PayloadTyp: 96,
+				VPS:        []byte{0x00, 0x71, 0x01, 0x02},
+				SPS: []byte{
+					0x00, 0x79, 0x00, 0x0d, 0x02, 0x43, 0x80, 0x00,
+					0x00, 0x0f, 0x02, 0x00, 0x44, 0x1f, 0x2c,
+				},
+				PPS: []byte{0x00, 0x81, 0x03, 0x04},
+			},
+		},
+		{
+			"video mpeg4 video",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 MP4V-ES/90000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "96 profile-level-id=1; config=000001B001000001B58913000001000000012000C48D8800CD3204709443000001B24C61766335382E35342E313030",
+					},
+				},
+			},
+			&MPEG4Video{
+				PayloadTyp:     96,
+				ProfileLevelID: 1,
+				Config: []byte{
+					0x00, 0x00, 0x01, 0xb0, 0x01, 0x00, 0x00, 0x01,
+					0xb5, 0x89, 0x13, 0x00, 0x00, 0x01, 0x00, 0x00,
+					0x00, 0x01, 0x20, 0x00, 0xc4, 0x8d, 0x88, 0x00,
+					0xcd, 0x32, 0x04, 0x70, 0x94, 0x43, 0x00, 0x00,
+					0x01, 0xb2, 0x4c, 0x61, 0x76, 0x63, 0x35, 0x38,
+					0x2e, 0x35, 0x34, 0x2e, 0x31, 0x30, 0x30,
+				},
+			},
+		},
 		{
 			"video vp8",
 			&psdp.MediaDescription{
@@ -575,6 +1300,41 @@ func TestNewFromMediaDescription(t *testing.T) {
 				}(),
 			},
 		},
+		{
+			"video av1",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 AV1/90000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "96 profile=1;level-idx=8;tier=1",
+					},
+				},
+			},
+			&AV1{
+				PayloadTyp: 96,
+				LevelIdx: func() *int {
+					v := 8
+					return &v
+				}(),
+				Profile: func() *int {
+					v := 1
+					return &v
+				}(),
+				Tier: func() *int {
+					v := 1
+					return &v
+				}(),
+			},
+		},
 		{
 			"application",
 			&psdp.MediaDescription{
@@ -890,6 +1650,107 @@ func TestNewFromMediaDescriptionErrors(t *testing.T) {
 			},
 			"invalid AAC IndexDeltaLength (aaa)",
 		},
+		{
+			"audio amr invalid mode-set",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 AMR/8000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "96 mode-set=0,aa",
+					},
+				},
+			},
+			"invalid mode-set (0,aa)",
+		},
+		{
+			"audio telephone-event invalid events",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"101"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "101 telephone-event/8000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "101 0-15,aa",
+					},
+				},
+			},
+			"invalid events (0-15,aa)",
+		},
+		{
+			"video raw missing sampling",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 raw/90000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "96 width=1920; height=1080; depth=10",
+					},
+				},
+			},
+			"sampling, width, height or depth is missing (width=1920; height=1080; depth=10)",
+		},
+		{
+			"audio g726 invalid bit rate",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 G726-48/8000",
+					},
+				},
+			},
+			"invalid G726 bit rate (48)",
+		},
+		{
+			"audio ilbc invalid mode",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"98"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "98 iLBC/8000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "98 mode=10",
+					},
+				},
+			},
+			"invalid iLBC mode (10)",
+		},
 		{
 			"audio vorbis missing configuration",
 			&psdp.MediaDescription{
@@ -1046,6 +1907,111 @@ func TestNewFromMediaDescriptionErrors(t *testing.T) {
 			},
 			"invalid packetization-mode (aaa)",
 		},
+		{
+			"video mpeg4 video invalid profile-level-id",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 MP4V-ES/90000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "96 profile-level-id=aa",
+					},
+				},
+			},
+			"invalid profile-level-id (aa)",
+		},
+		{
+			"video mpeg4 video invalid config",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "video",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 MP4V-ES/90000",
+					},
+					{
+						Key:   "fmtp",
+						Value: "96 profile-level-id=1; config=zz",
+					},
+				},
+			},
+			"invalid config (zz)",
+		},
+		{
+			"audio mpeg4 audio latm missing config",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 MP4A-LATM/24000/2",
+					},
+					{
+						Key:   "fmtp",
+						Value: "96 profile-level-id=15; cpresent=0",
+					},
+				},
+			},
+			"config is missing (profile-level-id=15; cpresent=0)",
+		},
+		{
+			"audio mpeg4 audio latm invalid config",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 MP4A-LATM/24000/2",
+					},
+					{
+						Key:   "fmtp",
+						Value: "96 profile-level-id=15; cpresent=0; config=c0",
+					},
+				},
+			},
+			"invalid LATM config (c0)",
+		},
+		{
+			"audio mpeg4 audio latm invalid cpresent",
+			&psdp.MediaDescription{
+				MediaName: psdp.MediaName{
+					Media:   "audio",
+					Protos:  []string{"RTP", "AVP"},
+					Formats: []string{"96"},
+				},
+				Attributes: []psdp.Attribute{
+					{
+						Key:   "rtpmap",
+						Value: "96 MP4A-LATM/24000/2",
+					},
+					{
+						Key:   "fmtp",
+						Value: "96 cpresent=2",
+					},
+				},
+			},
+			"invalid cpresent (2)",
+		},
 	} {
 		t.Run(ca.name, func(t *testing.T) {
 			_, err := Unmarshal(ca.md, ca.md.MediaName.Formats[0])
@@ -0,0 +1,105 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpg726"
+)
+
+// G726 is a G726 format.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3551
+type G726 struct {
+	PayloadTyp uint8
+
+	// bit rate, in kbit/s (16, 24, 32 or 40).
+	BitRate int
+
+	// whether code words are packed starting from the most significant bit (AAL2 packing),
+	// instead of the least significant one.
+	BigEndian bool
+}
+
+// String implements Format.
+func (t *G726) String() string {
+	return "G726"
+}
+
+// ClockRate implements Format.
+func (t *G726) ClockRate() int {
+	return 8000
+}
+
+// PayloadType implements Format.
+func (t *G726) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *G726) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+
+	if payloadType == 2 && codec == "" {
+		// static payload type 2, that was assigned to G721 and then to G726-32
+		t.BitRate = 32
+		return nil
+	}
+
+	if strings.HasPrefix(codec, "aal2-") {
+		t.BigEndian = true
+		codec = codec[len("aal2-"):]
+	}
+
+	tmp, err := strconv.ParseInt(strings.TrimPrefix(codec, "g726-"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid G726 codec (%v)", codec)
+	}
+
+	switch tmp {
+	case 16, 24, 32, 40:
+	default:
+		return fmt.Errorf("invalid G726 bit rate (%d)", tmp)
+	}
+
+	t.BitRate = int(tmp)
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *G726) Marshal() (string, string) {
+	codec := "G726-" + strconv.FormatInt(int64(t.BitRate), 10)
+	if t.BigEndian {
+		codec = "AAL2-" + codec
+	}
+
+	return codec + "/8000", ""
+}
+
+// PTSEqualsDTS implements Format.
+func (t *G726) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *G726) CreateDecoder() *rtpg726.Decoder {
+	d := &rtpg726.Decoder{
+		BitRate:   t.BitRate,
+		BigEndian: t.BigEndian,
+	}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *G726) CreateEncoder() *rtpg726.Encoder {
+	e := &rtpg726.Encoder{
+		PayloadType: t.PayloadTyp,
+		BitRate:     t.BitRate,
+		BigEndian:   t.BigEndian,
+	}
+	e.Init()
+	return e
+}
@@ -0,0 +1,51 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestG726Attributes(t *testing.T) {
+	format := &G726{
+		PayloadTyp: 96,
+		BitRate:    32,
+		BigEndian:  true,
+	}
+	require.Equal(t, "G726", format.String())
+	require.Equal(t, 8000, format.ClockRate())
+	require.Equal(t, uint8(96), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestG726MediaDescription(t *testing.T) {
+	format := &G726{
+		PayloadTyp: 96,
+		BitRate:    32,
+		BigEndian:  true,
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "AAL2-G726-32/8000", rtpmap)
+	require.Equal(t, "", fmtp)
+}
+
+func TestG726DecEncoder(t *testing.T) {
+	format := &G726{
+		PayloadTyp: 96,
+		BitRate:    32,
+		BigEndian:  true,
+	}
+
+	enc := format.CreateEncoder()
+	pkt, err := enc.Encode([]byte{0x01, 0x02, 0x03, 0x04}, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkt.PayloadType)
+	require.Equal(t, []byte{0x10, 0x20, 0x30, 0x40}, pkt.Payload)
+
+	dec := format.CreateDecoder()
+	byts, _, err := dec.Decode(pkt)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01, 0x02, 0x03, 0x04}, byts)
+}
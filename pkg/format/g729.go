@@ -0,0 +1,107 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpframedaudio"
+)
+
+// G729 is a G729 format.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3551
+type G729 struct {
+	PayloadTyp uint8
+
+	// whether comfort noise frames (Annex B) can be present.
+	AnnexB bool
+}
+
+// String implements Format.
+func (t *G729) String() string {
+	return "G729"
+}
+
+// ClockRate implements Format.
+func (t *G729) ClockRate() int {
+	return 8000
+}
+
+// PayloadType implements Format.
+func (t *G729) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *G729) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+
+	// RFC3555: if annexb is not present, Annex B is in use.
+	t.AnnexB = true
+
+	if fmtp != "" {
+		for _, kv := range strings.Split(fmtp, ";") {
+			kv = strings.Trim(kv, " ")
+
+			if len(kv) == 0 {
+				continue
+			}
+
+			tmp := strings.SplitN(kv, "=", 2)
+			if len(tmp) != 2 {
+				continue
+			}
+
+			if strings.ToLower(tmp[0]) == "annexb" {
+				t.AnnexB = (strings.ToLower(tmp[1]) != "no")
+			}
+		}
+	}
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *G729) Marshal() (string, string) {
+	fmtp := ""
+	if !t.AnnexB {
+		fmtp = "annexb=no"
+	}
+
+	return "G729/8000", fmtp
+}
+
+// PTSEqualsDTS implements Format.
+func (t *G729) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+func (t *G729) sidFrameSize() int {
+	if t.AnnexB {
+		return 2
+	}
+	return 0
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *G729) CreateDecoder() *rtpframedaudio.Decoder {
+	d := &rtpframedaudio.Decoder{
+		SampleRate:   8000,
+		FrameSize:    10,
+		SIDFrameSize: t.sidFrameSize(),
+	}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *G729) CreateEncoder() *rtpframedaudio.Encoder {
+	e := &rtpframedaudio.Encoder{
+		PayloadType:     t.PayloadTyp,
+		SampleRate:      8000,
+		FrameSize:       10,
+		SamplesPerFrame: 80,
+		SIDFrameSize:    t.sidFrameSize(),
+	}
+	e.Init()
+	return e
+}
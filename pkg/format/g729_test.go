@@ -0,0 +1,53 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestG729Attributes(t *testing.T) {
+	format := &G729{
+		PayloadTyp: 18,
+		AnnexB:     false,
+	}
+	require.Equal(t, "G729", format.String())
+	require.Equal(t, 8000, format.ClockRate())
+	require.Equal(t, uint8(18), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestG729MediaDescription(t *testing.T) {
+	format := &G729{
+		PayloadTyp: 18,
+		AnnexB:     false,
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "G729/8000", rtpmap)
+	require.Equal(t, "annexb=no", fmtp)
+}
+
+func TestG729DecEncoder(t *testing.T) {
+	format := &G729{
+		PayloadTyp: 18,
+		AnnexB:     false,
+	}
+
+	frames := [][]byte{
+		bytes.Repeat([]byte{0x01}, 10),
+		bytes.Repeat([]byte{0x02}, 10),
+	}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode(frames, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	dframes, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, frames, dframes)
+}
@@ -0,0 +1,65 @@
+package format
+
+import (
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpframedaudio"
+)
+
+// GSM is a GSM 06.10 (full rate) format.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3551
+type GSM struct {
+	PayloadTyp uint8
+}
+
+// String implements Format.
+func (t *GSM) String() string {
+	return "GSM"
+}
+
+// ClockRate implements Format.
+func (t *GSM) ClockRate() int {
+	return 8000
+}
+
+// PayloadType implements Format.
+func (t *GSM) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *GSM) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+	return nil
+}
+
+// Marshal implements Format.
+func (t *GSM) Marshal() (string, string) {
+	return "GSM/8000", ""
+}
+
+// PTSEqualsDTS implements Format.
+func (t *GSM) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *GSM) CreateDecoder() *rtpframedaudio.Decoder {
+	d := &rtpframedaudio.Decoder{
+		SampleRate: 8000,
+		FrameSize:  33,
+	}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *GSM) CreateEncoder() *rtpframedaudio.Encoder {
+	e := &rtpframedaudio.Encoder{
+		PayloadType:     t.PayloadTyp,
+		SampleRate:      8000,
+		FrameSize:       33,
+		SamplesPerFrame: 160,
+	}
+	e.Init()
+	return e
+}
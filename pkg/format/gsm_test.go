@@ -0,0 +1,50 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGSMAttributes(t *testing.T) {
+	format := &GSM{
+		PayloadTyp: 3,
+	}
+	require.Equal(t, "GSM", format.String())
+	require.Equal(t, 8000, format.ClockRate())
+	require.Equal(t, uint8(3), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestGSMMediaDescription(t *testing.T) {
+	format := &GSM{
+		PayloadTyp: 3,
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "GSM/8000", rtpmap)
+	require.Equal(t, "", fmtp)
+}
+
+func TestGSMDecEncoder(t *testing.T) {
+	format := &GSM{
+		PayloadTyp: 3,
+	}
+
+	frames := [][]byte{
+		bytes.Repeat([]byte{0x01}, 33),
+		bytes.Repeat([]byte{0x02}, 33),
+	}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode(frames, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	dframes, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, frames, dframes)
+}
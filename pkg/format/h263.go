@@ -0,0 +1,59 @@
+package format
+
+import (
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtph263"
+)
+
+// H263 is a H263 format.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2190
+type H263 struct {
+	PayloadTyp uint8
+}
+
+// String implements Format.
+func (t *H263) String() string {
+	return "H263"
+}
+
+// ClockRate implements Format.
+func (t *H263) ClockRate() int {
+	return 90000
+}
+
+// PayloadType implements Format.
+func (t *H263) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *H263) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+	return nil
+}
+
+// Marshal implements Format.
+func (t *H263) Marshal() (string, string) {
+	return "H263/90000", ""
+}
+
+// PTSEqualsDTS implements Format.
+func (t *H263) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *H263) CreateDecoder() *rtph263.Decoder {
+	d := &rtph263.Decoder{}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *H263) CreateEncoder() *rtph263.Encoder {
+	e := &rtph263.Encoder{
+		PayloadType: t.PayloadTyp,
+	}
+	e.Init()
+	return e
+}
@@ -0,0 +1,44 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestH263Attributes(t *testing.T) {
+	format := &H263{
+		PayloadTyp: 34,
+	}
+	require.Equal(t, "H263", format.String())
+	require.Equal(t, 90000, format.ClockRate())
+	require.Equal(t, uint8(34), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestH263MediaDescription(t *testing.T) {
+	format := &H263{
+		PayloadTyp: 34,
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "H263/90000", rtpmap)
+	require.Equal(t, "", fmtp)
+}
+
+func TestH263DecEncoder(t *testing.T) {
+	format := &H263{
+		PayloadTyp: 34,
+	}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode([]byte{0x00, 0x00, 0x80, 0x16, 0x0a, 0x0a, 0x01, 0x02}, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	byts, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x00, 0x00, 0x80, 0x16, 0x0a, 0x0a, 0x01, 0x02}, byts)
+}
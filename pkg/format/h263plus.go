@@ -0,0 +1,121 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtph263plus"
+)
+
+// H263Plus is a H263+ format (H263-1998 or H263-2000).
+// Specification: https://datatracker.ietf.org/doc/html/rfc4629
+type H263Plus struct {
+	PayloadTyp uint8
+
+	// whether the format is H263-2000 instead of H263-1998.
+	Version2000 bool
+
+	Profile *int
+	Level   *int
+}
+
+// String implements Format.
+func (t *H263Plus) String() string {
+	return "H263+"
+}
+
+// ClockRate implements Format.
+func (t *H263Plus) ClockRate() int {
+	return 90000
+}
+
+// PayloadType implements Format.
+func (t *H263Plus) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *H263Plus) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+	t.Version2000 = (codec == "h263-2000")
+
+	if fmtp != "" {
+		for _, kv := range strings.Split(fmtp, ";") {
+			kv = strings.Trim(kv, " ")
+
+			if len(kv) == 0 {
+				continue
+			}
+
+			tmp := strings.SplitN(kv, "=", 2)
+			if len(tmp) != 2 {
+				return fmt.Errorf("invalid fmtp attribute (%v)", fmtp)
+			}
+
+			switch strings.ToLower(tmp[0]) {
+			case "profile":
+				val, err := strconv.ParseUint(tmp[1], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid profile (%v)", tmp[1])
+				}
+				v2 := int(val)
+				t.Profile = &v2
+
+			case "level":
+				val, err := strconv.ParseUint(tmp[1], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid level (%v)", tmp[1])
+				}
+				v2 := int(val)
+				t.Level = &v2
+			}
+		}
+	}
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *H263Plus) Marshal() (string, string) {
+	var tmp []string
+	if t.Profile != nil {
+		tmp = append(tmp, "profile="+strconv.FormatInt(int64(*t.Profile), 10))
+	}
+	if t.Level != nil {
+		tmp = append(tmp, "level="+strconv.FormatInt(int64(*t.Level), 10))
+	}
+	var fmtp string
+	if tmp != nil {
+		fmtp = strings.Join(tmp, ";")
+	}
+
+	codec := "H263-1998"
+	if t.Version2000 {
+		codec = "H263-2000"
+	}
+
+	return codec + "/90000", fmtp
+}
+
+// PTSEqualsDTS implements Format.
+func (t *H263Plus) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *H263Plus) CreateDecoder() *rtph263plus.Decoder {
+	d := &rtph263plus.Decoder{}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *H263Plus) CreateEncoder() *rtph263plus.Encoder {
+	e := &rtph263plus.Encoder{
+		PayloadType: t.PayloadTyp,
+	}
+	e.Init()
+	return e
+}
@@ -0,0 +1,49 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestH263PlusAttributes(t *testing.T) {
+	format := &H263Plus{
+		PayloadTyp: 96,
+	}
+	require.Equal(t, "H263+", format.String())
+	require.Equal(t, 90000, format.ClockRate())
+	require.Equal(t, uint8(96), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestH263PlusMediaDescription(t *testing.T) {
+	profile := 3
+	level := 10
+	format := &H263Plus{
+		PayloadTyp:  96,
+		Version2000: true,
+		Profile:     &profile,
+		Level:       &level,
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "H263-2000/90000", rtpmap)
+	require.Equal(t, "profile=3;level=10", fmtp)
+}
+
+func TestH263PlusDecEncoder(t *testing.T) {
+	format := &H263Plus{
+		PayloadTyp: 96,
+	}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode([]byte{0x00, 0x00, 0x80, 0x02, 0x1c, 0x0a, 0x01, 0x02}, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	byts, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x00, 0x00, 0x80, 0x02, 0x1c, 0x0a, 0x01, 0x02}, byts)
+}
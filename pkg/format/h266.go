@@ -0,0 +1,247 @@
+package format
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h266"
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtph266"
+)
+
+func h266IsRandomAccess(typ h266.NALUType) bool {
+	switch typ {
+	case h266.NALUType_IDR_W_RADL, h266.NALUType_IDR_N_LP, h266.NALUType_CRA_NUT:
+		return true
+	}
+	return false
+}
+
+func rtpH266ContainsIDR(pkt *rtp.Packet) bool {
+	if len(pkt.Payload) < 2 {
+		return false
+	}
+
+	typ := h266.NALUType(pkt.Payload[1] >> 3)
+
+	switch typ {
+	case h266.NALUType_AggregationUnit:
+		payload := pkt.Payload[2:]
+
+		for len(payload) > 0 {
+			if len(payload) < 2 {
+				return false
+			}
+
+			size := uint16(payload[0])<<8 | uint16(payload[1])
+			payload = payload[2:]
+
+			if size == 0 || int(size) > len(payload) {
+				return false
+			}
+
+			nalu := payload[:size]
+			payload = payload[size:]
+
+			if len(nalu) < 2 {
+				return false
+			}
+
+			typ = h266.NALUType(nalu[1] >> 3)
+			if h266IsRandomAccess(typ) {
+				return true
+			}
+		}
+
+		return false
+
+	case h266.NALUType_FragmentationUnit:
+		if len(pkt.Payload) < 3 {
+			return false
+		}
+
+		start := pkt.Payload[2] >> 7
+		if start != 1 {
+			return false
+		}
+
+		typ := h266.NALUType(pkt.Payload[2] & 0b11111)
+		return h266IsRandomAccess(typ)
+
+	default:
+		return h266IsRandomAccess(typ)
+	}
+}
+
+// H266 is a H266 format.
+type H266 struct {
+	PayloadTyp uint8
+	VPS        []byte
+	SPS        []byte
+	PPS        []byte
+	MaxDONDiff int
+
+	mutex sync.RWMutex
+}
+
+// String implements Format.
+func (t *H266) String() string {
+	return "H266"
+}
+
+// ClockRate implements Format.
+func (t *H266) ClockRate() int {
+	return 90000
+}
+
+// PayloadType implements Format.
+func (t *H266) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *H266) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+
+	if fmtp != "" {
+		for _, kv := range strings.Split(fmtp, ";") {
+			kv = strings.Trim(kv, " ")
+
+			if len(kv) == 0 {
+				continue
+			}
+
+			tmp := strings.SplitN(kv, "=", 2)
+			if len(tmp) != 2 {
+				return fmt.Errorf("invalid fmtp attribute (%v)", fmtp)
+			}
+
+			switch tmp[0] {
+			case "sprop-vps":
+				var err error
+				t.VPS, err = base64.StdEncoding.DecodeString(tmp[1])
+				if err != nil {
+					return fmt.Errorf("invalid sprop-vps (%v)", fmtp)
+				}
+
+			case "sprop-sps":
+				var err error
+				t.SPS, err = base64.StdEncoding.DecodeString(tmp[1])
+				if err != nil {
+					return fmt.Errorf("invalid sprop-sps (%v)", fmtp)
+				}
+
+			case "sprop-pps":
+				var err error
+				t.PPS, err = base64.StdEncoding.DecodeString(tmp[1])
+				if err != nil {
+					return fmt.Errorf("invalid sprop-pps (%v)", fmtp)
+				}
+
+			case "sprop-max-don-diff":
+				tmp, err := strconv.ParseInt(tmp[1], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid sprop-max-don-diff (%v)", fmtp)
+				}
+				t.MaxDONDiff = int(tmp)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *H266) Marshal() (string, string) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	var tmp []string
+	if t.VPS != nil {
+		tmp = append(tmp, "sprop-vps="+base64.StdEncoding.EncodeToString(t.VPS))
+	}
+	if t.SPS != nil {
+		tmp = append(tmp, "sprop-sps="+base64.StdEncoding.EncodeToString(t.SPS))
+	}
+	if t.PPS != nil {
+		tmp = append(tmp, "sprop-pps="+base64.StdEncoding.EncodeToString(t.PPS))
+	}
+	if t.MaxDONDiff != 0 {
+		tmp = append(tmp, "sprop-max-don-diff="+strconv.FormatInt(int64(t.MaxDONDiff), 10))
+	}
+	var fmtp string
+	if tmp != nil {
+		fmtp = strings.Join(tmp, "; ")
+	}
+
+	return "H266/90000", fmtp
+}
+
+// PTSEqualsDTS implements Format.
+func (t *H266) PTSEqualsDTS(pkt *rtp.Packet) bool {
+	return rtpH266ContainsIDR(pkt)
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *H266) CreateDecoder() *rtph266.Decoder {
+	d := &rtph266.Decoder{
+		MaxDONDiff: t.MaxDONDiff,
+	}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *H266) CreateEncoder() *rtph266.Encoder {
+	e := &rtph266.Encoder{
+		PayloadType: t.PayloadTyp,
+		MaxDONDiff:  t.MaxDONDiff,
+	}
+	e.Init()
+	return e
+}
+
+// SafeVPS returns the format VPS.
+func (t *H266) SafeVPS() []byte {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.VPS
+}
+
+// SafeSPS returns the format SPS.
+func (t *H266) SafeSPS() []byte {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.SPS
+}
+
+// SafePPS returns the format PPS.
+func (t *H266) SafePPS() []byte {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.PPS
+}
+
+// SafeSetVPS sets the format VPS.
+func (t *H266) SafeSetVPS(v []byte) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.VPS = v
+}
+
+// SafeSetSPS sets the format SPS.
+func (t *H266) SafeSetSPS(v []byte) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.SPS = v
+}
+
+// SafeSetPPS sets the format PPS.
+func (t *H266) SafeSetPPS(v []byte) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.PPS = v
+}
@@ -0,0 +1,116 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestH266Attributes(t *testing.T) {
+	format := &H266{
+		PayloadTyp: 96,
+		VPS:        []byte{0x01, 0x02},
+		SPS:        []byte{0x03, 0x04},
+		PPS:        []byte{0x05, 0x06},
+	}
+	require.Equal(t, "H266", format.String())
+	require.Equal(t, 90000, format.ClockRate())
+	require.Equal(t, uint8(96), format.PayloadType())
+	require.Equal(t, []byte{0x01, 0x02}, format.SafeVPS())
+	require.Equal(t, []byte{0x03, 0x04}, format.SafeSPS())
+	require.Equal(t, []byte{0x05, 0x06}, format.SafePPS())
+
+	format.SafeSetVPS([]byte{0x07, 0x08})
+	format.SafeSetSPS([]byte{0x09, 0x0A})
+	format.SafeSetPPS([]byte{0x0B, 0x0C})
+	require.Equal(t, []byte{0x07, 0x08}, format.SafeVPS())
+	require.Equal(t, []byte{0x09, 0x0A}, format.SafeSPS())
+	require.Equal(t, []byte{0x0B, 0x0C}, format.SafePPS())
+}
+
+func TestH266PTSEqualsDTS(t *testing.T) {
+	format := &H266{
+		PayloadTyp: 96,
+	}
+
+	for _, ca := range []struct {
+		name    string
+		payload []byte
+		res     bool
+	}{
+		{
+			"idr",
+			[]byte{0x00, 0x39, 0xaf},
+			true,
+		},
+		{
+			"cra",
+			[]byte{0x00, 0x49, 0xaf},
+			true,
+		},
+		{
+			"non-idr",
+			[]byte{0x00, 0x01, 0xaf},
+			false,
+		},
+		{
+			"aggregation unit with idr",
+			[]byte{0x00, 0xe1, 0x00, 0x03, 0x00, 0x71, 0x0c, 0x00, 0x03, 0x00, 0x39, 0xaf},
+			true,
+		},
+		{
+			"aggregation unit without idr",
+			[]byte{0x00, 0xe1, 0x00, 0x03, 0x00, 0x71, 0x0c, 0x00, 0x03, 0x00, 0x01, 0xaf},
+			false,
+		},
+		{
+			"fragmentation unit, start of idr",
+			[]byte{0x00, 0xe9, 0x87, 0xaf},
+			true,
+		},
+		{
+			"fragmentation unit, continuation of idr",
+			[]byte{0x00, 0xe9, 0x07, 0xaf},
+			false,
+		},
+		{
+			"empty",
+			[]byte{},
+			false,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			require.Equal(t, ca.res, format.PTSEqualsDTS(&rtp.Packet{
+				Payload: ca.payload,
+			}))
+		})
+	}
+}
+
+func TestH266MediaDescription(t *testing.T) {
+	format := &H266{
+		PayloadTyp: 96,
+		VPS:        []byte{0x01, 0x02},
+		SPS:        []byte{0x03, 0x04},
+		PPS:        []byte{0x05, 0x06},
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "H266/90000", rtpmap)
+	require.Equal(t, "sprop-vps=AQI=; sprop-sps=AwQ=; sprop-pps=BQY=", fmtp)
+}
+
+func TestH266DecEncoder(t *testing.T) {
+	format := &H266{}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode([][]byte{{0x00, 0x39, 0x03, 0x04}}, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	byts, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x00, 0x39, 0x03, 0x04}}, byts)
+}
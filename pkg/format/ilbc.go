@@ -0,0 +1,131 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpframedaudio"
+)
+
+// ILBC is an iLBC format.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3952
+type ILBC struct {
+	PayloadTyp uint8
+
+	// frame duration, in milliseconds (20 or 30).
+	// When zero, it is not signaled and 30 is in use.
+	Mode int
+
+	// encoding name, as it appears in the rtpmap (optional).
+	// It defaults to "iLBC".
+	EncodingName string
+}
+
+// String implements Format.
+func (t *ILBC) String() string {
+	return "iLBC"
+}
+
+// ClockRate implements Format.
+func (t *ILBC) ClockRate() int {
+	return 8000
+}
+
+// PayloadType implements Format.
+func (t *ILBC) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *ILBC) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+
+	if tmp := strings.SplitN(rtpmap, "/", 2)[0]; tmp != "iLBC" {
+		t.EncodingName = tmp
+	}
+
+	if fmtp != "" {
+		for _, kv := range strings.Split(fmtp, ";") {
+			kv = strings.Trim(kv, " ")
+
+			if len(kv) == 0 {
+				continue
+			}
+
+			tmp := strings.SplitN(kv, "=", 2)
+			if len(tmp) != 2 {
+				return fmt.Errorf("invalid fmtp (%v)", fmtp)
+			}
+
+			if strings.ToLower(tmp[0]) == "mode" {
+				// some implementations quote the value
+				val, err := strconv.ParseUint(strings.Trim(tmp[1], "\""), 10, 64)
+				if err != nil || (val != 20 && val != 30) {
+					return fmt.Errorf("invalid iLBC mode (%v)", tmp[1])
+				}
+				t.Mode = int(val)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *ILBC) Marshal() (string, string) {
+	encodingName := t.EncodingName
+	if encodingName == "" {
+		encodingName = "iLBC"
+	}
+
+	var fmtp string
+	if t.Mode != 0 {
+		fmtp = "mode=" + strconv.FormatInt(int64(t.Mode), 10)
+	}
+
+	return encodingName + "/8000", fmtp
+}
+
+// PTSEqualsDTS implements Format.
+func (t *ILBC) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+func (t *ILBC) mode() int {
+	// RFC3952: if mode is not present, 30ms frames are in use.
+	if t.Mode == 0 {
+		return 30
+	}
+	return t.Mode
+}
+
+func (t *ILBC) frameSize() int {
+	if t.mode() == 20 {
+		return 38
+	}
+	return 50
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *ILBC) CreateDecoder() *rtpframedaudio.Decoder {
+	d := &rtpframedaudio.Decoder{
+		SampleRate: 8000,
+		FrameSize:  t.frameSize(),
+	}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *ILBC) CreateEncoder() *rtpframedaudio.Encoder {
+	e := &rtpframedaudio.Encoder{
+		PayloadType:     t.PayloadTyp,
+		SampleRate:      8000,
+		FrameSize:       t.frameSize(),
+		SamplesPerFrame: t.mode() * 8,
+	}
+	e.Init()
+	return e
+}
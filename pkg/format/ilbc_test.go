@@ -0,0 +1,62 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestILBCAttributes(t *testing.T) {
+	format := &ILBC{
+		PayloadTyp: 98,
+		Mode:       20,
+	}
+	require.Equal(t, "iLBC", format.String())
+	require.Equal(t, 8000, format.ClockRate())
+	require.Equal(t, uint8(98), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestILBCMediaDescription(t *testing.T) {
+	format := &ILBC{
+		PayloadTyp: 98,
+		Mode:       20,
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "iLBC/8000", rtpmap)
+	require.Equal(t, "mode=20", fmtp)
+
+	format = &ILBC{
+		PayloadTyp:   98,
+		EncodingName: "ILBC",
+	}
+
+	rtpmap, fmtp = format.Marshal()
+	require.Equal(t, "ILBC/8000", rtpmap)
+	require.Equal(t, "", fmtp)
+}
+
+func TestILBCDecEncoder(t *testing.T) {
+	format := &ILBC{
+		PayloadTyp: 98,
+		Mode:       20,
+	}
+
+	frames := [][]byte{
+		bytes.Repeat([]byte{0x01}, 38),
+		bytes.Repeat([]byte{0x02}, 38),
+	}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode(frames, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	dframes, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, frames, dframes)
+}
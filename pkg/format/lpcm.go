@@ -1,20 +1,45 @@
 package format
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pion/rtp"
+	psdp "github.com/pion/sdp/v3"
 
 	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtplpcm"
 )
 
 // LPCM is an uncompressed, Linear PCM format.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3190
+// Clock signalling: https://datatracker.ietf.org/doc/html/rfc7273
 type LPCM struct {
 	PayloadTyp   uint8
 	BitDepth     int
 	SampleRate   int
 	ChannelCount int
+
+	// duration of the audio contained in each packet (ptime attribute) (optional).
+	PacketTime time.Duration
+
+	// maximum duration of the audio contained in each packet (maxptime attribute) (optional).
+	MaxPacketTime time.Duration
+
+	// channel order (for instance, "SMPTE2110.(ST)") (optional).
+	ChannelOrder string
+
+	// timestamp reference clocks (ts-refclk attributes)
+	// (for instance, "ptp=IEEE1588-2008:39-A7-94-FF-FE-07-CB-D0:0") (optional).
+	// Session-level attributes are taken into account when medias are decoded
+	// with Medias.UnmarshalSessionDescription.
+	ReferenceClocks []string
+
+	// media clock (mediaclk attribute) (for instance, "direct=963214424") (optional).
+	// The session-level attribute is taken into account when medias are decoded
+	// with Medias.UnmarshalSessionDescription.
+	MediaClock string
 }
 
 // String implements Format.
@@ -64,9 +89,65 @@ func (t *LPCM) unmarshal(payloadType uint8, clock string, codec string, rtpmap s
 		t.ChannelCount = 1
 	}
 
+	for _, kv := range strings.Split(fmtp, ";") {
+		kv = strings.Trim(kv, " ")
+
+		if len(kv) == 0 {
+			continue
+		}
+
+		// malformed and unknown parameters are ignored
+		tmp := strings.SplitN(kv, "=", 2)
+		if len(tmp) != 2 {
+			continue
+		}
+
+		if strings.ToLower(tmp[0]) == "channel-order" {
+			t.ChannelOrder = tmp[1]
+		}
+	}
+
 	return nil
 }
 
+func parsePacketTime(v string) (time.Duration, error) {
+	tmp, err := strconv.ParseFloat(v, 64)
+	if err != nil || tmp <= 0 {
+		return 0, fmt.Errorf("invalid packet time (%v)", v)
+	}
+
+	return time.Duration(tmp * float64(time.Millisecond)), nil
+}
+
+func marshalPacketTime(v time.Duration) string {
+	return strconv.FormatFloat(float64(v)/float64(time.Millisecond), 'f', -1, 64)
+}
+
+func (t *LPCM) unmarshalMediaAttributes(attributes []psdp.Attribute) {
+	for _, attr := range attributes {
+		switch attr.Key {
+		// malformed packet times are ignored
+		case "ptime":
+			v, err := parsePacketTime(attr.Value)
+			if err == nil {
+				t.PacketTime = v
+			}
+
+		case "maxptime":
+			v, err := parsePacketTime(attr.Value)
+			if err == nil {
+				t.MaxPacketTime = v
+			}
+
+		case "ts-refclk":
+			t.ReferenceClocks = append(t.ReferenceClocks, attr.Value)
+
+		case "mediaclk":
+			t.MediaClock = attr.Value
+		}
+	}
+}
+
 // Marshal implements Format.
 func (t *LPCM) Marshal() (string, string) {
 	var codec string
@@ -81,8 +162,109 @@ func (t *LPCM) Marshal() (string, string) {
 		codec = "L24"
 	}
 
+	var fmtp string
+	if t.ChannelOrder != "" {
+		fmtp = "channel-order=" + t.ChannelOrder
+	}
+
 	return codec + "/" + strconv.FormatInt(int64(t.SampleRate), 10) +
-		"/" + strconv.FormatInt(int64(t.ChannelCount), 10), ""
+		"/" + strconv.FormatInt(int64(t.ChannelCount), 10), fmtp
+}
+
+// MarshalMediaAttributes implements MediaAttributesMarshaler.
+func (t *LPCM) MarshalMediaAttributes() []psdp.Attribute {
+	var ret []psdp.Attribute
+
+	if t.PacketTime != 0 {
+		ret = append(ret, psdp.Attribute{
+			Key:   "ptime",
+			Value: marshalPacketTime(t.PacketTime),
+		})
+	}
+
+	if t.MaxPacketTime != 0 {
+		ret = append(ret, psdp.Attribute{
+			Key:   "maxptime",
+			Value: marshalPacketTime(t.MaxPacketTime),
+		})
+	}
+
+	for _, clock := range t.ReferenceClocks {
+		ret = append(ret, psdp.Attribute{
+			Key:   "ts-refclk",
+			Value: clock,
+		})
+	}
+
+	if t.MediaClock != "" {
+		ret = append(ret, psdp.Attribute{
+			Key:   "mediaclk",
+			Value: t.MediaClock,
+		})
+	}
+
+	return ret
+}
+
+// MediaClockOffset returns the offset of the media clock, that is,
+// the RTP timestamp that corresponds to the epoch of the reference clock.
+// It is available only when the media clock is directly referenced to the reference clock
+// ("direct=<offset>").
+func (t *LPCM) MediaClockOffset() (uint32, bool) {
+	for _, part := range strings.Split(t.MediaClock, " ") {
+		tmp := strings.SplitN(part, "=", 2)
+		if tmp[0] != "direct" {
+			continue
+		}
+
+		if len(tmp) == 1 {
+			return 0, true
+		}
+
+		v, err := strconv.ParseUint(tmp[1], 10, 32)
+		if err != nil {
+			return 0, false
+		}
+		return uint32(v), true
+	}
+
+	return 0, false
+}
+
+// samples returns the number of samples that elapsed since the reference clock epoch.
+func (t *LPCM) samples(refTime time.Duration) uint64 {
+	return uint64(refTime/time.Second)*uint64(t.SampleRate) +
+		uint64(refTime%time.Second)*uint64(t.SampleRate)/uint64(time.Second)
+}
+
+// MediaClockTimestamp returns the RTP timestamp that corresponds to
+// the given time of the reference clock (for instance, the PTP time), expressed as
+// duration since the reference clock epoch.
+// It can be used to fill the InitialTimestamp of encoders.
+// It returns false when the media clock offset is not available.
+func (t *LPCM) MediaClockTimestamp(refTime time.Duration) (uint32, bool) {
+	offset, ok := t.MediaClockOffset()
+	if !ok {
+		return 0, false
+	}
+
+	return offset + uint32(t.samples(refTime)), true
+}
+
+// MediaClockTime returns the time of the reference clock that corresponds to
+// the given RTP timestamp, expressed as duration since the reference clock epoch.
+// Since RTP timestamps wrap around, the current time of the reference clock
+// must be provided too, and the returned time is the one that is nearest to it.
+// It returns false when the media clock offset is not available.
+func (t *LPCM) MediaClockTime(timestamp uint32, refNow time.Duration) (time.Duration, bool) {
+	nowTimestamp, ok := t.MediaClockTimestamp(refNow)
+	if !ok {
+		return 0, false
+	}
+
+	diff := int64(int32(timestamp - nowTimestamp))
+
+	return refNow + time.Duration(diff)*time.Second/time.Duration(t.SampleRate), true
 }
 
 // PTSEqualsDTS implements Format.
@@ -105,6 +287,7 @@ func (t *LPCM) CreateDecoder() *rtplpcm.Decoder {
 func (t *LPCM) CreateEncoder() *rtplpcm.Encoder {
 	e := &rtplpcm.Encoder{
 		PayloadType:  t.PayloadTyp,
+		PacketTime:   t.PacketTime,
 		BitDepth:     t.BitDepth,
 		SampleRate:   t.SampleRate,
 		ChannelCount: t.ChannelCount,
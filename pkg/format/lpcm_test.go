@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/pion/rtp"
+	psdp "github.com/pion/sdp/v3"
 	"github.com/stretchr/testify/require"
 )
 
@@ -39,6 +41,81 @@ func TestLPCMMediaDescription(t *testing.T) {
 	}
 }
 
+func TestLPCMMediaAttributes(t *testing.T) {
+	format := &LPCM{
+		PayloadTyp:      97,
+		BitDepth:        24,
+		SampleRate:      48000,
+		ChannelCount:    2,
+		PacketTime:      125 * time.Microsecond,
+		MaxPacketTime:   1 * time.Millisecond,
+		ChannelOrder:    "SMPTE2110.(ST)",
+		ReferenceClocks: []string{"ptp=IEEE1588-2008:39-A7-94-FF-FE-07-CB-D0:0"},
+		MediaClock:      "direct=963214424",
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "L24/48000/2", rtpmap)
+	require.Equal(t, "channel-order=SMPTE2110.(ST)", fmtp)
+
+	require.Equal(t, []psdp.Attribute{
+		{
+			Key:   "ptime",
+			Value: "0.125",
+		},
+		{
+			Key:   "maxptime",
+			Value: "1",
+		},
+		{
+			Key:   "ts-refclk",
+			Value: "ptp=IEEE1588-2008:39-A7-94-FF-FE-07-CB-D0:0",
+		},
+		{
+			Key:   "mediaclk",
+			Value: "direct=963214424",
+		},
+	}, format.MarshalMediaAttributes())
+}
+
+func TestLPCMMediaClock(t *testing.T) {
+	format := &LPCM{
+		PayloadTyp:   97,
+		BitDepth:     24,
+		SampleRate:   48000,
+		ChannelCount: 2,
+	}
+
+	_, ok := format.MediaClockOffset()
+	require.Equal(t, false, ok)
+
+	_, ok = format.MediaClockTimestamp(0)
+	require.Equal(t, false, ok)
+
+	format.MediaClock = "direct=963214424 rate=48000/1"
+
+	offset, ok := format.MediaClockOffset()
+	require.Equal(t, true, ok)
+	require.Equal(t, uint32(963214424), offset)
+
+	// 1'600'000'000 seconds after the PTP epoch
+	refTime := 1600000000*time.Second + 500*time.Millisecond
+
+	ts, ok := format.MediaClockTimestamp(refTime)
+	require.Equal(t, true, ok)
+	require.Equal(t, uint32(2653018648), ts)
+
+	// timestamp after the reference time
+	tim, ok := format.MediaClockTime(ts+48, refTime)
+	require.Equal(t, true, ok)
+	require.Equal(t, refTime+1*time.Millisecond, tim)
+
+	// timestamp before the reference time
+	tim, ok = format.MediaClockTime(ts-48000, refTime)
+	require.Equal(t, true, ok)
+	require.Equal(t, refTime-1*time.Second, tim)
+}
+
 func TestLPCMDecEncoder(t *testing.T) {
 	format := &LPCM{
 		PayloadTyp:   96,
@@ -2,6 +2,8 @@ package format
 
 import (
 	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpmpeg2audio"
 )
 
 // MPEG2Audio is a MPEG-1 or MPEG-2 audio format.
@@ -35,3 +37,17 @@ func (t *MPEG2Audio) Marshal() (string, string) {
 func (t *MPEG2Audio) PTSEqualsDTS(*rtp.Packet) bool {
 	return true
 }
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *MPEG2Audio) CreateDecoder() *rtpmpeg2audio.Decoder {
+	d := &rtpmpeg2audio.Decoder{}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *MPEG2Audio) CreateEncoder() *rtpmpeg2audio.Encoder {
+	e := &rtpmpeg2audio.Encoder{}
+	e.Init()
+	return e
+}
@@ -22,3 +22,26 @@ func TestMPEG2AudioMediaDescription(t *testing.T) {
 	require.Equal(t, "", rtpmap)
 	require.Equal(t, "", fmtp)
 }
+
+func TestMPEG2AudioDecEncoder(t *testing.T) {
+	format := &MPEG2Audio{}
+
+	frames := [][]byte{
+		{
+			0xff, 0xff, 0x14, 0x00, 0x01, 0x02, 0x03, 0x04,
+			0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c,
+			0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14,
+			0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c,
+		},
+	}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode(frames, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	byts, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, frames, byts)
+}
@@ -2,6 +2,8 @@ package format
 
 import (
 	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpmpeg2video"
 )
 
 // MPEG2Video is a MPEG-1 or MPEG-2 video format.
@@ -35,3 +37,17 @@ func (t *MPEG2Video) Marshal() (string, string) {
 func (t *MPEG2Video) PTSEqualsDTS(*rtp.Packet) bool {
 	return true
 }
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *MPEG2Video) CreateDecoder() *rtpmpeg2video.Decoder {
+	d := &rtpmpeg2video.Decoder{}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *MPEG2Video) CreateEncoder() *rtpmpeg2video.Encoder {
+	e := &rtpmpeg2video.Encoder{}
+	e.Init()
+	return e
+}
@@ -22,3 +22,22 @@ func TestMPEG2VideoMediaDescription(t *testing.T) {
 	require.Equal(t, "", rtpmap)
 	require.Equal(t, "", fmtp)
 }
+
+func TestMPEG2VideoDecEncoder(t *testing.T) {
+	format := &MPEG2Video{}
+
+	frame := []byte{
+		0x00, 0x00, 0x01, 0x00, 0x01, 0x4f, 0xff, 0xf8,
+		0x00, 0x00, 0x01, 0x01, 0x22, 0x22, 0x22, 0x22,
+	}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode(frame, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	byts, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, frame, byts)
+}
@@ -0,0 +1,217 @@
+package format
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpmpeg4audiolatm"
+)
+
+// MPEG4AudioLATM is a MPEG-4 audio LATM format.
+type MPEG4AudioLATM struct {
+	PayloadTyp     uint8
+	SampleRate     int
+	ChannelCount   int
+	ProfileLevelID int
+	Bitrate        *int
+	Object         int
+	CPresent       bool
+	Config         *mpeg4audio.StreamMuxConfig
+	SBREnabled     *bool
+}
+
+// String implements Format.
+func (t *MPEG4AudioLATM) String() string {
+	return "MPEG4-audio-LATM"
+}
+
+// ClockRate implements Format.
+func (t *MPEG4AudioLATM) ClockRate() int {
+	return t.SampleRate
+}
+
+// PayloadType implements Format.
+func (t *MPEG4AudioLATM) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *MPEG4AudioLATM) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+
+	tmp := strings.SplitN(clock, "/", 2)
+
+	sampleRate, err := strconv.ParseInt(tmp[0], 10, 64)
+	if err != nil {
+		return err
+	}
+	t.SampleRate = int(sampleRate)
+
+	if len(tmp) == 2 {
+		channelCount, err := strconv.ParseInt(tmp[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		t.ChannelCount = int(channelCount)
+	} else {
+		t.ChannelCount = 1
+	}
+
+	// default values defined by specification
+	t.ProfileLevelID = 30
+	t.CPresent = true
+
+	if fmtp != "" {
+		for _, kv := range strings.Split(fmtp, ";") {
+			kv = strings.Trim(kv, " ")
+
+			if len(kv) == 0 {
+				continue
+			}
+
+			tmp := strings.SplitN(kv, "=", 2)
+			if len(tmp) != 2 {
+				return fmt.Errorf("invalid fmtp (%v)", fmtp)
+			}
+
+			switch strings.ToLower(tmp[0]) {
+			case "profile-level-id":
+				val, err := strconv.ParseUint(tmp[1], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid profile-level-id (%v)", tmp[1])
+				}
+				t.ProfileLevelID = int(val)
+
+			case "bitrate":
+				val, err := strconv.ParseUint(tmp[1], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid bitrate (%v)", tmp[1])
+				}
+				v := int(val)
+				t.Bitrate = &v
+
+			case "object":
+				val, err := strconv.ParseUint(tmp[1], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid object (%v)", tmp[1])
+				}
+				t.Object = int(val)
+
+			case "cpresent":
+				switch tmp[1] {
+				case "0":
+					t.CPresent = false
+
+				case "1":
+					t.CPresent = true
+
+				default:
+					return fmt.Errorf("invalid cpresent (%v)", tmp[1])
+				}
+
+			case "config":
+				enc, err := hex.DecodeString(tmp[1])
+				if err != nil {
+					return fmt.Errorf("invalid LATM config (%v)", tmp[1])
+				}
+
+				t.Config = &mpeg4audio.StreamMuxConfig{}
+				err = t.Config.Unmarshal(enc)
+				if err != nil {
+					return fmt.Errorf("invalid LATM config (%v)", tmp[1])
+				}
+
+			case "sbr-enabled":
+				switch tmp[1] {
+				case "0":
+					v := false
+					t.SBREnabled = &v
+
+				case "1":
+					v := true
+					t.SBREnabled = &v
+
+				default:
+					return fmt.Errorf("invalid SBR-enabled (%v)", tmp[1])
+				}
+			}
+		}
+	}
+
+	if !t.CPresent && t.Config == nil {
+		return fmt.Errorf("config is missing (%v)", fmtp)
+	}
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *MPEG4AudioLATM) Marshal() (string, string) {
+	fmtp := "profile-level-id=" + strconv.FormatInt(int64(t.ProfileLevelID), 10)
+
+	if t.Bitrate != nil {
+		fmtp += "; bitrate=" + strconv.FormatInt(int64(*t.Bitrate), 10)
+	}
+
+	if t.CPresent {
+		fmtp += "; cpresent=1"
+	} else {
+		fmtp += "; cpresent=0"
+	}
+
+	if t.Object != 0 {
+		fmtp += "; object=" + strconv.FormatInt(int64(t.Object), 10)
+	}
+
+	if t.SBREnabled != nil {
+		if *t.SBREnabled {
+			fmtp += "; SBR-enabled=1"
+		} else {
+			fmtp += "; SBR-enabled=0"
+		}
+	}
+
+	if t.Config != nil {
+		enc, err := t.Config.Marshal()
+		if err != nil {
+			return "", ""
+		}
+		fmtp += "; config=" + hex.EncodeToString(enc)
+	}
+
+	return "MP4A-LATM/" + strconv.FormatInt(int64(t.SampleRate), 10) +
+		"/" + strconv.FormatInt(int64(t.ChannelCount), 10), fmtp
+}
+
+// PTSEqualsDTS implements Format.
+func (t *MPEG4AudioLATM) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *MPEG4AudioLATM) CreateDecoder() *rtpmpeg4audiolatm.Decoder {
+	d := &rtpmpeg4audiolatm.Decoder{
+		SampleRate: t.SampleRate,
+		CPresent:   t.CPresent,
+		Config:     t.Config,
+	}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+// The encoder doesn't insert the StreamMuxConfig into packets,
+// therefore the format must have CPresent set to false and Config set.
+func (t *MPEG4AudioLATM) CreateEncoder() *rtpmpeg4audiolatm.Encoder {
+	e := &rtpmpeg4audiolatm.Encoder{
+		PayloadType: t.PayloadTyp,
+		SampleRate:  t.SampleRate,
+		Config:      t.Config,
+	}
+	e.Init()
+	return e
+}
@@ -0,0 +1,88 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+)
+
+func TestMPEG4AudioLATMAttributes(t *testing.T) {
+	format := &MPEG4AudioLATM{
+		PayloadTyp:   96,
+		SampleRate:   48000,
+		ChannelCount: 2,
+		CPresent:     true,
+	}
+	require.Equal(t, "MPEG4-audio-LATM", format.String())
+	require.Equal(t, 48000, format.ClockRate())
+	require.Equal(t, uint8(96), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestMPEG4AudioLATMMediaDescription(t *testing.T) {
+	bitrate := 64000
+	sbrEnabled := false
+
+	format := &MPEG4AudioLATM{
+		PayloadTyp:     96,
+		SampleRate:     24000,
+		ChannelCount:   2,
+		ProfileLevelID: 15,
+		Bitrate:        &bitrate,
+		Object:         2,
+		CPresent:       false,
+		Config: &mpeg4audio.StreamMuxConfig{
+			Programs: []*mpeg4audio.StreamMuxConfigProgram{{
+				Layers: []*mpeg4audio.StreamMuxConfigLayer{{
+					AudioSpecificConfig: &mpeg4audio.Config{
+						Type:         mpeg4audio.ObjectTypeAACLC,
+						SampleRate:   24000,
+						ChannelCount: 2,
+					},
+					LatmBufferFullness: 255,
+				}},
+			}},
+		},
+		SBREnabled: &sbrEnabled,
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "MP4A-LATM/24000/2", rtpmap)
+	require.Equal(t, "profile-level-id=15; bitrate=64000; cpresent=0; object=2; "+
+		"SBR-enabled=0; config=400026203fc0", fmtp)
+}
+
+func TestMPEG4AudioLATMDecEncoder(t *testing.T) {
+	format := &MPEG4AudioLATM{
+		PayloadTyp:     96,
+		SampleRate:     48000,
+		ChannelCount:   2,
+		ProfileLevelID: 30,
+		CPresent:       false,
+		Config: &mpeg4audio.StreamMuxConfig{
+			Programs: []*mpeg4audio.StreamMuxConfigProgram{{
+				Layers: []*mpeg4audio.StreamMuxConfigLayer{{
+					AudioSpecificConfig: &mpeg4audio.Config{
+						Type:         mpeg4audio.ObjectTypeAACLC,
+						SampleRate:   48000,
+						ChannelCount: 2,
+					},
+					LatmBufferFullness: 255,
+				}},
+			}},
+		},
+	}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode([][]byte{{0x01, 0x02, 0x03, 0x04}}, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	byts, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x01, 0x02, 0x03, 0x04}}, byts)
+}
@@ -0,0 +1,103 @@
+package format
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpmpeg4video"
+)
+
+// MPEG4Video is a MPEG-4 part 2 video format.
+type MPEG4Video struct {
+	PayloadTyp     uint8
+	ProfileLevelID int
+	Config         []byte
+}
+
+// String implements Format.
+func (t *MPEG4Video) String() string {
+	return "MPEG4-video"
+}
+
+// ClockRate implements Format.
+func (t *MPEG4Video) ClockRate() int {
+	return 90000
+}
+
+// PayloadType implements Format.
+func (t *MPEG4Video) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *MPEG4Video) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+	t.ProfileLevelID = 1 // default value defined by specification
+
+	if fmtp != "" {
+		for _, kv := range strings.Split(fmtp, ";") {
+			kv = strings.Trim(kv, " ")
+
+			if len(kv) == 0 {
+				continue
+			}
+
+			tmp := strings.SplitN(kv, "=", 2)
+			if len(tmp) != 2 {
+				return fmt.Errorf("invalid fmtp (%v)", fmtp)
+			}
+
+			switch strings.ToLower(tmp[0]) {
+			case "profile-level-id":
+				val, err := strconv.ParseUint(tmp[1], 10, 8)
+				if err != nil {
+					return fmt.Errorf("invalid profile-level-id (%v)", tmp[1])
+				}
+				t.ProfileLevelID = int(val)
+
+			case "config":
+				var err error
+				t.Config, err = hex.DecodeString(tmp[1])
+				if err != nil {
+					return fmt.Errorf("invalid config (%v)", tmp[1])
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *MPEG4Video) Marshal() (string, string) {
+	fmtp := "profile-level-id=" + strconv.FormatInt(int64(t.ProfileLevelID), 10)
+	if t.Config != nil {
+		fmtp += "; config=" + strings.ToUpper(hex.EncodeToString(t.Config))
+	}
+
+	return "MP4V-ES/90000", fmtp
+}
+
+// PTSEqualsDTS implements Format.
+func (t *MPEG4Video) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *MPEG4Video) CreateDecoder() *rtpmpeg4video.Decoder {
+	d := &rtpmpeg4video.Decoder{}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *MPEG4Video) CreateEncoder() *rtpmpeg4video.Encoder {
+	e := &rtpmpeg4video.Encoder{
+		PayloadType: t.PayloadTyp,
+	}
+	e.Init()
+	return e
+}
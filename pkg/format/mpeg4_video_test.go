@@ -0,0 +1,49 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMPEG4VideoAttributes(t *testing.T) {
+	format := &MPEG4Video{
+		PayloadTyp:     96,
+		ProfileLevelID: 1,
+	}
+	require.Equal(t, "MPEG4-video", format.String())
+	require.Equal(t, 90000, format.ClockRate())
+	require.Equal(t, uint8(96), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestMPEG4VideoMediaDescription(t *testing.T) {
+	format := &MPEG4Video{
+		PayloadTyp:     96,
+		ProfileLevelID: 1,
+		Config:         []byte{0x0a, 0x0b, 0x03},
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "MP4V-ES/90000", rtpmap)
+	require.Equal(t, "profile-level-id=1; config=0A0B03", fmtp)
+}
+
+func TestMPEG4VideoDecEncoder(t *testing.T) {
+	format := &MPEG4Video{
+		PayloadTyp: 96,
+	}
+
+	frame := []byte{0x00, 0x00, 0x01, 0xb6, 0x01, 0x02, 0x03, 0x04}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode(frame, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	byts, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, frame, byts)
+}
@@ -0,0 +1,58 @@
+package format
+
+import (
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpmpegts"
+)
+
+// MPEGTS is a RTP/MPEG-TS format.
+type MPEGTS struct {
+	PayloadTyp uint8
+}
+
+// String implements Format.
+func (t *MPEGTS) String() string {
+	return "MPEG-TS"
+}
+
+// ClockRate implements Format.
+func (t *MPEGTS) ClockRate() int {
+	return 90000
+}
+
+// PayloadType implements Format.
+func (t *MPEGTS) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *MPEGTS) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+	return nil
+}
+
+// Marshal implements Format.
+func (t *MPEGTS) Marshal() (string, string) {
+	return "MP2T/90000", ""
+}
+
+// PTSEqualsDTS implements Format.
+func (t *MPEGTS) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *MPEGTS) CreateDecoder() *rtpmpegts.Decoder {
+	d := &rtpmpegts.Decoder{}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *MPEGTS) CreateEncoder() *rtpmpegts.Encoder {
+	e := &rtpmpegts.Encoder{
+		PayloadType: t.PayloadTyp,
+	}
+	e.Init()
+	return e
+}
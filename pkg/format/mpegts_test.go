@@ -0,0 +1,50 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMPEGTSAttributes(t *testing.T) {
+	format := &MPEGTS{
+		PayloadTyp: 33,
+	}
+	require.Equal(t, "MPEG-TS", format.String())
+	require.Equal(t, 90000, format.ClockRate())
+	require.Equal(t, uint8(33), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestMPEGTSMediaDescription(t *testing.T) {
+	format := &MPEGTS{
+		PayloadTyp: 33,
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "MP2T/90000", rtpmap)
+	require.Equal(t, "", fmtp)
+}
+
+func TestMPEGTSDecEncoder(t *testing.T) {
+	format := &MPEGTS{
+		PayloadTyp: 33,
+	}
+
+	tsPkts := [][]byte{
+		append([]byte{0x47, 0x01, 0x00, 0x10}, bytes.Repeat([]byte{0x01}, 184)...),
+		append([]byte{0x47, 0x01, 0x00, 0x11}, bytes.Repeat([]byte{0x02}, 184)...),
+	}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode(tsPkts, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	byts, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, tsPkts, byts)
+}
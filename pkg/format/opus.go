@@ -86,7 +86,7 @@ func (t *Opus) CreateDecoder() *rtpsimpleaudio.Decoder {
 func (t *Opus) CreateEncoder() *rtpsimpleaudio.Encoder {
 	e := &rtpsimpleaudio.Encoder{
 		PayloadType: t.PayloadTyp,
-		SampleRate:  8000,
+		SampleRate:  48000,
 	}
 	e.Init()
 	return e
@@ -0,0 +1,152 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtprawvideo"
+)
+
+// RawVideo is an uncompressed video format.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4175
+type RawVideo struct {
+	PayloadTyp     uint8
+	Sampling       string
+	Width          int
+	Height         int
+	Depth          int
+	Colorimetry    string
+	Interlace      bool
+	ExactFrameRate string
+}
+
+// String implements Format.
+func (t *RawVideo) String() string {
+	return "raw video"
+}
+
+// ClockRate implements Format.
+func (t *RawVideo) ClockRate() int {
+	return 90000
+}
+
+// PayloadType implements Format.
+func (t *RawVideo) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *RawVideo) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+
+	for _, kv := range strings.Split(fmtp, ";") {
+		kv = strings.Trim(kv, " ")
+
+		if len(kv) == 0 {
+			continue
+		}
+
+		tmp := strings.SplitN(kv, "=", 2)
+
+		if len(tmp) == 1 {
+			if strings.ToLower(tmp[0]) == "interlace" {
+				t.Interlace = true
+			}
+			continue
+		}
+
+		switch strings.ToLower(tmp[0]) {
+		case "sampling":
+			t.Sampling = tmp[1]
+
+		case "width":
+			val, err := strconv.ParseUint(tmp[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid width (%v)", tmp[1])
+			}
+			t.Width = int(val)
+
+		case "height":
+			val, err := strconv.ParseUint(tmp[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid height (%v)", tmp[1])
+			}
+			t.Height = int(val)
+
+		case "depth":
+			val, err := strconv.ParseUint(tmp[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid depth (%v)", tmp[1])
+			}
+			t.Depth = int(val)
+
+		case "colorimetry":
+			t.Colorimetry = tmp[1]
+
+		case "exactframerate":
+			t.ExactFrameRate = tmp[1]
+		}
+	}
+
+	if t.Sampling == "" || t.Width == 0 || t.Height == 0 || t.Depth == 0 {
+		return fmt.Errorf("sampling, width, height or depth is missing (%v)", fmtp)
+	}
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *RawVideo) Marshal() (string, string) {
+	tmp := []string{
+		"sampling=" + t.Sampling,
+		"width=" + strconv.FormatInt(int64(t.Width), 10),
+		"height=" + strconv.FormatInt(int64(t.Height), 10),
+		"depth=" + strconv.FormatInt(int64(t.Depth), 10),
+	}
+
+	if t.Colorimetry != "" {
+		tmp = append(tmp, "colorimetry="+t.Colorimetry)
+	}
+
+	if t.Interlace {
+		tmp = append(tmp, "interlace")
+	}
+
+	if t.ExactFrameRate != "" {
+		tmp = append(tmp, "exactframerate="+t.ExactFrameRate)
+	}
+
+	return "raw/90000", strings.Join(tmp, "; ")
+}
+
+// PTSEqualsDTS implements Format.
+func (t *RawVideo) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *RawVideo) CreateDecoder() *rtprawvideo.Decoder {
+	d := &rtprawvideo.Decoder{
+		Sampling: t.Sampling,
+		Depth:    t.Depth,
+		Width:    t.Width,
+		Height:   t.Height,
+	}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *RawVideo) CreateEncoder() *rtprawvideo.Encoder {
+	e := &rtprawvideo.Encoder{
+		PayloadType: t.PayloadTyp,
+		Sampling:    t.Sampling,
+		Depth:       t.Depth,
+		Width:       t.Width,
+		Height:      t.Height,
+	}
+	e.Init()
+	return e
+}
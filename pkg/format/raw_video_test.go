@@ -0,0 +1,65 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawVideoAttributes(t *testing.T) {
+	format := &RawVideo{
+		PayloadTyp: 96,
+		Sampling:   "YCbCr-4:2:2",
+		Width:      1920,
+		Height:     1080,
+		Depth:      10,
+	}
+	require.Equal(t, "raw video", format.String())
+	require.Equal(t, 90000, format.ClockRate())
+	require.Equal(t, uint8(96), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestRawVideoMediaDescription(t *testing.T) {
+	format := &RawVideo{
+		PayloadTyp:     96,
+		Sampling:       "YCbCr-4:2:2",
+		Width:          1920,
+		Height:         1080,
+		Depth:          10,
+		Colorimetry:    "BT709",
+		Interlace:      true,
+		ExactFrameRate: "30000/1001",
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "raw/90000", rtpmap)
+	require.Equal(t, "sampling=YCbCr-4:2:2; width=1920; height=1080; depth=10; "+
+		"colorimetry=BT709; interlace; exactframerate=30000/1001", fmtp)
+}
+
+func TestRawVideoDecEncoder(t *testing.T) {
+	format := &RawVideo{
+		PayloadTyp: 96,
+		Sampling:   "RGB",
+		Width:      2,
+		Height:     2,
+		Depth:      8,
+	}
+
+	frame := []byte{
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06,
+		0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c,
+	}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode(frame, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	byts, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, frame, byts)
+}
@@ -0,0 +1,113 @@
+package format
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpsimpleaudio"
+)
+
+// Speex is a Speex format.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5574
+type Speex struct {
+	PayloadTyp uint8
+	SampleRate int
+
+	// whether variable bit rate is in use.
+	VBR bool
+
+	// encoding modes that can be used (for instance, "1,any") (optional).
+	Mode string
+}
+
+// String implements Format.
+func (t *Speex) String() string {
+	return "Speex"
+}
+
+// ClockRate implements Format.
+func (t *Speex) ClockRate() int {
+	return t.SampleRate
+}
+
+// PayloadType implements Format.
+func (t *Speex) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *Speex) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+
+	tmp := strings.SplitN(clock, "/", 2)
+
+	sampleRate, err := strconv.ParseInt(tmp[0], 10, 64)
+	if err != nil {
+		return err
+	}
+	t.SampleRate = int(sampleRate)
+
+	if fmtp != "" {
+		for _, kv := range strings.Split(fmtp, ";") {
+			kv = strings.Trim(kv, " ")
+
+			if len(kv) == 0 {
+				continue
+			}
+
+			tmp := strings.SplitN(kv, "=", 2)
+			if len(tmp) != 2 {
+				continue
+			}
+
+			switch strings.ToLower(tmp[0]) {
+			case "vbr":
+				t.VBR = (strings.ToLower(tmp[1]) == "on")
+
+			case "mode":
+				t.Mode = strings.Trim(tmp[1], "\"")
+			}
+		}
+	}
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *Speex) Marshal() (string, string) {
+	var tmp []string
+	if t.Mode != "" {
+		tmp = append(tmp, "mode=\""+t.Mode+"\"")
+	}
+	if t.VBR {
+		tmp = append(tmp, "vbr=on")
+	}
+
+	return "speex/" + strconv.FormatInt(int64(t.SampleRate), 10), strings.Join(tmp, ";")
+}
+
+// PTSEqualsDTS implements Format.
+func (t *Speex) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+// Each packet is expected to contain a single frame.
+func (t *Speex) CreateDecoder() *rtpsimpleaudio.Decoder {
+	d := &rtpsimpleaudio.Decoder{
+		SampleRate: t.SampleRate,
+	}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *Speex) CreateEncoder() *rtpsimpleaudio.Encoder {
+	e := &rtpsimpleaudio.Encoder{
+		PayloadType: t.PayloadTyp,
+		SampleRate:  t.SampleRate,
+	}
+	e.Init()
+	return e
+}
@@ -0,0 +1,51 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpeexAttributes(t *testing.T) {
+	format := &Speex{
+		PayloadTyp: 97,
+		SampleRate: 16000,
+		VBR:        true,
+	}
+	require.Equal(t, "Speex", format.String())
+	require.Equal(t, 16000, format.ClockRate())
+	require.Equal(t, uint8(97), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestSpeexMediaDescription(t *testing.T) {
+	format := &Speex{
+		PayloadTyp: 97,
+		SampleRate: 16000,
+		VBR:        true,
+		Mode:       "1,any",
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "speex/16000", rtpmap)
+	require.Equal(t, "mode=\"1,any\";vbr=on", fmtp)
+}
+
+func TestSpeexDecEncoder(t *testing.T) {
+	format := &Speex{
+		PayloadTyp: 97,
+		SampleRate: 16000,
+		VBR:        true,
+	}
+
+	enc := format.CreateEncoder()
+	pkt, err := enc.Encode([]byte{0x01, 0x02, 0x03, 0x04}, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkt.PayloadType)
+
+	dec := format.CreateDecoder()
+	byts, _, err := dec.Decode(pkt)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01, 0x02, 0x03, 0x04}, byts)
+}
@@ -0,0 +1,128 @@
+package format
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtptelephoneevent"
+)
+
+// TelephoneEvent is a telephone-event format (DTMF digits, telephony tones and signals).
+// Specification: https://datatracker.ietf.org/doc/html/rfc4733
+type TelephoneEvent struct {
+	PayloadTyp uint8
+	SampleRate int
+
+	// supported events.
+	// When empty, events 0-15 (DTMF digits) are supported.
+	Events []uint8
+}
+
+// String implements Format.
+func (t *TelephoneEvent) String() string {
+	return "telephone-event"
+}
+
+// ClockRate implements Format.
+func (t *TelephoneEvent) ClockRate() int {
+	return t.SampleRate
+}
+
+// PayloadType implements Format.
+func (t *TelephoneEvent) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *TelephoneEvent) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+
+	tmp := strings.SplitN(clock, "/", 2)
+
+	sampleRate, err := strconv.ParseInt(tmp[0], 10, 64)
+	if err != nil {
+		return err
+	}
+	t.SampleRate = int(sampleRate)
+
+	fmtp = strings.Trim(fmtp, " ")
+
+	if fmtp == "" {
+		return nil
+	}
+
+	for _, part := range strings.Split(fmtp, ",") {
+		part = strings.Trim(part, " ")
+
+		tmp := strings.SplitN(part, "-", 2)
+
+		start, err := strconv.ParseUint(tmp[0], 10, 8)
+		if err != nil {
+			return fmt.Errorf("invalid events (%v)", fmtp)
+		}
+
+		end := start
+		if len(tmp) == 2 {
+			end, err = strconv.ParseUint(tmp[1], 10, 8)
+			if err != nil || end < start {
+				return fmt.Errorf("invalid events (%v)", fmtp)
+			}
+		}
+
+		for i := start; i <= end; i++ {
+			t.Events = append(t.Events, uint8(i))
+		}
+	}
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *TelephoneEvent) Marshal() (string, string) {
+	var tmp []string
+
+	for i := 0; i < len(t.Events); {
+		start := t.Events[i]
+		end := start
+		i++
+
+		for i < len(t.Events) && end != 255 && t.Events[i] == end+1 {
+			end = t.Events[i]
+			i++
+		}
+
+		if start == end {
+			tmp = append(tmp, strconv.FormatUint(uint64(start), 10))
+		} else {
+			tmp = append(tmp, strconv.FormatUint(uint64(start), 10)+"-"+strconv.FormatUint(uint64(end), 10))
+		}
+	}
+
+	return "telephone-event/" + strconv.FormatInt(int64(t.SampleRate), 10), strings.Join(tmp, ",")
+}
+
+// PTSEqualsDTS implements Format.
+func (t *TelephoneEvent) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *TelephoneEvent) CreateDecoder() *rtptelephoneevent.Decoder {
+	d := &rtptelephoneevent.Decoder{
+		ClockRate: t.SampleRate,
+	}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+func (t *TelephoneEvent) CreateEncoder() *rtptelephoneevent.Encoder {
+	e := &rtptelephoneevent.Encoder{
+		PayloadType: t.PayloadTyp,
+		ClockRate:   t.SampleRate,
+	}
+	e.Init()
+	return e
+}
@@ -0,0 +1,68 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtptelephoneevent"
+)
+
+func TestTelephoneEventAttributes(t *testing.T) {
+	format := &TelephoneEvent{
+		PayloadTyp: 101,
+		SampleRate: 8000,
+		Events:     []uint8{0, 1, 2},
+	}
+	require.Equal(t, "telephone-event", format.String())
+	require.Equal(t, 8000, format.ClockRate())
+	require.Equal(t, uint8(101), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestTelephoneEventMediaDescription(t *testing.T) {
+	format := &TelephoneEvent{
+		PayloadTyp: 101,
+		SampleRate: 8000,
+		Events:     []uint8{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 32, 36, 37},
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "telephone-event/8000", rtpmap)
+	require.Equal(t, "0-15,32,36-37", fmtp)
+}
+
+func TestTelephoneEventDecEncoder(t *testing.T) {
+	format := &TelephoneEvent{
+		PayloadTyp: 101,
+		SampleRate: 8000,
+		Events:     []uint8{0, 1, 2},
+	}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode(&rtptelephoneevent.Event{
+		Code:     5,
+		Volume:   10,
+		Duration: 100 * time.Millisecond,
+	}, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	var event *rtptelephoneevent.Event
+	for _, pkt := range pkts {
+		ev, _, err := dec.Decode(pkt)
+		if err == rtptelephoneevent.ErrMorePacketsNeeded {
+			continue
+		}
+		require.NoError(t, err)
+		event = ev
+	}
+	require.Equal(t, &rtptelephoneevent.Event{
+		Code:     5,
+		Volume:   10,
+		Duration: 100 * time.Millisecond,
+	}, event)
+}
@@ -0,0 +1,149 @@
+package format
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/xiph"
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpxiph"
+)
+
+// Theora is a Theora format.
+type Theora struct {
+	PayloadTyp    uint8
+	Sampling      string
+	Width         int
+	Height        int
+	Configuration []byte
+}
+
+// String implements Format.
+func (t *Theora) String() string {
+	return "Theora"
+}
+
+// ClockRate implements Format.
+func (t *Theora) ClockRate() int {
+	return 90000
+}
+
+// PayloadType implements Format.
+func (t *Theora) PayloadType() uint8 {
+	return t.PayloadTyp
+}
+
+func (t *Theora) unmarshal(payloadType uint8, clock string, codec string, rtpmap string, fmtp string) error {
+	t.PayloadTyp = payloadType
+
+	if fmtp != "" {
+		for _, kv := range strings.Split(fmtp, ";") {
+			kv = strings.Trim(kv, " ")
+
+			if len(kv) == 0 {
+				continue
+			}
+
+			tmp := strings.SplitN(kv, "=", 2)
+			if len(tmp) != 2 {
+				return fmt.Errorf("invalid fmtp (%v)", fmtp)
+			}
+
+			switch strings.ToLower(tmp[0]) {
+			case "sampling":
+				t.Sampling = tmp[1]
+
+			case "width":
+				val, err := strconv.ParseUint(tmp[1], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid width (%v)", tmp[1])
+				}
+				t.Width = int(val)
+
+			case "height":
+				val, err := strconv.ParseUint(tmp[1], 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid height (%v)", tmp[1])
+				}
+				t.Height = int(val)
+
+			case "configuration":
+				conf, err := base64.StdEncoding.DecodeString(tmp[1])
+				if err != nil {
+					return fmt.Errorf("invalid Theora configuration (%v)", tmp[1])
+				}
+				t.Configuration = conf
+			}
+		}
+	}
+
+	if t.Configuration == nil {
+		return fmt.Errorf("config is missing (%v)", fmtp)
+	}
+
+	return nil
+}
+
+// Marshal implements Format.
+func (t *Theora) Marshal() (string, string) {
+	var tmp []string
+
+	if t.Sampling != "" {
+		tmp = append(tmp, "sampling="+t.Sampling)
+	}
+
+	if t.Width != 0 {
+		tmp = append(tmp, "width="+strconv.FormatInt(int64(t.Width), 10))
+	}
+
+	if t.Height != 0 {
+		tmp = append(tmp, "height="+strconv.FormatInt(int64(t.Height), 10))
+	}
+
+	tmp = append(tmp, "configuration="+base64.StdEncoding.EncodeToString(t.Configuration))
+
+	return "THEORA/90000", strings.Join(tmp, "; ")
+}
+
+// PTSEqualsDTS implements Format.
+func (t *Theora) PTSEqualsDTS(*rtp.Packet) bool {
+	return true
+}
+
+// DecodeConfiguration decodes the configuration into identification, comment and setup headers.
+func (t *Theora) DecodeConfiguration() (*xiph.Config, error) {
+	var conf xiph.Config
+	err := conf.Unmarshal(t.Configuration)
+	if err != nil {
+		return nil, err
+	}
+	return &conf, nil
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *Theora) CreateDecoder() *rtpxiph.Decoder {
+	d := &rtpxiph.Decoder{
+		ClockRate: t.ClockRate(),
+	}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+// The ident of packets is taken from the configuration.
+func (t *Theora) CreateEncoder() *rtpxiph.Encoder {
+	e := &rtpxiph.Encoder{
+		PayloadType: t.PayloadTyp,
+		ClockRate:   t.ClockRate(),
+	}
+
+	if conf, err := t.DecodeConfiguration(); err == nil {
+		e.Ident = conf.Ident
+	}
+
+	e.Init()
+	return e
+}
@@ -0,0 +1,57 @@
+package format
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTheoraAttributes(t *testing.T) {
+	format := &Theora{
+		PayloadTyp:    96,
+		Sampling:      "YCbCr-4:2:0",
+		Width:         1280,
+		Height:        720,
+		Configuration: []byte{0x01, 0x02, 0x03, 0x04},
+	}
+	require.Equal(t, "Theora", format.String())
+	require.Equal(t, 90000, format.ClockRate())
+	require.Equal(t, uint8(96), format.PayloadType())
+	require.Equal(t, true, format.PTSEqualsDTS(&rtp.Packet{}))
+}
+
+func TestTheoraMediaDescription(t *testing.T) {
+	format := &Theora{
+		PayloadTyp:    96,
+		Sampling:      "YCbCr-4:2:0",
+		Width:         1280,
+		Height:        720,
+		Configuration: []byte{0x01, 0x02, 0x03, 0x04},
+	}
+
+	rtpmap, fmtp := format.Marshal()
+	require.Equal(t, "THEORA/90000", rtpmap)
+	require.Equal(t, "sampling=YCbCr-4:2:0; width=1280; height=720; configuration=AQIDBA==", fmtp)
+}
+
+func TestTheoraDecEncoder(t *testing.T) {
+	format := &Theora{
+		PayloadTyp: 96,
+		Configuration: []byte{
+			0x00, 0x00, 0x00, 0x01, 0xfe, 0xcd, 0xba, 0x00,
+			0x07, 0x02, 0x03, 0x01, 0x01, 0x02, 0x03, 0x04,
+			0x05, 0x06, 0x07,
+		},
+	}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode([][]byte{{0x01, 0x02, 0x03, 0x04}}, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+
+	dec := format.CreateDecoder()
+	byts, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x01, 0x02, 0x03, 0x04}}, byts)
+}
@@ -7,6 +7,9 @@ import (
 	"strings"
 
 	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/xiph"
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/rtpxiph"
 )
 
 // Vorbis is a Vorbis format.
@@ -68,7 +71,7 @@ func (t *Vorbis) unmarshal(payloadType uint8, clock string, codec string, rtpmap
 			if tmp[0] == "configuration" {
 				conf, err := base64.StdEncoding.DecodeString(tmp[1])
 				if err != nil {
-					return fmt.Errorf("invalid AAC config (%v)", tmp[1])
+					return fmt.Errorf("invalid Vorbis configuration (%v)", tmp[1])
 				}
 
 				t.Configuration = conf
@@ -94,3 +97,38 @@ func (t *Vorbis) Marshal() (string, string) {
 func (t *Vorbis) PTSEqualsDTS(*rtp.Packet) bool {
 	return true
 }
+
+// DecodeConfiguration decodes the configuration into identification, comment and setup headers.
+func (t *Vorbis) DecodeConfiguration() (*xiph.Config, error) {
+	var conf xiph.Config
+	err := conf.Unmarshal(t.Configuration)
+	if err != nil {
+		return nil, err
+	}
+	return &conf, nil
+}
+
+// CreateDecoder creates a decoder able to decode the content of the format.
+func (t *Vorbis) CreateDecoder() *rtpxiph.Decoder {
+	d := &rtpxiph.Decoder{
+		ClockRate: t.SampleRate,
+	}
+	d.Init()
+	return d
+}
+
+// CreateEncoder creates an encoder able to encode the content of the format.
+// The ident of packets is taken from the configuration.
+func (t *Vorbis) CreateEncoder() *rtpxiph.Encoder {
+	e := &rtpxiph.Encoder{
+		PayloadType: t.PayloadTyp,
+		ClockRate:   t.SampleRate,
+	}
+
+	if conf, err := t.DecodeConfiguration(); err == nil {
+		e.Ident = conf.Ident
+	}
+
+	e.Init()
+	return e
+}
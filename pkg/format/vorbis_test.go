@@ -5,6 +5,8 @@ import (
 
 	"github.com/pion/rtp"
 	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/xiph"
 )
 
 func TestVorbisAttributes(t *testing.T) {
@@ -32,3 +34,49 @@ func TestVorbisMediaDescription(t *testing.T) {
 	require.Equal(t, "VORBIS/48000/2", rtpmap)
 	require.Equal(t, "configuration=AQIDBA==", fmtp)
 }
+
+func TestVorbisDecodeConfiguration(t *testing.T) {
+	format := &Vorbis{
+		PayloadTyp:   96,
+		SampleRate:   48000,
+		ChannelCount: 2,
+		Configuration: []byte{
+			0x00, 0x00, 0x00, 0x01, 0xfe, 0xcd, 0xba, 0x00,
+			0x07, 0x02, 0x03, 0x01, 0x01, 0x02, 0x03, 0x04,
+			0x05, 0x06, 0x07,
+		},
+	}
+
+	conf, err := format.DecodeConfiguration()
+	require.NoError(t, err)
+	require.Equal(t, &xiph.Config{
+		Ident:                0xfecdba,
+		IdentificationHeader: []byte{0x01, 0x02, 0x03},
+		CommentHeader:        []byte{0x04},
+		SetupHeader:          []byte{0x05, 0x06, 0x07},
+	}, conf)
+}
+
+func TestVorbisDecEncoder(t *testing.T) {
+	format := &Vorbis{
+		PayloadTyp:   96,
+		SampleRate:   48000,
+		ChannelCount: 2,
+		Configuration: []byte{
+			0x00, 0x00, 0x00, 0x01, 0xfe, 0xcd, 0xba, 0x00,
+			0x07, 0x02, 0x03, 0x01, 0x01, 0x02, 0x03, 0x04,
+			0x05, 0x06, 0x07,
+		},
+	}
+
+	enc := format.CreateEncoder()
+	pkts, err := enc.Encode([][]byte{{0x01, 0x02, 0x03, 0x04}}, 0)
+	require.NoError(t, err)
+	require.Equal(t, format.PayloadType(), pkts[0].PayloadType)
+	require.Equal(t, []byte{0xfe, 0xcd, 0xba}, pkts[0].Payload[:3])
+
+	dec := format.CreateDecoder()
+	byts, _, err := dec.Decode(pkts[0])
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x01, 0x02, 0x03, 0x04}}, byts)
+}
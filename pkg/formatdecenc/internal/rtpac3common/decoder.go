@@ -0,0 +1,160 @@
+package rtpac3common
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented frame and we didn't received anything before.
+// It's normal to receive this when we are decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+// Decoder is a RTP/AC-3 or RTP/E-AC-3 decoder.
+type Decoder struct {
+	Codec Codec
+
+	// sample rate of input packets.
+	SampleRate int
+
+	timeDecoder         *rtptimedec.Decoder
+	firstPacketReceived bool
+	fragments           [][]byte
+	fragmentsSize       int
+	fragmentsExpected   int
+	fragmentsTimestamp  uint32
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(d.SampleRate)
+}
+
+func (d *Decoder) resetFragments() {
+	d.fragments = d.fragments[:0]
+	d.fragmentsSize = 0
+}
+
+// Decode decodes frames from a RTP packet.
+// It returns the frames and the PTS of the first frame.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, time.Duration, error) {
+	if len(pkt.Payload) < 3 {
+		d.resetFragments()
+		return nil, 0, fmt.Errorf("payload is too short")
+	}
+
+	ft := pkt.Payload[0] & 0x03
+	nf := int(pkt.Payload[1])
+	payload := pkt.Payload[2:]
+
+	switch {
+	case ft == frameTypeComplete:
+		d.resetFragments()
+		d.firstPacketReceived = true
+
+		frames, err := d.splitFrames(payload)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if len(frames) != nf {
+			return nil, 0, fmt.Errorf("frame count (%d) is different than the one in the header (%d)",
+				len(frames), nf)
+		}
+
+		return frames, d.timeDecoder.Decode(pkt.Timestamp), nil
+
+	case d.Codec.IsInitialFragment(ft):
+		d.resetFragments()
+		d.firstPacketReceived = true
+
+		fl, _, err := d.Codec.ParseFrameHeader(payload)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if len(payload) >= fl {
+			return nil, 0, fmt.Errorf("initial fragment contains a complete frame")
+		}
+
+		d.fragments = append(d.fragments, payload)
+		d.fragmentsSize = len(payload)
+		d.fragmentsExpected = fl
+		d.fragmentsTimestamp = pkt.Timestamp
+		return nil, 0, ErrMorePacketsNeeded
+
+	case ft == d.Codec.NonInitialFragmentType:
+		return d.decodeNonInitialFragment(pkt, payload)
+	}
+
+	d.resetFragments()
+	return nil, 0, fmt.Errorf("invalid frame type (%d)", ft)
+}
+
+func (d *Decoder) decodeNonInitialFragment(pkt *rtp.Packet, payload []byte) ([][]byte, time.Duration, error) {
+	if len(d.fragments) == 0 {
+		if !d.firstPacketReceived {
+			return nil, 0, ErrNonStartingPacketAndNoPrevious
+		}
+
+		return nil, 0, fmt.Errorf("received a non-starting fragment")
+	}
+
+	if pkt.Timestamp != d.fragmentsTimestamp {
+		d.resetFragments()
+		return nil, 0, fmt.Errorf("received a non-starting fragment with an unexpected timestamp")
+	}
+
+	d.fragments = append(d.fragments, payload)
+	d.fragmentsSize += len(payload)
+
+	if d.fragmentsSize < d.fragmentsExpected {
+		return nil, 0, ErrMorePacketsNeeded
+	}
+
+	if d.fragmentsSize > d.fragmentsExpected {
+		d.resetFragments()
+		return nil, 0, fmt.Errorf("fragmented frame is bigger than expected")
+	}
+
+	frame := make([]byte, d.fragmentsSize)
+	pos := 0
+
+	for _, frag := range d.fragments {
+		pos += copy(frame[pos:], frag)
+	}
+
+	d.resetFragments()
+
+	return [][]byte{frame}, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
+
+func (d *Decoder) splitFrames(payload []byte) ([][]byte, error) {
+	var frames [][]byte
+
+	for len(payload) > 0 {
+		fl, _, err := d.Codec.ParseFrameHeader(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(payload) < fl {
+			return nil, fmt.Errorf("frame is truncated")
+		}
+
+		frames = append(frames, payload[:fl])
+		payload = payload[fl:]
+	}
+
+	return frames, nil
+}
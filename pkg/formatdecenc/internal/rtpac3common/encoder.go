@@ -0,0 +1,203 @@
+package rtpac3common
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion = 2
+)
+
+func randUint32() uint32 {
+	var b [4]byte
+	rand.Read(b[:])
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// Encoder is a RTP/AC-3 or RTP/E-AC-3 encoder.
+type Encoder struct {
+	Codec Codec
+
+	// payload type of packets.
+	PayloadType uint8
+
+	// sample rate of packets.
+	SampleRate int
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// initial timestamp of packets (optional).
+	// It defaults to a random value.
+	InitialTimestamp *uint32
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() {
+	if e.SSRC == nil {
+		v := randUint32()
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v := uint16(randUint32())
+		e.InitialSequenceNumber = &v
+	}
+	if e.InitialTimestamp == nil {
+		v := randUint32()
+		e.InitialTimestamp = &v
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+}
+
+func (e *Encoder) encodeTimestamp(ts time.Duration) uint32 {
+	return *e.InitialTimestamp + uint32(ts.Seconds()*float64(e.SampleRate))
+}
+
+// Encode encodes frames into RTP packets.
+// Frames are aggregated into packets until PayloadMaxSize is reached,
+// while frames that are bigger than PayloadMaxSize are fragmented.
+func (e *Encoder) Encode(frames [][]byte, pts time.Duration) ([]*rtp.Packet, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("no frames provided")
+	}
+
+	var rets []*rtp.Packet
+	var batch [][]byte
+	batchSize := 0
+	batchPTS := pts
+
+	for _, frame := range frames {
+		fl, frameDuration, err := e.Codec.ParseFrameHeader(frame)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(frame) != fl {
+			return nil, fmt.Errorf("frame size (%d) is different than the one in the header (%d)",
+				len(frame), fl)
+		}
+
+		if batch != nil && ((2+batchSize+len(frame)) > e.PayloadMaxSize || len(batch) == 255) {
+			rets = append(rets, e.writeBatch(batch, batchSize, batchPTS))
+			batch = nil
+			batchSize = 0
+			batchPTS = pts
+		}
+
+		if (2 + len(frame)) > e.PayloadMaxSize {
+			pkts, err := e.writeFragmented(frame, pts)
+			if err != nil {
+				return nil, err
+			}
+			rets = append(rets, pkts...)
+			batchPTS = pts + frameDuration
+		} else {
+			batch = append(batch, frame)
+			batchSize += len(frame)
+		}
+
+		pts += frameDuration
+	}
+
+	if batch != nil {
+		rets = append(rets, e.writeBatch(batch, batchSize, batchPTS))
+	}
+
+	return rets, nil
+}
+
+func (e *Encoder) writeBatch(frames [][]byte, size int, pts time.Duration) *rtp.Packet {
+	payload := make([]byte, 2+size)
+	payload[0] = frameTypeComplete
+	payload[1] = uint8(len(frames))
+	pos := 2
+
+	for _, frame := range frames {
+		pos += copy(payload[pos:], frame)
+	}
+
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    e.PayloadType,
+			SequenceNumber: e.sequenceNumber,
+			Timestamp:      e.encodeTimestamp(pts),
+			SSRC:           *e.SSRC,
+			Marker:         true,
+		},
+		Payload: payload,
+	}
+
+	e.sequenceNumber++
+
+	return pkt
+}
+
+func (e *Encoder) writeFragmented(frame []byte, pts time.Duration) ([]*rtp.Packet, error) {
+	avail := e.PayloadMaxSize - 2
+	n := len(frame) / avail
+	if (len(frame) % avail) != 0 {
+		n++
+	}
+
+	if n > 255 {
+		return nil, fmt.Errorf("frame is too big")
+	}
+
+	ret := make([]*rtp.Packet, n)
+	ts := e.encodeTimestamp(pts)
+	offset := 0
+
+	for i := range ret {
+		le := len(frame) - offset
+		if le > avail {
+			le = avail
+		}
+
+		ft := e.Codec.NonInitialFragmentType
+		if i == 0 {
+			ft = e.Codec.InitialFragmentType(le, len(frame))
+		}
+
+		payload := make([]byte, 2+le)
+		payload[0] = ft
+		payload[1] = uint8(n)
+		copy(payload[2:], frame[offset:offset+le])
+
+		ret[i] = &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: e.sequenceNumber,
+				Timestamp:      ts,
+				SSRC:           *e.SSRC,
+				Marker:         i == (n - 1),
+			},
+			Payload: payload,
+		}
+
+		e.sequenceNumber++
+		offset += le
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,26 @@
+// Package rtpac3common contains the framing shared by the RTP/AC-3
+// and RTP/E-AC-3 decoders and encoders.
+package rtpac3common
+
+import (
+	"time"
+)
+
+// frame type of packets that contain one or more complete frames.
+const frameTypeComplete = 0
+
+// Codec contains the differences between the framing of AC-3 and E-AC-3.
+type Codec struct {
+	// parses the header of a frame and returns the frame size and duration.
+	// Frames that share the timestamp of the previous one have a zero duration.
+	ParseFrameHeader func(frame []byte) (int, time.Duration, error)
+
+	// returns the frame type of the initial fragment of a frame.
+	InitialFragmentType func(fragmentSize int, frameSize int) uint8
+
+	// returns whether a frame type is the one of an initial fragment.
+	IsInitialFragment func(ft uint8) bool
+
+	// frame type of fragments other than the initial one.
+	NonInitialFragmentType uint8
+}
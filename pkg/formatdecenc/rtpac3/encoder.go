@@ -0,0 +1,63 @@
+package rtpac3
+
+import (
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/internal/rtpac3common"
+)
+
+// Encoder is a RTP/AC-3 encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4184
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// sample rate of packets.
+	SampleRate int
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// initial timestamp of packets (optional).
+	// It defaults to a random value.
+	InitialTimestamp *uint32
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	e *rtpac3common.Encoder
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() {
+	e.e = &rtpac3common.Encoder{
+		Codec:                 codec,
+		PayloadType:           e.PayloadType,
+		SampleRate:            e.SampleRate,
+		SSRC:                  e.SSRC,
+		InitialSequenceNumber: e.InitialSequenceNumber,
+		InitialTimestamp:      e.InitialTimestamp,
+		PayloadMaxSize:        e.PayloadMaxSize,
+	}
+	e.e.Init()
+
+	e.SSRC = e.e.SSRC
+	e.InitialSequenceNumber = e.e.InitialSequenceNumber
+	e.InitialTimestamp = e.e.InitialTimestamp
+	e.PayloadMaxSize = e.e.PayloadMaxSize
+}
+
+// Encode encodes AC-3 frames into RTP/AC-3 packets.
+// Frames are aggregated into packets until PayloadMaxSize is reached,
+// while frames that are bigger than PayloadMaxSize are fragmented.
+func (e *Encoder) Encode(frames [][]byte, pts time.Duration) ([]*rtp.Packet, error) {
+	return e.e.Encode(frames, pts)
+}
@@ -0,0 +1,144 @@
+package rtpac3
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func mergeBytes(vals ...[]byte) []byte {
+	size := 0
+	for _, v := range vals {
+		size += len(v)
+	}
+	res := make([]byte, size)
+
+	pos := 0
+	for _, v := range vals {
+		n := copy(res[pos:], v)
+		pos += n
+	}
+
+	return res
+}
+
+// 48khz, 32kbps, stereo
+var testSmallFrame = mergeBytes(
+	[]byte{0x0b, 0x77, 0x00, 0x00, 0x00, 0x40, 0x40},
+	bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 30),
+	[]byte{0x01},
+)
+
+// 48khz, 640kbps, stereo
+var testBigFrame = mergeBytes(
+	[]byte{0x0b, 0x77, 0x00, 0x00, 0x24, 0x40, 0x40},
+	bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 638),
+	[]byte{0x01},
+)
+
+var cases = []struct {
+	name   string
+	frames [][]byte
+	pts    time.Duration
+	pkts   []*rtp.Packet
+}{
+	{
+		"aggregated",
+		[][]byte{testSmallFrame, testSmallFrame},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289527557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x02},
+					testSmallFrame,
+					testSmallFrame,
+				),
+			},
+		},
+	},
+	{
+		"fragmented",
+		[][]byte{testBigFrame},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289527557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x02, 0x02},
+					testBigFrame[:1458],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					Timestamp:      2289527557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x03, 0x02},
+					testBigFrame[1458:],
+				),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType: 96,
+				SampleRate:  48000,
+				SSRC: func() *uint32 {
+					v := uint32(0x9dbb7812)
+					return &v
+				}(),
+				InitialSequenceNumber: func() *uint16 {
+					v := uint16(0x44ed)
+					return &v
+				}(),
+				InitialTimestamp: func() *uint32 {
+					v := uint32(0x88776655)
+					return &v
+				}(),
+			}
+			e.Init()
+
+			pkts, err := e.Encode(ca.frames, ca.pts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+		SampleRate:  48000,
+	}
+	e.Init()
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+	require.NotEqual(t, nil, e.InitialTimestamp)
+}
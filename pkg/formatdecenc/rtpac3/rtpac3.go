@@ -0,0 +1,39 @@
+// Package rtpac3 contains a RTP/AC-3 decoder and encoder.
+package rtpac3
+
+import (
+	"time"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/ac3"
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/internal/rtpac3common"
+)
+
+// frame types.
+const (
+	frameTypeInitialFragmentOver58  = 1
+	frameTypeInitialFragmentUnder58 = 2
+	frameTypeNonInitialFragment     = 3
+)
+
+var codec = rtpac3common.Codec{
+	ParseFrameHeader: func(frame []byte) (int, time.Duration, error) {
+		var h ac3.SyncFrameHeader
+		err := h.Unmarshal(frame)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		return h.FrameLen(), time.Duration(h.SampleCount()) * time.Second / time.Duration(h.SampleRate), nil
+	},
+	InitialFragmentType: func(fragmentSize int, frameSize int) uint8 {
+		// whether the fragment contains at least the first 5/8 of the frame
+		if fragmentSize*8 >= frameSize*5 {
+			return frameTypeInitialFragmentOver58
+		}
+		return frameTypeInitialFragmentUnder58
+	},
+	IsInitialFragment: func(ft uint8) bool {
+		return ft == frameTypeInitialFragmentOver58 || ft == frameTypeInitialFragmentUnder58
+	},
+	NonInitialFragmentType: frameTypeNonInitialFragment,
+}
@@ -0,0 +1,198 @@
+package rtpamr
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/bits"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/amr"
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+type tocEntry struct {
+	frameType uint8
+	quality   bool
+}
+
+// Decoder is a RTP/AMR or RTP/AMR-WB decoder.
+// Interleaving and robust sorting are not supported.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4867
+type Decoder struct {
+	// whether the stream is AMR-WB.
+	Wideband bool
+
+	// whether the octet-aligned mode is in use.
+	OctetAligned bool
+
+	// whether speech frames are protected by CRCs.
+	// It can be used only in octet-aligned mode.
+	CRC bool
+
+	// whether the stream is interleaved.
+	// Interleaved streams are not supported and their packets are rejected.
+	Interleaved bool
+
+	timeDecoder *rtptimedec.Decoder
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	if d.Wideband {
+		d.timeDecoder = rtptimedec.New(16000)
+	} else {
+		d.timeDecoder = rtptimedec.New(8000)
+	}
+}
+
+// Decode decodes frames from a RTP/AMR or RTP/AMR-WB packet.
+// Frames are returned in storage format.
+// It returns the frames and the PTS of the first frame.
+// Frames of a multi-channel stream are returned one channel after the other;
+// every frame-block lasts 20ms.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, time.Duration, error) {
+	if d.Interleaved {
+		return nil, 0, fmt.Errorf("interleaving is not supported")
+	}
+
+	var frames [][]byte
+	var err error
+
+	if d.OctetAligned {
+		frames, err = d.decodeOctetAligned(pkt.Payload)
+	} else {
+		frames, err = d.decodeBandwidthEfficient(pkt.Payload)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return frames, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
+
+func (d *Decoder) decodeOctetAligned(payload []byte) ([][]byte, error) {
+	if len(payload) < 2 {
+		return nil, fmt.Errorf("payload is too short")
+	}
+
+	// skip CMR
+	payload = payload[1:]
+
+	var toc []tocEntry
+
+	for {
+		if len(payload) == 0 {
+			return nil, fmt.Errorf("payload is too short")
+		}
+
+		b := payload[0]
+		payload = payload[1:]
+
+		toc = append(toc, tocEntry{
+			frameType: (b >> 3) & 0x0F,
+			quality:   (b & 0x04) != 0,
+		})
+
+		if (b & 0x80) == 0 {
+			break
+		}
+	}
+
+	sizes := make([]int, len(toc))
+
+	for i, e := range toc {
+		n, err := amr.FrameBits(d.Wideband, e.frameType)
+		if err != nil {
+			return nil, err
+		}
+		sizes[i] = (n + 7) / 8
+
+		// frames without speech bits are not protected by CRCs
+		if d.CRC && n != 0 {
+			if len(payload) == 0 {
+				return nil, fmt.Errorf("payload is too short")
+			}
+			payload = payload[1:]
+		}
+	}
+
+	frames := make([][]byte, len(toc))
+
+	for i, e := range toc {
+		if len(payload) < sizes[i] {
+			return nil, fmt.Errorf("payload is too short")
+		}
+
+		frame := make([]byte, 1+sizes[i])
+		frame[0] = storageHeader(e)
+		copy(frame[1:], payload[:sizes[i]])
+		payload = payload[sizes[i]:]
+		frames[i] = frame
+	}
+
+	return frames, nil
+}
+
+func (d *Decoder) decodeBandwidthEfficient(payload []byte) ([][]byte, error) {
+	pos := 0
+
+	// skip CMR
+	err := bits.HasSpace(payload, pos, 4)
+	if err != nil {
+		return nil, fmt.Errorf("payload is too short")
+	}
+	pos += 4
+
+	var toc []tocEntry
+
+	for {
+		err := bits.HasSpace(payload, pos, 6)
+		if err != nil {
+			return nil, fmt.Errorf("payload is too short")
+		}
+
+		follow := bits.ReadFlagUnsafe(payload, &pos)
+		frameType := uint8(bits.ReadBitsUnsafe(payload, &pos, 4))
+		quality := bits.ReadFlagUnsafe(payload, &pos)
+
+		toc = append(toc, tocEntry{
+			frameType: frameType,
+			quality:   quality,
+		})
+
+		if !follow {
+			break
+		}
+	}
+
+	frames := make([][]byte, len(toc))
+
+	for i, e := range toc {
+		n, err := amr.FrameBits(d.Wideband, e.frameType)
+		if err != nil {
+			return nil, err
+		}
+
+		err = bits.HasSpace(payload, pos, n)
+		if err != nil {
+			return nil, fmt.Errorf("payload is too short")
+		}
+
+		frame := make([]byte, 1+(n+7)/8)
+		frame[0] = storageHeader(e)
+		framePos := 8
+		copyBits(frame, &framePos, payload, &pos, n)
+		frames[i] = frame
+	}
+
+	return frames, nil
+}
+
+func storageHeader(e tocEntry) byte {
+	b := e.frameType << 3
+	if e.quality {
+		b |= 0x04
+	}
+	return b
+}
@@ -0,0 +1,174 @@
+//go:build go1.18
+// +build go1.18
+
+package rtpamr
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{
+				Wideband:     ca.wideband,
+				OctetAligned: ca.octetAligned,
+			}
+			d.Init()
+
+			// send an initial packet downstream
+			// in order to compute the right timestamp,
+			// that is relative to the initial packet
+			initialPayload := []byte{0xf7, 0xc0}
+			if ca.octetAligned {
+				initialPayload = []byte{0xf0, 0x7c}
+			}
+
+			pkt := rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289526357,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: initialPayload,
+			}
+			_, _, err := d.Decode(&pkt)
+			require.NoError(t, err)
+
+			var frames [][]byte
+
+			for _, pkt := range ca.pkts {
+				addFrames, pts, err := d.Decode(pkt)
+				require.NoError(t, err)
+				require.Equal(t, ca.pts, pts)
+				frames = append(frames, addFrames...)
+			}
+
+			require.Equal(t, ca.frames, frames)
+		})
+	}
+}
+
+func TestDecodeCRC(t *testing.T) {
+	d := &Decoder{
+		OctetAligned: true,
+		CRC:          true,
+	}
+	d.Init()
+
+	frames, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			PayloadType: 96,
+		},
+		Payload: mergeBytes(
+			[]byte{0xf0, 0xbc, 0x7c, 0x55},
+			testFrame[1:],
+		),
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{testFrame, testNoDataFrame}, frames)
+}
+
+func TestDecodeInterleaved(t *testing.T) {
+	d := &Decoder{
+		OctetAligned: true,
+		Interleaved:  true,
+	}
+	d.Init()
+
+	_, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			PayloadType: 96,
+		},
+		Payload: mergeBytes(
+			[]byte{0xf0, 0x30, 0x3c},
+			testFrame[1:],
+		),
+	})
+	require.EqualError(t, err, "interleaving is not supported")
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name         string
+		octetAligned bool
+		payload      []byte
+		err          string
+	}{
+		{
+			"octet-aligned empty",
+			true,
+			[]byte{},
+			"payload is too short",
+		},
+		{
+			"octet-aligned reserved frame type",
+			true,
+			[]byte{0xf0, 0x60},
+			"reserved frame type (12)",
+		},
+		{
+			"octet-aligned truncated",
+			true,
+			[]byte{0xf0, 0x3c, 0x01},
+			"payload is too short",
+		},
+		{
+			"bandwidth-efficient truncated",
+			false,
+			[]byte{0xf3, 0xea},
+			"payload is too short",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{
+				OctetAligned: ca.octetAligned,
+			}
+			d.Init()
+
+			_, _, err := d.Decode(&rtp.Packet{
+				Header: rtp.Header{
+					Version:     2,
+					PayloadType: 96,
+				},
+				Payload: ca.payload,
+			})
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzDecoderUnmarshal(f *testing.F) {
+	d1 := &Decoder{}
+	d1.Init()
+
+	d2 := &Decoder{
+		OctetAligned: true,
+		CRC:          true,
+	}
+	d2.Init()
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		for _, d := range []*Decoder{d1, d2} {
+			d.Decode(&rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289527317,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: b,
+			})
+		}
+	})
+}
@@ -0,0 +1,243 @@
+package rtpamr
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/bits"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/amr"
+)
+
+const (
+	rtpVersion = 2
+)
+
+func randUint32() uint32 {
+	var b [4]byte
+	rand.Read(b[:])
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// Encoder is a RTP/AMR or RTP/AMR-WB encoder.
+// Interleaving, robust sorting and CRCs are not supported.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4867
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// whether the stream is AMR-WB.
+	Wideband bool
+
+	// whether to use the octet-aligned mode.
+	OctetAligned bool
+
+	// channel count of the stream (optional).
+	// It defaults to 1.
+	ChannelCount int
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// initial timestamp of packets (optional).
+	// It defaults to a random value.
+	InitialTimestamp *uint32
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() {
+	if e.ChannelCount == 0 {
+		e.ChannelCount = 1
+	}
+	if e.SSRC == nil {
+		v := randUint32()
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v := uint16(randUint32())
+		e.InitialSequenceNumber = &v
+	}
+	if e.InitialTimestamp == nil {
+		v := randUint32()
+		e.InitialTimestamp = &v
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+}
+
+func (e *Encoder) clockRate() int {
+	if e.Wideband {
+		return 16000
+	}
+	return 8000
+}
+
+func (e *Encoder) encodeTimestamp(ts time.Duration) uint32 {
+	return *e.InitialTimestamp + uint32(ts.Seconds()*float64(e.clockRate()))
+}
+
+func (e *Encoder) payloadSize(toc []tocEntry) int {
+	if e.OctetAligned {
+		n := 1 + len(toc)
+		for _, entry := range toc {
+			fb, _ := amr.FrameBits(e.Wideband, entry.frameType)
+			n += (fb + 7) / 8
+		}
+		return n
+	}
+
+	n := 4 + 6*len(toc)
+	for _, entry := range toc {
+		fb, _ := amr.FrameBits(e.Wideband, entry.frameType)
+		n += fb
+	}
+	return (n + 7) / 8
+}
+
+// Encode encodes frames in storage format into RTP/AMR or RTP/AMR-WB packets.
+// Frames of a multi-channel stream must be provided one channel after the other,
+// and are aggregated into packets until PayloadMaxSize is reached.
+// The first frame has the given PTS, and every frame-block lasts 20ms.
+func (e *Encoder) Encode(frames [][]byte, pts time.Duration) ([]*rtp.Packet, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("no frames provided")
+	}
+
+	if (len(frames) % e.ChannelCount) != 0 {
+		return nil, fmt.Errorf("frame count (%d) is not a multiple of the channel count (%d)",
+			len(frames), e.ChannelCount)
+	}
+
+	toc := make([]tocEntry, len(frames))
+
+	for i, frame := range frames {
+		if len(frame) == 0 {
+			return nil, fmt.Errorf("invalid frame")
+		}
+
+		entry := tocEntry{
+			frameType: (frame[0] >> 3) & 0x0F,
+			quality:   (frame[0] & 0x04) != 0,
+		}
+
+		size, err := amr.StorageFrameSize(e.Wideband, entry.frameType)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(frame) != size {
+			return nil, fmt.Errorf("frame size (%d) is different than the expected one (%d)",
+				len(frame), size)
+		}
+
+		toc[i] = entry
+	}
+
+	blockDuration := 20 * time.Millisecond
+
+	var rets []*rtp.Packet
+	start := 0
+
+	for start < len(frames) {
+		end := start + e.ChannelCount
+
+		if e.payloadSize(toc[start:end]) > e.PayloadMaxSize {
+			return nil, fmt.Errorf("frame-block is too big")
+		}
+
+		for end < len(frames) && e.payloadSize(toc[start:end+e.ChannelCount]) <= e.PayloadMaxSize {
+			end += e.ChannelCount
+		}
+
+		var payload []byte
+		if e.OctetAligned {
+			payload = e.writeOctetAligned(frames[start:end], toc[start:end])
+		} else {
+			payload = e.writeBandwidthEfficient(frames[start:end], toc[start:end])
+		}
+
+		rets = append(rets, &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: e.sequenceNumber,
+				Timestamp:      e.encodeTimestamp(pts),
+				SSRC:           *e.SSRC,
+			},
+			Payload: payload,
+		})
+
+		e.sequenceNumber++
+		pts += time.Duration((end-start)/e.ChannelCount) * blockDuration
+		start = end
+	}
+
+	return rets, nil
+}
+
+func (e *Encoder) writeOctetAligned(frames [][]byte, toc []tocEntry) []byte {
+	payload := make([]byte, e.payloadSize(toc))
+	payload[0] = cmrNoRequest << 4
+	pos := 1
+
+	for i, entry := range toc {
+		payload[pos] = storageHeader(entry)
+		if i != (len(toc) - 1) {
+			payload[pos] |= 0x80
+		}
+		pos++
+	}
+
+	for _, frame := range frames {
+		pos += copy(payload[pos:], frame[1:])
+	}
+
+	return payload
+}
+
+func (e *Encoder) writeBandwidthEfficient(frames [][]byte, toc []tocEntry) []byte {
+	payload := make([]byte, e.payloadSize(toc))
+	pos := 0
+
+	bits.WriteBits(payload, &pos, cmrNoRequest, 4)
+
+	for i, entry := range toc {
+		if i != (len(toc) - 1) {
+			bits.WriteBits(payload, &pos, 1, 1)
+		} else {
+			bits.WriteBits(payload, &pos, 0, 1)
+		}
+
+		bits.WriteBits(payload, &pos, uint64(entry.frameType), 4)
+
+		if entry.quality {
+			bits.WriteBits(payload, &pos, 1, 1)
+		} else {
+			bits.WriteBits(payload, &pos, 0, 1)
+		}
+	}
+
+	for i, frame := range frames {
+		n, _ := amr.FrameBits(e.Wideband, toc[i].frameType)
+		framePos := 8
+		copyBits(payload, &pos, frame, &framePos, n)
+	}
+
+	return payload
+}
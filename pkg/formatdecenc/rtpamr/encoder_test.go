@@ -0,0 +1,182 @@
+package rtpamr
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func mergeBytes(vals ...[]byte) []byte {
+	size := 0
+	for _, v := range vals {
+		size += len(v)
+	}
+	res := make([]byte, size)
+
+	pos := 0
+	for _, v := range vals {
+		n := copy(res[pos:], v)
+		pos += n
+	}
+
+	return res
+}
+
+// AMR 12.2 kbit/s frame in storage format
+var testFrame = mergeBytes(
+	[]byte{0x3c},
+	bytes.Repeat([]byte{0xab}, 30),
+	[]byte{0xa0},
+)
+
+// AMR NO_DATA frame in storage format
+var testNoDataFrame = []byte{0x7c}
+
+// AMR-WB 6.60 kbit/s frame in storage format
+var testFrameWB = mergeBytes(
+	[]byte{0x04},
+	bytes.Repeat([]byte{0xab}, 16),
+	[]byte{0xa0},
+)
+
+var cases = []struct {
+	name         string
+	wideband     bool
+	octetAligned bool
+	frames       [][]byte
+	pts          time.Duration
+	pkts         []*rtp.Packet
+}{
+	{
+		"octet-aligned",
+		false,
+		true,
+		[][]byte{testFrame, testNoDataFrame},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289526557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0xf0, 0xbc, 0x7c},
+					testFrame[1:],
+				),
+			},
+		},
+	},
+	{
+		"bandwidth-efficient",
+		false,
+		false,
+		[][]byte{testFrame},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289526557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0xf3},
+					bytes.Repeat([]byte{0xea}, 30),
+					[]byte{0xe8},
+				),
+			},
+		},
+	},
+	{
+		"wideband octet-aligned",
+		true,
+		true,
+		[][]byte{testFrameWB},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289526757,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0xf0, 0x04},
+					testFrameWB[1:],
+				),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:  96,
+				Wideband:     ca.wideband,
+				OctetAligned: ca.octetAligned,
+				SSRC: func() *uint32 {
+					v := uint32(0x9dbb7812)
+					return &v
+				}(),
+				InitialSequenceNumber: func() *uint16 {
+					v := uint16(0x44ed)
+					return &v
+				}(),
+				InitialTimestamp: func() *uint32 {
+					v := uint32(0x88776655)
+					return &v
+				}(),
+			}
+			e.Init()
+
+			pkts, err := e.Encode(ca.frames, ca.pts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeSplit(t *testing.T) {
+	e := &Encoder{
+		PayloadType:    96,
+		OctetAligned:   true,
+		PayloadMaxSize: 70,
+		InitialTimestamp: func() *uint32 {
+			v := uint32(0x88776655)
+			return &v
+		}(),
+	}
+	e.Init()
+
+	pkts, err := e.Encode([][]byte{testFrame, testFrame, testFrame}, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(pkts))
+	require.Equal(t, 65, len(pkts[0].Payload))
+	require.Equal(t, uint32(0x88776655), pkts[0].Timestamp)
+	require.Equal(t, uint32(0x88776655+320), pkts[1].Timestamp)
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+	}
+	e.Init()
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+	require.NotEqual(t, nil, e.InitialTimestamp)
+}
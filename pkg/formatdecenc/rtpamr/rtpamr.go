@@ -0,0 +1,23 @@
+// Package rtpamr contains a RTP/AMR and RTP/AMR-WB decoder and encoder.
+package rtpamr
+
+import (
+	"github.com/aler9/gortsplib/v2/pkg/bits"
+)
+
+const (
+	// codec mode request that indicates that no specific mode is requested.
+	cmrNoRequest = 15
+)
+
+func copyBits(dst []byte, dstPos *int, src []byte, srcPos *int, n int) {
+	for n > 0 {
+		le := n
+		if le > 8 {
+			le = 8
+		}
+
+		bits.WriteBits(dst, dstPos, bits.ReadBitsUnsafe(src, srcPos, le), le)
+		n -= le
+	}
+}
@@ -0,0 +1,181 @@
+package rtpav1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/av1"
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented OBU and we didn't received anything before.
+// It's normal to receive this when we are decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+// Decoder is a RTP/AV1 decoder.
+// Specification: https://aomediacodec.github.io/av1-rtp-spec/
+type Decoder struct {
+	timeDecoder         *rtptimedec.Decoder
+	firstPacketReceived bool
+	fragmentsSize       int
+	fragments           [][]byte
+
+	// for DecodeUntilMarker()
+	obuBuffer [][]byte
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(rtpClockRate)
+}
+
+// Decode decodes OBUs from a RTP/AV1 packet.
+// Returned OBUs don't have the size field.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, time.Duration, error) {
+	if len(pkt.Payload) < 2 {
+		d.fragments = d.fragments[:0] // discard pending fragmented packets
+		return nil, 0, fmt.Errorf("payload is too short")
+	}
+
+	z := (pkt.Payload[0] & 0x80) != 0
+	y := (pkt.Payload[0] & 0x40) != 0
+	w := int((pkt.Payload[0] >> 4) & 0x03)
+
+	elements, err := unmarshalElements(pkt.Payload[1:], w)
+	if err != nil {
+		d.fragments = d.fragments[:0] // discard pending fragmented packets
+		return nil, 0, err
+	}
+
+	var obus [][]byte
+
+	if z {
+		if len(d.fragments) == 0 {
+			if !d.firstPacketReceived {
+				return nil, 0, ErrNonStartingPacketAndNoPrevious
+			}
+
+			return nil, 0, fmt.Errorf("received a non-starting fragment")
+		}
+
+		d.fragmentsSize += len(elements[0])
+		if d.fragmentsSize > av1.MaxOBUSize {
+			d.fragments = d.fragments[:0]
+			return nil, 0, fmt.Errorf("OBU size (%d) is too big (maximum is %d)", d.fragmentsSize, av1.MaxOBUSize)
+		}
+
+		d.fragments = append(d.fragments, elements[0])
+		elements = elements[1:]
+
+		// the fragmented OBU is complete
+		if len(elements) != 0 || !y {
+			obu := make([]byte, d.fragmentsSize)
+			pos := 0
+
+			for _, frag := range d.fragments {
+				pos += copy(obu[pos:], frag)
+			}
+
+			d.fragments = d.fragments[:0]
+			obus = append(obus, obu)
+		}
+	} else {
+		d.fragments = d.fragments[:0] // discard pending fragmented packets
+		d.firstPacketReceived = true
+	}
+
+	if y && len(elements) != 0 {
+		last := elements[len(elements)-1]
+		elements = elements[:len(elements)-1]
+
+		d.fragmentsSize = len(last)
+		d.fragments = append(d.fragments, last)
+	}
+
+	obus = append(obus, elements...)
+
+	if len(obus) == 0 {
+		return nil, 0, ErrMorePacketsNeeded
+	}
+
+	return obus, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
+
+func unmarshalElements(payload []byte, w int) ([][]byte, error) {
+	var elements [][]byte
+
+	for len(payload) > 0 {
+		var size int
+
+		if w != 0 && len(elements) == (w-1) {
+			// the last element doesn't have the size field
+			size = len(payload)
+		} else {
+			tmp, n, err := av1.LEB128Unmarshal(payload)
+			if err != nil {
+				return nil, fmt.Errorf("invalid OBU element size (%v)", err)
+			}
+			payload = payload[n:]
+
+			if tmp == 0 || tmp > uint(len(payload)) {
+				return nil, fmt.Errorf("invalid OBU element size (%d)", tmp)
+			}
+			size = int(tmp)
+		}
+
+		elements = append(elements, payload[:size])
+		payload = payload[size:]
+
+		if len(elements) > av1.MaxOBUsPerTemporalUnit {
+			return nil, fmt.Errorf("OBU count exceeds maximum allowed (%d)", av1.MaxOBUsPerTemporalUnit)
+		}
+	}
+
+	if elements == nil {
+		return nil, fmt.Errorf("packet doesn't contain any OBU")
+	}
+
+	if w != 0 && len(elements) != w {
+		return nil, fmt.Errorf("OBU count (%d) doesn't match W (%d)", len(elements), w)
+	}
+
+	return elements, nil
+}
+
+// DecodeUntilMarker decodes OBUs from a RTP/AV1 packet and puts them in a buffer.
+// When a packet has the marker flag (meaning that all the OBUs of a temporal unit
+// have been received), the buffer is returned.
+func (d *Decoder) DecodeUntilMarker(pkt *rtp.Packet) ([][]byte, time.Duration, error) {
+	obus, pts, err := d.Decode(pkt)
+	if err != nil {
+		if err == ErrMorePacketsNeeded && pkt.Marker {
+			return nil, 0, fmt.Errorf("temporal unit ends with a fragment")
+		}
+		return nil, 0, err
+	}
+
+	if (len(d.obuBuffer) + len(obus)) > av1.MaxOBUsPerTemporalUnit {
+		return nil, 0, fmt.Errorf("OBU count (%d) exceeds maximum allowed (%d)",
+			len(d.obuBuffer)+len(obus), av1.MaxOBUsPerTemporalUnit)
+	}
+
+	d.obuBuffer = append(d.obuBuffer, obus...)
+
+	if !pkt.Marker {
+		return nil, 0, ErrMorePacketsNeeded
+	}
+
+	ret := d.obuBuffer
+	d.obuBuffer = d.obuBuffer[:0]
+
+	return ret, pts, nil
+}
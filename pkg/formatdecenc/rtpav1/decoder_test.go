@@ -0,0 +1,128 @@
+//go:build go1.18
+// +build go1.18
+
+package rtpav1
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{}
+			d.Init()
+
+			// send an initial packet downstream
+			// in order to compute the right timestamp,
+			// that is relative to the initial packet
+			pkt := rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289526357,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x00, 0x01, 0x10},
+			}
+			_, _, err := d.Decode(&pkt)
+			require.NoError(t, err)
+
+			var obus [][]byte
+
+			for _, pkt := range ca.pkts {
+				addOBUs, pts, err := d.Decode(pkt)
+				if err == ErrMorePacketsNeeded {
+					continue
+				}
+
+				require.NoError(t, err)
+				require.Equal(t, ca.pts, pts)
+				obus = append(obus, addOBUs...)
+			}
+
+			require.Equal(t, ca.obus, obus)
+		})
+	}
+}
+
+func TestDecodeW(t *testing.T) {
+	d := &Decoder{}
+	d.Init()
+
+	obus, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      true,
+			PayloadType: 96,
+		},
+		Payload: []byte{0x20, 0x02, 0x30, 0x10, 0x30, 0x20, 0x01},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x30, 0x10}, {0x30, 0x20, 0x01}}, obus)
+}
+
+func TestDecodeUntilMarker(t *testing.T) {
+	d := &Decoder{}
+	d.Init()
+
+	_, _, err := d.DecodeUntilMarker(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      false,
+			PayloadType: 96,
+		},
+		Payload: mergeBytes([]byte{0x08, 0x0c}, testSequenceHeader),
+	})
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	obus, _, err := d.DecodeUntilMarker(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      true,
+			PayloadType: 96,
+		},
+		Payload: []byte{0x10, 0x30, 0x10, 0x01, 0x02},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{testSequenceHeader, {0x30, 0x10, 0x01, 0x02}}, obus)
+}
+
+func TestDecodeNonStarting(t *testing.T) {
+	d := &Decoder{}
+	d.Init()
+
+	_, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      true,
+			PayloadType: 96,
+		},
+		Payload: []byte{0x80, 0x02, 0x01, 0x02},
+	})
+	require.Equal(t, ErrNonStartingPacketAndNoPrevious, err)
+}
+
+func FuzzDecoderUnmarshal(f *testing.F) {
+	d := &Decoder{}
+	d.Init()
+
+	f.Fuzz(func(t *testing.T, b []byte, m bool) {
+		d.DecodeUntilMarker(&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         m,
+				PayloadType:    96,
+				SequenceNumber: 17645,
+				Timestamp:      2289527317,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: b,
+		})
+	})
+}
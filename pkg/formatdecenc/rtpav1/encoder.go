@@ -0,0 +1,174 @@
+package rtpav1
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/av1"
+)
+
+const (
+	rtpVersion = 2
+)
+
+func randUint32() uint32 {
+	var b [4]byte
+	rand.Read(b[:])
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// Encoder is a RTP/AV1 encoder.
+// Specification: https://aomediacodec.github.io/av1-rtp-spec/
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// initial timestamp of packets (optional).
+	// It defaults to a random value.
+	InitialTimestamp *uint32
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() {
+	if e.SSRC == nil {
+		v := randUint32()
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v := uint16(randUint32())
+		e.InitialSequenceNumber = &v
+	}
+	if e.InitialTimestamp == nil {
+		v := randUint32()
+		e.InitialTimestamp = &v
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+}
+
+func (e *Encoder) encodeTimestamp(ts time.Duration) uint32 {
+	return *e.InitialTimestamp + uint32(ts.Seconds()*rtpClockRate)
+}
+
+type packetElements struct {
+	z        bool
+	y        bool
+	elements [][]byte
+	size     int
+}
+
+// Encode encodes the OBUs of a temporal unit into RTP/AV1 packets.
+// OBUs must not have the size field.
+// Temporal delimiters are removed, as required by the specification.
+func (e *Encoder) Encode(obus [][]byte, pts time.Duration) ([]*rtp.Packet, error) {
+	cur := &packetElements{size: 1}
+	pkts := []*packetElements{cur}
+
+	for _, obu := range obus {
+		if len(obu) == 0 {
+			continue
+		}
+
+		typ := av1.OBUType((obu[0] >> 3) & 0x0F)
+		if typ == av1.OBUTypeTemporalDelimiter {
+			continue
+		}
+
+		for len(obu) > 0 {
+			avail := e.PayloadMaxSize - cur.size
+			avail -= av1.LEB128MarshalSize(uint(avail))
+
+			// the packet is full
+			if avail <= 0 {
+				if len(cur.elements) == 0 {
+					return nil, fmt.Errorf("payload max size is too small")
+				}
+
+				cur = &packetElements{size: 1}
+				pkts = append(pkts, cur)
+				continue
+			}
+
+			le := len(obu)
+			if le > avail {
+				le = avail
+			}
+
+			cur.elements = append(cur.elements, obu[:le])
+			cur.size += av1.LEB128MarshalSize(uint(le)) + le
+			obu = obu[le:]
+
+			// the OBU continues in the next packet
+			if len(obu) > 0 {
+				cur.y = true
+				cur = &packetElements{size: 1, z: true}
+				pkts = append(pkts, cur)
+			}
+		}
+	}
+
+	if len(pkts[0].elements) == 0 {
+		return nil, nil
+	}
+
+	keyFrame := av1.ContainsKeyFrame(obus)
+	encPTS := e.encodeTimestamp(pts)
+	ret := make([]*rtp.Packet, len(pkts))
+
+	for i, p := range pkts {
+		payload := make([]byte, p.size)
+
+		if p.z {
+			payload[0] |= 1 << 7
+		}
+		if p.y {
+			payload[0] |= 1 << 6
+		}
+		if i == 0 && keyFrame {
+			payload[0] |= 1 << 3
+		}
+
+		pos := 1
+
+		for _, el := range p.elements {
+			pos += av1.LEB128MarshalTo(uint(len(el)), payload[pos:])
+			pos += copy(payload[pos:], el)
+		}
+
+		ret[i] = &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: e.sequenceNumber,
+				Timestamp:      encPTS,
+				SSRC:           *e.SSRC,
+				Marker:         i == (len(pkts) - 1),
+			},
+			Payload: payload,
+		}
+
+		e.sequenceNumber++
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,150 @@
+package rtpav1
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func mergeBytes(vals ...[]byte) []byte {
+	size := 0
+	for _, v := range vals {
+		size += len(v)
+	}
+	res := make([]byte, size)
+
+	pos := 0
+	for _, v := range vals {
+		n := copy(res[pos:], v)
+		pos += n
+	}
+
+	return res
+}
+
+var testSequenceHeader = []byte{
+	0x08, 0x00, 0x00, 0x00, 0x42, 0xab, 0xbf, 0xc3,
+	0x71, 0xab, 0xe6, 0x01,
+}
+
+var cases = []struct {
+	name string
+	obus [][]byte
+	pts  time.Duration
+	pkts []*rtp.Packet
+}{
+	{
+		"aggregated",
+		[][]byte{
+			testSequenceHeader,
+			{0x30, 0x10, 0x01, 0x02},
+		},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x08, 0x0c},
+					testSequenceHeader,
+					[]byte{0x04, 0x30, 0x10, 0x01, 0x02},
+				),
+			},
+		},
+	},
+	{
+		"fragmented",
+		[][]byte{
+			mergeBytes([]byte{0x30}, bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 512)),
+		},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x40, 0xb1, 0x0b, 0x30},
+					bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 364),
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x80, 0xd0, 0x04},
+					bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 148),
+				),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType: 96,
+				SSRC: func() *uint32 {
+					v := uint32(0x9dbb7812)
+					return &v
+				}(),
+				InitialSequenceNumber: func() *uint16 {
+					v := uint16(0x44ed)
+					return &v
+				}(),
+				InitialTimestamp: func() *uint32 {
+					v := uint32(0x88776655)
+					return &v
+				}(),
+			}
+			e.Init()
+
+			pkts, err := e.Encode(ca.obus, ca.pts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeTemporalDelimiter(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+	}
+	e.Init()
+
+	pkts, err := e.Encode([][]byte{{0x10}, {0x30, 0x10, 0x01, 0x02}}, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(pkts))
+	require.Equal(t, []byte{0x00, 0x04, 0x30, 0x10, 0x01, 0x02}, pkts[0].Payload)
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+	}
+	e.Init()
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+	require.NotEqual(t, nil, e.InitialTimestamp)
+}
@@ -0,0 +1,41 @@
+package rtpcn
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// Decoder is a RTP/CN decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3389
+type Decoder struct {
+	// clock rate of input packets.
+	ClockRate int
+
+	timeDecoder *rtptimedec.Decoder
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(d.ClockRate)
+}
+
+// Decode decodes comfort noise parameters from a RTP/CN packet.
+func (d *Decoder) Decode(pkt *rtp.Packet) (*Parameters, time.Duration, error) {
+	if len(pkt.Payload) < 1 {
+		return nil, 0, fmt.Errorf("payload is too short")
+	}
+
+	params := &Parameters{
+		NoiseLevel: pkt.Payload[0] & 0x7F,
+	}
+
+	if len(pkt.Payload) > 1 {
+		params.ReflectionCoefficients = pkt.Payload[1:]
+	}
+
+	return params, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
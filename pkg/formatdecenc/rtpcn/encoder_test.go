@@ -0,0 +1,93 @@
+package rtpcn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+var cases = []struct {
+	name   string
+	params *Parameters
+	pts    time.Duration
+	pkt    *rtp.Packet
+}{
+	{
+		"noise level only",
+		&Parameters{
+			NoiseLevel: 64,
+		},
+		25 * time.Millisecond,
+		&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         false,
+				PayloadType:    13,
+				SequenceNumber: 17645,
+				Timestamp:      2289526557,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: []byte{0x40},
+		},
+	},
+	{
+		"reflection coefficients",
+		&Parameters{
+			NoiseLevel:             90,
+			ReflectionCoefficients: []uint8{0x7f, 0x80, 0x11},
+		},
+		25 * time.Millisecond,
+		&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         false,
+				PayloadType:    13,
+				SequenceNumber: 17645,
+				Timestamp:      2289526557,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: []byte{0x5a, 0x7f, 0x80, 0x11},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType: 13,
+				ClockRate:   8000,
+				SSRC: func() *uint32 {
+					v := uint32(0x9dbb7812)
+					return &v
+				}(),
+				InitialSequenceNumber: func() *uint16 {
+					v := uint16(0x44ed)
+					return &v
+				}(),
+				InitialTimestamp: func() *uint32 {
+					v := uint32(0x88776655)
+					return &v
+				}(),
+			}
+			e.Init()
+
+			pkt, err := e.Encode(ca.params, ca.pts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkt, pkt)
+		})
+	}
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 13,
+		ClockRate:   8000,
+	}
+	e.Init()
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+	require.NotEqual(t, nil, e.InitialTimestamp)
+}
@@ -0,0 +1,11 @@
+// Package rtpcn contains a RTP/CN (comfort noise) decoder and encoder.
+package rtpcn
+
+// Parameters are comfort noise parameters.
+type Parameters struct {
+	// noise level, expressed in -dBov (0-127).
+	NoiseLevel uint8
+
+	// quantized reflection coefficients that describe the spectrum of the noise (optional).
+	ReflectionCoefficients []uint8
+}
@@ -0,0 +1,42 @@
+package rtpeac3
+
+import (
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/internal/rtpac3common"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = rtpac3common.ErrMorePacketsNeeded
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented frame and we didn't received anything before.
+// It's normal to receive this when we are decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = rtpac3common.ErrNonStartingPacketAndNoPrevious
+
+// Decoder is a RTP/E-AC-3 decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4598
+type Decoder struct {
+	// sample rate of input packets.
+	SampleRate int
+
+	d *rtpac3common.Decoder
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.d = &rtpac3common.Decoder{
+		Codec:      codec,
+		SampleRate: d.SampleRate,
+	}
+	d.d.Init()
+}
+
+// Decode decodes E-AC-3 frames from a RTP/E-AC-3 packet.
+// It returns the frames and the PTS of the first frame.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, time.Duration, error) {
+	return d.d.Decode(pkt)
+}
@@ -0,0 +1,40 @@
+// Package rtpeac3 contains a RTP/E-AC-3 decoder and encoder.
+package rtpeac3
+
+import (
+	"time"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/ac3"
+	"github.com/aler9/gortsplib/v2/pkg/formatdecenc/internal/rtpac3common"
+)
+
+// frame types.
+const (
+	frameTypeInitialFragment    = 1
+	frameTypeNonInitialFragment = 2
+)
+
+var codec = rtpac3common.Codec{
+	ParseFrameHeader: func(frame []byte) (int, time.Duration, error) {
+		var h ac3.EAC3SyncFrameHeader
+		err := h.Unmarshal(frame)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		// dependent substreams and additional independent substreams
+		// share the timestamp of the independent substream 0
+		if h.StreamType == 1 || h.SubstreamID != 0 {
+			return h.FrameLen(), 0, nil
+		}
+
+		return h.FrameLen(), time.Duration(h.SampleCount()) * time.Second / time.Duration(h.SampleRate), nil
+	},
+	InitialFragmentType: func(int, int) uint8 {
+		return frameTypeInitialFragment
+	},
+	IsInitialFragment: func(ft uint8) bool {
+		return ft == frameTypeInitialFragment
+	},
+	NonInitialFragmentType: frameTypeNonInitialFragment,
+}
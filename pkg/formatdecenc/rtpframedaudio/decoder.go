@@ -0,0 +1,66 @@
+package rtpframedaudio
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// Decoder is a RTP/framed audio decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3551
+type Decoder struct {
+	// sample rate of input packets.
+	SampleRate int
+
+	// size of frames.
+	FrameSize int
+
+	// size of silence insertion descriptor frames (optional).
+	// These frames can be placed at the end of a packet only.
+	SIDFrameSize int
+
+	timeDecoder *rtptimedec.Decoder
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(d.SampleRate)
+}
+
+// Decode decodes frames from a RTP packet.
+// It returns the frames and the PTS of the first frame.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, time.Duration, error) {
+	payload := pkt.Payload
+
+	if len(payload) == 0 {
+		return nil, 0, fmt.Errorf("payload is empty")
+	}
+
+	var sid []byte
+
+	if d.SIDFrameSize != 0 && (len(payload)%d.FrameSize) == d.SIDFrameSize {
+		sid = payload[len(payload)-d.SIDFrameSize:]
+		payload = payload[:len(payload)-d.SIDFrameSize]
+	}
+
+	if (len(payload) % d.FrameSize) != 0 {
+		return nil, 0, fmt.Errorf("payload size (%d) is not a multiple of the frame size (%d)",
+			len(pkt.Payload), d.FrameSize)
+	}
+
+	n := len(payload) / d.FrameSize
+	frames := make([][]byte, n, n+1)
+
+	for i := range frames {
+		frames[i] = payload[i*d.FrameSize : (i+1)*d.FrameSize]
+	}
+
+	if sid != nil {
+		frames = append(frames, sid)
+	}
+
+	return frames, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
@@ -0,0 +1,144 @@
+package rtpframedaudio
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion = 2
+)
+
+func randUint32() uint32 {
+	var b [4]byte
+	rand.Read(b[:])
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// Encoder is a RTP/framed audio encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3551
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// sample rate of packets.
+	SampleRate int
+
+	// size of frames.
+	FrameSize int
+
+	// number of samples contained in each frame.
+	SamplesPerFrame int
+
+	// size of silence insertion descriptor frames (optional).
+	// These frames are always placed at the end of a packet.
+	SIDFrameSize int
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// initial timestamp of packets (optional).
+	// It defaults to a random value.
+	InitialTimestamp *uint32
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() {
+	if e.SSRC == nil {
+		v := randUint32()
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v := uint16(randUint32())
+		e.InitialSequenceNumber = &v
+	}
+	if e.InitialTimestamp == nil {
+		v := randUint32()
+		e.InitialTimestamp = &v
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+}
+
+func (e *Encoder) encodeTimestamp(ts time.Duration) uint32 {
+	return *e.InitialTimestamp + uint32(ts.Seconds()*float64(e.SampleRate))
+}
+
+// Encode encodes frames into RTP packets.
+// Frames are grouped into packets as long as they fit into PayloadMaxSize.
+func (e *Encoder) Encode(frames [][]byte, pts time.Duration) ([]*rtp.Packet, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("no frames provided")
+	}
+
+	if e.FrameSize > e.PayloadMaxSize {
+		return nil, fmt.Errorf("frame is too big")
+	}
+
+	var rets []*rtp.Packet
+	var payload []byte
+	frameCount := 0
+	ts := e.encodeTimestamp(pts)
+
+	writePacket := func() {
+		rets = append(rets, &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: e.sequenceNumber,
+				Timestamp:      ts,
+				SSRC:           *e.SSRC,
+				Marker:         false,
+			},
+			Payload: payload,
+		})
+		e.sequenceNumber++
+		ts += uint32(frameCount * e.SamplesPerFrame)
+		payload = nil
+		frameCount = 0
+	}
+
+	for _, frame := range frames {
+		isSID := e.SIDFrameSize != 0 && len(frame) == e.SIDFrameSize
+
+		if len(frame) != e.FrameSize && !isSID {
+			return nil, fmt.Errorf("frame size (%d) is different than the expected one (%d)",
+				len(frame), e.FrameSize)
+		}
+
+		if (len(payload) + len(frame)) > e.PayloadMaxSize {
+			writePacket()
+		}
+
+		payload = append(payload, frame...)
+		frameCount++
+
+		// a SID frame terminates the packet
+		if isSID {
+			writePacket()
+		}
+	}
+
+	if payload != nil {
+		writePacket()
+	}
+
+	return rets, nil
+}
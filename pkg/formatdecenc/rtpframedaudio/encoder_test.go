@@ -0,0 +1,172 @@
+package rtpframedaudio
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func repeatFrames(frame []byte, n int) [][]byte {
+	ret := make([][]byte, n)
+	for i := range ret {
+		ret[i] = frame
+	}
+	return ret
+}
+
+var cases = []struct {
+	name   string
+	frames [][]byte
+	pts    time.Duration
+	pkts   []*rtp.Packet
+}{
+	{
+		"single",
+		[][]byte{
+			{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a},
+			{0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14},
+		},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    18,
+					SequenceNumber: 17645,
+					Timestamp:      2289526557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{
+					0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a,
+					0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14,
+				},
+			},
+		},
+	},
+	{
+		"sid",
+		[][]byte{
+			{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a},
+			{0x0b, 0x0c},
+			{0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16},
+		},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    18,
+					SequenceNumber: 17645,
+					Timestamp:      2289526557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{
+					0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a,
+					0x0b, 0x0c,
+				},
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    18,
+					SequenceNumber: 17646,
+					Timestamp:      2289526717,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16},
+			},
+		},
+	},
+	{
+		"split",
+		repeatFrames([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a}, 147),
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    18,
+					SequenceNumber: 17645,
+					Timestamp:      2289526557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a}, 146),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    18,
+					SequenceNumber: 17646,
+					Timestamp:      2289538237,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a},
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType:     18,
+				SampleRate:      8000,
+				FrameSize:       10,
+				SamplesPerFrame: 80,
+				SIDFrameSize:    2,
+				SSRC: func() *uint32 {
+					v := uint32(0x9dbb7812)
+					return &v
+				}(),
+				InitialSequenceNumber: func() *uint16 {
+					v := uint16(0x44ed)
+					return &v
+				}(),
+				InitialTimestamp: func() *uint32 {
+					v := uint32(0x88776655)
+					return &v
+				}(),
+			}
+			e.Init()
+
+			pkts, err := e.Encode(ca.frames, ca.pts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType:     18,
+		SampleRate:      8000,
+		FrameSize:       10,
+		SamplesPerFrame: 80,
+	}
+	e.Init()
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+	require.NotEqual(t, nil, e.InitialTimestamp)
+}
+
+func TestEncodeErrors(t *testing.T) {
+	e := &Encoder{
+		PayloadType:     18,
+		SampleRate:      8000,
+		FrameSize:       10,
+		SamplesPerFrame: 80,
+	}
+	e.Init()
+
+	_, err := e.Encode([][]byte{{0x01, 0x02}}, 0)
+	require.EqualError(t, err, "frame size (2) is different than the expected one (10)")
+}
@@ -0,0 +1,3 @@
+// Package rtpframedaudio contains a RTP decoder and encoder for audio codecs
+// whose frames have a constant size (G729, GSM, iLBC).
+package rtpframedaudio
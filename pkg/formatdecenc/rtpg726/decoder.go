@@ -0,0 +1,39 @@
+package rtpg726
+
+import (
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// Decoder is a RTP/G726 decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3551
+type Decoder struct {
+	// bit rate, in kbit/s (16, 24, 32 or 40).
+	BitRate int
+
+	// whether code words of input packets are packed starting from
+	// the most significant bit (AAL2 packing).
+	BigEndian bool
+
+	timeDecoder *rtptimedec.Decoder
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(8000)
+}
+
+// Decode decodes code words from a RTP/G726 packet.
+// Code words are always returned with the RFC3551 packing,
+// starting from the least significant bit.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([]byte, time.Duration, error) {
+	frame := pkt.Payload
+	if d.BigEndian {
+		frame = repack(frame, d.BitRate, false)
+	}
+
+	return frame, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
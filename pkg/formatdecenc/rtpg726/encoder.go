@@ -0,0 +1,105 @@
+package rtpg726
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion = 2
+)
+
+func randUint32() uint32 {
+	var b [4]byte
+	rand.Read(b[:])
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// Encoder is a RTP/G726 encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc3551
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// bit rate, in kbit/s (16, 24, 32 or 40).
+	BitRate int
+
+	// whether code words of output packets are packed starting from
+	// the most significant bit (AAL2 packing).
+	BigEndian bool
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// initial timestamp of packets (optional).
+	// It defaults to a random value.
+	InitialTimestamp *uint32
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() {
+	if e.SSRC == nil {
+		v := randUint32()
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v := uint16(randUint32())
+		e.InitialSequenceNumber = &v
+	}
+	if e.InitialTimestamp == nil {
+		v := randUint32()
+		e.InitialTimestamp = &v
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+}
+
+func (e *Encoder) encodeTimestamp(ts time.Duration) uint32 {
+	return *e.InitialTimestamp + uint32(ts.Seconds()*8000)
+}
+
+// Encode encodes code words into a RTP/G726 packet.
+// Code words must be provided with the RFC3551 packing,
+// starting from the least significant bit.
+func (e *Encoder) Encode(frame []byte, pts time.Duration) (*rtp.Packet, error) {
+	if len(frame) > e.PayloadMaxSize {
+		return nil, fmt.Errorf("frame is too big")
+	}
+
+	if e.BigEndian {
+		frame = repack(frame, e.BitRate, true)
+	}
+
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    e.PayloadType,
+			SequenceNumber: e.sequenceNumber,
+			Timestamp:      e.encodeTimestamp(pts),
+			SSRC:           *e.SSRC,
+			Marker:         false,
+		},
+		Payload: frame,
+	}
+
+	e.sequenceNumber++
+
+	return pkt, nil
+}
@@ -0,0 +1,103 @@
+package rtpg726
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+var cases = []struct {
+	name      string
+	bitRate   int
+	bigEndian bool
+	frame     []byte
+	pts       time.Duration
+	pkt       *rtp.Packet
+}{
+	{
+		"32 kbit/s",
+		32,
+		false,
+		[]byte{0x01, 0x02, 0x03, 0x04},
+		25 * time.Millisecond,
+		&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         false,
+				PayloadType:    96,
+				SequenceNumber: 17645,
+				Timestamp:      2289526557,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: []byte{0x01, 0x02, 0x03, 0x04},
+		},
+	},
+	{
+		"32 kbit/s big endian",
+		32,
+		true,
+		[]byte{0x01, 0x02, 0x03, 0x04},
+		25 * time.Millisecond,
+		&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         false,
+				PayloadType:    96,
+				SequenceNumber: 17645,
+				Timestamp:      2289526557,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: []byte{0x10, 0x20, 0x30, 0x40},
+		},
+	},
+	{
+		"24 kbit/s big endian",
+		24,
+		true,
+		// code words 1, 2, 3, 4, 5, 6, 7, 0
+		[]byte{0xd1, 0x58, 0x1f},
+		25 * time.Millisecond,
+		&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         false,
+				PayloadType:    96,
+				SequenceNumber: 17645,
+				Timestamp:      2289526557,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: []byte{0x29, 0xcb, 0xb8},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType: 96,
+				BitRate:     ca.bitRate,
+				BigEndian:   ca.bigEndian,
+				SSRC: func() *uint32 {
+					v := uint32(0x9dbb7812)
+					return &v
+				}(),
+				InitialSequenceNumber: func() *uint16 {
+					v := uint16(0x44ed)
+					return &v
+				}(),
+				InitialTimestamp: func() *uint32 {
+					v := uint32(0x88776655)
+					return &v
+				}(),
+			}
+			e.Init()
+
+			pkt, err := e.Encode(ca.frame, ca.pts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkt, pkt)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Package rtpg726 contains a RTP/G726 decoder and encoder.
+package rtpg726
+
+// repack converts code words between the RFC3551 packing,
+// that places the first code word in the least significant bits of the first byte,
+// and the AAL2 packing, that places it in the most significant ones.
+func repack(buf []byte, bitRate int, toBigEndian bool) []byte {
+	bitsPerSample := bitRate / 8
+	n := len(buf) * 8 / bitsPerSample
+	out := make([]byte, len(buf))
+
+	for i := 0; i < n; i++ {
+		pos := i * bitsPerSample
+		var v uint8
+
+		if toBigEndian {
+			for j := 0; j < bitsPerSample; j++ {
+				p := pos + j
+				v |= ((buf[p/8] >> (p % 8)) & 0x01) << j
+			}
+			for j := 0; j < bitsPerSample; j++ {
+				p := pos + j
+				out[p/8] |= ((v >> (bitsPerSample - 1 - j)) & 0x01) << (7 - p%8)
+			}
+		} else {
+			for j := 0; j < bitsPerSample; j++ {
+				p := pos + j
+				v = v<<1 | ((buf[p/8] >> (7 - p%8)) & 0x01)
+			}
+			for j := 0; j < bitsPerSample; j++ {
+				p := pos + j
+				out[p/8] |= ((v >> j) & 0x01) << (p % 8)
+			}
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,124 @@
+package rtph263
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h263"
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented frame and we didn't received anything before.
+// It's normal to receive this when we are decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+// Decoder is a RTP/H263 decoder.
+// Packets in mode A, B and C are supported.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2190
+type Decoder struct {
+	timeDecoder         *rtptimedec.Decoder
+	firstPacketReceived bool
+	frame               []byte
+	frameTimestamp      uint32
+	frameEBIT           uint8
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(rtpClockRate)
+}
+
+func (d *Decoder) resetFragments() {
+	d.frame = nil
+	d.frameEBIT = 0
+}
+
+// Decode decodes a H263 frame from RTP packets.
+// It returns the frame and its PTS.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([]byte, time.Duration, error) {
+	if len(pkt.Payload) < 4 {
+		d.resetFragments()
+		return nil, 0, fmt.Errorf("payload is too short")
+	}
+
+	var headerLen int
+	switch {
+	case (pkt.Payload[0] & 0x80) == 0: // mode A
+		headerLen = 4
+
+	case (pkt.Payload[0] & 0x40) == 0: // mode B
+		headerLen = 8
+
+	default: // mode C
+		headerLen = 12
+	}
+
+	if len(pkt.Payload) < headerLen {
+		d.resetFragments()
+		return nil, 0, fmt.Errorf("payload is too short")
+	}
+
+	sbit := (pkt.Payload[0] >> 3) & 0x07
+	ebit := pkt.Payload[0] & 0x07
+	payload := pkt.Payload[headerLen:]
+
+	isFrameStart := sbit == 0 && h263.IsPictureStart(payload)
+
+	if d.frame == nil {
+		if !isFrameStart {
+			if !d.firstPacketReceived {
+				return nil, 0, ErrNonStartingPacketAndNoPrevious
+			}
+
+			return nil, 0, fmt.Errorf("received a non-starting fragment")
+		}
+
+		d.firstPacketReceived = true
+		d.frameTimestamp = pkt.Timestamp
+	} else if pkt.Timestamp != d.frameTimestamp {
+		// a packet with the marker flag has been lost
+		d.resetFragments()
+
+		if !isFrameStart {
+			return nil, 0, fmt.Errorf("received a non-starting fragment")
+		}
+
+		d.frameTimestamp = pkt.Timestamp
+	}
+
+	if d.frame != nil && (d.frameEBIT+sbit) != 0 {
+		if (d.frameEBIT+sbit) != 8 || len(payload) == 0 {
+			d.resetFragments()
+			return nil, 0, fmt.Errorf("SBIT and EBIT of consecutive packets do not match")
+		}
+
+		// merge the byte that is shared between the previous and the current packet
+		d.frame[len(d.frame)-1] |= payload[0] & (0xFF >> sbit)
+		payload = payload[1:]
+	}
+
+	d.frame = append(d.frame, payload...)
+
+	if ebit != 0 && len(d.frame) != 0 {
+		d.frame[len(d.frame)-1] &= 0xFF << ebit
+	}
+	d.frameEBIT = ebit
+
+	if !pkt.Marker {
+		return nil, 0, ErrMorePacketsNeeded
+	}
+
+	frame := d.frame
+	d.resetFragments()
+
+	return frame, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
@@ -0,0 +1,120 @@
+//go:build go1.18
+// +build go1.18
+
+package rtph263
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{}
+			d.Init()
+
+			// send an initial packet downstream
+			// in order to compute the right timestamp,
+			// that is relative to the initial packet
+			pkt := rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    34,
+					SequenceNumber: 17645,
+					Timestamp:      2289526357,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes([]byte{0x00, 0x50, 0x00, 0x00}, testPictureHeader),
+			}
+			_, _, err := d.Decode(&pkt)
+			require.NoError(t, err)
+
+			var frame []byte
+
+			for _, pkt := range ca.pkts {
+				var pts time.Duration
+				frame, pts, err = d.Decode(pkt)
+				if err == ErrMorePacketsNeeded {
+					continue
+				}
+
+				require.NoError(t, err)
+				require.Equal(t, ca.pts, pts)
+			}
+
+			require.Equal(t, ca.frame, frame)
+		})
+	}
+}
+
+func TestDecodeModeBSharedByte(t *testing.T) {
+	d := &Decoder{}
+	d.Init()
+
+	_, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      false,
+			PayloadType: 34,
+		},
+		Payload: mergeBytes(
+			[]byte{0x83, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // F=1, P=0, SBIT=0, EBIT=3
+			testPictureHeader,
+			[]byte{0xaf},
+		),
+	})
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	frame, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      true,
+			PayloadType: 34,
+		},
+		Payload: []byte{
+			0xa8, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // F=1, P=0, SBIT=5, EBIT=0
+			0xf3, 0xcd,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, mergeBytes(testPictureHeader, []byte{0xab, 0xcd}), frame)
+}
+
+func TestDecodeNonStarting(t *testing.T) {
+	d := &Decoder{}
+	d.Init()
+
+	_, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      true,
+			PayloadType: 34,
+		},
+		Payload: []byte{0x00, 0x50, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04},
+	})
+	require.Equal(t, ErrNonStartingPacketAndNoPrevious, err)
+}
+
+func FuzzDecoderUnmarshal(f *testing.F) {
+	d := &Decoder{}
+	d.Init()
+
+	f.Fuzz(func(t *testing.T, b []byte, m bool) {
+		d.Decode(&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         m,
+				PayloadType:    34,
+				SequenceNumber: 17645,
+				Timestamp:      2289527317,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: b,
+		})
+	})
+}
@@ -0,0 +1,162 @@
+package rtph263
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h263"
+)
+
+const (
+	rtpVersion = 2
+)
+
+func randUint32() uint32 {
+	var b [4]byte
+	rand.Read(b[:])
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+func boolToUint8(v bool) uint8 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// Encoder is a RTP/H263 encoder.
+// Packets are generated in mode A, and are split at picture or GOB boundaries.
+// GOBs that do not fit into a single packet are split at byte boundaries.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2190
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// initial timestamp of packets (optional).
+	// It defaults to a random value.
+	InitialTimestamp *uint32
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() {
+	if e.SSRC == nil {
+		v := randUint32()
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v := uint16(randUint32())
+		e.InitialSequenceNumber = &v
+	}
+	if e.InitialTimestamp == nil {
+		v := randUint32()
+		e.InitialTimestamp = &v
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+}
+
+func (e *Encoder) encodeTimestamp(ts time.Duration) uint32 {
+	return *e.InitialTimestamp + uint32(ts.Seconds()*rtpClockRate)
+}
+
+// Encode encodes a H263 frame into RTP/H263 packets.
+// The frame must begin with a picture start code.
+func (e *Encoder) Encode(frame []byte, pts time.Duration) ([]*rtp.Packet, error) {
+	var ph h263.PictureHeader
+	err := ph.Unmarshal(frame)
+	if err != nil {
+		return nil, err
+	}
+
+	if ph.SourceFormat == h263.SourceFormatExtended {
+		return nil, fmt.Errorf("pictures with an extended source format are not supported by this payload format")
+	}
+
+	// mode A header
+	header := []byte{
+		0x00,
+		uint8(ph.SourceFormat)<<5 |
+			boolToUint8(ph.Inter)<<4 |
+			boolToUint8(ph.UnrestrictedMotionVector)<<3 |
+			boolToUint8(ph.SyntaxBasedArithmeticCoding)<<2 |
+			boolToUint8(ph.AdvancedPrediction)<<1,
+		0x00,
+		0x00,
+	}
+
+	maxFragmentSize := e.PayloadMaxSize - len(header)
+
+	var fragments [][]byte
+	start := 0
+
+	for start < len(frame) {
+		end := start + maxFragmentSize
+
+		if end >= len(frame) {
+			end = len(frame)
+		} else {
+			// split at the last GOB that fits into the packet
+			split := -1
+			for pos := start + 1; ; {
+				pos = h263.FindStartCode(frame, pos)
+				if pos < 0 || pos > end {
+					break
+				}
+				split = pos
+				pos++
+			}
+
+			if split > 0 {
+				end = split
+			}
+		}
+
+		fragments = append(fragments, frame[start:end])
+		start = end
+	}
+
+	ret := make([]*rtp.Packet, len(fragments))
+	ts := e.encodeTimestamp(pts)
+
+	for i, fragment := range fragments {
+		payload := make([]byte, len(header)+len(fragment))
+		copy(payload, header)
+		copy(payload[len(header):], fragment)
+
+		ret[i] = &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: e.sequenceNumber,
+				Timestamp:      ts,
+				SSRC:           *e.SSRC,
+				Marker:         i == (len(fragments) - 1),
+			},
+			Payload: payload,
+		}
+
+		e.sequenceNumber++
+	}
+
+	return ret, nil
+}
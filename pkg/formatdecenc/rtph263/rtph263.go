@@ -0,0 +1,6 @@
+// Package rtph263 contains a RTP/H263 decoder and encoder.
+package rtph263
+
+const (
+	rtpClockRate = 90000 // H263 always uses 90khz
+)
@@ -0,0 +1,100 @@
+package rtph263plus
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented frame and we didn't received anything before.
+// It's normal to receive this when we are decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+// Decoder is a RTP/H263+ decoder.
+// Video redundancy coding (VRC) information and extra picture headers are skipped.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4629
+type Decoder struct {
+	timeDecoder         *rtptimedec.Decoder
+	firstPacketReceived bool
+	frame               []byte
+	frameTimestamp      uint32
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(rtpClockRate)
+}
+
+// Decode decodes a H263+ frame from RTP packets.
+// It returns the frame and its PTS.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([]byte, time.Duration, error) {
+	if len(pkt.Payload) < 2 {
+		d.frame = nil
+		return nil, 0, fmt.Errorf("payload is too short")
+	}
+
+	p := (pkt.Payload[0] & 0x04) != 0
+	v := (pkt.Payload[0] & 0x02) != 0
+	plen := int(pkt.Payload[0]&0x01)<<5 | int(pkt.Payload[1]>>3)
+
+	headerLen := 2 + plen
+	if v {
+		headerLen++
+	}
+
+	if len(pkt.Payload) < headerLen {
+		d.frame = nil
+		return nil, 0, fmt.Errorf("payload is too short")
+	}
+
+	payload := pkt.Payload[headerLen:]
+
+	// when P is set, the first two bytes of the start code are omitted
+	isFrameStart := p && len(payload) != 0 && (payload[0]&0xFC) == 0x80
+
+	if d.frame == nil {
+		if !isFrameStart {
+			if !d.firstPacketReceived {
+				return nil, 0, ErrNonStartingPacketAndNoPrevious
+			}
+
+			return nil, 0, fmt.Errorf("received a non-starting fragment")
+		}
+
+		d.firstPacketReceived = true
+		d.frameTimestamp = pkt.Timestamp
+	} else if pkt.Timestamp != d.frameTimestamp {
+		// a packet with the marker flag has been lost
+		d.frame = nil
+
+		if !isFrameStart {
+			return nil, 0, fmt.Errorf("received a non-starting fragment")
+		}
+
+		d.frameTimestamp = pkt.Timestamp
+	}
+
+	if p {
+		d.frame = append(d.frame, 0x00, 0x00)
+	}
+	d.frame = append(d.frame, payload...)
+
+	if !pkt.Marker {
+		return nil, 0, ErrMorePacketsNeeded
+	}
+
+	frame := d.frame
+	d.frame = nil
+
+	return frame, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
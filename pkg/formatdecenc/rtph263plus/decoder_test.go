@@ -0,0 +1,108 @@
+//go:build go1.18
+// +build go1.18
+
+package rtph263plus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{}
+			d.Init()
+
+			// send an initial packet downstream
+			// in order to compute the right timestamp,
+			// that is relative to the initial packet
+			pkt := rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289526357,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x04, 0x00, 0x80, 0x02, 0x1c, 0x0a},
+			}
+			_, _, err := d.Decode(&pkt)
+			require.NoError(t, err)
+
+			var frame []byte
+
+			for _, pkt := range ca.pkts {
+				var pts time.Duration
+				frame, pts, err = d.Decode(pkt)
+				if err == ErrMorePacketsNeeded {
+					continue
+				}
+
+				require.NoError(t, err)
+				require.Equal(t, ca.pts, pts)
+			}
+
+			require.Equal(t, ca.frame, frame)
+		})
+	}
+}
+
+func TestDecodeVRCAndExtraPictureHeader(t *testing.T) {
+	d := &Decoder{}
+	d.Init()
+
+	frame, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      true,
+			PayloadType: 96,
+		},
+		Payload: []byte{
+			0x06, 0x18, // P=1, V=1, PLEN=3, PEBIT=0
+			0x20,             // VRC
+			0xaa, 0xbb, 0xcc, // extra picture header
+			0x80, 0x02, 0x1c, 0x0a, 0x01, 0x02,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x00, 0x00, 0x80, 0x02, 0x1c, 0x0a, 0x01, 0x02}, frame)
+}
+
+func TestDecodeNonStarting(t *testing.T) {
+	d := &Decoder{}
+	d.Init()
+
+	_, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      true,
+			PayloadType: 96,
+		},
+		Payload: []byte{0x00, 0x00, 0x01, 0x02, 0x03, 0x04},
+	})
+	require.Equal(t, ErrNonStartingPacketAndNoPrevious, err)
+}
+
+func FuzzDecoderUnmarshal(f *testing.F) {
+	d := &Decoder{}
+	d.Init()
+
+	f.Fuzz(func(t *testing.T, b []byte, m bool) {
+		d.Decode(&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         m,
+				PayloadType:    96,
+				SequenceNumber: 17645,
+				Timestamp:      2289527317,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: b,
+		})
+	})
+}
@@ -0,0 +1,6 @@
+// Package rtph263plus contains a RTP/H263+ (H263-1998 and H263-2000) decoder and encoder.
+package rtph263plus
+
+const (
+	rtpClockRate = 90000 // H263+ always uses 90khz
+)
@@ -0,0 +1,6 @@
+// Package rtph266 contains a RTP/H266 decoder and encoder.
+package rtph266
+
+const (
+	rtpClockRate = 90000 // H266 always uses 90khz
+)
@@ -39,6 +39,11 @@ type Encoder struct {
 	// It defaults to 1460.
 	PayloadMaxSize int
 
+	// duration of the audio contained in each packet (optional).
+	// If set, packets contain exactly this amount of audio (for instance, 125µs or 1ms with AES67),
+	// otherwise they are filled up to PayloadMaxSize.
+	PacketTime time.Duration
+
 	BitDepth     int
 	SampleRate   int
 	ChannelCount int
@@ -69,6 +74,17 @@ func (e *Encoder) Init() {
 	e.sequenceNumber = *e.InitialSequenceNumber
 	e.sampleSize = e.BitDepth * e.ChannelCount / 8
 	e.maxPayloadSize = (e.PayloadMaxSize / e.sampleSize) * e.sampleSize
+
+	if e.PacketTime != 0 {
+		samplesPerPacket := int(int64(e.PacketTime) * int64(e.SampleRate) / int64(time.Second))
+		if samplesPerPacket < 1 {
+			samplesPerPacket = 1
+		}
+
+		if (samplesPerPacket * e.sampleSize) < e.maxPayloadSize {
+			e.maxPayloadSize = samplesPerPacket * e.sampleSize
+		}
+	}
 }
 
 func (e *Encoder) encodeTimestamp(ts time.Duration) uint32 {
@@ -91,6 +107,7 @@ func (e *Encoder) Encode(samples []byte, pts time.Duration) ([]*rtp.Packet, erro
 	i := 0
 	pos := 0
 	payloadSize := e.maxPayloadSize
+	ts := e.encodeTimestamp(pts)
 
 	for {
 		if payloadSize > len(samples[pos:]) {
@@ -102,7 +119,7 @@ func (e *Encoder) Encode(samples []byte, pts time.Duration) ([]*rtp.Packet, erro
 				Version:        rtpVersion,
 				PayloadType:    e.PayloadType,
 				SequenceNumber: e.sequenceNumber,
-				Timestamp:      e.encodeTimestamp(pts),
+				Timestamp:      ts,
 				SSRC:           *e.SSRC,
 				Marker:         false,
 			},
@@ -112,7 +129,7 @@ func (e *Encoder) Encode(samples []byte, pts time.Duration) ([]*rtp.Packet, erro
 		e.sequenceNumber++
 		i++
 		pos += payloadSize
-		pts += time.Duration(payloadSize/e.sampleSize) * time.Second / time.Duration(e.SampleRate)
+		ts += uint32(payloadSize / e.sampleSize)
 
 		if pos == slen {
 			break
@@ -94,6 +94,40 @@ func TestEncode(t *testing.T) {
 	}
 }
 
+func TestEncodePacketTime(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+		SSRC: func() *uint32 {
+			v := uint32(0x9dbb7812)
+			return &v
+		}(),
+		InitialSequenceNumber: func() *uint16 {
+			v := uint16(0x44ed)
+			return &v
+		}(),
+		InitialTimestamp: func() *uint32 {
+			v := uint32(0x88776655)
+			return &v
+		}(),
+		PacketTime:   125 * time.Microsecond,
+		BitDepth:     24,
+		SampleRate:   48000,
+		ChannelCount: 2,
+	}
+	e.Init()
+
+	// 1ms of audio
+	pkts, err := e.Encode(bytes.Repeat([]byte{0x41, 0x42, 0x43, 0x44, 0x45, 0x46}, 48), 25*time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, 8, len(pkts))
+
+	for i, pkt := range pkts {
+		require.Equal(t, uint16(17645+i), pkt.SequenceNumber)
+		require.Equal(t, uint32(2289527557+i*6), pkt.Timestamp)
+		require.Equal(t, bytes.Repeat([]byte{0x41, 0x42, 0x43, 0x44, 0x45, 0x46}, 6), pkt.Payload)
+	}
+}
+
 func TestEncodeRandomInitialState(t *testing.T) {
 	e := &Encoder{
 		PayloadType:  96,
@@ -0,0 +1,148 @@
+package rtpmpeg2audio
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg1audio"
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented frame and we didn't received anything before.
+// It's normal to receive this when we are decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+// Decoder is a RTP/MPEG-1/2 audio decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2250
+type Decoder struct {
+	timeDecoder         *rtptimedec.Decoder
+	firstPacketReceived bool
+	fragments           [][]byte
+	fragmentsSize       int
+	fragmentsExpected   int
+	fragmentsTimestamp  uint32
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(rtpClockRate)
+}
+
+func (d *Decoder) resetFragments() {
+	d.fragments = d.fragments[:0]
+	d.fragmentsSize = 0
+}
+
+// Decode decodes MPEG-1/2 audio frames from a RTP/MPEG-1/2 audio packet.
+// It returns the frames and the PTS of the first frame.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, time.Duration, error) {
+	if len(pkt.Payload) < 5 {
+		d.resetFragments()
+		return nil, 0, fmt.Errorf("payload is too short")
+	}
+
+	mbz := uint16(pkt.Payload[0])<<8 | uint16(pkt.Payload[1])
+	if mbz != 0 {
+		d.resetFragments()
+		return nil, 0, fmt.Errorf("invalid MBZ: %v", mbz)
+	}
+
+	offset := int(uint16(pkt.Payload[2])<<8 | uint16(pkt.Payload[3]))
+	payload := pkt.Payload[4:]
+
+	if offset == 0 {
+		d.resetFragments()
+		d.firstPacketReceived = true
+
+		frames, err := d.splitFrames(payload)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if frames == nil {
+			// the packet contains the first fragment of a frame
+			d.fragments = append(d.fragments, payload)
+			d.fragmentsSize = len(payload)
+			d.fragmentsTimestamp = pkt.Timestamp
+			return nil, 0, ErrMorePacketsNeeded
+		}
+
+		return frames, d.timeDecoder.Decode(pkt.Timestamp), nil
+	}
+
+	if len(d.fragments) == 0 {
+		if !d.firstPacketReceived {
+			return nil, 0, ErrNonStartingPacketAndNoPrevious
+		}
+
+		return nil, 0, fmt.Errorf("received a non-starting fragment")
+	}
+
+	if pkt.Timestamp != d.fragmentsTimestamp || offset != d.fragmentsSize {
+		expected := d.fragmentsSize
+		d.resetFragments()
+		return nil, 0, fmt.Errorf("unexpected fragment offset %d (expected %d)", offset, expected)
+	}
+
+	d.fragments = append(d.fragments, payload)
+	d.fragmentsSize += len(payload)
+
+	if d.fragmentsSize < d.fragmentsExpected {
+		return nil, 0, ErrMorePacketsNeeded
+	}
+
+	if d.fragmentsSize > d.fragmentsExpected {
+		d.resetFragments()
+		return nil, 0, fmt.Errorf("fragmented frame is bigger than expected")
+	}
+
+	frame := make([]byte, d.fragmentsSize)
+	pos := 0
+
+	for _, frag := range d.fragments {
+		pos += copy(frame[pos:], frag)
+	}
+
+	d.resetFragments()
+
+	return [][]byte{frame}, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
+
+// splitFrames splits a payload into frames.
+// It returns nil if the payload contains the first fragment of a frame.
+func (d *Decoder) splitFrames(payload []byte) ([][]byte, error) {
+	var frames [][]byte
+
+	for len(payload) > 0 {
+		var h mpeg1audio.FrameHeader
+		err := h.Unmarshal(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		fl := h.FrameLen()
+
+		if len(payload) < fl {
+			if frames != nil {
+				return nil, fmt.Errorf("frame is truncated")
+			}
+
+			d.fragmentsExpected = fl
+			return nil, nil
+		}
+
+		frames = append(frames, payload[:fl])
+		payload = payload[fl:]
+	}
+
+	return frames, nil
+}
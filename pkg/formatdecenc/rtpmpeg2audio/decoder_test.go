@@ -0,0 +1,120 @@
+//go:build go1.18
+// +build go1.18
+
+package rtpmpeg2audio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{}
+			d.Init()
+
+			// send an initial packet downstream
+			// in order to compute the right timestamp,
+			// that is relative to the initial packet
+			pkt := rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    14,
+					SequenceNumber: 17645,
+					Timestamp:      2289526357,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00, 0x00, 0x00},
+					testSmallFrame(0x01),
+				),
+			}
+			_, _, err := d.Decode(&pkt)
+			require.NoError(t, err)
+
+			var frames [][]byte
+
+			for _, pkt := range ca.pkts {
+				var pts time.Duration
+				frames, pts, err = d.Decode(pkt)
+				if err == ErrMorePacketsNeeded {
+					continue
+				}
+
+				require.NoError(t, err)
+				require.Equal(t, ca.pts, pts)
+			}
+
+			require.Equal(t, ca.frames, frames)
+		})
+	}
+}
+
+func TestDecodeNonStarting(t *testing.T) {
+	d := &Decoder{}
+	d.Init()
+
+	_, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			PayloadType: 14,
+		},
+		Payload: mergeBytes(
+			[]byte{0x00, 0x00, 0x05, 0xb0},
+			testBigFrame[1456:],
+		),
+	})
+	require.Equal(t, ErrNonStartingPacketAndNoPrevious, err)
+}
+
+func TestDecodeWrongOffset(t *testing.T) {
+	d := &Decoder{}
+	d.Init()
+
+	_, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			PayloadType: 14,
+		},
+		Payload: mergeBytes(
+			[]byte{0x00, 0x00, 0x00, 0x00},
+			testBigFrame[:1456],
+		),
+	})
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	_, _, err = d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			PayloadType: 14,
+		},
+		Payload: mergeBytes(
+			[]byte{0x00, 0x00, 0x05, 0xaf},
+			testBigFrame[1455:],
+		),
+	})
+	require.EqualError(t, err, "unexpected fragment offset 1455 (expected 1456)")
+}
+
+func FuzzDecoderUnmarshal(f *testing.F) {
+	d := &Decoder{}
+	d.Init()
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		d.Decode(&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				PayloadType:    14,
+				SequenceNumber: 17645,
+				Timestamp:      2289527317,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: b,
+		})
+	})
+}
@@ -0,0 +1,184 @@
+package rtpmpeg2audio
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg1audio"
+)
+
+const (
+	rtpVersion = 2
+)
+
+func randUint32() uint32 {
+	var b [4]byte
+	rand.Read(b[:])
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// Encoder is a RTP/MPEG-1/2 audio encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2250
+type Encoder struct {
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// initial timestamp of packets (optional).
+	// It defaults to a random value.
+	InitialTimestamp *uint32
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() {
+	if e.SSRC == nil {
+		v := randUint32()
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v := uint16(randUint32())
+		e.InitialSequenceNumber = &v
+	}
+	if e.InitialTimestamp == nil {
+		v := randUint32()
+		e.InitialTimestamp = &v
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+}
+
+func (e *Encoder) encodeTimestamp(ts time.Duration) uint32 {
+	return *e.InitialTimestamp + uint32(ts.Seconds()*rtpClockRate)
+}
+
+// Encode encodes MPEG-1/2 audio frames into RTP packets.
+// Frames are aggregated into packets until PayloadMaxSize is reached,
+// while frames that are bigger than PayloadMaxSize are fragmented.
+func (e *Encoder) Encode(frames [][]byte, pts time.Duration) ([]*rtp.Packet, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("no frames provided")
+	}
+
+	var rets []*rtp.Packet
+	var batch [][]byte
+	batchSize := 0
+	batchPTS := pts
+
+	for _, frame := range frames {
+		var h mpeg1audio.FrameHeader
+		err := h.Unmarshal(frame)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(frame) != h.FrameLen() {
+			return nil, fmt.Errorf("frame size (%d) is different than the one in the header (%d)",
+				len(frame), h.FrameLen())
+		}
+
+		if batch != nil && (4+batchSize+len(frame)) > e.PayloadMaxSize {
+			rets = append(rets, e.writeBatch(batch, batchSize, batchPTS))
+			batch = nil
+			batchSize = 0
+			batchPTS = pts
+		}
+
+		if (4 + len(frame)) > e.PayloadMaxSize {
+			rets = append(rets, e.writeFragmented(frame, pts)...)
+			batchPTS = pts + time.Duration(h.SampleCount())*time.Second/time.Duration(h.SampleRate)
+		} else {
+			batch = append(batch, frame)
+			batchSize += len(frame)
+		}
+
+		pts += time.Duration(h.SampleCount()) * time.Second / time.Duration(h.SampleRate)
+	}
+
+	if batch != nil {
+		rets = append(rets, e.writeBatch(batch, batchSize, batchPTS))
+	}
+
+	return rets, nil
+}
+
+func (e *Encoder) writeBatch(frames [][]byte, size int, pts time.Duration) *rtp.Packet {
+	payload := make([]byte, 4+size)
+	pos := 4
+
+	for _, frame := range frames {
+		pos += copy(payload[pos:], frame)
+	}
+
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    14,
+			SequenceNumber: e.sequenceNumber,
+			Timestamp:      e.encodeTimestamp(pts),
+			SSRC:           *e.SSRC,
+			Marker:         false,
+		},
+		Payload: payload,
+	}
+
+	e.sequenceNumber++
+
+	return pkt
+}
+
+func (e *Encoder) writeFragmented(frame []byte, pts time.Duration) []*rtp.Packet {
+	avail := e.PayloadMaxSize - 4
+	n := len(frame) / avail
+	if (len(frame) % avail) != 0 {
+		n++
+	}
+
+	ret := make([]*rtp.Packet, n)
+	ts := e.encodeTimestamp(pts)
+	offset := 0
+
+	for i := range ret {
+		le := len(frame) - offset
+		if le > avail {
+			le = avail
+		}
+
+		payload := make([]byte, 4+le)
+		payload[2] = byte(offset >> 8)
+		payload[3] = byte(offset)
+		copy(payload[4:], frame[offset:offset+le])
+
+		ret[i] = &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    14,
+				SequenceNumber: e.sequenceNumber,
+				Timestamp:      ts,
+				SSRC:           *e.SSRC,
+				Marker:         false,
+			},
+			Payload: payload,
+		}
+
+		e.sequenceNumber++
+		offset += le
+	}
+
+	return ret
+}
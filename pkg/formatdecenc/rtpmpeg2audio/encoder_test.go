@@ -0,0 +1,189 @@
+package rtpmpeg2audio
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func mergeBytes(vals ...[]byte) []byte {
+	size := 0
+	for _, v := range vals {
+		size += len(v)
+	}
+	res := make([]byte, size)
+
+	pos := 0
+	for _, v := range vals {
+		n := copy(res[pos:], v)
+		pos += n
+	}
+
+	return res
+}
+
+// MPEG-1 layer 1, 32kbit/s, 48khz, 384 samples (8ms), 32 bytes.
+func testSmallFrame(b byte) []byte {
+	return append([]byte{0xff, 0xff, 0x14, 0x00}, bytes.Repeat([]byte{b}, 28)...)
+}
+
+// MPEG-1 layer 2, 384kbit/s, 32khz, 1152 samples (36ms), 1728 bytes.
+var testBigFrame = append([]byte{0xff, 0xfd, 0xe8, 0x00}, bytes.Repeat([]byte{0x33}, 1724)...)
+
+var cases = []struct {
+	name   string
+	frames [][]byte
+	pts    time.Duration
+	pkts   []*rtp.Packet
+}{
+	{
+		"single",
+		[][]byte{testSmallFrame(0x01)},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    14,
+					SequenceNumber: 17645,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00, 0x00, 0x00},
+					testSmallFrame(0x01),
+				),
+			},
+		},
+	},
+	{
+		"aggregated",
+		[][]byte{testSmallFrame(0x01), testSmallFrame(0x02)},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    14,
+					SequenceNumber: 17645,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00, 0x00, 0x00},
+					testSmallFrame(0x01),
+					testSmallFrame(0x02),
+				),
+			},
+		},
+	},
+	{
+		"fragmented",
+		[][]byte{testBigFrame},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    14,
+					SequenceNumber: 17645,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00, 0x00, 0x00},
+					testBigFrame[:1456],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    14,
+					SequenceNumber: 17646,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x00, 0x05, 0xb0},
+					testBigFrame[1456:],
+				),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				SSRC: func() *uint32 {
+					v := uint32(0x9dbb7812)
+					return &v
+				}(),
+				InitialSequenceNumber: func() *uint16 {
+					v := uint16(0x44ed)
+					return &v
+				}(),
+				InitialTimestamp: func() *uint32 {
+					v := uint32(0x88776655)
+					return &v
+				}(),
+			}
+			e.Init()
+
+			pkts, err := e.Encode(ca.frames, ca.pts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeMixed(t *testing.T) {
+	e := &Encoder{
+		InitialTimestamp: func() *uint32 {
+			v := uint32(0)
+			return &v
+		}(),
+	}
+	e.Init()
+
+	// frames that follow a fragmented frame are placed into a new packet,
+	// whose timestamp takes into account the duration of previous frames.
+	pkts, err := e.Encode([][]byte{testSmallFrame(0x01), testBigFrame, testSmallFrame(0x02)}, 0)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(pkts))
+	require.Equal(t, uint32(0), pkts[0].Timestamp)
+	require.Equal(t, uint32(720), pkts[1].Timestamp)
+	require.Equal(t, uint32(720), pkts[2].Timestamp)
+	require.InDelta(t, 720+3240, pkts[3].Timestamp, 1)
+	require.Equal(t, mergeBytes([]byte{0x00, 0x00, 0x00, 0x00}, testSmallFrame(0x02)), pkts[3].Payload)
+}
+
+func TestEncodeErrors(t *testing.T) {
+	e := &Encoder{}
+	e.Init()
+
+	_, err := e.Encode(nil, 0)
+	require.EqualError(t, err, "no frames provided")
+
+	_, err = e.Encode([][]byte{{0x01, 0x02, 0x03, 0x04}}, 0)
+	require.EqualError(t, err, "sync word not found: 10")
+
+	_, err = e.Encode([][]byte{testSmallFrame(0x01)[:20]}, 0)
+	require.EqualError(t, err, "frame size (20) is different than the one in the header (32)")
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{}
+	e.Init()
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+	require.NotEqual(t, nil, e.InitialTimestamp)
+}
@@ -0,0 +1,6 @@
+// Package rtpmpeg2audio contains a RTP/MPEG-1/2 audio decoder and encoder.
+package rtpmpeg2audio
+
+const (
+	rtpClockRate = 90000 // MPEG-1/2 audio always uses 90khz
+)
@@ -0,0 +1,107 @@
+package rtpmpeg2video
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented frame and we didn't received anything before.
+// It's normal to receive this when we are decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+// Decoder is a RTP/MPEG-1/2 video decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2250
+type Decoder struct {
+	timeDecoder         *rtptimedec.Decoder
+	firstPacketReceived bool
+	fragments           [][]byte
+	fragmentsSize       int
+	fragmentsTimestamp  uint32
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(rtpClockRate)
+}
+
+// isFrameStart checks whether a payload starts with a sequence header,
+// a GOP header or a picture header, that are always placed
+// at the beginning of the first packet of a frame.
+func isFrameStart(payload []byte) bool {
+	if len(payload) < 4 || payload[0] != 0 || payload[1] != 0 || payload[2] != 1 {
+		return false
+	}
+
+	switch payload[3] {
+	case startCodeSequenceHeader, startCodeGOP, startCodePicture:
+		return true
+	}
+	return false
+}
+
+// Decode decodes a MPEG-1/2 video frame from RTP packets.
+// It returns the frame and its PTS.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([]byte, time.Duration, error) {
+	var h header
+	n, err := h.unmarshal(pkt.Payload)
+	if err != nil {
+		d.fragments = d.fragments[:0] // discard pending fragmented packets
+		d.fragmentsSize = 0
+		return nil, 0, err
+	}
+
+	payload := pkt.Payload[n:]
+
+	if len(d.fragments) == 0 {
+		if !isFrameStart(payload) {
+			if !d.firstPacketReceived {
+				return nil, 0, ErrNonStartingPacketAndNoPrevious
+			}
+
+			return nil, 0, fmt.Errorf("received a non-starting fragment")
+		}
+
+		d.firstPacketReceived = true
+		d.fragmentsTimestamp = pkt.Timestamp
+	} else if pkt.Timestamp != d.fragmentsTimestamp {
+		// a packet with the marker flag has been lost
+		d.fragments = d.fragments[:0]
+		d.fragmentsSize = 0
+
+		if !isFrameStart(payload) {
+			return nil, 0, fmt.Errorf("received a non-starting fragment")
+		}
+
+		d.fragmentsTimestamp = pkt.Timestamp
+	}
+
+	d.fragments = append(d.fragments, payload)
+	d.fragmentsSize += len(payload)
+
+	if !pkt.Marker {
+		return nil, 0, ErrMorePacketsNeeded
+	}
+
+	frame := make([]byte, d.fragmentsSize)
+	pos := 0
+
+	for _, frag := range d.fragments {
+		pos += copy(frame[pos:], frag)
+	}
+
+	d.fragments = d.fragments[:0]
+	d.fragmentsSize = 0
+
+	return frame, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
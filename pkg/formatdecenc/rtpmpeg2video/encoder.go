@@ -0,0 +1,230 @@
+package rtpmpeg2video
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion = 2
+)
+
+func randUint32() uint32 {
+	var b [4]byte
+	rand.Read(b[:])
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// readBits reads n bits from a buffer, starting from the given bit position.
+func readBits(buf []byte, pos int, n int) uint32 {
+	var v uint32
+	for i := 0; i < n; i++ {
+		v = v<<1 | uint32((buf[(pos+i)/8]>>(7-((pos+i)%8)))&0x01)
+	}
+	return v
+}
+
+// Encoder is a RTP/MPEG-1/2 video encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2250
+type Encoder struct {
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// initial timestamp of packets (optional).
+	// It defaults to a random value.
+	InitialTimestamp *uint32
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() {
+	if e.SSRC == nil {
+		v := randUint32()
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v := uint16(randUint32())
+		e.InitialSequenceNumber = &v
+	}
+	if e.InitialTimestamp == nil {
+		v := randUint32()
+		e.InitialTimestamp = &v
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+}
+
+func (e *Encoder) encodeTimestamp(ts time.Duration) uint32 {
+	return *e.InitialTimestamp + uint32(ts.Seconds()*rtpClockRate)
+}
+
+// Encode encodes a MPEG-1/2 video frame into RTP packets.
+// The frame must contain a picture header and its slices,
+// optionally preceded by a sequence header and a GOP header.
+func (e *Encoder) Encode(frame []byte, pts time.Duration) ([]*rtp.Packet, error) {
+	var h header
+	var picturePos int
+	hasSequenceHeader := false
+	hasPicture := false
+
+	// split the frame into the initial headers and slices
+	var slices [][]byte
+	headersEnd := -1
+	pos := findStartCode(frame, 0)
+	if pos != 0 {
+		return nil, fmt.Errorf("frame doesn't start with a start code")
+	}
+
+	for pos >= 0 {
+		next := findStartCode(frame, pos+4)
+		code := frame[pos+3]
+
+		switch {
+		case code == startCodeSequenceHeader:
+			hasSequenceHeader = true
+
+		case code == startCodePicture:
+			hasPicture = true
+			picturePos = pos + 4
+
+		case isSliceStartCode(code):
+			if headersEnd < 0 {
+				headersEnd = pos
+			}
+
+			end := next
+			if end < 0 {
+				end = len(frame)
+			}
+			slices = append(slices, frame[pos:end])
+		}
+
+		pos = next
+	}
+
+	if !hasPicture {
+		return nil, fmt.Errorf("picture header not found")
+	}
+	if len(slices) == 0 {
+		return nil, fmt.Errorf("no slices found")
+	}
+
+	if (len(frame) - picturePos) < 4 {
+		return nil, fmt.Errorf("picture header is too short")
+	}
+
+	h.TemporalReference = uint16(readBits(frame[picturePos:], 0, 10))
+	h.PictureType = uint8(readBits(frame[picturePos:], 10, 3))
+
+	switch h.PictureType {
+	case 2, 3:
+		if (len(frame) - picturePos) < 5 {
+			return nil, fmt.Errorf("picture header is too short")
+		}
+		h.FullPelForwardVector = readBits(frame[picturePos:], 29, 1) == 1
+		h.ForwardFCode = uint8(readBits(frame[picturePos:], 30, 3))
+
+		if h.PictureType == 3 {
+			h.FullPelBackwardVector = readBits(frame[picturePos:], 33, 1) == 1
+			h.BackwardFCode = uint8(readBits(frame[picturePos:], 34, 3))
+		}
+	}
+
+	maxSize := e.PayloadMaxSize - 4
+
+	// split each slice into fragments, in order to fit them into packets.
+	// headers are put at the beginning of the first packet.
+	type fragment struct {
+		data  []byte
+		begin bool
+		end   bool
+	}
+
+	var fragments []fragment
+
+	for i, slice := range slices {
+		if i == 0 {
+			slice = frame[:headersEnd+len(slice)]
+		}
+
+		for len(slice) > maxSize {
+			fragments = append(fragments, fragment{
+				data:  slice[:maxSize],
+				begin: len(fragments) == 0 || fragments[len(fragments)-1].end,
+			})
+			slice = slice[maxSize:]
+		}
+
+		fragments = append(fragments, fragment{
+			data:  slice,
+			begin: len(fragments) == 0 || fragments[len(fragments)-1].end,
+			end:   true,
+		})
+	}
+
+	// aggregate entire slices into packets
+	var payloads []fragment
+
+	for _, frag := range fragments {
+		if len(payloads) != 0 {
+			cur := &payloads[len(payloads)-1]
+
+			if cur.end && frag.begin && frag.end && (len(cur.data)+len(frag.data)) <= maxSize {
+				cur.data = append(cur.data, frag.data...)
+				continue
+			}
+		}
+
+		payloads = append(payloads, fragment{
+			data:  append([]byte(nil), frag.data...),
+			begin: frag.begin,
+			end:   frag.end,
+		})
+	}
+
+	ret := make([]*rtp.Packet, len(payloads))
+	ts := e.encodeTimestamp(pts)
+
+	for i, payload := range payloads {
+		ph := h
+		ph.SequenceHeaderPresent = (i == 0) && hasSequenceHeader
+		ph.BeginningOfSlice = payload.begin
+		ph.EndOfSlice = payload.end
+
+		buf := make([]byte, 4+len(payload.data))
+		ph.marshalTo(buf)
+		copy(buf[4:], payload.data)
+
+		ret[i] = &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    32,
+				SequenceNumber: e.sequenceNumber,
+				Timestamp:      ts,
+				SSRC:           *e.SSRC,
+				Marker:         i == (len(payloads) - 1),
+			},
+			Payload: buf,
+		}
+
+		e.sequenceNumber++
+	}
+
+	return ret, nil
+}
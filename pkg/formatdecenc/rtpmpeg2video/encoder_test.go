@@ -0,0 +1,229 @@
+package rtpmpeg2video
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func mergeBytes(vals ...[]byte) []byte {
+	size := 0
+	for _, v := range vals {
+		size += len(v)
+	}
+	res := make([]byte, size)
+
+	pos := 0
+	for _, v := range vals {
+		n := copy(res[pos:], v)
+		pos += n
+	}
+
+	return res
+}
+
+var (
+	testSequenceHeader = []byte{
+		0x00, 0x00, 0x01, 0xb3, 0x14, 0x00, 0xf0, 0x13,
+		0xff, 0xff, 0xe0, 0x18,
+	}
+
+	testGOPHeader = []byte{0x00, 0x00, 0x01, 0xb8, 0x00, 0x08, 0x00, 0x00}
+
+	// temporal reference 5, type I
+	testIPictureHeader = []byte{0x00, 0x00, 0x01, 0x00, 0x01, 0x4f, 0xff, 0xf8}
+
+	// temporal reference 2, type B
+	testBPictureHeader = []byte{0x00, 0x00, 0x01, 0x00, 0x00, 0x9f, 0xff, 0xfd, 0x28}
+)
+
+func testSlice(num byte, size int) []byte {
+	return append([]byte{0x00, 0x00, 0x01, num}, bytes.Repeat([]byte{0x22}, size-4)...)
+}
+
+var cases = []struct {
+	name  string
+	frame []byte
+	pts   time.Duration
+	pkts  []*rtp.Packet
+}{
+	{
+		"single",
+		mergeBytes(
+			testSequenceHeader,
+			testGOPHeader,
+			testIPictureHeader,
+			testSlice(1, 10),
+		),
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    32,
+					SequenceNumber: 17645,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x05, 0x39, 0x00},
+					testSequenceHeader,
+					testGOPHeader,
+					testIPictureHeader,
+					testSlice(1, 10),
+				),
+			},
+		},
+	},
+	{
+		"aggregated",
+		mergeBytes(
+			testBPictureHeader,
+			testSlice(1, 10),
+			testSlice(2, 10),
+		),
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    32,
+					SequenceNumber: 17645,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x02, 0x1b, 0x5a},
+					testBPictureHeader,
+					testSlice(1, 10),
+					testSlice(2, 10),
+				),
+			},
+		},
+	},
+	{
+		"fragmented",
+		mergeBytes(
+			testIPictureHeader,
+			testSlice(1, 3000),
+			testSlice(2, 10),
+		),
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    32,
+					SequenceNumber: 17645,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x05, 0x11, 0x00},
+					mergeBytes(testIPictureHeader, testSlice(1, 3000))[:1456],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    32,
+					SequenceNumber: 17646,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x05, 0x01, 0x00},
+					mergeBytes(testIPictureHeader, testSlice(1, 3000))[1456:1456*2],
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    32,
+					SequenceNumber: 17647,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x00, 0x05, 0x09, 0x00},
+					mergeBytes(testIPictureHeader, testSlice(1, 3000))[1456*2:],
+					testSlice(2, 10),
+				),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				SSRC: func() *uint32 {
+					v := uint32(0x9dbb7812)
+					return &v
+				}(),
+				InitialSequenceNumber: func() *uint16 {
+					v := uint16(0x44ed)
+					return &v
+				}(),
+				InitialTimestamp: func() *uint32 {
+					v := uint32(0x88776655)
+					return &v
+				}(),
+			}
+			e.Init()
+
+			pkts, err := e.Encode(ca.frame, ca.pts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name  string
+		frame []byte
+		err   string
+	}{
+		{
+			"no start code",
+			[]byte{0x01, 0x02, 0x03, 0x04},
+			"frame doesn't start with a start code",
+		},
+		{
+			"no picture",
+			mergeBytes(testSequenceHeader, testSlice(1, 10)),
+			"picture header not found",
+		},
+		{
+			"no slices",
+			testIPictureHeader,
+			"no slices found",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{}
+			e.Init()
+
+			_, err := e.Encode(ca.frame, 0)
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{}
+	e.Init()
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+	require.NotEqual(t, nil, e.InitialTimestamp)
+}
@@ -0,0 +1,79 @@
+package rtpmpeg2video
+
+import (
+	"fmt"
+)
+
+// header is the MPEG video-specific header.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2250#section-3.4
+type header struct {
+	TemporalReference     uint16
+	ActiveN               bool
+	NewPictureHeader      bool
+	SequenceHeaderPresent bool
+	BeginningOfSlice      bool
+	EndOfSlice            bool
+	PictureType           uint8
+	FullPelBackwardVector bool
+	BackwardFCode         uint8
+	FullPelForwardVector  bool
+	ForwardFCode          uint8
+}
+
+// unmarshal decodes the header and returns its size.
+func (h *header) unmarshal(buf []byte) (int, error) {
+	if len(buf) < 4 {
+		return 0, fmt.Errorf("buffer is too small")
+	}
+
+	mbz := buf[0] >> 3
+	if mbz != 0 {
+		return 0, fmt.Errorf("invalid MBZ bits")
+	}
+
+	t := (buf[0] >> 2) & 0x01
+	h.TemporalReference = uint16(buf[0]&0x03)<<8 | uint16(buf[1])
+	h.ActiveN = (buf[2] >> 7) == 1
+	h.NewPictureHeader = ((buf[2] >> 6) & 0x01) == 1
+	h.SequenceHeaderPresent = ((buf[2] >> 5) & 0x01) == 1
+	h.BeginningOfSlice = ((buf[2] >> 4) & 0x01) == 1
+	h.EndOfSlice = ((buf[2] >> 3) & 0x01) == 1
+	h.PictureType = buf[2] & 0x07
+	h.FullPelBackwardVector = (buf[3] >> 7) == 1
+	h.BackwardFCode = (buf[3] >> 4) & 0x07
+	h.FullPelForwardVector = ((buf[3] >> 3) & 0x01) == 1
+	h.ForwardFCode = buf[3] & 0x07
+
+	// skip the MPEG-2 video-specific header extension
+	if t == 1 {
+		if len(buf) < 8 {
+			return 0, fmt.Errorf("buffer is too small")
+		}
+		return 8, nil
+	}
+
+	return 4, nil
+}
+
+func boolToUint8(v bool) uint8 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// marshalTo encodes the header into a buffer of 4 bytes.
+func (h header) marshalTo(buf []byte) {
+	buf[0] = byte(h.TemporalReference>>8) & 0x03
+	buf[1] = byte(h.TemporalReference)
+	buf[2] = boolToUint8(h.ActiveN)<<7 |
+		boolToUint8(h.NewPictureHeader)<<6 |
+		boolToUint8(h.SequenceHeaderPresent)<<5 |
+		boolToUint8(h.BeginningOfSlice)<<4 |
+		boolToUint8(h.EndOfSlice)<<3 |
+		h.PictureType&0x07
+	buf[3] = boolToUint8(h.FullPelBackwardVector)<<7 |
+		(h.BackwardFCode&0x07)<<4 |
+		boolToUint8(h.FullPelForwardVector)<<3 |
+		h.ForwardFCode&0x07
+}
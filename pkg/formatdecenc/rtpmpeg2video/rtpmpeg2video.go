@@ -0,0 +1,30 @@
+// Package rtpmpeg2video contains a RTP/MPEG-1/2 video decoder and encoder.
+package rtpmpeg2video
+
+const (
+	rtpClockRate = 90000 // MPEG-1/2 video always uses 90khz
+)
+
+// start codes.
+const (
+	startCodePicture        = 0x00
+	startCodeSliceFirst     = 0x01
+	startCodeSliceLast      = 0xAF
+	startCodeSequenceHeader = 0xB3
+	startCodeGOP            = 0xB8
+)
+
+func isSliceStartCode(c byte) bool {
+	return c >= startCodeSliceFirst && c <= startCodeSliceLast
+}
+
+// findStartCode returns the position of the first start code
+// that is found at or after the given position, or -1.
+func findStartCode(buf []byte, pos int) int {
+	for ; pos+3 < len(buf); pos++ {
+		if buf[pos] == 0 && buf[pos+1] == 0 && buf[pos+2] == 1 {
+			return pos
+		}
+	}
+	return -1
+}
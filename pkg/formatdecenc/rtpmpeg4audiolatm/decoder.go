@@ -0,0 +1,176 @@
+package rtpmpeg4audiolatm
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/bits"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// Decoder is a RTP/MPEG-4 audio LATM decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc6416
+type Decoder struct {
+	// sample rate of input packets.
+	SampleRate int
+
+	// whether the StreamMuxConfig is transmitted inside packets.
+	CPresent bool
+
+	// StreamMuxConfig.
+	// It can be nil when CPresent is true, and it is updated
+	// every time a new StreamMuxConfig is received.
+	Config *mpeg4audio.StreamMuxConfig
+
+	timeDecoder        *rtptimedec.Decoder
+	fragments          [][]byte
+	fragmentsSize      int
+	fragmentsTimestamp uint32
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(d.SampleRate)
+}
+
+// Decode decodes AUs from a RTP/MPEG-4 audio LATM packet.
+// It returns the AUs and the PTS of the first AU.
+// The PTS of subsequent AUs can be calculated by adding time.Second*mpeg4audio.SamplesPerAccessUnit/clockRate.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, time.Duration, error) {
+	if len(d.fragments) != 0 && pkt.Timestamp != d.fragmentsTimestamp {
+		// a packet with the marker flag has been lost
+		d.fragments = d.fragments[:0]
+		d.fragmentsSize = 0
+	}
+
+	if len(d.fragments) == 0 {
+		d.fragmentsTimestamp = pkt.Timestamp
+	}
+
+	d.fragments = append(d.fragments, pkt.Payload)
+	d.fragmentsSize += len(pkt.Payload)
+
+	if !pkt.Marker {
+		return nil, 0, ErrMorePacketsNeeded
+	}
+
+	var buf []byte
+	if len(d.fragments) == 1 {
+		buf = d.fragments[0]
+	} else {
+		buf = make([]byte, d.fragmentsSize)
+		n := 0
+		for _, frag := range d.fragments {
+			n += copy(buf[n:], frag)
+		}
+	}
+
+	d.fragments = d.fragments[:0]
+	d.fragmentsSize = 0
+
+	aus, err := d.readAudioMuxElements(buf)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return aus, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
+
+func (d *Decoder) readAudioMuxElements(buf []byte) ([][]byte, error) {
+	var aus [][]byte
+	pos := 0
+	bitLen := len(buf) * 8
+
+	for pos < bitLen {
+		if d.CPresent {
+			useSameStreamMux, err := bits.ReadFlag(buf, &pos)
+			if err != nil {
+				return nil, err
+			}
+
+			if !useSameStreamMux {
+				var conf mpeg4audio.StreamMuxConfig
+				err := conf.UnmarshalBits(buf, &pos)
+				if err != nil {
+					return nil, err
+				}
+				d.Config = &conf
+			}
+		}
+
+		if d.Config == nil {
+			return nil, fmt.Errorf("StreamMuxConfig has not been received yet")
+		}
+
+		if len(d.Config.Programs) != 1 || len(d.Config.Programs[0].Layers) != 1 {
+			return nil, fmt.Errorf("multiple programs or layers are not supported")
+		}
+
+		if d.Config.Programs[0].Layers[0].FrameLengthType != 0 {
+			return nil, fmt.Errorf("frameLengthType %d is not supported",
+				d.Config.Programs[0].Layers[0].FrameLengthType)
+		}
+
+		for i := uint(0); i <= d.Config.NumSubFrames; i++ {
+			// PayloadLengthInfo
+			auLen := 0
+			for {
+				tmp, err := bits.ReadBits(buf, &pos, 8)
+				if err != nil {
+					return nil, err
+				}
+
+				auLen += int(tmp)
+
+				if tmp != 255 {
+					break
+				}
+			}
+
+			if auLen > mpeg4audio.MaxAccessUnitSize {
+				return nil, fmt.Errorf("AU size (%d) is too big (maximum is %d)", auLen, mpeg4audio.MaxAccessUnitSize)
+			}
+
+			// PayloadMux
+			err := bits.HasSpace(buf, pos, auLen*8)
+			if err != nil {
+				return nil, err
+			}
+
+			var au []byte
+			if (pos % 8) == 0 {
+				au = buf[pos/8 : pos/8+auLen]
+				pos += auLen * 8
+			} else {
+				au = make([]byte, auLen)
+				for j := range au {
+					au[j] = byte(bits.ReadBitsUnsafe(buf, &pos, 8))
+				}
+			}
+
+			aus = append(aus, au)
+		}
+
+		if d.Config.OtherDataPresent {
+			err := bits.HasSpace(buf, pos, int(d.Config.OtherDataLenBits))
+			if err != nil {
+				return nil, err
+			}
+			pos += int(d.Config.OtherDataLenBits)
+		}
+
+		// byte alignment
+		if (pos % 8) != 0 {
+			pos += 8 - (pos % 8)
+		}
+	}
+
+	return aus, nil
+}
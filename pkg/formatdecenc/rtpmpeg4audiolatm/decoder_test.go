@@ -0,0 +1,140 @@
+//go:build go1.18
+// +build go1.18
+
+package rtpmpeg4audiolatm
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gortsplib/v2/pkg/bits"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{
+				SampleRate: 48000,
+				Config:     testConfig(ca.numSubFrames),
+			}
+			d.Init()
+
+			// send an initial packet downstream
+			// in order to compute the right timestamp,
+			// that is relative to the initial packet
+			pkt := rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289526357,
+					SSRC:           0x9dbb7812,
+				},
+			}
+			if ca.numSubFrames == 1 {
+				pkt.Payload = []byte{0x01, 0x01, 0x01, 0x01}
+			} else {
+				pkt.Payload = []byte{0x01, 0x01}
+			}
+			_, _, err := d.Decode(&pkt)
+			require.NoError(t, err)
+
+			var aus [][]byte
+
+			for _, pkt := range ca.pkts {
+				addAUs, pts, err := d.Decode(pkt)
+				if err == ErrMorePacketsNeeded {
+					continue
+				}
+
+				require.NoError(t, err)
+				if aus == nil {
+					require.Equal(t, ca.pts, pts)
+				}
+				aus = append(aus, addAUs...)
+			}
+
+			require.Equal(t, ca.aus, aus)
+		})
+	}
+}
+
+func TestDecodeInBandConfig(t *testing.T) {
+	d := &Decoder{
+		SampleRate: 48000,
+		CPresent:   true,
+	}
+	d.Init()
+
+	_, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      true,
+			PayloadType: 96,
+		},
+		Payload: []byte{0x80, 0x01, 0x02},
+	})
+	require.EqualError(t, err, "StreamMuxConfig has not been received yet")
+
+	conf, err := testConfig(0).Marshal()
+	require.NoError(t, err)
+
+	// useSameStreamMux (1 bit), StreamMuxConfig (44 bits), PayloadLengthInfo, PayloadMux
+	payload := make([]byte, 9)
+	pos := 1
+	for i := 0; i < 44; i++ {
+		bits.WriteBits(payload, &pos, uint64(conf[i/8]>>(7-(i%8)))&0x01, 1)
+	}
+	bits.WriteBits(payload, &pos, 2, 8)
+	bits.WriteBits(payload, &pos, 0x01, 8)
+	bits.WriteBits(payload, &pos, 0x02, 8)
+
+	aus, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      true,
+			PayloadType: 96,
+		},
+		Payload: payload,
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x01, 0x02}}, aus)
+	require.Equal(t, testConfig(0), d.Config)
+
+	// useSameStreamMux = 1
+	aus, _, err = d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      true,
+			PayloadType: 96,
+		},
+		Payload: []byte{0x81, 0x01, 0x81, 0x80},
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{{0x03, 0x03}}, aus)
+}
+
+func FuzzDecoderUnmarshal(f *testing.F) {
+	d := &Decoder{
+		SampleRate: 48000,
+		CPresent:   true,
+	}
+	d.Init()
+
+	f.Fuzz(func(t *testing.T, b []byte, m bool) {
+		d.Decode(&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         m,
+				PayloadType:    96,
+				SequenceNumber: 17645,
+				Timestamp:      2289527317,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: b,
+		})
+	})
+}
@@ -0,0 +1,166 @@
+package rtpmpeg4audiolatm
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+)
+
+const (
+	rtpVersion = 2
+)
+
+func randUint32() uint32 {
+	var b [4]byte
+	rand.Read(b[:])
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// Encoder is a RTP/MPEG-4 audio LATM encoder.
+// It produces packets with cpresent=0, that is, the StreamMuxConfig
+// must be transmitted out of band.
+// Specification: https://datatracker.ietf.org/doc/html/rfc6416
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// initial timestamp of packets (optional).
+	// It defaults to a random value.
+	InitialTimestamp *uint32
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	// sample rate of packets.
+	SampleRate int
+
+	// StreamMuxConfig.
+	Config *mpeg4audio.StreamMuxConfig
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() {
+	if e.SSRC == nil {
+		v := randUint32()
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v := uint16(randUint32())
+		e.InitialSequenceNumber = &v
+	}
+	if e.InitialTimestamp == nil {
+		v := randUint32()
+		e.InitialTimestamp = &v
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+}
+
+func (e *Encoder) encodeTimestamp(ts time.Duration) uint32 {
+	return *e.InitialTimestamp + uint32(ts.Seconds()*float64(e.SampleRate))
+}
+
+// Encode encodes AUs into RTP/MPEG-4 audio LATM packets.
+// AUs are grouped into AudioMuxElements, each containing Config.NumSubFrames+1 AUs.
+// Every AudioMuxElement is placed into a single packet, or fragmented
+// into multiple packets if it is bigger than PayloadMaxSize.
+func (e *Encoder) Encode(aus [][]byte, pts time.Duration) ([]*rtp.Packet, error) {
+	auCount := 1
+	if e.Config != nil {
+		auCount = int(e.Config.NumSubFrames) + 1
+	}
+
+	if len(aus) == 0 || (len(aus)%auCount) != 0 {
+		return nil, fmt.Errorf("number of AUs (%d) is not a multiple of %d", len(aus), auCount)
+	}
+
+	var rets []*rtp.Packet
+
+	for i := 0; i < len(aus); i += auCount {
+		rets = append(rets, e.writeElement(marshalAudioMuxElement(aus[i:i+auCount]), pts)...)
+		pts += time.Duration(auCount) * mpeg4audio.SamplesPerAccessUnit * time.Second / time.Duration(e.SampleRate)
+	}
+
+	return rets, nil
+}
+
+// marshalAudioMuxElement encodes an AudioMuxElement with muxConfigPresent = 0.
+func marshalAudioMuxElement(aus [][]byte) []byte {
+	n := 0
+	for _, au := range aus {
+		n += len(au)/255 + 1 + len(au)
+	}
+
+	buf := make([]byte, n)
+	pos := 0
+
+	for _, au := range aus {
+		// PayloadLengthInfo
+		le := len(au)
+		for le >= 255 {
+			buf[pos] = 255
+			pos++
+			le -= 255
+		}
+		buf[pos] = byte(le)
+		pos++
+
+		// PayloadMux
+		pos += copy(buf[pos:], au)
+	}
+
+	return buf
+}
+
+func (e *Encoder) writeElement(element []byte, pts time.Duration) []*rtp.Packet {
+	n := len(element) / e.PayloadMaxSize
+	if (len(element) % e.PayloadMaxSize) != 0 {
+		n++
+	}
+
+	ret := make([]*rtp.Packet, n)
+	ts := e.encodeTimestamp(pts)
+	pos := 0
+
+	for i := range ret {
+		le := len(element) - pos
+		if le > e.PayloadMaxSize {
+			le = e.PayloadMaxSize
+		}
+
+		ret[i] = &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: e.sequenceNumber,
+				Timestamp:      ts,
+				SSRC:           *e.SSRC,
+				Marker:         i == (n - 1),
+			},
+			Payload: element[pos : pos+le],
+		}
+
+		e.sequenceNumber++
+		pos += le
+	}
+
+	return ret
+}
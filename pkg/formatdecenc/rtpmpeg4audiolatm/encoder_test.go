@@ -0,0 +1,210 @@
+package rtpmpeg4audiolatm
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+)
+
+func mergeBytes(vals ...[]byte) []byte {
+	size := 0
+	for _, v := range vals {
+		size += len(v)
+	}
+	res := make([]byte, size)
+
+	pos := 0
+	for _, v := range vals {
+		n := copy(res[pos:], v)
+		pos += n
+	}
+
+	return res
+}
+
+func testConfig(numSubFrames uint) *mpeg4audio.StreamMuxConfig {
+	return &mpeg4audio.StreamMuxConfig{
+		NumSubFrames: numSubFrames,
+		Programs: []*mpeg4audio.StreamMuxConfigProgram{{
+			Layers: []*mpeg4audio.StreamMuxConfigLayer{{
+				AudioSpecificConfig: &mpeg4audio.Config{
+					Type:         mpeg4audio.ObjectTypeAACLC,
+					SampleRate:   48000,
+					ChannelCount: 2,
+				},
+				LatmBufferFullness: 255,
+			}},
+		}},
+	}
+}
+
+var cases = []struct {
+	name         string
+	numSubFrames uint
+	aus          [][]byte
+	pts          time.Duration
+	pkts         []*rtp.Packet
+}{
+	{
+		"single",
+		0,
+		[][]byte{{0x01, 0x02, 0x03, 0x04}},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289527557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x04, 0x01, 0x02, 0x03, 0x04},
+			},
+		},
+	},
+	{
+		"multiple elements",
+		0,
+		[][]byte{{0x01, 0x02}, {0x03, 0x04}},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289527557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x02, 0x01, 0x02},
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					Timestamp:      2289528580,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x02, 0x03, 0x04},
+			},
+		},
+	},
+	{
+		"sub frames",
+		1,
+		[][]byte{{0x01, 0x02}, {0x03, 0x04, 0x05}},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289527557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x02, 0x01, 0x02, 0x03, 0x03, 0x04, 0x05},
+			},
+		},
+	},
+	{
+		"fragmented",
+		0,
+		[][]byte{bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 512)},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289527557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x08},
+					bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 362),
+					[]byte{0x01, 0x02, 0x03},
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					Timestamp:      2289527557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{0x04},
+					bytes.Repeat([]byte{0x01, 0x02, 0x03, 0x04}, 149),
+				),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType: 96,
+				SampleRate:  48000,
+				Config:      testConfig(ca.numSubFrames),
+				SSRC: func() *uint32 {
+					v := uint32(0x9dbb7812)
+					return &v
+				}(),
+				InitialSequenceNumber: func() *uint16 {
+					v := uint16(0x44ed)
+					return &v
+				}(),
+				InitialTimestamp: func() *uint32 {
+					v := uint32(0x88776655)
+					return &v
+				}(),
+			}
+			e.Init()
+
+			pkts, err := e.Encode(ca.aus, ca.pts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeInvalidAUCount(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+		SampleRate:  48000,
+		Config:      testConfig(1),
+	}
+	e.Init()
+
+	_, err := e.Encode([][]byte{{0x01, 0x02}}, 0)
+	require.EqualError(t, err, "number of AUs (1) is not a multiple of 2")
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+		SampleRate:  48000,
+	}
+	e.Init()
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+	require.NotEqual(t, nil, e.InitialTimestamp)
+}
@@ -0,0 +1,2 @@
+// Package rtpmpeg4audiolatm contains a RTP/MPEG-4 audio LATM decoder and encoder.
+package rtpmpeg4audiolatm
@@ -0,0 +1,89 @@
+package rtpmpeg4video
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4video"
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented frame and we didn't received anything before.
+// It's normal to receive this when we are decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+// Decoder is a RTP/MPEG-4 video decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc6416
+type Decoder struct {
+	timeDecoder         *rtptimedec.Decoder
+	firstPacketReceived bool
+	fragments           [][]byte
+	fragmentsSize       int
+	fragmentsTimestamp  uint32
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(rtpClockRate)
+}
+
+// the first packet of a frame always begins with a start code,
+// that can be a visual object sequence, a GOV or a VOP start code.
+func isFrameStart(payload []byte) bool {
+	return mpeg4video.FindStartCode(payload, 0) == 0
+}
+
+// Decode decodes a MPEG-4 video frame from RTP packets.
+// It returns the frame and its PTS.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([]byte, time.Duration, error) {
+	if len(d.fragments) == 0 {
+		if !isFrameStart(pkt.Payload) {
+			if !d.firstPacketReceived {
+				return nil, 0, ErrNonStartingPacketAndNoPrevious
+			}
+
+			return nil, 0, fmt.Errorf("received a non-starting fragment")
+		}
+
+		d.firstPacketReceived = true
+		d.fragmentsTimestamp = pkt.Timestamp
+	} else if pkt.Timestamp != d.fragmentsTimestamp {
+		// a packet with the marker flag has been lost
+		d.fragments = d.fragments[:0]
+		d.fragmentsSize = 0
+
+		if !isFrameStart(pkt.Payload) {
+			return nil, 0, fmt.Errorf("received a non-starting fragment")
+		}
+
+		d.fragmentsTimestamp = pkt.Timestamp
+	}
+
+	d.fragments = append(d.fragments, pkt.Payload)
+	d.fragmentsSize += len(pkt.Payload)
+
+	if !pkt.Marker {
+		return nil, 0, ErrMorePacketsNeeded
+	}
+
+	frame := make([]byte, d.fragmentsSize)
+	pos := 0
+
+	for _, frag := range d.fragments {
+		pos += copy(frame[pos:], frag)
+	}
+
+	d.fragments = d.fragments[:0]
+	d.fragmentsSize = 0
+
+	return frame, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
@@ -0,0 +1,6 @@
+// Package rtpmpeg4video contains a RTP/MPEG-4 video decoder and encoder.
+package rtpmpeg4video
+
+const (
+	rtpClockRate = 90000 // MPEG-4 video always uses 90khz
+)
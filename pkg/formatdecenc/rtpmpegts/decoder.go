@@ -0,0 +1,45 @@
+package rtpmpegts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// Decoder is a RTP/MPEG-TS decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2250
+type Decoder struct {
+	timeDecoder *rtptimedec.Decoder
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(90000)
+}
+
+// Decode decodes MPEG-TS packets from a RTP packet.
+// It returns the MPEG-TS packets and the PTS of the RTP packet.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, time.Duration, error) {
+	plen := len(pkt.Payload)
+	if plen == 0 || (plen%packetSize) != 0 {
+		return nil, 0, fmt.Errorf("payload size (%d) is not a multiple of %d", plen, packetSize)
+	}
+
+	n := plen / packetSize
+	ret := make([][]byte, n)
+
+	for i := 0; i < n; i++ {
+		tsPkt := pkt.Payload[i*packetSize : (i+1)*packetSize]
+
+		if tsPkt[0] != syncByte {
+			return nil, 0, fmt.Errorf("invalid sync byte")
+		}
+
+		ret[i] = tsPkt
+	}
+
+	return ret, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
@@ -0,0 +1,100 @@
+//go:build go1.18
+// +build go1.18
+
+package rtpmpegts
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{}
+			d.Init()
+
+			// send an initial packet downstream
+			// in order to compute the right timestamp,
+			// that is relative to the initial packet
+			pkt := rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    33,
+					SequenceNumber: 17645,
+					Timestamp:      2289526357,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: tsPacket(0),
+			}
+			_, _, err := d.Decode(&pkt)
+			require.NoError(t, err)
+
+			var tsPkts [][]byte
+
+			for _, pkt := range ca.pkts {
+				partial, pts, err := d.Decode(pkt)
+				require.NoError(t, err)
+				require.Equal(t, ca.pts, pts)
+				tsPkts = append(tsPkts, partial...)
+			}
+
+			require.Equal(t, ca.tsPkts, tsPkts)
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name    string
+		payload []byte
+		err     string
+	}{
+		{
+			"invalid size",
+			[]byte{0x47, 0x01, 0x02},
+			"payload size (3) is not a multiple of 188",
+		},
+		{
+			"invalid sync byte",
+			append([]byte{0x48}, make([]byte, 187)...),
+			"invalid sync byte",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{}
+			d.Init()
+
+			_, _, err := d.Decode(&rtp.Packet{
+				Header: rtp.Header{
+					Version:     2,
+					PayloadType: 33,
+				},
+				Payload: ca.payload,
+			})
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func FuzzDecoderUnmarshal(f *testing.F) {
+	d := &Decoder{}
+	d.Init()
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		d.Decode(&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         false,
+				PayloadType:    33,
+				SequenceNumber: 17645,
+				Timestamp:      2289527317,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: b,
+		})
+	})
+}
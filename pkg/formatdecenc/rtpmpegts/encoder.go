@@ -0,0 +1,130 @@
+package rtpmpegts
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion = 2
+)
+
+func randUint32() uint32 {
+	var b [4]byte
+	rand.Read(b[:])
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// Encoder is a RTP/MPEG-TS encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc2250
+type Encoder struct {
+	// payload type of packets.
+	// It defaults to 33.
+	PayloadType uint8
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// initial timestamp of packets (optional).
+	// It defaults to a random value.
+	InitialTimestamp *uint32
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber       uint16
+	maxPacketsPerPayload int
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() {
+	if e.PayloadType == 0 {
+		e.PayloadType = 33
+	}
+	if e.SSRC == nil {
+		v := randUint32()
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v := uint16(randUint32())
+		e.InitialSequenceNumber = &v
+	}
+	if e.InitialTimestamp == nil {
+		v := randUint32()
+		e.InitialTimestamp = &v
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+
+	e.maxPacketsPerPayload = e.PayloadMaxSize / packetSize
+	if e.maxPacketsPerPayload == 0 {
+		e.maxPacketsPerPayload = 1
+	}
+}
+
+func (e *Encoder) encodeTimestamp(ts time.Duration) uint32 {
+	return *e.InitialTimestamp + uint32(ts.Seconds()*90000)
+}
+
+// Encode encodes MPEG-TS packets into RTP packets.
+// Each RTP packet contains as many MPEG-TS packets as allowed by PayloadMaxSize.
+func (e *Encoder) Encode(tsPkts [][]byte, pts time.Duration) ([]*rtp.Packet, error) {
+	if len(tsPkts) == 0 {
+		return nil, fmt.Errorf("no MPEG-TS packets provided")
+	}
+
+	for _, tsPkt := range tsPkts {
+		if len(tsPkt) != packetSize || tsPkt[0] != syncByte {
+			return nil, fmt.Errorf("invalid MPEG-TS packet")
+		}
+	}
+
+	n := len(tsPkts) / e.maxPacketsPerPayload
+	if (len(tsPkts) % e.maxPacketsPerPayload) != 0 {
+		n++
+	}
+
+	ret := make([]*rtp.Packet, n)
+	ts := e.encodeTimestamp(pts)
+
+	for i := range ret {
+		end := (i + 1) * e.maxPacketsPerPayload
+		if end > len(tsPkts) {
+			end = len(tsPkts)
+		}
+		group := tsPkts[i*e.maxPacketsPerPayload : end]
+
+		payload := make([]byte, len(group)*packetSize)
+		for j, tsPkt := range group {
+			copy(payload[j*packetSize:], tsPkt)
+		}
+
+		ret[i] = &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: e.sequenceNumber,
+				Timestamp:      ts,
+				SSRC:           *e.SSRC,
+				Marker:         false,
+			},
+			Payload: payload,
+		}
+
+		e.sequenceNumber++
+	}
+
+	return ret, nil
+}
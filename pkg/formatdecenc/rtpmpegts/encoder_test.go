@@ -0,0 +1,127 @@
+package rtpmpegts
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func tsPacket(b byte) []byte {
+	return append([]byte{0x47, 0x01, 0x00, b}, bytes.Repeat([]byte{b}, 184)...)
+}
+
+func tsPackets(n int) [][]byte {
+	ret := make([][]byte, n)
+	for i := range ret {
+		ret[i] = tsPacket(byte(i))
+	}
+	return ret
+}
+
+func mergeBytes(vals ...[]byte) []byte {
+	var ret []byte
+	for _, v := range vals {
+		ret = append(ret, v...)
+	}
+	return ret
+}
+
+var cases = []struct {
+	name   string
+	tsPkts [][]byte
+	pts    time.Duration
+	pkts   []*rtp.Packet
+}{
+	{
+		"single",
+		tsPackets(1),
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    33,
+					SequenceNumber: 17645,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: tsPacket(0),
+			},
+		},
+	},
+	{
+		"aggregated",
+		tsPackets(10),
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    33,
+					SequenceNumber: 17645,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(tsPackets(10)[:7]...),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    33,
+					SequenceNumber: 17646,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(tsPackets(10)[7:]...),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				SSRC: func() *uint32 {
+					v := uint32(0x9dbb7812)
+					return &v
+				}(),
+				InitialSequenceNumber: func() *uint16 {
+					v := uint16(0x44ed)
+					return &v
+				}(),
+				InitialTimestamp: func() *uint32 {
+					v := uint32(0x88776655)
+					return &v
+				}(),
+			}
+			e.Init()
+
+			pkts, err := e.Encode(ca.tsPkts, ca.pts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	e := &Encoder{}
+	e.Init()
+
+	_, err := e.Encode([][]byte{{0x47, 0x01}}, 0)
+	require.EqualError(t, err, "invalid MPEG-TS packet")
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{}
+	e.Init()
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+	require.NotEqual(t, nil, e.InitialTimestamp)
+}
@@ -0,0 +1,10 @@
+// Package rtpmpegts contains a RTP/MPEG-TS decoder and encoder.
+package rtpmpegts
+
+const (
+	// packetSize is the size of a MPEG-TS packet.
+	packetSize = 188
+
+	// syncByte is the first byte of every MPEG-TS packet.
+	syncByte = 0x47
+)
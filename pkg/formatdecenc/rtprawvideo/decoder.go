@@ -0,0 +1,182 @@
+package rtprawvideo
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented frame and we didn't received anything before.
+// It's normal to receive this when we are decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+type segmentHeader struct {
+	length int
+	field  bool
+	line   int
+	offset int
+}
+
+// Decoder is a RTP/raw video decoder.
+// Frames are returned in pgroup format, that is, pgroups are stored
+// one after the other, row by row.
+// Interlaced video is not supported.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4175
+type Decoder struct {
+	// sampling (YCbCr-4:4:4, YCbCr-4:2:2, YCbCr-4:2:0, YCbCr-4:1:1, RGB, RGBA, BGR, BGRA).
+	Sampling string
+
+	// bit depth of each sample.
+	Depth int
+
+	// width of frames.
+	Width int
+
+	// height of frames.
+	Height int
+
+	timeDecoder         *rtptimedec.Decoder
+	layout              frameLayout
+	layoutErr           error
+	firstPacketReceived bool
+	sequenceNumber      uint32
+	frame               []byte
+	frameTimestamp      uint32
+	frameIncomplete     bool
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(rtpClockRate)
+	d.layout, d.layoutErr = newFrameLayout(d.Sampling, d.Depth, d.Width, d.Height)
+}
+
+func parseSegmentHeaders(payload []byte) ([]segmentHeader, []byte, error) {
+	var headers []segmentHeader
+
+	for {
+		if len(payload) < 6 {
+			return nil, nil, fmt.Errorf("payload is too short")
+		}
+
+		headers = append(headers, segmentHeader{
+			length: int(uint16(payload[0])<<8 | uint16(payload[1])),
+			field:  (payload[2] & 0x80) != 0,
+			line:   int(uint16(payload[2]&0x7F)<<8 | uint16(payload[3])),
+			offset: int(uint16(payload[4]&0x7F)<<8 | uint16(payload[5])),
+		})
+		continuation := (payload[4] & 0x80) != 0
+		payload = payload[6:]
+
+		if !continuation {
+			break
+		}
+	}
+
+	return headers, payload, nil
+}
+
+func (d *Decoder) writeSegment(h segmentHeader, data []byte) error {
+	pg := d.layout.pgroup
+
+	if h.field {
+		return fmt.Errorf("interlaced video is not supported")
+	}
+
+	if (h.line%pg.height) != 0 || (h.offset%pg.width) != 0 || (h.length%pg.size) != 0 {
+		return fmt.Errorf("segment is not aligned to pgroups")
+	}
+
+	row := h.line / pg.height
+	pos := (h.offset / pg.width) * pg.size
+
+	if row >= d.layout.rows || (pos+h.length) > d.layout.stride {
+		return fmt.Errorf("segment is out of bounds")
+	}
+
+	copy(d.frame[row*d.layout.stride+pos:], data)
+	return nil
+}
+
+// Decode decodes a raw video frame from RTP packets.
+// It returns the frame and its PTS.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([]byte, time.Duration, error) {
+	if d.layoutErr != nil {
+		return nil, 0, d.layoutErr
+	}
+
+	if len(pkt.Payload) < 2 {
+		d.frame = nil
+		return nil, 0, fmt.Errorf("payload is too short")
+	}
+
+	seq := uint32(pkt.Payload[0])<<24 | uint32(pkt.Payload[1])<<16 | uint32(pkt.SequenceNumber)
+
+	headers, payload, err := parseSegmentHeaders(pkt.Payload[2:])
+	if err != nil {
+		d.frame = nil
+		return nil, 0, err
+	}
+
+	isFrameStart := headers[0].line == 0 && headers[0].offset == 0
+
+	if d.frame == nil || pkt.Timestamp != d.frameTimestamp {
+		// a packet with the marker flag may have been lost
+		d.frame = nil
+
+		if !isFrameStart {
+			if !d.firstPacketReceived {
+				return nil, 0, ErrNonStartingPacketAndNoPrevious
+			}
+
+			return nil, 0, fmt.Errorf("received a non-starting fragment")
+		}
+
+		d.firstPacketReceived = true
+		d.frame = make([]byte, d.layout.size())
+		d.frameTimestamp = pkt.Timestamp
+		d.frameIncomplete = false
+	} else if seq != (d.sequenceNumber + 1) {
+		d.frameIncomplete = true
+	}
+
+	d.sequenceNumber = seq
+
+	for _, h := range headers {
+		if len(payload) < h.length {
+			d.frame = nil
+			return nil, 0, fmt.Errorf("payload is too short")
+		}
+
+		err := d.writeSegment(h, payload[:h.length])
+		if err != nil {
+			d.frame = nil
+			return nil, 0, err
+		}
+
+		payload = payload[h.length:]
+	}
+
+	if !pkt.Marker {
+		return nil, 0, ErrMorePacketsNeeded
+	}
+
+	frame := d.frame
+	d.frame = nil
+
+	if d.frameIncomplete {
+		return nil, 0, fmt.Errorf("one or more packets of the frame have been lost")
+	}
+
+	return frame, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
@@ -0,0 +1,120 @@
+//go:build go1.18
+// +build go1.18
+
+package rtprawvideo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{
+				Sampling: "YCbCr-4:2:2",
+				Depth:    8,
+				Width:    ca.width,
+				Height:   ca.height,
+			}
+			d.Init()
+
+			// send an initial packet downstream
+			// in order to compute the right timestamp,
+			// that is relative to the initial packet
+			pkt := rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17644,
+					Timestamp:      2289526357,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{
+					0x00, 0x00,
+					0x00, 0x04, 0x00, 0x00, 0x00, 0x00,
+					0x01, 0x02, 0x03, 0x04,
+				},
+			}
+			_, _, err := d.Decode(&pkt)
+			require.NoError(t, err)
+
+			var frame []byte
+
+			for _, pkt := range ca.pkts {
+				var pts time.Duration
+				frame, pts, err = d.Decode(pkt)
+				if err == ErrMorePacketsNeeded {
+					continue
+				}
+
+				require.NoError(t, err)
+				require.Equal(t, ca.pts, pts)
+			}
+
+			require.Equal(t, ca.frame, frame)
+		})
+	}
+}
+
+func TestDecodePacketLoss(t *testing.T) {
+	ca := cases[1]
+
+	d := &Decoder{
+		Sampling: "YCbCr-4:2:2",
+		Depth:    8,
+		Width:    ca.width,
+		Height:   ca.height,
+	}
+	d.Init()
+
+	_, _, err := d.Decode(ca.pkts[0])
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	pkt := *ca.pkts[1]
+	pkt.SequenceNumber++
+
+	_, _, err = d.Decode(&pkt)
+	require.EqualError(t, err, "one or more packets of the frame have been lost")
+}
+
+func TestDecodeNonStarting(t *testing.T) {
+	d := &Decoder{
+		Sampling: "YCbCr-4:2:2",
+		Depth:    8,
+		Width:    720,
+		Height:   2,
+	}
+	d.Init()
+
+	_, _, err := d.Decode(cases[1].pkts[1])
+	require.Equal(t, ErrNonStartingPacketAndNoPrevious, err)
+}
+
+func FuzzDecoderUnmarshal(f *testing.F) {
+	d := &Decoder{
+		Sampling: "YCbCr-4:2:2",
+		Depth:    8,
+		Width:    4,
+		Height:   2,
+	}
+	d.Init()
+
+	f.Fuzz(func(t *testing.T, b []byte, m bool) {
+		d.Decode(&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         m,
+				PayloadType:    96,
+				SequenceNumber: 17645,
+				Timestamp:      2289527317,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: b,
+		})
+	})
+}
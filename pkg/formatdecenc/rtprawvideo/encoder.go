@@ -0,0 +1,184 @@
+package rtprawvideo
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion = 2
+)
+
+func randUint32() uint32 {
+	var b [4]byte
+	rand.Read(b[:])
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// Encoder is a RTP/raw video encoder.
+// Frames must be provided in pgroup format, that is, pgroups must be stored
+// one after the other, row by row.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4175
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// sampling (YCbCr-4:4:4, YCbCr-4:2:2, YCbCr-4:2:0, YCbCr-4:1:1, RGB, RGBA, BGR, BGRA).
+	Sampling string
+
+	// bit depth of each sample.
+	Depth int
+
+	// width of frames.
+	Width int
+
+	// height of frames.
+	Height int
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// initial timestamp of packets (optional).
+	// It defaults to a random value.
+	InitialTimestamp *uint32
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	layout         frameLayout
+	layoutErr      error
+	sequenceNumber uint32
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() {
+	if e.SSRC == nil {
+		v := randUint32()
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v := uint16(randUint32())
+		e.InitialSequenceNumber = &v
+	}
+	if e.InitialTimestamp == nil {
+		v := randUint32()
+		e.InitialTimestamp = &v
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+	}
+
+	e.layout, e.layoutErr = newFrameLayout(e.Sampling, e.Depth, e.Width, e.Height)
+	e.sequenceNumber = uint32(*e.InitialSequenceNumber)
+}
+
+func (e *Encoder) encodeTimestamp(ts time.Duration) uint32 {
+	return *e.InitialTimestamp + uint32(ts.Seconds()*rtpClockRate)
+}
+
+type segment struct {
+	row int
+	pos int
+	le  int
+}
+
+// Encode encodes a raw video frame into RTP/raw video packets.
+func (e *Encoder) Encode(frame []byte, pts time.Duration) ([]*rtp.Packet, error) {
+	if e.layoutErr != nil {
+		return nil, e.layoutErr
+	}
+
+	if len(frame) != e.layout.size() {
+		return nil, fmt.Errorf("frame size (%d) is different than the expected one (%d)",
+			len(frame), e.layout.size())
+	}
+
+	pg := e.layout.pgroup
+
+	if (e.PayloadMaxSize - 2 - 6) < pg.size {
+		return nil, fmt.Errorf("PayloadMaxSize is too small")
+	}
+
+	var ret []*rtp.Packet
+	ts := e.encodeTimestamp(pts)
+	row := 0
+	pos := 0
+
+	for row < e.layout.rows {
+		var segments []segment
+		size := 2
+
+		for row < e.layout.rows {
+			avail := e.PayloadMaxSize - size - 6
+			avail -= avail % pg.size
+			if avail <= 0 {
+				break
+			}
+
+			le := e.layout.stride - pos
+			if le > avail {
+				le = avail
+			}
+
+			segments = append(segments, segment{row, pos, le})
+			size += 6 + le
+			pos += le
+
+			if pos == e.layout.stride {
+				row++
+				pos = 0
+			}
+		}
+
+		payload := make([]byte, size)
+		payload[0] = byte(e.sequenceNumber >> 24)
+		payload[1] = byte(e.sequenceNumber >> 16)
+		n := 2
+
+		for i, seg := range segments {
+			line := seg.row * pg.height
+			offset := (seg.pos / pg.size) * pg.width
+
+			payload[n] = byte(seg.le >> 8)
+			payload[n+1] = byte(seg.le)
+			payload[n+2] = byte(line >> 8)
+			payload[n+3] = byte(line)
+			payload[n+4] = byte(offset >> 8)
+			if i != (len(segments) - 1) {
+				payload[n+4] |= 0x80 // continuation
+			}
+			payload[n+5] = byte(offset)
+			n += 6
+		}
+
+		for _, seg := range segments {
+			start := seg.row*e.layout.stride + seg.pos
+			n += copy(payload[n:], frame[start:start+seg.le])
+		}
+
+		ret = append(ret, &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: uint16(e.sequenceNumber),
+				Timestamp:      ts,
+				SSRC:           *e.SSRC,
+				Marker:         row == e.layout.rows,
+			},
+			Payload: payload,
+		})
+
+		e.sequenceNumber++
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,185 @@
+package rtprawvideo
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func mergeBytes(vals ...[]byte) []byte {
+	size := 0
+	for _, v := range vals {
+		size += len(v)
+	}
+	res := make([]byte, size)
+
+	pos := 0
+	for _, v := range vals {
+		n := copy(res[pos:], v)
+		pos += n
+	}
+
+	return res
+}
+
+var cases = []struct {
+	name   string
+	width  int
+	height int
+	frame  []byte
+	pts    time.Duration
+	pkts   []*rtp.Packet
+}{
+	{
+		"single",
+		4,
+		2,
+		[]byte{
+			0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+			0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+		},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{
+					0x00, 0x00,
+					0x00, 0x08, 0x00, 0x00, 0x80, 0x00,
+					0x00, 0x08, 0x00, 0x01, 0x00, 0x00,
+					0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+					0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+				},
+			},
+		},
+	},
+	{
+		"split",
+		720,
+		2,
+		mergeBytes(
+			bytes.Repeat([]byte{0x01}, 1440),
+			bytes.Repeat([]byte{0x02}, 1440),
+		),
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{
+						0x00, 0x00,
+						0x05, 0xa0, 0x00, 0x00, 0x80, 0x00,
+						0x00, 0x04, 0x00, 0x01, 0x00, 0x00,
+					},
+					bytes.Repeat([]byte{0x01}, 1440),
+					bytes.Repeat([]byte{0x02}, 4),
+				),
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    96,
+					SequenceNumber: 17646,
+					Timestamp:      2289528607,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: mergeBytes(
+					[]byte{
+						0x00, 0x00,
+						0x05, 0x9c, 0x00, 0x01, 0x00, 0x02,
+					},
+					bytes.Repeat([]byte{0x02}, 1436),
+				),
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType: 96,
+				Sampling:    "YCbCr-4:2:2",
+				Depth:       8,
+				Width:       ca.width,
+				Height:      ca.height,
+				SSRC: func() *uint32 {
+					v := uint32(0x9dbb7812)
+					return &v
+				}(),
+				InitialSequenceNumber: func() *uint16 {
+					v := uint16(0x44ed)
+					return &v
+				}(),
+				InitialTimestamp: func() *uint32 {
+					v := uint32(0x88776655)
+					return &v
+				}(),
+			}
+			e.Init()
+
+			pkts, err := e.Encode(ca.frame, ca.pts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeExtendedSequenceNumber(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+		Sampling:    "YCbCr-4:2:2",
+		Depth:       8,
+		Width:       4,
+		Height:      2,
+		InitialSequenceNumber: func() *uint16 {
+			v := uint16(0xffff)
+			return &v
+		}(),
+	}
+	e.Init()
+
+	frame := make([]byte, 16)
+
+	pkts, err := e.Encode(frame, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint16(0xffff), pkts[0].SequenceNumber)
+	require.Equal(t, []byte{0x00, 0x00}, pkts[0].Payload[:2])
+
+	pkts, err = e.Encode(frame, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint16(0x0000), pkts[0].SequenceNumber)
+	require.Equal(t, []byte{0x00, 0x01}, pkts[0].Payload[:2])
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 96,
+		Sampling:    "YCbCr-4:2:2",
+		Depth:       8,
+		Width:       4,
+		Height:      2,
+	}
+	e.Init()
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+	require.NotEqual(t, nil, e.InitialTimestamp)
+}
@@ -0,0 +1,128 @@
+// Package rtprawvideo contains a RTP/raw video decoder and encoder.
+package rtprawvideo
+
+import (
+	"fmt"
+)
+
+const (
+	rtpClockRate = 90000 // raw video always uses 90khz
+)
+
+// pgroup is the smallest group of pixels whose samples
+// are aligned to a byte boundary.
+type pgroup struct {
+	// size in bytes.
+	size int
+
+	// horizontal pixels.
+	width int
+
+	// lines.
+	height int
+}
+
+func newPgroup(sampling string, depth int) (pgroup, error) {
+	switch sampling {
+	case "YCbCr-4:4:4", "RGB", "BGR":
+		switch depth {
+		case 8:
+			return pgroup{3, 1, 1}, nil
+		case 10:
+			return pgroup{15, 4, 1}, nil
+		case 12:
+			return pgroup{9, 2, 1}, nil
+		case 16:
+			return pgroup{6, 1, 1}, nil
+		}
+
+	case "RGBA", "BGRA":
+		switch depth {
+		case 8:
+			return pgroup{4, 1, 1}, nil
+		case 10:
+			return pgroup{5, 1, 1}, nil
+		case 12:
+			return pgroup{6, 1, 1}, nil
+		case 16:
+			return pgroup{8, 1, 1}, nil
+		}
+
+	case "YCbCr-4:2:2":
+		switch depth {
+		case 8:
+			return pgroup{4, 2, 1}, nil
+		case 10:
+			return pgroup{5, 2, 1}, nil
+		case 12:
+			return pgroup{6, 2, 1}, nil
+		case 16:
+			return pgroup{8, 2, 1}, nil
+		}
+
+	case "YCbCr-4:1:1":
+		switch depth {
+		case 8:
+			return pgroup{6, 4, 1}, nil
+		case 10:
+			return pgroup{15, 8, 1}, nil
+		case 12:
+			return pgroup{9, 4, 1}, nil
+		case 16:
+			return pgroup{12, 4, 1}, nil
+		}
+
+	case "YCbCr-4:2:0":
+		switch depth {
+		case 8:
+			return pgroup{6, 2, 2}, nil
+		case 10:
+			return pgroup{15, 4, 2}, nil
+		case 12:
+			return pgroup{9, 2, 2}, nil
+		case 16:
+			return pgroup{12, 2, 2}, nil
+		}
+
+	default:
+		return pgroup{}, fmt.Errorf("unsupported sampling (%v)", sampling)
+	}
+
+	return pgroup{}, fmt.Errorf("unsupported depth (%d)", depth)
+}
+
+// frameLayout describes how a frame is stored in a pgroup buffer.
+type frameLayout struct {
+	pgroup pgroup
+
+	// size of a row of pgroups, in bytes.
+	stride int
+
+	// number of rows of pgroups.
+	rows int
+}
+
+func newFrameLayout(sampling string, depth int, width int, height int) (frameLayout, error) {
+	pg, err := newPgroup(sampling, depth)
+	if err != nil {
+		return frameLayout{}, err
+	}
+
+	if width <= 0 || (width%pg.width) != 0 {
+		return frameLayout{}, fmt.Errorf("invalid width (%d)", width)
+	}
+
+	if height <= 0 || (height%pg.height) != 0 {
+		return frameLayout{}, fmt.Errorf("invalid height (%d)", height)
+	}
+
+	return frameLayout{
+		pgroup: pg,
+		stride: (width / pg.width) * pg.size,
+		rows:   height / pg.height,
+	}, nil
+}
+
+func (l frameLayout) size() int {
+	return l.stride * l.rows
+}
@@ -0,0 +1,97 @@
+package rtptelephoneevent
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// Decoder is a RTP/telephone-event decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4733
+type Decoder struct {
+	// clock rate of input packets.
+	ClockRate int
+
+	timeDecoder  *rtptimedec.Decoder
+	cur          *Event
+	curTimestamp uint32
+	curPTS       time.Duration
+	curEmitted   bool
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(d.ClockRate)
+}
+
+func (d *Decoder) decodeDuration(v uint16) time.Duration {
+	return time.Duration(v) * time.Second / time.Duration(d.ClockRate)
+}
+
+// Decode decodes an event from a RTP/telephone-event packet.
+// Packets that belong to the same event are coalesced, and the event is returned
+// once, when its end is received or when a subsequent event begins.
+// It returns the event and its PTS.
+func (d *Decoder) Decode(pkt *rtp.Packet) (*Event, time.Duration, error) {
+	if len(pkt.Payload) < 4 {
+		return nil, 0, fmt.Errorf("payload is too short")
+	}
+
+	code := pkt.Payload[0]
+	end := (pkt.Payload[1] & 0x80) != 0
+	volume := pkt.Payload[1] & 0x3F
+	duration := d.decodeDuration(uint16(pkt.Payload[2])<<8 | uint16(pkt.Payload[3]))
+
+	// packet belongs to the current event
+	if d.cur != nil && pkt.Timestamp == d.curTimestamp {
+		// retransmission of the end of an event that has already been returned
+		if d.curEmitted {
+			return nil, 0, ErrMorePacketsNeeded
+		}
+
+		d.cur.Volume = volume
+		if duration > d.cur.Duration {
+			d.cur.Duration = duration
+		}
+
+		if !end {
+			return nil, 0, ErrMorePacketsNeeded
+		}
+
+		d.curEmitted = true
+		return d.cur, d.curPTS, nil
+	}
+
+	// a previous event ended without receiving its end
+	prev := d.cur
+	prevPTS := d.curPTS
+	prevEmitted := d.curEmitted
+
+	d.cur = &Event{
+		Code:     code,
+		Volume:   volume,
+		Duration: duration,
+	}
+	d.curTimestamp = pkt.Timestamp
+	d.curPTS = d.timeDecoder.Decode(pkt.Timestamp)
+	d.curEmitted = false
+
+	if prev != nil && !prevEmitted {
+		// the new event, if ended, is returned when the end is retransmitted
+		return prev, prevPTS, nil
+	}
+
+	if !end {
+		return nil, 0, ErrMorePacketsNeeded
+	}
+
+	d.curEmitted = true
+	return d.cur, d.curPTS, nil
+}
@@ -0,0 +1,129 @@
+//go:build go1.18
+// +build go1.18
+
+package rtptelephoneevent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{
+				ClockRate: 8000,
+			}
+			d.Init()
+
+			// send an initial packet downstream
+			// in order to compute the right timestamp,
+			// that is relative to the initial packet
+			pkt := rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    101,
+					SequenceNumber: 17645,
+					Timestamp:      2289526357,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x01, 0x80, 0x00, 0xa0},
+			}
+			_, _, err := d.Decode(&pkt)
+			require.NoError(t, err)
+
+			var events []*Event
+
+			for _, pkt := range ca.pkts {
+				event, pts, err := d.Decode(pkt)
+				if err == ErrMorePacketsNeeded {
+					continue
+				}
+
+				require.NoError(t, err)
+				require.Equal(t, ca.pts, pts)
+				events = append(events, event)
+			}
+
+			require.Equal(t, []*Event{ca.event}, events)
+		})
+	}
+}
+
+func TestDecodeMissingEnd(t *testing.T) {
+	d := &Decoder{
+		ClockRate: 8000,
+	}
+	d.Init()
+
+	_, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      true,
+			PayloadType: 101,
+			Timestamp:   1000,
+		},
+		Payload: []byte{0x03, 0x0a, 0x01, 0x90},
+	})
+	require.Equal(t, ErrMorePacketsNeeded, err)
+
+	// a new event begins before the end of the previous one is received
+	event, pts, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      true,
+			PayloadType: 101,
+			Timestamp:   9000,
+		},
+		Payload: []byte{0x04, 0x8a, 0x01, 0x90},
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(0), pts)
+	require.Equal(t, &Event{
+		Code:     3,
+		Volume:   10,
+		Duration: 50 * time.Millisecond,
+	}, event)
+
+	// the new event is returned when its end is retransmitted
+	event, pts, err = d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			PayloadType: 101,
+			Timestamp:   9000,
+		},
+		Payload: []byte{0x04, 0x8a, 0x01, 0x90},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1*time.Second, pts)
+	require.Equal(t, &Event{
+		Code:     4,
+		Volume:   10,
+		Duration: 50 * time.Millisecond,
+	}, event)
+}
+
+func FuzzDecoderUnmarshal(f *testing.F) {
+	d := &Decoder{
+		ClockRate: 8000,
+	}
+	d.Init()
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		d.Decode(&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         false,
+				PayloadType:    101,
+				SequenceNumber: 17645,
+				Timestamp:      2289527317,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: b,
+		})
+	})
+}
@@ -0,0 +1,144 @@
+package rtptelephoneevent
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion = 2
+
+	// number of times the last packet of an event is sent.
+	endRetransmissions = 3
+)
+
+func randUint32() uint32 {
+	var b [4]byte
+	rand.Read(b[:])
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// Encoder is a RTP/telephone-event encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc4733
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// clock rate of packets.
+	ClockRate int
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// initial timestamp of packets (optional).
+	// It defaults to a random value.
+	InitialTimestamp *uint32
+
+	// interval between packets of the same event (optional).
+	// It defaults to 50ms.
+	PacketDuration time.Duration
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() {
+	if e.SSRC == nil {
+		v := randUint32()
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v := uint16(randUint32())
+		e.InitialSequenceNumber = &v
+	}
+	if e.InitialTimestamp == nil {
+		v := randUint32()
+		e.InitialTimestamp = &v
+	}
+	if e.PacketDuration == 0 {
+		e.PacketDuration = 50 * time.Millisecond
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+}
+
+func (e *Encoder) encodeTimestamp(ts time.Duration) uint32 {
+	return *e.InitialTimestamp + uint32(ts.Seconds()*float64(e.ClockRate))
+}
+
+func (e *Encoder) encodeDuration(d time.Duration) uint64 {
+	return uint64(d.Seconds() * float64(e.ClockRate))
+}
+
+// Encode encodes an event into RTP/telephone-event packets.
+// All packets share the timestamp of the beginning of the event,
+// while their duration field grows by PacketDuration.
+// Packet N is meant to be sent at pts + N*PacketDuration, except the
+// retransmissions of the final packet, that are meant to be sent right after it.
+func (e *Encoder) Encode(event *Event, pts time.Duration) ([]*rtp.Packet, error) {
+	if event.Volume > 63 {
+		return nil, fmt.Errorf("invalid volume (%d)", event.Volume)
+	}
+
+	if event.Duration <= 0 {
+		return nil, fmt.Errorf("invalid duration (%v)", event.Duration)
+	}
+
+	total := e.encodeDuration(event.Duration)
+	if total > 0xFFFF {
+		return nil, fmt.Errorf("event is too long")
+	}
+
+	n := int(event.Duration / e.PacketDuration)
+	if (event.Duration % e.PacketDuration) != 0 {
+		n++
+	}
+
+	ts := e.encodeTimestamp(pts)
+	rets := make([]*rtp.Packet, 0, n+endRetransmissions-1)
+
+	for i := 0; i < (n + endRetransmissions - 1); i++ {
+		last := i >= (n - 1)
+
+		var duration uint64
+		if last {
+			duration = total
+		} else {
+			duration = e.encodeDuration(time.Duration(i+1) * e.PacketDuration)
+		}
+
+		payload := []byte{
+			event.Code,
+			event.Volume,
+			byte(duration >> 8),
+			byte(duration),
+		}
+		if last {
+			payload[1] |= 0x80
+		}
+
+		rets = append(rets, &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: e.sequenceNumber,
+				Timestamp:      ts,
+				SSRC:           *e.SSRC,
+				Marker:         i == 0,
+			},
+			Payload: payload,
+		})
+
+		e.sequenceNumber++
+	}
+
+	return rets, nil
+}
@@ -0,0 +1,180 @@
+package rtptelephoneevent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+var cases = []struct {
+	name  string
+	event *Event
+	pts   time.Duration
+	pkts  []*rtp.Packet
+}{
+	{
+		"digit",
+		&Event{
+			Code:     5,
+			Volume:   10,
+			Duration: 120 * time.Millisecond,
+		},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    101,
+					SequenceNumber: 17645,
+					Timestamp:      2289526557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x05, 0x0a, 0x01, 0x90},
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    101,
+					SequenceNumber: 17646,
+					Timestamp:      2289526557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x05, 0x0a, 0x03, 0x20},
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    101,
+					SequenceNumber: 17647,
+					Timestamp:      2289526557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x05, 0x8a, 0x03, 0xc0},
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    101,
+					SequenceNumber: 17648,
+					Timestamp:      2289526557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x05, 0x8a, 0x03, 0xc0},
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    101,
+					SequenceNumber: 17649,
+					Timestamp:      2289526557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x05, 0x8a, 0x03, 0xc0},
+			},
+		},
+	},
+	{
+		"short",
+		&Event{
+			Code:     11,
+			Volume:   0,
+			Duration: 40 * time.Millisecond,
+		},
+		25 * time.Millisecond,
+		[]*rtp.Packet{
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         true,
+					PayloadType:    101,
+					SequenceNumber: 17645,
+					Timestamp:      2289526557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x0b, 0x80, 0x01, 0x40},
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    101,
+					SequenceNumber: 17646,
+					Timestamp:      2289526557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x0b, 0x80, 0x01, 0x40},
+			},
+			{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    101,
+					SequenceNumber: 17647,
+					Timestamp:      2289526557,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0x0b, 0x80, 0x01, 0x40},
+			},
+		},
+	},
+}
+
+func TestEncode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			e := &Encoder{
+				PayloadType: 101,
+				ClockRate:   8000,
+				SSRC: func() *uint32 {
+					v := uint32(0x9dbb7812)
+					return &v
+				}(),
+				InitialSequenceNumber: func() *uint16 {
+					v := uint16(0x44ed)
+					return &v
+				}(),
+				InitialTimestamp: func() *uint32 {
+					v := uint32(0x88776655)
+					return &v
+				}(),
+			}
+			e.Init()
+
+			pkts, err := e.Encode(ca.event, ca.pts)
+			require.NoError(t, err)
+			require.Equal(t, ca.pkts, pkts)
+		})
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 101,
+		ClockRate:   8000,
+	}
+	e.Init()
+
+	_, err := e.Encode(&Event{Code: 1, Volume: 64, Duration: time.Second}, 0)
+	require.EqualError(t, err, "invalid volume (64)")
+
+	_, err = e.Encode(&Event{Code: 1, Duration: 10 * time.Second}, 0)
+	require.EqualError(t, err, "event is too long")
+}
+
+func TestEncodeRandomInitialState(t *testing.T) {
+	e := &Encoder{
+		PayloadType: 101,
+		ClockRate:   8000,
+	}
+	e.Init()
+	require.NotEqual(t, nil, e.SSRC)
+	require.NotEqual(t, nil, e.InitialSequenceNumber)
+	require.NotEqual(t, nil, e.InitialTimestamp)
+}
@@ -0,0 +1,18 @@
+// Package rtptelephoneevent contains a RTP/telephone-event decoder and encoder.
+package rtptelephoneevent
+
+import (
+	"time"
+)
+
+// Event is a telephone event.
+type Event struct {
+	// event code (0-9, *, #, A-D are codes 0-15).
+	Code uint8
+
+	// power level of the tone, expressed in -dBm0 (0-63).
+	Volume uint8
+
+	// duration of the event.
+	Duration time.Duration
+}
@@ -0,0 +1,180 @@
+package rtpxiph
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/xiph"
+	"github.com/aler9/gortsplib/v2/pkg/rtptimedec"
+)
+
+// ErrMorePacketsNeeded is returned when more packets are needed.
+var ErrMorePacketsNeeded = errors.New("need more packets")
+
+// ErrNonStartingPacketAndNoPrevious is returned when we received a non-starting
+// packet of a fragmented packet and we didn't received anything before.
+// It's normal to receive this when we are decoding a stream that has been already
+// running for some time.
+var ErrNonStartingPacketAndNoPrevious = errors.New(
+	"received a non-starting fragment without any previous starting fragment")
+
+// Decoder is a RTP/Vorbis or RTP/Theora decoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5215
+type Decoder struct {
+	// clock rate of input packets.
+	ClockRate int
+
+	timeDecoder         *rtptimedec.Decoder
+	firstPacketReceived bool
+	fragments           [][]byte
+	fragmentsSize       int
+	fragmentsDataType   uint8
+}
+
+// Init initializes the decoder.
+func (d *Decoder) Init() {
+	d.timeDecoder = rtptimedec.New(d.ClockRate)
+}
+
+func (d *Decoder) resetFragments() {
+	d.fragments = d.fragments[:0]
+	d.fragmentsSize = 0
+}
+
+// Decode decodes Vorbis or Theora packets from a RTP packet.
+// It returns the packets and the PTS of the first packet.
+// Configuration headers sent in band are discarded.
+func (d *Decoder) Decode(pkt *rtp.Packet) ([][]byte, time.Duration, error) {
+	if len(pkt.Payload) < 4 {
+		d.resetFragments()
+		return nil, 0, fmt.Errorf("payload is too short")
+	}
+
+	ft := pkt.Payload[3] >> 6
+	tdt := (pkt.Payload[3] >> 4) & 0x03
+	count := int(pkt.Payload[3] & 0x0F)
+	payload := pkt.Payload[4:]
+
+	if tdt > dataTypeLegacyComment {
+		d.resetFragments()
+		return nil, 0, fmt.Errorf("invalid data type (%d)", tdt)
+	}
+
+	switch ft {
+	case fragmentTypeNotFragmented:
+		d.resetFragments()
+		d.firstPacketReceived = true
+
+		if count == 0 {
+			return nil, 0, fmt.Errorf("invalid packet count (%d)", count)
+		}
+
+		packets := make([][]byte, count)
+
+		for i := range packets {
+			if len(payload) < 2 {
+				return nil, 0, fmt.Errorf("payload is too short")
+			}
+
+			le := int(uint16(payload[0])<<8 | uint16(payload[1]))
+			payload = payload[2:]
+
+			if len(payload) < le {
+				return nil, 0, fmt.Errorf("payload is too short")
+			}
+
+			packets[i] = payload[:le]
+			payload = payload[le:]
+		}
+
+		if len(payload) != 0 {
+			return nil, 0, fmt.Errorf("payload contains trailing bytes")
+		}
+
+		if tdt != dataTypeRaw {
+			return nil, 0, ErrMorePacketsNeeded
+		}
+
+		return packets, d.timeDecoder.Decode(pkt.Timestamp), nil
+
+	case fragmentTypeStart:
+		d.resetFragments()
+		d.firstPacketReceived = true
+
+		frag, err := fragmentPayload(payload, count)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		d.fragments = append(d.fragments, frag)
+		d.fragmentsSize = len(frag)
+		d.fragmentsDataType = tdt
+		return nil, 0, ErrMorePacketsNeeded
+	}
+
+	if len(d.fragments) == 0 {
+		if !d.firstPacketReceived {
+			return nil, 0, ErrNonStartingPacketAndNoPrevious
+		}
+
+		return nil, 0, fmt.Errorf("received a non-starting fragment")
+	}
+
+	frag, err := fragmentPayload(payload, count)
+	if err != nil {
+		d.resetFragments()
+		return nil, 0, err
+	}
+
+	d.fragmentsSize += len(frag)
+	if d.fragmentsSize > xiph.MaxPacketSize {
+		errSize := d.fragmentsSize
+		d.resetFragments()
+		return nil, 0, fmt.Errorf("packet size (%d) is too big, maximum is %d", errSize, xiph.MaxPacketSize)
+	}
+
+	d.fragments = append(d.fragments, frag)
+
+	if ft != fragmentTypeEnd {
+		return nil, 0, ErrMorePacketsNeeded
+	}
+
+	packet := make([]byte, d.fragmentsSize)
+	pos := 0
+
+	for _, frag := range d.fragments {
+		pos += copy(packet[pos:], frag)
+	}
+
+	dataType := d.fragmentsDataType
+	d.resetFragments()
+
+	if dataType != dataTypeRaw {
+		return nil, 0, ErrMorePacketsNeeded
+	}
+
+	return [][]byte{packet}, d.timeDecoder.Decode(pkt.Timestamp), nil
+}
+
+func fragmentPayload(payload []byte, count int) ([]byte, error) {
+	if count != 0 {
+		return nil, fmt.Errorf("invalid packet count (%d)", count)
+	}
+
+	if len(payload) < 2 {
+		return nil, fmt.Errorf("payload is too short")
+	}
+
+	le := int(uint16(payload[0])<<8 | uint16(payload[1]))
+	payload = payload[2:]
+
+	if len(payload) != le {
+		return nil, fmt.Errorf("fragment length (%d) is different than the payload length (%d)",
+			le, len(payload))
+	}
+
+	return payload, nil
+}
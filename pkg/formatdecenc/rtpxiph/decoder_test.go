@@ -0,0 +1,111 @@
+//go:build go1.18
+// +build go1.18
+
+package rtpxiph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	for _, ca := range cases {
+		t.Run(ca.name, func(t *testing.T) {
+			d := &Decoder{
+				ClockRate: 48000,
+			}
+			d.Init()
+
+			// send an initial packet downstream
+			// in order to compute the right timestamp,
+			// that is relative to the initial packet
+			pkt := rtp.Packet{
+				Header: rtp.Header{
+					Version:        2,
+					Marker:         false,
+					PayloadType:    96,
+					SequenceNumber: 17645,
+					Timestamp:      2289526357,
+					SSRC:           0x9dbb7812,
+				},
+				Payload: []byte{0xfe, 0xcd, 0xba, 0x01, 0x00, 0x01, 0x01},
+			}
+			_, _, err := d.Decode(&pkt)
+			require.NoError(t, err)
+
+			var packets [][]byte
+
+			for _, pkt := range ca.pkts {
+				var addPackets [][]byte
+				var pts time.Duration
+				addPackets, pts, err = d.Decode(pkt)
+				if err == ErrMorePacketsNeeded {
+					continue
+				}
+
+				require.NoError(t, err)
+				require.Equal(t, ca.pts, pts)
+				packets = append(packets, addPackets...)
+			}
+
+			require.Equal(t, ca.packets, packets)
+		})
+	}
+}
+
+func TestDecodeConfiguration(t *testing.T) {
+	d := &Decoder{
+		ClockRate: 48000,
+	}
+	d.Init()
+
+	// packed configuration sent in band
+	_, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			PayloadType: 96,
+		},
+		Payload: []byte{0xfe, 0xcd, 0xba, 0x11, 0x00, 0x02, 0x01, 0x02},
+	})
+	require.Equal(t, ErrMorePacketsNeeded, err)
+}
+
+func TestDecodeNonStarting(t *testing.T) {
+	d := &Decoder{
+		ClockRate: 48000,
+	}
+	d.Init()
+
+	_, _, err := d.Decode(&rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			PayloadType: 96,
+		},
+		Payload: []byte{0xfe, 0xcd, 0xba, 0xc0, 0x00, 0x02, 0x01, 0x02},
+	})
+	require.Equal(t, ErrNonStartingPacketAndNoPrevious, err)
+}
+
+func FuzzDecoderUnmarshal(f *testing.F) {
+	d := &Decoder{
+		ClockRate: 48000,
+	}
+	d.Init()
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		d.Decode(&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         false,
+				PayloadType:    96,
+				SequenceNumber: 17645,
+				Timestamp:      2289527317,
+				SSRC:           0x9dbb7812,
+			},
+			Payload: b,
+		})
+	})
+}
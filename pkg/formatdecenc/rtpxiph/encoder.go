@@ -0,0 +1,186 @@
+package rtpxiph
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	rtpVersion = 2
+)
+
+func randUint32() uint32 {
+	var b [4]byte
+	rand.Read(b[:])
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// Encoder is a RTP/Vorbis or RTP/Theora encoder.
+// Specification: https://datatracker.ietf.org/doc/html/rfc5215
+type Encoder struct {
+	// payload type of packets.
+	PayloadType uint8
+
+	// clock rate of packets.
+	ClockRate int
+
+	// ident of the configuration the packets refer to.
+	Ident uint32
+
+	// SSRC of packets (optional).
+	// It defaults to a random value.
+	SSRC *uint32
+
+	// initial sequence number of packets (optional).
+	// It defaults to a random value.
+	InitialSequenceNumber *uint16
+
+	// initial timestamp of packets (optional).
+	// It defaults to a random value.
+	InitialTimestamp *uint32
+
+	// maximum size of packet payloads (optional).
+	// It defaults to 1460.
+	PayloadMaxSize int
+
+	sequenceNumber uint16
+}
+
+// Init initializes the encoder.
+func (e *Encoder) Init() {
+	if e.SSRC == nil {
+		v := randUint32()
+		e.SSRC = &v
+	}
+	if e.InitialSequenceNumber == nil {
+		v := uint16(randUint32())
+		e.InitialSequenceNumber = &v
+	}
+	if e.InitialTimestamp == nil {
+		v := randUint32()
+		e.InitialTimestamp = &v
+	}
+	if e.PayloadMaxSize == 0 {
+		e.PayloadMaxSize = 1460 // 1500 (UDP MTU) - 20 (IP header) - 8 (UDP header) - 12 (RTP header)
+	}
+
+	e.sequenceNumber = *e.InitialSequenceNumber
+}
+
+func (e *Encoder) encodeTimestamp(ts time.Duration) uint32 {
+	return *e.InitialTimestamp + uint32(ts.Seconds()*float64(e.ClockRate))
+}
+
+// Encode encodes Vorbis or Theora packets into RTP packets.
+// Since the duration of packets is not known, multiple packets are put into a single
+// RTP packet, and they must fit into PayloadMaxSize.
+// A single packet that is bigger than PayloadMaxSize is fragmented.
+func (e *Encoder) Encode(packets [][]byte, pts time.Duration) ([]*rtp.Packet, error) {
+	switch {
+	case len(packets) == 0:
+		return nil, fmt.Errorf("no packets provided")
+
+	case len(packets) > 15:
+		return nil, fmt.Errorf("too many packets (%d), maximum is 15", len(packets))
+	}
+
+	size := 4
+	for _, packet := range packets {
+		size += 2 + len(packet)
+	}
+
+	if size > e.PayloadMaxSize {
+		if len(packets) != 1 {
+			return nil, fmt.Errorf("packets don't fit into a single RTP packet")
+		}
+
+		return e.writeFragmented(packets[0], pts), nil
+	}
+
+	payload := make([]byte, size)
+	e.writeHeader(payload, fragmentTypeNotFragmented, len(packets))
+	pos := 4
+
+	for _, packet := range packets {
+		payload[pos] = byte(len(packet) >> 8)
+		payload[pos+1] = byte(len(packet))
+		pos += 2
+		pos += copy(payload[pos:], packet)
+	}
+
+	pkt := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        rtpVersion,
+			PayloadType:    e.PayloadType,
+			SequenceNumber: e.sequenceNumber,
+			Timestamp:      e.encodeTimestamp(pts),
+			SSRC:           *e.SSRC,
+		},
+		Payload: payload,
+	}
+
+	e.sequenceNumber++
+
+	return []*rtp.Packet{pkt}, nil
+}
+
+func (e *Encoder) writeHeader(buf []byte, ft uint8, count int) {
+	buf[0] = byte(e.Ident >> 16)
+	buf[1] = byte(e.Ident >> 8)
+	buf[2] = byte(e.Ident)
+	buf[3] = ft<<6 | dataTypeRaw<<4 | uint8(count)
+}
+
+func (e *Encoder) writeFragmented(packet []byte, pts time.Duration) []*rtp.Packet {
+	avail := e.PayloadMaxSize - 6
+	n := len(packet) / avail
+	if (len(packet) % avail) != 0 {
+		n++
+	}
+
+	ret := make([]*rtp.Packet, n)
+	ts := e.encodeTimestamp(pts)
+	offset := 0
+
+	for i := range ret {
+		le := len(packet) - offset
+		if le > avail {
+			le = avail
+		}
+
+		var ft uint8
+		switch {
+		case i == 0:
+			ft = fragmentTypeStart
+		case i == (n - 1):
+			ft = fragmentTypeEnd
+		default:
+			ft = fragmentTypeContinuation
+		}
+
+		payload := make([]byte, 6+le)
+		e.writeHeader(payload, ft, 0)
+		payload[4] = byte(le >> 8)
+		payload[5] = byte(le)
+		copy(payload[6:], packet[offset:offset+le])
+
+		ret[i] = &rtp.Packet{
+			Header: rtp.Header{
+				Version:        rtpVersion,
+				PayloadType:    e.PayloadType,
+				SequenceNumber: e.sequenceNumber,
+				Timestamp:      ts,
+				SSRC:           *e.SSRC,
+			},
+			Payload: payload,
+		}
+
+		e.sequenceNumber++
+		offset += le
+	}
+
+	return ret
+}
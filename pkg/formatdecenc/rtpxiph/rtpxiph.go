@@ -0,0 +1,17 @@
+// Package rtpxiph contains a RTP/Vorbis and RTP/Theora decoder and encoder.
+package rtpxiph
+
+// fragment types.
+const (
+	fragmentTypeNotFragmented = 0
+	fragmentTypeStart         = 1
+	fragmentTypeContinuation  = 2
+	fragmentTypeEnd           = 3
+)
+
+// data types.
+const (
+	dataTypeRaw                 = 0
+	dataTypePackedConfiguration = 1
+	dataTypeLegacyComment       = 2
+)
@@ -17,6 +17,9 @@ const (
 
 	// AuthDigest is the Digest authentication method
 	AuthDigest
+
+	// AuthBearer is the Bearer authentication method
+	AuthBearer
 )
 
 // Authenticate is an Authenticate or a WWW-Authenticate header.
@@ -74,6 +77,9 @@ func (h *Authenticate) Unmarshal(v base.HeaderValue) error {
 	case "Digest":
 		h.Method = AuthDigest
 
+	case "Bearer":
+		h.Method = AuthBearer
+
 	default:
 		return fmt.Errorf("invalid method (%s)", method)
 	}
@@ -126,6 +132,9 @@ func (h Authenticate) Marshal() base.HeaderValue {
 
 	case AuthDigest:
 		ret += "Digest"
+
+	case AuthBearer:
+		ret += "Bearer"
 	}
 
 	ret += " "
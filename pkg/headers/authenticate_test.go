@@ -176,6 +176,18 @@ var casesAuthenticate = []struct {
 			}(),
 		},
 	},
+	{
+		"bearer",
+		base.HeaderValue{`Bearer realm="4419b63f5e51"`},
+		base.HeaderValue{`Bearer realm="4419b63f5e51"`},
+		Authenticate{
+			Method: AuthBearer,
+			Realm: func() *string {
+				v := "4419b63f5e51"
+				return &v
+			}(),
+		},
+	},
 }
 
 func TestAuthenticateUnmarshal(t *testing.T) {
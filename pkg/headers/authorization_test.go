@@ -47,6 +47,15 @@ var casesAuthorization = []struct {
 			},
 		},
 	},
+	{
+		"bearer",
+		base.HeaderValue{"Bearer eyJhbGciOiJIUzI1NiJ9.e30.ZRrHA1JJJW8opsbCGfG_HACGpVUMN_a9IV7pAx_Zmeo"},
+		base.HeaderValue{"Bearer eyJhbGciOiJIUzI1NiJ9.e30.ZRrHA1JJJW8opsbCGfG_HACGpVUMN_a9IV7pAx_Zmeo"},
+		Authorization{
+			Method:      AuthBearer,
+			BearerToken: "eyJhbGciOiJIUzI1NiJ9.e30.ZRrHA1JJJW8opsbCGfG_HACGpVUMN_a9IV7pAx_Zmeo",
+		},
+	},
 }
 
 func TestAuthorizationUnmarshal(t *testing.T) {
@@ -91,6 +100,11 @@ func TestAuthorizationUnmarshalErrors(t *testing.T) {
 			base.HeaderValue{`Basic aW52YWxpZA==`},
 			"invalid value",
 		},
+		{
+			"bearer empty",
+			base.HeaderValue{`Bearer  `},
+			"invalid value",
+		},
 		{
 			"digest invalid",
 			base.HeaderValue{`Digest test="v`},
@@ -98,6 +98,26 @@ func (e ErrServerTransportHeaderInvalid) Error() string {
 	return fmt.Sprintf("invalid transport header: %v", e.Err)
 }
 
+// ErrServerRangeHeaderInvalid is an error that can be returned by a server.
+type ErrServerRangeHeaderInvalid struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e ErrServerRangeHeaderInvalid) Error() string {
+	return fmt.Sprintf("invalid range header: %v", e.Err)
+}
+
+// ErrServerScaleHeaderInvalid is an error that can be returned by a server.
+type ErrServerScaleHeaderInvalid struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e ErrServerScaleHeaderInvalid) Error() string {
+	return fmt.Sprintf("invalid scale header: %v", e.Err)
+}
+
 // ErrServerMediaAlreadySetup is an error that can be returned by a server.
 type ErrServerMediaAlreadySetup struct{}
 
@@ -259,3 +279,71 @@ type ErrServerUnexpectedFrame struct{}
 func (e ErrServerUnexpectedFrame) Error() string {
 	return "received unexpected interleaved frame"
 }
+
+// ErrServerUnexpectedResponse is an error that can be returned by a server.
+type ErrServerUnexpectedResponse struct{}
+
+// Error implements the error interface.
+func (e ErrServerUnexpectedResponse) Error() string {
+	return "received unexpected response"
+}
+
+// ErrServerTooManyConnections is an error that can be returned by a server.
+type ErrServerTooManyConnections struct{}
+
+// Error implements the error interface.
+func (e ErrServerTooManyConnections) Error() string {
+	return "too many connections"
+}
+
+// ErrServerTooManyConnectionsPerIP is an error that can be returned by a server.
+type ErrServerTooManyConnectionsPerIP struct {
+	IP net.IP
+}
+
+// Error implements the error interface.
+func (e ErrServerTooManyConnectionsPerIP) Error() string {
+	return fmt.Sprintf("too many connections from IP %v", e.IP)
+}
+
+// ErrServerTooManySessions is an error that can be returned by a server.
+type ErrServerTooManySessions struct{}
+
+// Error implements the error interface.
+func (e ErrServerTooManySessions) Error() string {
+	return "too many sessions"
+}
+
+// ErrServerTooManySessionsPerIP is an error that can be returned by a server.
+type ErrServerTooManySessionsPerIP struct {
+	IP net.IP
+}
+
+// Error implements the error interface.
+func (e ErrServerTooManySessionsPerIP) Error() string {
+	return fmt.Sprintf("too many sessions from IP %v", e.IP)
+}
+
+// ErrServerRequestRateExceeded is an error that can be returned by a server.
+type ErrServerRequestRateExceeded struct{}
+
+// Error implements the error interface.
+func (e ErrServerRequestRateExceeded) Error() string {
+	return "request rate exceeded"
+}
+
+// ErrServerFirstRequestTimedOut is an error that can be returned by a server.
+type ErrServerFirstRequestTimedOut struct{}
+
+// Error implements the error interface.
+func (e ErrServerFirstRequestTimedOut) Error() string {
+	return "no request received within the timeout"
+}
+
+// ErrServerShutdown is an error that can be returned by a server.
+type ErrServerShutdown struct{}
+
+// Error implements the error interface.
+func (e ErrServerShutdown) Error() string {
+	return "server is shutting down"
+}
@@ -137,9 +137,31 @@ func (m Media) Marshal() *psdp.MediaDescription {
 		}
 	}
 
+	for _, forma := range m.Formats {
+		if fma, ok := forma.(format.MediaAttributesMarshaler); ok {
+			// media-level attributes are shared by all formats,
+			// write only the ones that are not present yet.
+			existing := md.Attributes
+			for _, attr := range fma.MarshalMediaAttributes() {
+				if !hasAttribute(existing, attr.Key) {
+					md.Attributes = append(md.Attributes, attr)
+				}
+			}
+		}
+	}
+
 	return md
 }
 
+func hasAttribute(attributes []psdp.Attribute, key string) bool {
+	for _, attr := range attributes {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
 // URL returns the media URL.
 func (m Media) URL(contentBase *url.URL) (*url.URL, error) {
 	if contentBase == nil {
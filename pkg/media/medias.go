@@ -12,13 +12,54 @@ import (
 // Medias is a list of media streams.
 type Medias []*Media
 
+// session-level attributes that are inherited by medias
+// when they are not present at the media level.
+// Specification: https://datatracker.ietf.org/doc/html/rfc7273#section-5
+var inheritedSessionAttributes = []string{"ts-refclk", "mediaclk"}
+
+func inheritSessionAttributes(md *psdp.MediaDescription, attributes []psdp.Attribute) *psdp.MediaDescription {
+	var inherited []psdp.Attribute
+
+	for _, key := range inheritedSessionAttributes {
+		if _, ok := md.Attribute(key); ok {
+			continue
+		}
+
+		for _, attr := range attributes {
+			if attr.Key == key {
+				inherited = append(inherited, attr)
+			}
+		}
+	}
+
+	if inherited == nil {
+		return md
+	}
+
+	md2 := *md
+	md2.Attributes = append(append([]psdp.Attribute(nil), md.Attributes...), inherited...)
+	return &md2
+}
+
 // Unmarshal decodes medias from the SDP format.
+// Session-level attributes are ignored; use UnmarshalSessionDescription to take them into account.
 func (ms *Medias) Unmarshal(mds []*psdp.MediaDescription) error {
+	return ms.unmarshal(mds, nil)
+}
+
+// UnmarshalSessionDescription decodes medias from a session description.
+// Session-level clock attributes (ts-refclk, mediaclk) are inherited by medias
+// that don't override them.
+func (ms *Medias) UnmarshalSessionDescription(sd *sdp.SessionDescription) error {
+	return ms.unmarshal(sd.MediaDescriptions, sd.Attributes)
+}
+
+func (ms *Medias) unmarshal(mds []*psdp.MediaDescription, sessionAttributes []psdp.Attribute) error {
 	*ms = make(Medias, len(mds))
 
 	for i, md := range mds {
 		var m Media
-		err := m.unmarshal(md)
+		err := m.unmarshal(inheritSessionAttributes(md, sessionAttributes))
 		if err != nil {
 			return fmt.Errorf("media %d is invalid: %v", i+1, err)
 		}
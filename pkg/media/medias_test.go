@@ -2,6 +2,7 @@ package media
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -245,10 +246,9 @@ var casesMedias = []struct {
 						ClockRat:   32000,
 					},
 					&format.G722{},
-					&format.Generic{
-						PayloadTyp: 102,
-						RTPMap:     "ILBC/8000",
-						ClockRat:   8000,
+					&format.ILBC{
+						PayloadTyp:   102,
+						EncodingName: "ILBC",
 					},
 					&format.G711{
 						MULaw: true,
@@ -256,40 +256,33 @@ var casesMedias = []struct {
 					&format.G711{
 						MULaw: false,
 					},
-					&format.Generic{
+					&format.CN{
 						PayloadTyp: 106,
-						RTPMap:     "CN/32000",
-						ClockRat:   32000,
+						SampleRate: 32000,
 					},
-					&format.Generic{
+					&format.CN{
 						PayloadTyp: 105,
-						RTPMap:     "CN/16000",
-						ClockRat:   16000,
+						SampleRate: 16000,
 					},
-					&format.Generic{
+					&format.CN{
 						PayloadTyp: 13,
-						RTPMap:     "CN/8000",
-						ClockRat:   8000,
+						SampleRate: 8000,
 					},
-					&format.Generic{
+					&format.TelephoneEvent{
 						PayloadTyp: 110,
-						RTPMap:     "telephone-event/48000",
-						ClockRat:   48000,
+						SampleRate: 48000,
 					},
-					&format.Generic{
+					&format.TelephoneEvent{
 						PayloadTyp: 112,
-						RTPMap:     "telephone-event/32000",
-						ClockRat:   32000,
+						SampleRate: 32000,
 					},
-					&format.Generic{
+					&format.TelephoneEvent{
 						PayloadTyp: 113,
-						RTPMap:     "telephone-event/16000",
-						ClockRat:   16000,
+						SampleRate: 16000,
 					},
-					&format.Generic{
+					&format.TelephoneEvent{
 						PayloadTyp: 126,
-						RTPMap:     "telephone-event/8000",
-						ClockRat:   8000,
+						SampleRate: 8000,
 					},
 				},
 			},
@@ -389,6 +382,78 @@ var casesMedias = []struct {
 			},
 		},
 	},
+	{
+		"aes67",
+		"v=0\r\n" +
+			"o=- 1311738121 1311738121 IN IP4 192.168.1.1\r\n" +
+			"s=Stage left I/O\r\n" +
+			"c=IN IP4 239.0.0.1/32\r\n" +
+			"t=0 0\r\n" +
+			"m=audio 5004 RTP/AVP 96\r\n" +
+			"i=Channels 1-8\r\n" +
+			"a=rtpmap:96 L24/48000/8\r\n" +
+			"a=fmtp:96 channel-order=SMPTE2110.(SGRP,SGRP)\r\n" +
+			"a=recvonly\r\n" +
+			"a=ptime:1\r\n" +
+			"a=ts-refclk:ptp=IEEE1588-2008:39-A7-94-FF-FE-07-CB-D0:0\r\n" +
+			"a=mediaclk:direct=963214424\r\n",
+		"v=0\r\n" +
+			"o=- 0 0 IN IP4 127.0.0.1\r\n" +
+			"s=Stream\r\n" +
+			"c=IN IP4 0.0.0.0\r\n" +
+			"t=0 0\r\n" +
+			"m=audio 0 RTP/AVP 96\r\n" +
+			"a=control\r\n" +
+			"a=recvonly\r\n" +
+			"a=rtpmap:96 L24/48000/8\r\n" +
+			"a=fmtp:96 channel-order=SMPTE2110.(SGRP,SGRP)\r\n" +
+			"a=ptime:1\r\n" +
+			"a=ts-refclk:ptp=IEEE1588-2008:39-A7-94-FF-FE-07-CB-D0:0\r\n" +
+			"a=mediaclk:direct=963214424\r\n",
+		Medias{
+			{
+				Type:      "audio",
+				Direction: DirectionRecvonly,
+				Formats: []format.Format{&format.LPCM{
+					PayloadTyp:      96,
+					BitDepth:        24,
+					SampleRate:      48000,
+					ChannelCount:    8,
+					PacketTime:      1 * time.Millisecond,
+					ChannelOrder:    "SMPTE2110.(SGRP,SGRP)",
+					ReferenceClocks: []string{"ptp=IEEE1588-2008:39-A7-94-FF-FE-07-CB-D0:0"},
+					MediaClock:      "direct=963214424",
+				}},
+			},
+		},
+	},
+	{
+		"ilbc mode",
+		"v=0\r\n" +
+			"o=- 0 0 IN IP4 127.0.0.1\r\n" +
+			"s=Stream\r\n" +
+			"m=audio 0 RTP/AVP 98\r\n" +
+			"a=rtpmap:98 iLBC/8000\r\n" +
+			"a=fmtp:98 mode=20\r\n",
+		"v=0\r\n" +
+			"o=- 0 0 IN IP4 127.0.0.1\r\n" +
+			"s=Stream\r\n" +
+			"c=IN IP4 0.0.0.0\r\n" +
+			"t=0 0\r\n" +
+			"m=audio 0 RTP/AVP 98\r\n" +
+			"a=control\r\n" +
+			"a=rtpmap:98 iLBC/8000\r\n" +
+			"a=fmtp:98 mode=20\r\n",
+		Medias{
+			{
+				Type: "audio",
+				Formats: []format.Format{&format.ILBC{
+					PayloadTyp: 98,
+					Mode:       20,
+				}},
+			},
+		},
+	},
 	{
 		"onvif back channel",
 		"v=0\r\n" +
@@ -462,6 +527,53 @@ func TestMediasUnmarshal(t *testing.T) {
 	}
 }
 
+func TestMediasUnmarshalSessionDescription(t *testing.T) {
+	var sd sdp.SessionDescription
+	err := sd.Unmarshal([]byte("v=0\r\n" +
+		"o=- 1311738121 1311738121 IN IP4 192.168.1.1\r\n" +
+		"s=Stage left I/O\r\n" +
+		"t=0 0\r\n" +
+		"a=ts-refclk:ptp=IEEE1588-2008:39-A7-94-FF-FE-07-CB-D0:0\r\n" +
+		"a=mediaclk:direct=963214424\r\n" +
+		"m=audio 5004 RTP/AVP 96\r\n" +
+		"a=rtpmap:96 L24/48000/2\r\n" +
+		"m=audio 5006 RTP/AVP 97\r\n" +
+		"a=rtpmap:97 L24/48000/2\r\n" +
+		"a=mediaclk:direct=0\r\n"))
+	require.NoError(t, err)
+
+	var medias Medias
+	err = medias.UnmarshalSessionDescription(&sd)
+	require.NoError(t, err)
+	require.Equal(t, Medias{
+		{
+			Type: "audio",
+			Formats: []format.Format{&format.LPCM{
+				PayloadTyp:      96,
+				BitDepth:        24,
+				SampleRate:      48000,
+				ChannelCount:    2,
+				ReferenceClocks: []string{"ptp=IEEE1588-2008:39-A7-94-FF-FE-07-CB-D0:0"},
+				MediaClock:      "direct=963214424",
+			}},
+		},
+		{
+			Type: "audio",
+			Formats: []format.Format{&format.LPCM{
+				PayloadTyp:      97,
+				BitDepth:        24,
+				SampleRate:      48000,
+				ChannelCount:    2,
+				ReferenceClocks: []string{"ptp=IEEE1588-2008:39-A7-94-FF-FE-07-CB-D0:0"},
+				MediaClock:      "direct=0",
+			}},
+		},
+	}, medias)
+
+	// media descriptions are not modified
+	require.Equal(t, 1, len(sd.MediaDescriptions[0].Attributes))
+}
+
 func TestMediasReadErrors(t *testing.T) {
 	for _, ca := range []struct {
 		name string
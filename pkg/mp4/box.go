@@ -0,0 +1,93 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+type box struct {
+	typ     string
+	content []byte
+}
+
+// parseBoxes parses a sequence of boxes.
+func parseBoxes(buf []byte) ([]*box, error) {
+	var ret []*box
+
+	for len(buf) > 0 {
+		if len(buf) < 8 {
+			return nil, fmt.Errorf("invalid box header")
+		}
+
+		size := uint64(binary.BigEndian.Uint32(buf))
+		typ := string(buf[4:8])
+		headerSize := uint64(8)
+
+		switch size {
+		case 0: // box extends to the end of the buffer
+			size = uint64(len(buf))
+
+		case 1: // 64-bit size
+			if len(buf) < 16 {
+				return nil, fmt.Errorf("invalid box header")
+			}
+			size = binary.BigEndian.Uint64(buf[8:])
+			headerSize = 16
+		}
+
+		if size < headerSize || size > uint64(len(buf)) {
+			return nil, fmt.Errorf("invalid size of box '%s'", typ)
+		}
+
+		ret = append(ret, &box{
+			typ:     typ,
+			content: buf[headerSize:size],
+		})
+		buf = buf[size:]
+	}
+
+	return ret, nil
+}
+
+// findBox returns the first box with the given type.
+func findBox(boxes []*box, typ string) *box {
+	for _, b := range boxes {
+		if b.typ == typ {
+			return b
+		}
+	}
+	return nil
+}
+
+// findBoxes returns all the boxes with the given type.
+func findBoxes(boxes []*box, typ string) []*box {
+	var ret []*box
+	for _, b := range boxes {
+		if b.typ == typ {
+			ret = append(ret, b)
+		}
+	}
+	return ret
+}
+
+// findChildBox returns the first box that matches the given path.
+func findChildBox(boxes []*box, path ...string) (*box, error) {
+	for i, typ := range path {
+		b := findBox(boxes, typ)
+		if b == nil {
+			return nil, fmt.Errorf("box '%s' not found", typ)
+		}
+
+		if i == len(path)-1 {
+			return b, nil
+		}
+
+		var err error
+		boxes, err = parseBoxes(b.content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("empty path")
+}
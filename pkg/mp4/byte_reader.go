@@ -0,0 +1,88 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// byteReader reads big-endian fields from a buffer.
+// When the buffer is exhausted, all subsequent reads return zero and err is set.
+type byteReader struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func (r *byteReader) take(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || (len(r.buf)-r.pos) < n {
+		r.err = fmt.Errorf("not enough bytes")
+		return nil
+	}
+	v := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return v
+}
+
+func (r *byteReader) skip(n int) {
+	r.take(n)
+}
+
+func (r *byteReader) remaining() []byte {
+	if r.err != nil {
+		return nil
+	}
+	return r.buf[r.pos:]
+}
+
+func (r *byteReader) readUint8() uint8 {
+	v := r.take(1)
+	if v == nil {
+		return 0
+	}
+	return v[0]
+}
+
+func (r *byteReader) readUint16() uint16 {
+	v := r.take(2)
+	if v == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint16(v)
+}
+
+func (r *byteReader) readUint24() uint32 {
+	v := r.take(3)
+	if v == nil {
+		return 0
+	}
+	return uint32(v[0])<<16 | uint32(v[1])<<8 | uint32(v[2])
+}
+
+func (r *byteReader) readUint32() uint32 {
+	v := r.take(4)
+	if v == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(v)
+}
+
+func (r *byteReader) readUint64() uint64 {
+	v := r.take(8)
+	if v == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(v)
+}
+
+func (r *byteReader) readBytes(n int) []byte {
+	return r.take(n)
+}
+
+// readFullBoxHeader reads version and flags of a full box.
+func (r *byteReader) readFullBoxHeader() (uint8, uint32) {
+	v := r.readUint32()
+	return uint8(v >> 24), v & 0xFFFFFF
+}
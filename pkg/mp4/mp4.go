@@ -0,0 +1,14 @@
+// Package mp4 contains a MP4 reader.
+package mp4
+
+import (
+	"time"
+)
+
+// convert a timestamp expressed in the given timescale into a duration.
+func durationMP4ToGo(v int64, timeScale uint32) time.Duration {
+	timeScale64 := int64(timeScale)
+	secs := v / timeScale64
+	dec := v % timeScale64
+	return time.Duration(secs)*time.Second + time.Duration(dec)*time.Second/time.Duration(timeScale64)
+}
@@ -0,0 +1,915 @@
+package mp4
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/aler9/gortsplib/v2/pkg/format"
+	"github.com/aler9/gortsplib/v2/pkg/media"
+)
+
+const (
+	// sample_is_non_sync_sample
+	sampleFlagIsNonSync = 0x00010000
+
+	// maximum size of a moov or moof box.
+	maxMetadataBoxSize = 64 * 1024 * 1024
+
+	// maximum size of a sample.
+	maxSampleSize = 64 * 1024 * 1024
+
+	// maximum number of samples of a track.
+	maxTrackSamples = 16 * 1024 * 1024
+)
+
+// checkSampleCount checks that a sample count is plausible, in order to prevent
+// crafted files from allocating huge amounts of memory.
+// Samples are stored in the file, therefore they can't exceed its size.
+func checkSampleCount(count int, sampleSize uint32, fileSize int64) bool {
+	if count > maxTrackSamples {
+		return false
+	}
+
+	if sampleSize == 0 {
+		sampleSize = 1
+	}
+
+	return uint64(count)*uint64(sampleSize) <= uint64(fileSize)
+}
+
+// Sample is a sample read from a MP4 file.
+type Sample struct {
+	// presentation timestamp.
+	PTS time.Duration
+
+	// decoding timestamp.
+	DTS time.Duration
+
+	// whether the sample is a random access point.
+	IsSync bool
+
+	// sample content.
+	// H264 and H265 access units are in AVCC format.
+	Payload []byte
+}
+
+type sampleInfo struct {
+	offset    int64
+	size      uint32
+	dts       int64
+	ptsOffset int32
+	isSync    bool
+}
+
+// Track is a track of a MP4 file.
+type Track struct {
+	// track ID.
+	ID int
+
+	// timescale of the track.
+	TimeScale uint32
+
+	// media that contains the format of the track.
+	Media *media.Media
+
+	// format of the track.
+	Format format.Format
+
+	samples []*sampleInfo
+	endDTS  int64
+	cursor  int
+}
+
+func (t *Track) isVideo() bool {
+	return t.Media.Type == media.TypeVideo
+}
+
+func (t *Track) sampleDTS(i int) time.Duration {
+	return durationMP4ToGo(t.samples[i].dts, t.TimeScale)
+}
+
+func (t *Track) samplePTS(i int) time.Duration {
+	return durationMP4ToGo(t.samples[i].dts+int64(t.samples[i].ptsOffset), t.TimeScale)
+}
+
+type trackDefaults struct {
+	sampleDuration uint32
+	sampleSize     uint32
+	sampleFlags    uint32
+}
+
+// Reader is a MP4 reader.
+// It supports both progressive and fragmented files.
+type Reader struct {
+	r        io.ReadSeeker
+	fileSize int64
+	tracks   []*Track
+	duration time.Duration
+}
+
+// NewReader allocates a Reader.
+// It reads metadata of the whole file, in order to build an index of all samples.
+// Tracks with unsupported codecs are skipped.
+func NewReader(r io.ReadSeeker) (*Reader, error) {
+	rd := &Reader{
+		r: r,
+	}
+
+	err := rd.readMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rd.tracks) == 0 {
+		return nil, fmt.Errorf("no supported tracks found")
+	}
+
+	for _, track := range rd.tracks {
+		// samples of fragmented files are not guaranteed to be in order
+		sort.SliceStable(track.samples, func(i, j int) bool {
+			return track.samples[i].dts < track.samples[j].dts
+		})
+
+		d := durationMP4ToGo(track.endDTS, track.TimeScale)
+		if d > rd.duration {
+			rd.duration = d
+		}
+	}
+
+	return rd, nil
+}
+
+func (rd *Reader) readMetadata() error {
+	fileSize, err := rd.r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	rd.fileSize = fileSize
+
+	var defaults map[int]*trackDefaults
+	tracksByID := make(map[int]*Track)
+	pos := int64(0)
+	moovFound := false
+
+	for pos < fileSize {
+		typ, headerSize, size, err := rd.readBoxHeader(pos, fileSize)
+		if err != nil {
+			return err
+		}
+
+		switch typ {
+		case "moov", "moof":
+			if size > maxMetadataBoxSize {
+				return fmt.Errorf("box '%s' is too big", typ)
+			}
+
+			content := make([]byte, size-headerSize)
+			_, err := io.ReadFull(rd.r, content)
+			if err != nil {
+				return err
+			}
+
+			if typ == "moov" {
+				defaults, err = rd.parseMoov(content)
+				if err != nil {
+					return err
+				}
+
+				for _, track := range rd.tracks {
+					tracksByID[track.ID] = track
+				}
+				moovFound = true
+			} else {
+				if !moovFound {
+					return fmt.Errorf("moof box found before moov box")
+				}
+
+				err := parseMoof(content, pos, fileSize, tracksByID, defaults)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		pos += size
+	}
+
+	if !moovFound {
+		return fmt.Errorf("moov box not found")
+	}
+
+	return nil
+}
+
+func (rd *Reader) readBoxHeader(pos int64, fileSize int64) (string, int64, int64, error) {
+	_, err := rd.r.Seek(pos, io.SeekStart)
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	var header [16]byte
+	_, err = io.ReadFull(rd.r, header[:8])
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	size := int64(uint32(header[0])<<24 | uint32(header[1])<<16 | uint32(header[2])<<8 | uint32(header[3]))
+	typ := string(header[4:8])
+	headerSize := int64(8)
+
+	switch size {
+	case 0: // box extends to the end of the file
+		size = fileSize - pos
+
+	case 1: // 64-bit size
+		_, err = io.ReadFull(rd.r, header[8:16])
+		if err != nil {
+			return "", 0, 0, err
+		}
+
+		r := &byteReader{buf: header[8:16]}
+		size = int64(r.readUint64())
+		headerSize = 16
+	}
+
+	if size < headerSize || size > (fileSize-pos) {
+		return "", 0, 0, fmt.Errorf("invalid size of box '%s'", typ)
+	}
+
+	return typ, headerSize, size, nil
+}
+
+func (rd *Reader) parseMoov(buf []byte) (map[int]*trackDefaults, error) {
+	boxes, err := parseBoxes(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, trak := range findBoxes(boxes, "trak") {
+		track, err := parseTrak(trak.content, uint8(96+len(rd.tracks)), rd.fileSize)
+		if err != nil {
+			if errors.Is(err, errUnsupportedCodec) {
+				continue
+			}
+			return nil, err
+		}
+
+		rd.tracks = append(rd.tracks, track)
+	}
+
+	defaults := make(map[int]*trackDefaults)
+
+	if mvex := findBox(boxes, "mvex"); mvex != nil {
+		children, err := parseBoxes(mvex.content)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, trex := range findBoxes(children, "trex") {
+			r := &byteReader{buf: trex.content}
+			r.readFullBoxHeader()
+			trackID := int(r.readUint32())
+			r.skip(4) // default sample description index
+			d := &trackDefaults{
+				sampleDuration: r.readUint32(),
+				sampleSize:     r.readUint32(),
+				sampleFlags:    r.readUint32(),
+			}
+			if r.err != nil {
+				return nil, fmt.Errorf("invalid trex: %v", r.err)
+			}
+
+			defaults[trackID] = d
+		}
+	}
+
+	return defaults, nil
+}
+
+func parseTrak(buf []byte, payloadType uint8, fileSize int64) (*Track, error) {
+	boxes, err := parseBoxes(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	tkhd, err := findChildBox(boxes, "tkhd")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &byteReader{buf: tkhd.content}
+	version, _ := r.readFullBoxHeader()
+	if version == 1 {
+		r.skip(16) // creation time, modification time
+	} else {
+		r.skip(8)
+	}
+	track := &Track{
+		ID: int(r.readUint32()),
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("invalid tkhd: %v", r.err)
+	}
+
+	mdhd, err := findChildBox(boxes, "mdia", "mdhd")
+	if err != nil {
+		return nil, err
+	}
+
+	r = &byteReader{buf: mdhd.content}
+	version, _ = r.readFullBoxHeader()
+	if version == 1 {
+		r.skip(16)
+	} else {
+		r.skip(8)
+	}
+	track.TimeScale = r.readUint32()
+	if r.err != nil {
+		return nil, fmt.Errorf("invalid mdhd: %v", r.err)
+	}
+	if track.TimeScale == 0 {
+		return nil, fmt.Errorf("invalid timescale")
+	}
+
+	stbl, err := findChildBox(boxes, "mdia", "minf", "stbl")
+	if err != nil {
+		return nil, err
+	}
+
+	stblBoxes, err := parseBoxes(stbl.content)
+	if err != nil {
+		return nil, err
+	}
+
+	stsd := findBox(stblBoxes, "stsd")
+	if stsd == nil {
+		return nil, fmt.Errorf("box 'stsd' not found")
+	}
+
+	track.Format, err = parseStsd(stsd.content, payloadType)
+	if err != nil {
+		return nil, err
+	}
+
+	switch track.Format.(type) {
+	case *format.H264, *format.H265, *format.VP9:
+		track.Media = &media.Media{Type: media.TypeVideo}
+	default:
+		track.Media = &media.Media{Type: media.TypeAudio}
+	}
+	track.Media.Formats = []format.Format{track.Format}
+
+	err = parseSampleTable(track, stblBoxes, fileSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// apply the media time of the edit list, that is used to compensate
+	// the composition offset of the first sample.
+	if elst, err := findChildBox(boxes, "edts", "elst"); err == nil {
+		mediaTime, err := parseElstMediaTime(elst.content)
+		if err != nil {
+			return nil, err
+		}
+
+		if mediaTime > 0 {
+			for _, sample := range track.samples {
+				sample.dts -= mediaTime
+			}
+			track.endDTS -= mediaTime
+		}
+	}
+
+	return track, nil
+}
+
+// parseElstMediaTime returns the media time of the first non-empty edit.
+func parseElstMediaTime(buf []byte) (int64, error) {
+	r := &byteReader{buf: buf}
+	version, _ := r.readFullBoxHeader()
+	entryCount := int(r.readUint32())
+
+	for i := 0; i < entryCount; i++ {
+		var mediaTime int64
+		if version == 1 {
+			r.skip(8) // segment duration
+			mediaTime = int64(r.readUint64())
+		} else {
+			r.skip(4)
+			mediaTime = int64(int32(r.readUint32()))
+		}
+		r.skip(4) // media rate
+
+		if r.err != nil {
+			return 0, fmt.Errorf("invalid elst: %v", r.err)
+		}
+
+		// -1 means an empty edit
+		if mediaTime >= 0 {
+			return mediaTime, nil
+		}
+	}
+
+	return 0, nil
+}
+
+func parseSampleTable(track *Track, boxes []*box, fileSize int64) error {
+	stts := findBox(boxes, "stts")
+	stsz := findBox(boxes, "stsz")
+	stsc := findBox(boxes, "stsc")
+	stco := findBox(boxes, "stco")
+	co64 := findBox(boxes, "co64")
+
+	if stts == nil || stsz == nil || stsc == nil || (stco == nil && co64 == nil) {
+		return fmt.Errorf("sample table is incomplete")
+	}
+
+	// sizes
+	r := &byteReader{buf: stsz.content}
+	r.readFullBoxHeader()
+	sampleSize := r.readUint32()
+	sampleCount := int(r.readUint32())
+	if r.err != nil {
+		return fmt.Errorf("invalid stsz: %v", r.err)
+	}
+
+	// samples of fragmented files are stored in moof boxes
+	if sampleCount == 0 {
+		return nil
+	}
+
+	if sampleSize == 0 {
+		if len(r.remaining()) < sampleCount*4 {
+			return fmt.Errorf("invalid stsz")
+		}
+	} else if !checkSampleCount(sampleCount, sampleSize, fileSize) {
+		return fmt.Errorf("invalid stsz: too many samples")
+	}
+
+	track.samples = make([]*sampleInfo, sampleCount)
+	for i := range track.samples {
+		track.samples[i] = &sampleInfo{
+			isSync: true,
+		}
+
+		if sampleSize != 0 {
+			track.samples[i].size = sampleSize
+		} else {
+			track.samples[i].size = r.readUint32()
+		}
+	}
+
+	// timestamps
+	r = &byteReader{buf: stts.content}
+	r.readFullBoxHeader()
+	entryCount := int(r.readUint32())
+	i := 0
+	dts := int64(0)
+
+	for e := 0; e < entryCount; e++ {
+		count := int(r.readUint32())
+		delta := int64(r.readUint32())
+		if r.err != nil {
+			return fmt.Errorf("invalid stts: %v", r.err)
+		}
+
+		for j := 0; j < count && i < sampleCount; j++ {
+			track.samples[i].dts = dts
+			dts += delta
+			i++
+		}
+	}
+	if i != sampleCount {
+		return fmt.Errorf("invalid stts: sample count mismatch")
+	}
+	track.endDTS = dts
+
+	// composition offsets
+	if ctts := findBox(boxes, "ctts"); ctts != nil {
+		r = &byteReader{buf: ctts.content}
+		r.readFullBoxHeader()
+		entryCount = int(r.readUint32())
+		i = 0
+
+		for e := 0; e < entryCount; e++ {
+			count := int(r.readUint32())
+			offset := int32(r.readUint32())
+			if r.err != nil {
+				return fmt.Errorf("invalid ctts: %v", r.err)
+			}
+
+			for j := 0; j < count && i < sampleCount; j++ {
+				track.samples[i].ptsOffset = offset
+				i++
+			}
+		}
+	}
+
+	// sync samples
+	if stss := findBox(boxes, "stss"); stss != nil {
+		for _, sample := range track.samples {
+			sample.isSync = false
+		}
+
+		r = &byteReader{buf: stss.content}
+		r.readFullBoxHeader()
+		entryCount = int(r.readUint32())
+
+		for e := 0; e < entryCount; e++ {
+			n := int(r.readUint32())
+			if r.err != nil {
+				return fmt.Errorf("invalid stss: %v", r.err)
+			}
+
+			if n >= 1 && n <= sampleCount {
+				track.samples[n-1].isSync = true
+			}
+		}
+	}
+
+	// chunk offsets
+	var chunkOffsets []int64
+
+	if stco != nil {
+		r = &byteReader{buf: stco.content}
+		r.readFullBoxHeader()
+		entryCount = int(r.readUint32())
+		for e := 0; e < entryCount; e++ {
+			chunkOffsets = append(chunkOffsets, int64(r.readUint32()))
+		}
+		if r.err != nil {
+			return fmt.Errorf("invalid stco: %v", r.err)
+		}
+	} else {
+		r = &byteReader{buf: co64.content}
+		r.readFullBoxHeader()
+		entryCount = int(r.readUint32())
+		for e := 0; e < entryCount; e++ {
+			chunkOffsets = append(chunkOffsets, int64(r.readUint64()))
+		}
+		if r.err != nil {
+			return fmt.Errorf("invalid co64: %v", r.err)
+		}
+	}
+
+	// samples to chunks
+	type stscEntry struct {
+		firstChunk      int
+		samplesPerChunk int
+	}
+	var stscEntries []stscEntry
+
+	r = &byteReader{buf: stsc.content}
+	r.readFullBoxHeader()
+	entryCount = int(r.readUint32())
+	for e := 0; e < entryCount; e++ {
+		entry := stscEntry{
+			firstChunk:      int(r.readUint32()),
+			samplesPerChunk: int(r.readUint32()),
+		}
+		r.skip(4) // sample description index
+		stscEntries = append(stscEntries, entry)
+	}
+	if r.err != nil {
+		return fmt.Errorf("invalid stsc: %v", r.err)
+	}
+
+	i = 0
+	e := 0
+
+	for c, chunkOffset := range chunkOffsets {
+		for (e+1) < len(stscEntries) && stscEntries[e+1].firstChunk <= (c+1) {
+			e++
+		}
+		if e >= len(stscEntries) {
+			break
+		}
+
+		offset := chunkOffset
+		for j := 0; j < stscEntries[e].samplesPerChunk && i < sampleCount; j++ {
+			track.samples[i].offset = offset
+			offset += int64(track.samples[i].size)
+			i++
+		}
+	}
+	if i != sampleCount {
+		return fmt.Errorf("invalid stsc: sample count mismatch")
+	}
+
+	return nil
+}
+
+func parseMoof(
+	buf []byte,
+	moofOffset int64,
+	fileSize int64,
+	tracksByID map[int]*Track,
+	defaults map[int]*trackDefaults,
+) error {
+	boxes, err := parseBoxes(buf)
+	if err != nil {
+		return err
+	}
+
+	for i, traf := range findBoxes(boxes, "traf") {
+		err := parseTraf(traf.content, moofOffset, fileSize, i == 0, tracksByID, defaults)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseTraf(
+	buf []byte,
+	moofOffset int64,
+	fileSize int64,
+	isFirst bool,
+	tracksByID map[int]*Track,
+	defaults map[int]*trackDefaults,
+) error {
+	boxes, err := parseBoxes(buf)
+	if err != nil {
+		return err
+	}
+
+	tfhd := findBox(boxes, "tfhd")
+	if tfhd == nil {
+		return fmt.Errorf("box 'tfhd' not found")
+	}
+
+	r := &byteReader{buf: tfhd.content}
+	_, flags := r.readFullBoxHeader()
+	trackID := int(r.readUint32())
+
+	track, ok := tracksByID[trackID]
+	if !ok {
+		// track is not supported
+		return nil
+	}
+
+	d := trackDefaults{}
+	if v, ok := defaults[trackID]; ok {
+		d = *v
+	}
+
+	baseOffset := moofOffset
+	if (flags & 0x000001) != 0 {
+		baseOffset = int64(r.readUint64())
+	} else if (flags&0x020000) == 0 && !isFirst && len(track.samples) != 0 {
+		// base offset is the end of the data of the previous traf
+		last := track.samples[len(track.samples)-1]
+		baseOffset = last.offset + int64(last.size)
+	}
+	if (flags & 0x000002) != 0 {
+		r.skip(4) // sample description index
+	}
+	if (flags & 0x000008) != 0 {
+		d.sampleDuration = r.readUint32()
+	}
+	if (flags & 0x000010) != 0 {
+		d.sampleSize = r.readUint32()
+	}
+	if (flags & 0x000020) != 0 {
+		d.sampleFlags = r.readUint32()
+	}
+	if r.err != nil {
+		return fmt.Errorf("invalid tfhd: %v", r.err)
+	}
+
+	dts := track.endDTS
+
+	if tfdt := findBox(boxes, "tfdt"); tfdt != nil {
+		r = &byteReader{buf: tfdt.content}
+		version, _ := r.readFullBoxHeader()
+		if version == 1 {
+			dts = int64(r.readUint64())
+		} else {
+			dts = int64(r.readUint32())
+		}
+		if r.err != nil {
+			return fmt.Errorf("invalid tfdt: %v", r.err)
+		}
+	}
+
+	dataOffset := baseOffset
+
+	for _, trun := range findBoxes(boxes, "trun") {
+		r = &byteReader{buf: trun.content}
+		_, flags := r.readFullBoxHeader()
+		sampleCount := int(r.readUint32())
+
+		if (flags & 0x000001) != 0 {
+			dataOffset = baseOffset + int64(int32(r.readUint32()))
+		}
+
+		firstSampleFlags := d.sampleFlags
+		firstSampleFlagsPresent := (flags & 0x000004) != 0
+		if firstSampleFlagsPresent {
+			firstSampleFlags = r.readUint32()
+		}
+
+		if r.err != nil {
+			return fmt.Errorf("invalid trun: %v", r.err)
+		}
+
+		// per-sample fields are stored in the box
+		fieldsSize := 0
+		for _, flag := range []uint32{0x000100, 0x000200, 0x000400, 0x000800} {
+			if (flags & flag) != 0 {
+				fieldsSize += 4
+			}
+		}
+
+		if fieldsSize != 0 {
+			if len(r.remaining()) < sampleCount*fieldsSize {
+				return fmt.Errorf("invalid trun")
+			}
+		}
+
+		if !checkSampleCount(len(track.samples)+sampleCount, d.sampleSize, fileSize) {
+			return fmt.Errorf("invalid trun: too many samples")
+		}
+
+		for i := 0; i < sampleCount; i++ {
+			duration := d.sampleDuration
+			if (flags & 0x000100) != 0 {
+				duration = r.readUint32()
+			}
+
+			size := d.sampleSize
+			if (flags & 0x000200) != 0 {
+				size = r.readUint32()
+			}
+
+			sampleFlags := d.sampleFlags
+			if (flags & 0x000400) != 0 {
+				sampleFlags = r.readUint32()
+			}
+			if i == 0 && firstSampleFlagsPresent {
+				sampleFlags = firstSampleFlags
+			}
+
+			var ptsOffset int32
+			if (flags & 0x000800) != 0 {
+				ptsOffset = int32(r.readUint32())
+			}
+
+			if r.err != nil {
+				return fmt.Errorf("invalid trun: %v", r.err)
+			}
+
+			track.samples = append(track.samples, &sampleInfo{
+				offset:    dataOffset,
+				size:      size,
+				dts:       dts,
+				ptsOffset: ptsOffset,
+				isSync:    (sampleFlags & sampleFlagIsNonSync) == 0,
+			})
+
+			dataOffset += int64(size)
+			dts += int64(duration)
+		}
+	}
+
+	track.endDTS = dts
+
+	return nil
+}
+
+// Tracks returns the tracks of the file.
+func (rd *Reader) Tracks() []*Track {
+	return rd.tracks
+}
+
+// Medias returns the medias of the file.
+// Each track is associated with a media.
+func (rd *Reader) Medias() media.Medias {
+	ret := make(media.Medias, len(rd.tracks))
+	for i, track := range rd.tracks {
+		ret[i] = track.Media
+	}
+	return ret
+}
+
+// Duration returns the duration of the file.
+func (rd *Reader) Duration() time.Duration {
+	return rd.duration
+}
+
+func (rd *Reader) leadingTrack() *Track {
+	for _, track := range rd.tracks {
+		if track.isVideo() {
+			return track
+		}
+	}
+	return rd.tracks[0]
+}
+
+// Seek moves the reader to the last random access point of the leading track
+// (the first video track, or the first track) whose PTS is less or equal than pos.
+// Other tracks are moved to their first sample whose DTS is greater or equal
+// than the PTS of that random access point.
+// It returns the PTS of the random access point.
+func (rd *Reader) Seek(pos time.Duration) (time.Duration, error) {
+	if pos < 0 || pos > rd.duration {
+		return 0, fmt.Errorf("position is out of range")
+	}
+
+	leading := rd.leadingTrack()
+	index := -1
+
+	for i, sample := range leading.samples {
+		if !sample.isSync {
+			continue
+		}
+
+		if index >= 0 && leading.samplePTS(i) > pos {
+			break
+		}
+
+		index = i
+	}
+
+	if index < 0 {
+		index = len(leading.samples)
+	}
+
+	leading.cursor = index
+
+	var start time.Duration
+	if index < len(leading.samples) {
+		start = leading.samplePTS(index)
+	} else {
+		start = pos
+	}
+
+	for _, track := range rd.tracks {
+		if track == leading {
+			continue
+		}
+
+		track.cursor = sort.Search(len(track.samples), func(i int) bool {
+			return track.sampleDTS(i) >= start
+		})
+	}
+
+	return start, nil
+}
+
+// Read reads the next sample, in DTS order among all tracks.
+// It returns io.EOF when the file is over.
+func (rd *Reader) Read() (*Track, *Sample, error) {
+	var next *Track
+	var nextDTS time.Duration
+
+	for _, track := range rd.tracks {
+		if track.cursor >= len(track.samples) {
+			continue
+		}
+
+		dts := track.sampleDTS(track.cursor)
+		if next == nil || dts < nextDTS {
+			next = track
+			nextDTS = dts
+		}
+	}
+
+	if next == nil {
+		return nil, nil, io.EOF
+	}
+
+	i := next.cursor
+	next.cursor++
+	info := next.samples[i]
+
+	if info.size > maxSampleSize {
+		return nil, nil, fmt.Errorf("sample size (%d) is too big (maximum is %d)", info.size, maxSampleSize)
+	}
+
+	if info.offset < 0 || (info.offset+int64(info.size)) > rd.fileSize {
+		return nil, nil, fmt.Errorf("sample exceeds file size")
+	}
+
+	_, err := rd.r.Seek(info.offset, io.SeekStart)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payload := make([]byte, info.size)
+	_, err = io.ReadFull(rd.r, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return next, &Sample{
+		PTS:     next.samplePTS(i),
+		DTS:     nextDTS,
+		IsSync:  info.isSync,
+		Payload: payload,
+	}, nil
+}
@@ -0,0 +1,476 @@
+package mp4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h264"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+	"github.com/aler9/gortsplib/v2/pkg/fmp4"
+	"github.com/aler9/gortsplib/v2/pkg/format"
+	"github.com/aler9/gortsplib/v2/pkg/media"
+)
+
+var testSPS = []byte{
+	0x67, 0x64, 0x00, 0x28, 0xac, 0xd9, 0x40, 0x78,
+	0x02, 0x27, 0xe5, 0x84, 0x00, 0x00, 0x03, 0x00,
+	0x04, 0x00, 0x00, 0x03, 0x00, 0xf0, 0x3c, 0x60,
+	0xc6, 0x58,
+}
+
+var testPPS = []byte{0x68, 0xee, 0x3c, 0x80}
+
+var testConfig = &mpeg4audio.Config{
+	Type:         mpeg4audio.ObjectTypeAACLC,
+	SampleRate:   44100,
+	ChannelCount: 2,
+}
+
+func mustMarshalAVCC(au [][]byte) []byte {
+	buf, err := h264.AVCCMarshal(au)
+	if err != nil {
+		panic(err)
+	}
+	return buf
+}
+
+func testBox(typ string, content ...[]byte) []byte {
+	buf := make([]byte, 8)
+	copy(buf[4:], typ)
+	for _, c := range content {
+		buf = append(buf, c...)
+	}
+	binary.BigEndian.PutUint32(buf, uint32(len(buf)))
+	return buf
+}
+
+func testFullBox(typ string, version uint8, flags uint32, content ...[]byte) []byte {
+	return testBox(typ, append([][]byte{{version, byte(flags >> 16), byte(flags >> 8), byte(flags)}}, content...)...)
+}
+
+func testUint32s(vals ...uint32) []byte {
+	buf := make([]byte, 4*len(vals))
+	for i, v := range vals {
+		binary.BigEndian.PutUint32(buf[i*4:], v)
+	}
+	return buf
+}
+
+func testStsd(t *testing.T) []byte {
+	init := fmp4.Init{
+		Tracks: []*fmp4.InitTrack{{
+			ID:        1,
+			TimeScale: 90000,
+			Codec: &fmp4.CodecH264{
+				SPS: testSPS,
+				PPS: testPPS,
+			},
+		}},
+	}
+	initBuf, err := init.Marshal()
+	require.NoError(t, err)
+	boxes, err := parseBoxes(initBuf)
+	require.NoError(t, err)
+	stsd, err := findChildBox(boxes, "moov", "trak", "mdia", "minf", "stbl", "stsd")
+	require.NoError(t, err)
+	return testBox("stsd", stsd.content)
+}
+
+func testMoov(stbl ...[]byte) []byte {
+	return testBox("moov",
+		testFullBox("mvhd", 0, 0, make([]byte, 96)),
+		testBox("trak",
+			testFullBox("tkhd", 0, 3, testUint32s(0, 0, 1), make([]byte, 68)),
+			testBox("mdia",
+				testFullBox("mdhd", 0, 0, testUint32s(0, 0, 90000, 0), make([]byte, 4)),
+				testBox("minf",
+					testBox("stbl", stbl...),
+				),
+			),
+		),
+	)
+}
+
+type readSample struct {
+	trackID int
+	sample  *Sample
+}
+
+func readAll(t *testing.T, r *Reader) []readSample {
+	var ret []readSample
+	for {
+		track, sample, err := r.Read()
+		if err == io.EOF {
+			return ret
+		}
+		require.NoError(t, err)
+		ret = append(ret, readSample{track.ID, sample})
+	}
+}
+
+func TestReaderFragmented(t *testing.T) {
+	videoTrack := &fmp4.Track{
+		Format: &format.H264{
+			PayloadTyp:        96,
+			PacketizationMode: 1,
+		},
+	}
+
+	audioTrack := &fmp4.Track{
+		Format: &format.MPEG4Audio{
+			PayloadTyp: 97,
+			Config:     testConfig,
+		},
+	}
+
+	var buf bytes.Buffer
+	m, err := fmp4.NewMuxer(&buf, 1*time.Second, []*fmp4.Track{videoTrack, audioTrack})
+	require.NoError(t, err)
+
+	idr := [][]byte{testSPS, testPPS, {0x65, 0x88, 0x84, 0x00, 0x33, 0xff}}
+	nonIDR := [][]byte{{0x41, 0x9a, 0x21, 0x6c, 0x45, 0xff}}
+
+	for i := 0; i < 6; i++ {
+		pts := time.Duration(i) * 500 * time.Millisecond
+		au := nonIDR
+		if (i % 2) == 0 {
+			au = idr
+		}
+
+		err = m.WriteH264(videoTrack, pts, au)
+		require.NoError(t, err)
+
+		err = m.WriteMPEG4Audio(audioTrack, pts, [][]byte{{byte(i)}})
+		require.NoError(t, err)
+	}
+
+	err = m.Flush()
+	require.NoError(t, err)
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	require.Equal(t, media.Medias{
+		{
+			Type: media.TypeVideo,
+			Formats: []format.Format{&format.H264{
+				PayloadTyp:        96,
+				SPS:               testSPS,
+				PPS:               testPPS,
+				PacketizationMode: 1,
+			}},
+		},
+		{
+			Type: media.TypeAudio,
+			Formats: []format.Format{&format.MPEG4Audio{
+				PayloadTyp:       97,
+				Config:           testConfig,
+				SizeLength:       13,
+				IndexLength:      3,
+				IndexDeltaLength: 3,
+			}},
+		},
+	}, r.Medias())
+
+	// the last sample of each track lasts as the previous one
+	require.Equal(t, 3*time.Second, r.Duration())
+
+	samples := readAll(t, r)
+	require.Equal(t, 12, len(samples))
+	// parameters are stored in the sample description
+	require.Equal(t, readSample{1, &Sample{
+		IsSync:  true,
+		Payload: mustMarshalAVCC(idr[2:]),
+	}}, samples[0])
+	require.Equal(t, readSample{2, &Sample{
+		IsSync:  true,
+		Payload: []byte{0},
+	}}, samples[1])
+	require.Equal(t, readSample{1, &Sample{
+		PTS:     500 * time.Millisecond,
+		DTS:     500 * time.Millisecond,
+		Payload: mustMarshalAVCC(nonIDR),
+	}}, samples[2])
+
+	start, err := r.Seek(1700 * time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, 1*time.Second, start)
+
+	samples = readAll(t, r)
+	require.Equal(t, 8, len(samples))
+	require.Equal(t, 1, samples[0].trackID)
+	require.Equal(t, 1*time.Second, samples[0].sample.PTS)
+	require.Equal(t, true, samples[0].sample.IsSync)
+	require.Equal(t, 2, samples[1].trackID)
+	require.Equal(t, []byte{2}, samples[1].sample.Payload)
+
+	_, err = r.Seek(4 * time.Second)
+	require.EqualError(t, err, "position is out of range")
+}
+
+func TestReaderAC3(t *testing.T) {
+	track := &fmp4.Track{
+		Format: &format.AC3{
+			PayloadTyp:   96,
+			SampleRate:   48000,
+			ChannelCount: 6,
+		},
+	}
+
+	var buf bytes.Buffer
+	m, err := fmp4.NewMuxer(&buf, 1*time.Second, []*fmp4.Track{track})
+	require.NoError(t, err)
+
+	// 48khz, 3/2 mode with LFE
+	frame := []byte{0x0b, 0x77, 0x00, 0x00, 0x0e, 0x40, 0xe1, 0x40}
+
+	err = m.WriteAC3(track, 0, [][]byte{frame, frame})
+	require.NoError(t, err)
+
+	err = m.Flush()
+	require.NoError(t, err)
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	require.Equal(t, media.Medias{{
+		Type: media.TypeAudio,
+		Formats: []format.Format{&format.AC3{
+			PayloadTyp:   96,
+			SampleRate:   48000,
+			ChannelCount: 6,
+		}},
+	}}, r.Medias())
+
+	samples := readAll(t, r)
+	require.Equal(t, 2, len(samples))
+	require.Equal(t, frame, samples[0].sample.Payload)
+	require.Equal(t, 32*time.Millisecond, samples[1].sample.PTS)
+}
+
+func TestReaderProgressive(t *testing.T) {
+	// take the sample description from an initialization segment
+	init := fmp4.Init{
+		Tracks: []*fmp4.InitTrack{{
+			ID:        1,
+			TimeScale: 90000,
+			Codec: &fmp4.CodecH264{
+				SPS: testSPS,
+				PPS: testPPS,
+			},
+		}},
+	}
+	initBuf, err := init.Marshal()
+	require.NoError(t, err)
+	boxes, err := parseBoxes(initBuf)
+	require.NoError(t, err)
+	stsd, err := findChildBox(boxes, "moov", "trak", "mdia", "minf", "stbl", "stsd")
+	require.NoError(t, err)
+
+	payloads := [][]byte{
+		mustMarshalAVCC([][]byte{{0x65, 0x01}}),
+		mustMarshalAVCC([][]byte{{0x41, 0x02}}),
+		mustMarshalAVCC([][]byte{{0x41, 0x03, 0x04}}),
+		mustMarshalAVCC([][]byte{{0x65, 0x05}}),
+	}
+
+	ftyp := testBox("ftyp", []byte("isom"), testUint32s(512), []byte("isomiso2avc1mp41"))
+
+	buildMoov := func(mdatOffset uint32) []byte {
+		return testBox("moov",
+			testFullBox("mvhd", 0, 0, make([]byte, 96)),
+			testBox("trak",
+				testFullBox("tkhd", 0, 3, testUint32s(0, 0, 1), make([]byte, 68)),
+				testBox("edts",
+					testFullBox("elst", 0, 0, testUint32s(1, 0, 3000, 0x00010000)),
+				),
+				testBox("mdia",
+					testFullBox("mdhd", 0, 0, testUint32s(0, 0, 90000, 0), make([]byte, 4)),
+					testBox("minf",
+						testBox("stbl",
+							testBox("stsd", stsd.content),
+							testFullBox("stts", 0, 0, testUint32s(1, 4, 3000)),
+							testFullBox("ctts", 0, 0, testUint32s(4, 1, 3000, 1, 6000, 1, 0, 1, 3000)),
+							testFullBox("stss", 0, 0, testUint32s(2, 1, 4)),
+							testFullBox("stsz", 0, 0, testUint32s(0, 4,
+								uint32(len(payloads[0])), uint32(len(payloads[1])),
+								uint32(len(payloads[2])), uint32(len(payloads[3])))),
+							testFullBox("stsc", 0, 0, testUint32s(2, 1, 3, 1, 2, 1, 1)),
+							testFullBox("stco", 0, 0, testUint32s(2,
+								mdatOffset+8,
+								mdatOffset+8+uint32(len(payloads[0])+len(payloads[1])+len(payloads[2])))),
+						),
+					),
+				),
+			),
+		)
+	}
+
+	mdatOffset := uint32(len(ftyp) + len(buildMoov(0)))
+
+	var file []byte
+	file = append(file, ftyp...)
+	file = append(file, buildMoov(mdatOffset)...)
+	file = append(file, testBox("mdat", payloads...)...)
+
+	r, err := NewReader(bytes.NewReader(file))
+	require.NoError(t, err)
+
+	require.Equal(t, media.Medias{{
+		Type: media.TypeVideo,
+		Formats: []format.Format{&format.H264{
+			PayloadTyp:        96,
+			SPS:               testSPS,
+			PPS:               testPPS,
+			PacketizationMode: 1,
+		}},
+	}}, r.Medias())
+
+	require.Equal(t, 100*time.Millisecond, r.Duration())
+
+	samples := readAll(t, r)
+	require.Equal(t, []readSample{
+		{1, &Sample{
+			PTS:     0,
+			DTS:     -33333333,
+			IsSync:  true,
+			Payload: payloads[0],
+		}},
+		{1, &Sample{
+			PTS:     66666666,
+			DTS:     0,
+			Payload: payloads[1],
+		}},
+		{1, &Sample{
+			PTS:     33333333,
+			DTS:     33333333,
+			Payload: payloads[2],
+		}},
+		{1, &Sample{
+			PTS:     100 * time.Millisecond,
+			DTS:     66666666,
+			IsSync:  true,
+			Payload: payloads[3],
+		}},
+	}, samples)
+
+	start, err := r.Seek(50 * time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(0), start)
+
+	start, err = r.Seek(100 * time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, 100*time.Millisecond, start)
+
+	samples = readAll(t, r)
+	require.Equal(t, 1, len(samples))
+	require.Equal(t, payloads[3], samples[0].sample.Payload)
+}
+
+func TestReaderErrors(t *testing.T) {
+	for _, ca := range []struct {
+		name string
+		byts []byte
+		err  string
+	}{
+		{
+			"empty",
+			[]byte{},
+			"moov box not found",
+		},
+		{
+			"invalid box size",
+			[]byte{0, 0, 0, 20, 'f', 't', 'y', 'p'},
+			"invalid size of box 'ftyp'",
+		},
+		{
+			"moof before moov",
+			testBox("moof"),
+			"moof box found before moov box",
+		},
+		{
+			"no tracks",
+			testBox("moov", testFullBox("mvhd", 0, 0, make([]byte, 96))),
+			"no supported tracks found",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			_, err := NewReader(bytes.NewReader(ca.byts))
+			require.EqualError(t, err, ca.err)
+		})
+	}
+}
+
+func TestReaderCraftedSizes(t *testing.T) {
+	stsd := testStsd(t)
+
+	t.Run("stsz too many samples", func(t *testing.T) {
+		file := testMoov(
+			stsd,
+			testFullBox("stts", 0, 0, testUint32s(1, 0xFFFFFFFF, 3000)),
+			testFullBox("stsz", 0, 0, testUint32s(1, 0xFFFFFFF0)),
+			testFullBox("stsc", 0, 0, testUint32s(1, 1, 0xFFFFFFF0, 1)),
+			testFullBox("stco", 0, 0, testUint32s(1, 0)),
+		)
+
+		_, err := NewReader(bytes.NewReader(file))
+		require.EqualError(t, err, "invalid stsz: too many samples")
+	})
+
+	t.Run("trun too many samples", func(t *testing.T) {
+		var file []byte
+		file = append(file, testMoov(
+			stsd,
+			testFullBox("stts", 0, 0, testUint32s(0)),
+			testFullBox("stsz", 0, 0, testUint32s(0, 0)),
+			testFullBox("stsc", 0, 0, testUint32s(0)),
+			testFullBox("stco", 0, 0, testUint32s(0)),
+		)...)
+		file = append(file, testBox("moof",
+			testBox("traf",
+				testFullBox("tfhd", 0, 0x000018, testUint32s(1, 3000, 1)),
+				testFullBox("trun", 0, 0, testUint32s(0xFFFFFFF)),
+			),
+		)...)
+
+		_, err := NewReader(bytes.NewReader(file))
+		require.EqualError(t, err, "invalid trun: too many samples")
+	})
+
+	t.Run("sample exceeds file size", func(t *testing.T) {
+		file := testMoov(
+			stsd,
+			testFullBox("stts", 0, 0, testUint32s(1, 1, 3000)),
+			testFullBox("stsz", 0, 0, testUint32s(0, 1, 0xFFFFFF)),
+			testFullBox("stsc", 0, 0, testUint32s(1, 1, 1, 1)),
+			testFullBox("stco", 0, 0, testUint32s(1, 0)),
+		)
+
+		r, err := NewReader(bytes.NewReader(file))
+		require.NoError(t, err)
+
+		_, _, err = r.Read()
+		require.EqualError(t, err, "sample exceeds file size")
+	})
+
+	t.Run("sample too big", func(t *testing.T) {
+		file := testMoov(
+			stsd,
+			testFullBox("stts", 0, 0, testUint32s(1, 1, 3000)),
+			testFullBox("stsz", 0, 0, testUint32s(0, 1, 0xFFFFFFFF)),
+			testFullBox("stsc", 0, 0, testUint32s(1, 1, 1, 1)),
+			testFullBox("stco", 0, 0, testUint32s(1, 0)),
+		)
+
+		r, err := NewReader(bytes.NewReader(file))
+		require.NoError(t, err)
+
+		_, _, err = r.Read()
+		require.EqualError(t, err, "sample size (4294967295) is too big (maximum is 67108864)")
+	})
+}
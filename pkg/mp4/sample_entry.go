@@ -0,0 +1,370 @@
+package mp4
+
+import (
+	"fmt"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/ac3"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+	"github.com/aler9/gortsplib/v2/pkg/format"
+)
+
+const (
+	visualSampleEntryHeaderSize = 78
+	audioSampleEntryHeaderSize  = 28
+)
+
+// errUnsupportedCodec is returned when the codec of a track is not supported.
+// Tracks with unsupported codecs are skipped.
+var errUnsupportedCodec = fmt.Errorf("unsupported codec")
+
+func parseStsd(buf []byte, payloadType uint8) (format.Format, error) {
+	r := &byteReader{buf: buf}
+	r.readFullBoxHeader()
+	entryCount := r.readUint32()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if entryCount == 0 {
+		return nil, fmt.Errorf("no sample entries")
+	}
+
+	boxes, err := parseBoxes(r.remaining())
+	if err != nil {
+		return nil, err
+	}
+
+	// only the first sample entry is used
+	entry := boxes[0]
+
+	switch entry.typ {
+	case "avc1", "avc3":
+		return parseAVC1(entry.content, payloadType)
+
+	case "hvc1", "hev1":
+		return parseHVC1(entry.content, payloadType)
+
+	case "vp09":
+		return &format.VP9{
+			PayloadTyp: payloadType,
+		}, nil
+
+	case "mp4a":
+		return parseMP4A(entry.content, payloadType)
+
+	case "Opus":
+		return parseOpus(entry.content, payloadType)
+
+	case "ac-3":
+		return parseAC3(entry.content, payloadType)
+
+	case "ec-3":
+		return parseEAC3(entry.content, payloadType)
+	}
+
+	return nil, errUnsupportedCodec
+}
+
+func sampleEntryChildren(buf []byte, headerSize int) ([]*box, error) {
+	if len(buf) < headerSize {
+		return nil, fmt.Errorf("invalid sample entry")
+	}
+	return parseBoxes(buf[headerSize:])
+}
+
+func audioSampleEntryChildren(buf []byte) ([]*box, error) {
+	if len(buf) < audioSampleEntryHeaderSize {
+		return nil, fmt.Errorf("invalid sample entry")
+	}
+
+	// QuickTime sound sample description versions 1 and 2 contain additional fields
+	headerSize := audioSampleEntryHeaderSize
+	switch uint16(buf[8])<<8 | uint16(buf[9]) {
+	case 1:
+		headerSize += 16
+	case 2:
+		headerSize += 36
+	}
+
+	return sampleEntryChildren(buf, headerSize)
+}
+
+func parseAVC1(buf []byte, payloadType uint8) (format.Format, error) {
+	children, err := sampleEntryChildren(buf, visualSampleEntryHeaderSize)
+	if err != nil {
+		return nil, err
+	}
+
+	avcc := findBox(children, "avcC")
+	if avcc == nil {
+		return nil, fmt.Errorf("avcC box not found")
+	}
+
+	r := &byteReader{buf: avcc.content}
+	r.skip(4) // version, profile, compatibility, level
+	lengthSize := (r.readUint8() & 0x03) + 1
+	if lengthSize != 4 {
+		return nil, fmt.Errorf("NALU length size %d is not supported", lengthSize)
+	}
+
+	var sps []byte
+	spsCount := int(r.readUint8() & 0x1F)
+	for i := 0; i < spsCount; i++ {
+		v := r.readBytes(int(r.readUint16()))
+		if sps == nil {
+			sps = v
+		}
+	}
+
+	var pps []byte
+	ppsCount := int(r.readUint8())
+	for i := 0; i < ppsCount; i++ {
+		v := r.readBytes(int(r.readUint16()))
+		if pps == nil {
+			pps = v
+		}
+	}
+
+	if r.err != nil {
+		return nil, fmt.Errorf("invalid avcC: %v", r.err)
+	}
+
+	return &format.H264{
+		PayloadTyp:        payloadType,
+		SPS:               sps,
+		PPS:               pps,
+		PacketizationMode: 1,
+	}, nil
+}
+
+func parseHVC1(buf []byte, payloadType uint8) (format.Format, error) {
+	children, err := sampleEntryChildren(buf, visualSampleEntryHeaderSize)
+	if err != nil {
+		return nil, err
+	}
+
+	hvcc := findBox(children, "hvcC")
+	if hvcc == nil {
+		return nil, fmt.Errorf("hvcC box not found")
+	}
+
+	r := &byteReader{buf: hvcc.content}
+	r.skip(21)
+	lengthSize := (r.readUint8() & 0x03) + 1
+	if lengthSize != 4 {
+		return nil, fmt.Errorf("NALU length size %d is not supported", lengthSize)
+	}
+
+	forma := &format.H265{
+		PayloadTyp: payloadType,
+	}
+
+	arrayCount := int(r.readUint8())
+	for i := 0; i < arrayCount; i++ {
+		typ := r.readUint8() & 0x3F
+		naluCount := int(r.readUint16())
+
+		for j := 0; j < naluCount; j++ {
+			v := r.readBytes(int(r.readUint16()))
+
+			switch typ {
+			case 32:
+				if forma.VPS == nil {
+					forma.VPS = v
+				}
+			case 33:
+				if forma.SPS == nil {
+					forma.SPS = v
+				}
+			case 34:
+				if forma.PPS == nil {
+					forma.PPS = v
+				}
+			}
+		}
+	}
+
+	if r.err != nil {
+		return nil, fmt.Errorf("invalid hvcC: %v", r.err)
+	}
+
+	return forma, nil
+}
+
+// readDescriptorHeader reads tag and size of a MPEG-4 descriptor.
+// Specification: ISO 14496-1, 8.3.3
+func readDescriptorHeader(r *byteReader) (uint8, int) {
+	tag := r.readUint8()
+	size := 0
+
+	for i := 0; i < 4; i++ {
+		b := r.readUint8()
+		size = size<<7 | int(b&0x7F)
+		if (b & 0x80) == 0 {
+			break
+		}
+	}
+
+	return tag, size
+}
+
+func parseMP4A(buf []byte, payloadType uint8) (format.Format, error) {
+	children, err := audioSampleEntryChildren(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	esds := findBox(children, "esds")
+	if esds == nil {
+		return nil, fmt.Errorf("esds box not found")
+	}
+
+	r := &byteReader{buf: esds.content}
+	r.readFullBoxHeader()
+
+	tag, _ := readDescriptorHeader(r)
+	if tag != 0x03 {
+		return nil, fmt.Errorf("ES descriptor not found")
+	}
+
+	r.skip(2) // ES ID
+	flags := r.readUint8()
+	if (flags & 0x80) != 0 { // stream dependence
+		r.skip(2)
+	}
+	if (flags & 0x40) != 0 { // URL
+		r.skip(int(r.readUint8()))
+	}
+	if (flags & 0x20) != 0 { // OCR stream
+		r.skip(2)
+	}
+
+	tag, _ = readDescriptorHeader(r)
+	if tag != 0x04 {
+		return nil, fmt.Errorf("decoder config descriptor not found")
+	}
+
+	objectType := r.readUint8()
+	if objectType != 0x40 {
+		return nil, errUnsupportedCodec
+	}
+	r.skip(12) // stream type, buffer size, bitrates
+
+	tag, size := readDescriptorHeader(r)
+	if tag != 0x05 {
+		return nil, fmt.Errorf("decoder specific info not found")
+	}
+
+	enc := r.readBytes(size)
+	if r.err != nil {
+		return nil, fmt.Errorf("invalid esds: %v", r.err)
+	}
+
+	var conf mpeg4audio.Config
+	err = conf.Unmarshal(enc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MPEG-4 Audio configuration: %v", err)
+	}
+
+	return &format.MPEG4Audio{
+		PayloadTyp:       payloadType,
+		Config:           &conf,
+		SizeLength:       13,
+		IndexLength:      3,
+		IndexDeltaLength: 3,
+	}, nil
+}
+
+func parseOpus(buf []byte, payloadType uint8) (format.Format, error) {
+	children, err := audioSampleEntryChildren(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	dops := findBox(children, "dOps")
+	if dops == nil {
+		return nil, fmt.Errorf("dOps box not found")
+	}
+
+	r := &byteReader{buf: dops.content}
+	r.skip(1) // version
+	channelCount := int(r.readUint8())
+	if r.err != nil {
+		return nil, fmt.Errorf("invalid dOps: %v", r.err)
+	}
+
+	return &format.Opus{
+		PayloadTyp:   payloadType,
+		SampleRate:   48000,
+		ChannelCount: channelCount,
+	}, nil
+}
+
+var ac3SampleRates = []int{48000, 44100, 32000}
+
+func parseAC3(buf []byte, payloadType uint8) (format.Format, error) {
+	children, err := audioSampleEntryChildren(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	dac3 := findBox(children, "dac3")
+	if dac3 == nil {
+		return nil, fmt.Errorf("dac3 box not found")
+	}
+
+	r := &byteReader{buf: dac3.content}
+	v := r.readUint24()
+	if r.err != nil {
+		return nil, fmt.Errorf("invalid dac3: %v", r.err)
+	}
+
+	fscod := v >> 22
+	if fscod >= 3 {
+		return nil, fmt.Errorf("invalid fscod (%d)", fscod)
+	}
+
+	h := ac3.SyncFrameHeader{
+		SampleRate: ac3SampleRates[fscod],
+		ACMod:      uint8((v >> 11) & 0x07),
+		LFEOn:      ((v >> 10) & 0x01) != 0,
+	}
+
+	return &format.AC3{
+		PayloadTyp:   payloadType,
+		SampleRate:   h.SampleRate,
+		ChannelCount: h.ChannelCount(),
+	}, nil
+}
+
+func parseEAC3(buf []byte, payloadType uint8) (format.Format, error) {
+	children, err := audioSampleEntryChildren(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	dec3 := findBox(children, "dec3")
+	if dec3 == nil {
+		return nil, fmt.Errorf("dec3 box not found")
+	}
+
+	r := &byteReader{buf: dec3.content}
+	r.skip(2) // data rate, number of independent substreams
+	v := r.readUint24()
+	if r.err != nil {
+		return nil, fmt.Errorf("invalid dec3: %v", r.err)
+	}
+
+	// the sample rate of the sample entry covers the reduced sample rates too
+	h := ac3.EAC3SyncFrameHeader{
+		SampleRate: int(uint32(buf[24])<<8 | uint32(buf[25])),
+		ACMod:      uint8((v >> 9) & 0x07),
+		LFEOn:      ((v >> 8) & 0x01) != 0,
+	}
+
+	return &format.EAC3{
+		PayloadTyp:   payloadType,
+		SampleRate:   h.SampleRate,
+		ChannelCount: h.ChannelCount(),
+	}, nil
+}
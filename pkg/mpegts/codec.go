@@ -0,0 +1,14 @@
+package mpegts
+
+import (
+	"github.com/asticode/go-astits"
+)
+
+// Codec is a MPEG-TS codec.
+type Codec interface {
+	// returns whether the codec is a video codec.
+	isVideo() bool
+
+	// returns the PMT elementary stream that describes the codec.
+	marshal(pid uint16) (*astits.PMTElementaryStream, error)
+}
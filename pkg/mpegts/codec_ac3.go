@@ -0,0 +1,20 @@
+package mpegts
+
+import (
+	"github.com/asticode/go-astits"
+)
+
+// CodecAC3 is an AC-3 codec.
+type CodecAC3 struct{}
+
+func (c *CodecAC3) isVideo() bool {
+	return false
+}
+
+func (c *CodecAC3) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
+	return &astits.PMTElementaryStream{
+		ElementaryPID:               pid,
+		ElementaryStreamDescriptors: nil,
+		StreamType:                  astits.StreamTypeAC3Audio,
+	}, nil
+}
@@ -0,0 +1,20 @@
+package mpegts
+
+import (
+	"github.com/asticode/go-astits"
+)
+
+// CodecEAC3 is an E-AC-3 codec.
+type CodecEAC3 struct{}
+
+func (c *CodecEAC3) isVideo() bool {
+	return false
+}
+
+func (c *CodecEAC3) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
+	return &astits.PMTElementaryStream{
+		ElementaryPID:               pid,
+		ElementaryStreamDescriptors: nil,
+		StreamType:                  astits.StreamTypeEAC3Audio,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package mpegts
+
+import (
+	"github.com/asticode/go-astits"
+)
+
+// CodecKLV is a KLV metadata codec (SMPTE ST 336).
+type CodecKLV struct {
+	// whether KLV units are synchronized with the other tracks.
+	// When false, units are written without timestamps.
+	Synchronous bool
+}
+
+func (c *CodecKLV) isVideo() bool {
+	return false
+}
+
+func (c *CodecKLV) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
+	return &astits.PMTElementaryStream{
+		ElementaryPID: pid,
+		ElementaryStreamDescriptors: []*astits.Descriptor{
+			{
+				Length: 4,
+				Tag:    astits.DescriptorTagRegistration,
+				Registration: &astits.DescriptorRegistration{
+					FormatIdentifier: registrationKLVA,
+				},
+			},
+		},
+		StreamType: astits.StreamTypePrivateData,
+	}, nil
+}
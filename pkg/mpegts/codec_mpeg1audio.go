@@ -0,0 +1,21 @@
+package mpegts
+
+import (
+	"github.com/asticode/go-astits"
+)
+
+// CodecMPEG1Audio is a MPEG-1 Audio codec.
+// It can be used to write MPEG-2 Audio too, since the two formats share the same frame structure.
+type CodecMPEG1Audio struct{}
+
+func (c *CodecMPEG1Audio) isVideo() bool {
+	return false
+}
+
+func (c *CodecMPEG1Audio) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
+	return &astits.PMTElementaryStream{
+		ElementaryPID:               pid,
+		ElementaryStreamDescriptors: nil,
+		StreamType:                  astits.StreamTypeMPEG1Audio,
+	}, nil
+}
@@ -0,0 +1,24 @@
+package mpegts
+
+import (
+	"github.com/asticode/go-astits"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+)
+
+// CodecMPEG4Audio is a MPEG-4 Audio codec.
+type CodecMPEG4Audio struct {
+	Config mpeg4audio.Config
+}
+
+func (c *CodecMPEG4Audio) isVideo() bool {
+	return false
+}
+
+func (c *CodecMPEG4Audio) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
+	return &astits.PMTElementaryStream{
+		ElementaryPID:               pid,
+		ElementaryStreamDescriptors: nil,
+		StreamType:                  astits.StreamTypeAACAudio,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package mpegts
+
+import (
+	"github.com/asticode/go-astits"
+)
+
+// CodecOpus is a Opus codec.
+type CodecOpus struct {
+	ChannelCount int
+}
+
+func (c *CodecOpus) isVideo() bool {
+	return false
+}
+
+func (c *CodecOpus) marshal(pid uint16) (*astits.PMTElementaryStream, error) {
+	return &astits.PMTElementaryStream{
+		ElementaryPID: pid,
+		ElementaryStreamDescriptors: []*astits.Descriptor{
+			{
+				Length: 4,
+				Tag:    astits.DescriptorTagRegistration,
+				Registration: &astits.DescriptorRegistration{
+					FormatIdentifier: registrationOpus,
+				},
+			},
+			{
+				Length: 2,
+				Tag:    astits.DescriptorTagExtension,
+				Extension: &astits.DescriptorExtension{
+					Tag:     0x80,
+					Unknown: &[]uint8{uint8(c.ChannelCount)},
+				},
+			},
+		},
+		StreamType: astits.StreamTypePrivateData,
+	}, nil
+}
@@ -0,0 +1,29 @@
+// Package mpegts contains a MPEG-TS muxer and utilities to work with MPEG-TS.
+package mpegts
+
+import (
+	"time"
+)
+
+const (
+	// PCR is written this amount of time before DTS, in order to give
+	// decoders enough time to buffer frames.
+	pcrOffset = 400 * time.Millisecond
+
+	// first PID assigned automatically to tracks.
+	firstAutoPID = 256
+
+	// identifiers used in registration descriptors.
+	registrationOpus = 0x4f707573 // "Opus"
+	registrationKLVA = 0x4b4c5641 // "KLVA"
+
+	// PES stream ID of private_stream_1.
+	streamIDPrivate1 = 0xbd
+)
+
+// convert a duration into a 33-bit MPEG-TS timestamp (90kHz).
+func durationGoToMPEGTS(v time.Duration) int64 {
+	secs := v / time.Second
+	dec := v % time.Second
+	return (int64(secs)*90000 + int64(dec)*90000/int64(time.Second)) & 0x1FFFFFFFF
+}
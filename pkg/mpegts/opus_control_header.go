@@ -0,0 +1,78 @@
+package mpegts
+
+import (
+	"fmt"
+)
+
+// Opus packets are prefixed by a control header.
+// Specification: ETSI TS Opus 0.1.3-draft
+
+func opusControlHeaderSize(packet []byte) int {
+	return 2 + len(packet)/255 + 1
+}
+
+func opusControlHeaderMarshal(buf []byte, packet []byte) int {
+	buf[0] = 0x7f
+	buf[1] = 0xe0 // prefix (11 bits) + start trim flag + end trim flag + control extension flag
+	n := 2
+
+	size := len(packet)
+	for size >= 255 {
+		buf[n] = 255
+		n++
+		size -= 255
+	}
+	buf[n] = uint8(size)
+	n++
+
+	return n
+}
+
+func opusControlHeaderUnmarshal(buf []byte) (int, int, error) {
+	if len(buf) < 3 {
+		return 0, 0, fmt.Errorf("invalid Opus control header")
+	}
+
+	if (uint16(buf[0])<<3 | uint16(buf[1])>>5) != 0x3FF {
+		return 0, 0, fmt.Errorf("invalid Opus control header prefix")
+	}
+
+	startTrimFlag := (buf[1] >> 4) & 0x01
+	endTrimFlag := (buf[1] >> 3) & 0x01
+	controlExtensionFlag := (buf[1] >> 2) & 0x01
+	n := 2
+
+	packetSize := 0
+	for {
+		if n >= len(buf) {
+			return 0, 0, fmt.Errorf("invalid Opus control header")
+		}
+
+		v := buf[n]
+		n++
+		packetSize += int(v)
+
+		if v != 255 {
+			break
+		}
+	}
+
+	if startTrimFlag == 1 {
+		n += 2
+	}
+	if endTrimFlag == 1 {
+		n += 2
+	}
+	if controlExtensionFlag == 1 {
+		if n >= len(buf) {
+			return 0, 0, fmt.Errorf("invalid Opus control header")
+		}
+		n += 1 + int(buf[n])
+	}
+
+	if n > len(buf) {
+		return 0, 0, fmt.Errorf("invalid Opus control header")
+	}
+
+	return n, packetSize, nil
+}
@@ -0,0 +1,302 @@
+package mpegts
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/asticode/go-astits"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h264"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+)
+
+func findOpusRegistration(descriptors []*astits.Descriptor) bool {
+	for _, sd := range descriptors {
+		if sd.Registration != nil && sd.Registration.FormatIdentifier == registrationOpus {
+			return true
+		}
+	}
+	return false
+}
+
+func findOpusChannelCount(descriptors []*astits.Descriptor) int {
+	for _, sd := range descriptors {
+		if sd.Extension != nil && sd.Extension.Tag == 0x80 &&
+			sd.Extension.Unknown != nil && len(*sd.Extension.Unknown) >= 1 {
+			v := int((*sd.Extension.Unknown)[0])
+			if v >= 1 && v <= 8 {
+				return v
+			}
+		}
+	}
+	return 2
+}
+
+func findTracks(pmt *astits.PMTData) []*Track {
+	var tracks []*Track //nolint:prealloc
+
+	for _, es := range pmt.ElementaryStreams {
+		var codec Codec
+
+		switch es.StreamType {
+		case astits.StreamTypeH264Video:
+			codec = &CodecH264{}
+
+		case astits.StreamTypeH265Video:
+			codec = &CodecH265{}
+
+		case astits.StreamTypeAACAudio:
+			codec = &CodecMPEG4Audio{}
+
+		case astits.StreamTypeMPEG1Audio, astits.StreamTypeMPEG2Audio:
+			codec = &CodecMPEG1Audio{}
+
+		case astits.StreamTypeAC3Audio:
+			codec = &CodecAC3{}
+
+		case astits.StreamTypeEAC3Audio:
+			codec = &CodecEAC3{}
+
+		case astits.StreamTypePrivateData:
+			if !findOpusRegistration(es.ElementaryStreamDescriptors) {
+				continue
+			}
+
+			codec = &CodecOpus{
+				ChannelCount: findOpusChannelCount(es.ElementaryStreamDescriptors),
+			}
+
+		default:
+			continue
+		}
+
+		tracks = append(tracks, &Track{
+			PID:   es.ElementaryPID,
+			Codec: codec,
+		})
+	}
+
+	return tracks
+}
+
+// Reader is a MPEG-TS reader.
+type Reader struct {
+	dem     *astits.Demuxer
+	tracks  []*Track
+	queue   []*astits.DemuxerData
+	timeDec timeDecoder
+	onData  map[uint16]func(time.Duration, time.Duration, []byte) error
+}
+
+// NewReader allocates a Reader.
+// It reads the stream until the PMT and all the information required
+// to describe tracks are found. Data read in the process is not lost,
+// it is returned by subsequent calls to Read.
+// Input is buffered, therefore datagram-based readers (i.e. net.UDPConn) can be used too.
+func NewReader(br io.Reader) (*Reader, error) {
+	r := &Reader{
+		dem: astits.NewDemuxer(context.Background(), bufio.NewReader(br),
+			astits.DemuxerOptPacketSize(188)),
+		onData: make(map[uint16]func(time.Duration, time.Duration, []byte) error),
+	}
+
+	var pendingConfigs map[uint16]*CodecMPEG4Audio
+
+	for {
+		data, err := r.dem.NextData()
+		if err != nil {
+			if errors.Is(err, astits.ErrNoMorePackets) {
+				if r.tracks == nil {
+					return nil, fmt.Errorf("PMT not found")
+				}
+				return nil, fmt.Errorf("MPEG-4 Audio configuration not found")
+			}
+			return nil, err
+		}
+
+		if r.tracks == nil {
+			if data.PMT == nil {
+				continue
+			}
+
+			r.tracks = findTracks(data.PMT)
+			if len(r.tracks) == 0 {
+				return nil, fmt.Errorf("no supported tracks found")
+			}
+
+			pendingConfigs = make(map[uint16]*CodecMPEG4Audio)
+
+			for _, track := range r.tracks {
+				if codec, ok := track.Codec.(*CodecMPEG4Audio); ok {
+					pendingConfigs[track.PID] = codec
+				}
+			}
+		} else if data.PES != nil {
+			r.queue = append(r.queue, data)
+
+			if codec, ok := pendingConfigs[data.PID]; ok {
+				var pkts mpeg4audio.ADTSPackets
+				err := pkts.Unmarshal(data.PES.Data)
+				if err != nil {
+					return nil, fmt.Errorf("unable to decode ADTS: %s", err)
+				}
+
+				codec.Config = mpeg4audio.Config{
+					Type:         pkts[0].Type,
+					SampleRate:   pkts[0].SampleRate,
+					ChannelCount: pkts[0].ChannelCount,
+				}
+				delete(pendingConfigs, data.PID)
+			}
+		}
+
+		if r.tracks != nil && len(pendingConfigs) == 0 {
+			return r, nil
+		}
+	}
+}
+
+// Tracks returns the tracks of the stream.
+func (r *Reader) Tracks() []*Track {
+	return r.tracks
+}
+
+// OnDataH26x sets a callback that is called when data from a H264 or H265 track is received.
+func (r *Reader) OnDataH26x(track *Track, cb func(pts time.Duration, dts time.Duration, au [][]byte) error) {
+	r.onData[track.PID] = func(pts time.Duration, dts time.Duration, data []byte) error {
+		// H265 uses the same Annex-B format of H264
+		au, err := h264.AnnexBUnmarshal(data)
+		if err != nil {
+			return err
+		}
+
+		return cb(pts, dts, au)
+	}
+}
+
+// OnDataMPEG4Audio sets a callback that is called when data from a MPEG-4 Audio track is received.
+// pts is the PTS of the first access unit.
+func (r *Reader) OnDataMPEG4Audio(track *Track, cb func(pts time.Duration, aus [][]byte) error) {
+	r.onData[track.PID] = func(pts time.Duration, dts time.Duration, data []byte) error {
+		var pkts mpeg4audio.ADTSPackets
+		err := pkts.Unmarshal(data)
+		if err != nil {
+			return err
+		}
+
+		aus := make([][]byte, len(pkts))
+		for i, pkt := range pkts {
+			aus[i] = pkt.AU
+		}
+
+		return cb(pts, aus)
+	}
+}
+
+// OnDataOpus sets a callback that is called when data from a Opus track is received.
+// pts is the PTS of the first packet.
+func (r *Reader) OnDataOpus(track *Track, cb func(pts time.Duration, packets [][]byte) error) {
+	r.onData[track.PID] = func(pts time.Duration, dts time.Duration, data []byte) error {
+		var packets [][]byte
+
+		for len(data) > 0 {
+			n, packetSize, err := opusControlHeaderUnmarshal(data)
+			if err != nil {
+				return err
+			}
+			data = data[n:]
+
+			if packetSize > len(data) {
+				return fmt.Errorf("invalid Opus packet size")
+			}
+
+			packets = append(packets, data[:packetSize])
+			data = data[packetSize:]
+		}
+
+		return cb(pts, packets)
+	}
+}
+
+// OnDataMPEG1Audio sets a callback that is called when data from a MPEG-1 Audio track is received.
+// data contains one or more complete frames.
+func (r *Reader) OnDataMPEG1Audio(track *Track, cb func(pts time.Duration, data []byte) error) {
+	r.onData[track.PID] = func(pts time.Duration, dts time.Duration, data []byte) error {
+		return cb(pts, data)
+	}
+}
+
+// OnDataAC3 sets a callback that is called when data from an AC-3 track is received.
+// data contains one or more complete syncframes.
+func (r *Reader) OnDataAC3(track *Track, cb func(pts time.Duration, data []byte) error) {
+	r.onData[track.PID] = func(pts time.Duration, dts time.Duration, data []byte) error {
+		return cb(pts, data)
+	}
+}
+
+// OnDataEAC3 sets a callback that is called when data from an E-AC-3 track is received.
+// data contains one or more complete syncframes.
+func (r *Reader) OnDataEAC3(track *Track, cb func(pts time.Duration, data []byte) error) {
+	r.onData[track.PID] = func(pts time.Duration, dts time.Duration, data []byte) error {
+		return cb(pts, data)
+	}
+}
+
+func (r *Reader) nextData() (*astits.DemuxerData, error) {
+	if len(r.queue) != 0 {
+		data := r.queue[0]
+		r.queue = r.queue[1:]
+		return data, nil
+	}
+
+	data, err := r.dem.NextData()
+	if err != nil {
+		if errors.Is(err, astits.ErrNoMorePackets) {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Read reads data from the stream and calls the callback of the track it belongs to.
+// It returns io.EOF when the stream is over.
+func (r *Reader) Read() error {
+	data, err := r.nextData()
+	if err != nil {
+		return err
+	}
+
+	if data.PES == nil {
+		return nil
+	}
+
+	onData, ok := r.onData[data.PID]
+	if !ok {
+		return nil
+	}
+
+	oh := data.PES.Header.OptionalHeader
+	if oh == nil || oh.PTS == nil {
+		return fmt.Errorf("PTS is missing")
+	}
+
+	var pts time.Duration
+	var dts time.Duration
+
+	// decode DTS first, since it is the smallest one
+	if oh.PTSDTSIndicator == astits.PTSDTSIndicatorBothPresent && oh.DTS != nil {
+		dts = r.timeDec.decode(oh.DTS.Base)
+		pts = r.timeDec.decode(oh.PTS.Base)
+	} else {
+		pts = r.timeDec.decode(oh.PTS.Base)
+		dts = pts
+	}
+
+	return onData(pts, dts, data.PES.Data)
+}
@@ -0,0 +1,216 @@
+package mpegts
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+)
+
+func TestReader(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(&buf, []*Track{
+		{
+			Codec: &CodecH264{},
+		},
+		{
+			Codec: &CodecMPEG4Audio{
+				Config: mpeg4audio.Config{
+					Type:         mpeg4audio.ObjectTypeAACLC,
+					SampleRate:   44100,
+					ChannelCount: 2,
+				},
+			},
+		},
+		{
+			Codec: &CodecOpus{
+				ChannelCount: 1,
+			},
+		},
+		{
+			Codec: &CodecMPEG1Audio{},
+		},
+		{
+			Codec: &CodecKLV{},
+		},
+	})
+	require.NoError(t, err)
+
+	err = w.WriteH264(w.tracks[0], 2*time.Second, [][]byte{
+		testSPS,
+		{0x68, 0xee, 0x3c, 0x80}, // PPS
+		{0x65, 0x88, 0x84, 0x00, 0x33, 0xff},
+	})
+	require.NoError(t, err)
+
+	err = w.WriteMPEG4Audio(w.tracks[1], 2*time.Second, [][]byte{{1, 2, 3, 4}, {5, 6}})
+	require.NoError(t, err)
+
+	err = w.WriteOpus(w.tracks[2], 2500*time.Millisecond, [][]byte{{7, 8}, bytes.Repeat([]byte{9}, 300)})
+	require.NoError(t, err)
+
+	err = w.WriteMPEG1Audio(w.tracks[3], 3*time.Second, [][]byte{{0xff, 0xfb, 10, 11}})
+	require.NoError(t, err)
+
+	err = w.WriteKLV(w.tracks[4], 3*time.Second, [][]byte{{12, 13}})
+	require.NoError(t, err)
+
+	r, err := NewReader(&buf)
+	require.NoError(t, err)
+
+	require.Equal(t, []*Track{
+		{
+			PID:   256,
+			Codec: &CodecH264{},
+		},
+		{
+			PID: 257,
+			Codec: &CodecMPEG4Audio{
+				Config: mpeg4audio.Config{
+					Type:         mpeg4audio.ObjectTypeAACLC,
+					SampleRate:   44100,
+					ChannelCount: 2,
+				},
+			},
+		},
+		{
+			PID: 258,
+			Codec: &CodecOpus{
+				ChannelCount: 1,
+			},
+		},
+		{
+			PID:   259,
+			Codec: &CodecMPEG1Audio{},
+		},
+	}, r.Tracks())
+
+	received := 0
+
+	r.OnDataH26x(r.Tracks()[0], func(pts time.Duration, dts time.Duration, au [][]byte) error {
+		require.Equal(t, time.Duration(0), pts)
+		require.Equal(t, time.Duration(0), dts)
+		require.Equal(t, [][]byte{
+			{0x09, 0xf0},
+			testSPS,
+			{0x68, 0xee, 0x3c, 0x80},
+			{0x65, 0x88, 0x84, 0x00, 0x33, 0xff},
+		}, au)
+		received++
+		return nil
+	})
+
+	r.OnDataMPEG4Audio(r.Tracks()[1], func(pts time.Duration, aus [][]byte) error {
+		require.Equal(t, time.Duration(0), pts)
+		require.Equal(t, [][]byte{{1, 2, 3, 4}, {5, 6}}, aus)
+		received++
+		return nil
+	})
+
+	r.OnDataOpus(r.Tracks()[2], func(pts time.Duration, packets [][]byte) error {
+		require.Equal(t, 500*time.Millisecond, pts)
+		require.Equal(t, [][]byte{{7, 8}, bytes.Repeat([]byte{9}, 300)}, packets)
+		received++
+		return nil
+	})
+
+	r.OnDataMPEG1Audio(r.Tracks()[3], func(pts time.Duration, data []byte) error {
+		require.Equal(t, 1*time.Second, pts)
+		require.Equal(t, []byte{0xff, 0xfb, 10, 11}, data)
+		received++
+		return nil
+	})
+
+	for {
+		err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 4, received)
+}
+
+func TestReaderAC3(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(&buf, []*Track{
+		{
+			Codec: &CodecAC3{},
+		},
+		{
+			Codec: &CodecEAC3{},
+		},
+	})
+	require.NoError(t, err)
+
+	err = w.WriteAC3(w.tracks[0], 2*time.Second, [][]byte{{0x0b, 0x77, 1, 2}, {0x0b, 0x77, 3, 4}})
+	require.NoError(t, err)
+
+	err = w.WriteEAC3(w.tracks[1], 3*time.Second, [][]byte{{0x0b, 0x77, 5, 6}})
+	require.NoError(t, err)
+
+	err = w.WriteAC3(w.tracks[1], 3*time.Second, [][]byte{{0x0b, 0x77, 5, 6}})
+	require.EqualError(t, err, "track is not an AC-3 track")
+
+	r, err := NewReader(&buf)
+	require.NoError(t, err)
+
+	require.Equal(t, []*Track{
+		{
+			PID:   256,
+			Codec: &CodecAC3{},
+		},
+		{
+			PID:   257,
+			Codec: &CodecEAC3{},
+		},
+	}, r.Tracks())
+
+	received := 0
+
+	r.OnDataAC3(r.Tracks()[0], func(pts time.Duration, data []byte) error {
+		require.Equal(t, time.Duration(0), pts)
+		require.Equal(t, []byte{0x0b, 0x77, 1, 2, 0x0b, 0x77, 3, 4}, data)
+		received++
+		return nil
+	})
+
+	r.OnDataEAC3(r.Tracks()[1], func(pts time.Duration, data []byte) error {
+		require.Equal(t, 1*time.Second, pts)
+		require.Equal(t, []byte{0x0b, 0x77, 5, 6}, data)
+		received++
+		return nil
+	})
+
+	for {
+		err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 2, received)
+}
+
+func TestReaderErrors(t *testing.T) {
+	_, err := NewReader(bytes.NewReader(nil))
+	require.EqualError(t, err, "PMT not found")
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, []*Track{{Codec: &CodecKLV{}}})
+	require.NoError(t, err)
+
+	err = w.WriteKLV(w.tracks[0], 0, [][]byte{{1, 2}})
+	require.NoError(t, err)
+
+	_, err = NewReader(&buf)
+	require.EqualError(t, err, "no supported tracks found")
+}
@@ -0,0 +1,290 @@
+package mpegts
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h264"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h265"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg1audio"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/opus"
+	"github.com/aler9/gortsplib/v2/pkg/format"
+	"github.com/aler9/gortsplib/v2/pkg/media"
+)
+
+// RTPReader reads a MPEG-TS stream and converts its content into RTP packets,
+// that can be routed to ServerStream.WritePacketRTP or Client.WritePacketRTP.
+type RTPReader struct {
+	r           *Reader
+	medias      media.Medias
+	onPacketRTP func(*media.Media, *rtp.Packet, time.Duration)
+}
+
+// NewRTPReader allocates a RTPReader.
+func NewRTPReader(br io.Reader) (*RTPReader, error) {
+	r, err := NewReader(br)
+	if err != nil {
+		return nil, err
+	}
+
+	rr := &RTPReader{
+		r:           r,
+		onPacketRTP: func(*media.Media, *rtp.Packet, time.Duration) {},
+	}
+
+	for _, track := range r.Tracks() {
+		switch codec := track.Codec.(type) {
+		case *CodecH264:
+			rr.setupH264(track)
+
+		case *CodecH265:
+			rr.setupH265(track)
+
+		case *CodecMPEG4Audio:
+			rr.setupMPEG4Audio(track, codec)
+
+		case *CodecOpus:
+			rr.setupOpus(track, codec)
+
+		case *CodecMPEG1Audio:
+			rr.setupMPEG1Audio(track)
+		}
+	}
+
+	return rr, nil
+}
+
+// Medias returns the medias of the stream.
+func (rr *RTPReader) Medias() media.Medias {
+	return rr.medias
+}
+
+// OnPacketRTP sets a callback that is called when a RTP packet is produced.
+// pts is the presentation timestamp of the frame the packet belongs to.
+func (rr *RTPReader) OnPacketRTP(cb func(medi *media.Media, pkt *rtp.Packet, pts time.Duration)) {
+	rr.onPacketRTP = cb
+}
+
+// Read reads data from the stream and produces RTP packets.
+// It returns io.EOF when the stream is over.
+func (rr *RTPReader) Read() error {
+	return rr.r.Read()
+}
+
+func (rr *RTPReader) writePackets(medi *media.Media, pkts []*rtp.Packet, pts time.Duration) {
+	for _, pkt := range pkts {
+		rr.onPacketRTP(medi, pkt, pts)
+	}
+}
+
+func (rr *RTPReader) setupH264(track *Track) {
+	forma := &format.H264{
+		PayloadTyp:        96,
+		PacketizationMode: 1,
+	}
+	medi := &media.Media{
+		Type:    media.TypeVideo,
+		Formats: []format.Format{forma},
+	}
+	rr.medias = append(rr.medias, medi)
+
+	enc := forma.CreateEncoder()
+
+	rr.r.OnDataH26x(track, func(pts time.Duration, dts time.Duration, au [][]byte) error {
+		filteredAU := make([][]byte, 0, len(au))
+
+		for _, nalu := range au {
+			// empty NALUs are skipped
+			if len(nalu) == 0 {
+				continue
+			}
+
+			switch h264.NALUType(nalu[0] & 0x1F) {
+			case h264.NALUTypeAccessUnitDelimiter:
+				continue
+
+			case h264.NALUTypeSPS:
+				if !bytes.Equal(nalu, forma.SafeSPS()) {
+					forma.SafeSetSPS(nalu)
+				}
+
+			case h264.NALUTypePPS:
+				if !bytes.Equal(nalu, forma.SafePPS()) {
+					forma.SafeSetPPS(nalu)
+				}
+			}
+
+			filteredAU = append(filteredAU, nalu)
+		}
+
+		if len(filteredAU) == 0 {
+			return nil
+		}
+
+		pkts, err := enc.Encode(filteredAU, pts)
+		if err != nil {
+			return err
+		}
+
+		rr.writePackets(medi, pkts, pts)
+		return nil
+	})
+}
+
+func (rr *RTPReader) setupH265(track *Track) {
+	forma := &format.H265{
+		PayloadTyp: 96,
+	}
+	medi := &media.Media{
+		Type:    media.TypeVideo,
+		Formats: []format.Format{forma},
+	}
+	rr.medias = append(rr.medias, medi)
+
+	enc := forma.CreateEncoder()
+
+	rr.r.OnDataH26x(track, func(pts time.Duration, dts time.Duration, au [][]byte) error {
+		filteredAU := make([][]byte, 0, len(au))
+
+		for _, nalu := range au {
+			// empty NALUs are skipped
+			if len(nalu) == 0 {
+				continue
+			}
+
+			switch h265.NALUType((nalu[0] >> 1) & 0b111111) {
+			case h265.NALUType_AUD_NUT:
+				continue
+
+			case h265.NALUType_VPS_NUT:
+				if !bytes.Equal(nalu, forma.SafeVPS()) {
+					forma.SafeSetVPS(nalu)
+				}
+
+			case h265.NALUType_SPS_NUT:
+				if !bytes.Equal(nalu, forma.SafeSPS()) {
+					forma.SafeSetSPS(nalu)
+				}
+
+			case h265.NALUType_PPS_NUT:
+				if !bytes.Equal(nalu, forma.SafePPS()) {
+					forma.SafeSetPPS(nalu)
+				}
+			}
+
+			filteredAU = append(filteredAU, nalu)
+		}
+
+		if len(filteredAU) == 0 {
+			return nil
+		}
+
+		pkts, err := enc.Encode(filteredAU, pts)
+		if err != nil {
+			return err
+		}
+
+		rr.writePackets(medi, pkts, pts)
+		return nil
+	})
+}
+
+func (rr *RTPReader) setupMPEG4Audio(track *Track, codec *CodecMPEG4Audio) {
+	conf := codec.Config
+	forma := &format.MPEG4Audio{
+		PayloadTyp:       96,
+		Config:           &conf,
+		SizeLength:       13,
+		IndexLength:      3,
+		IndexDeltaLength: 3,
+	}
+	medi := &media.Media{
+		Type:    media.TypeAudio,
+		Formats: []format.Format{forma},
+	}
+	rr.medias = append(rr.medias, medi)
+
+	enc := forma.CreateEncoder()
+
+	rr.r.OnDataMPEG4Audio(track, func(pts time.Duration, aus [][]byte) error {
+		pkts, err := enc.Encode(aus, pts)
+		if err != nil {
+			return err
+		}
+
+		rr.writePackets(medi, pkts, pts)
+		return nil
+	})
+}
+
+func (rr *RTPReader) setupOpus(track *Track, codec *CodecOpus) {
+	forma := &format.Opus{
+		PayloadTyp:   96,
+		SampleRate:   48000,
+		ChannelCount: codec.ChannelCount,
+	}
+	medi := &media.Media{
+		Type:    media.TypeAudio,
+		Formats: []format.Format{forma},
+	}
+	rr.medias = append(rr.medias, medi)
+
+	enc := forma.CreateEncoder()
+
+	rr.r.OnDataOpus(track, func(pts time.Duration, packets [][]byte) error {
+		for _, packet := range packets {
+			pkt, err := enc.Encode(packet, pts)
+			if err != nil {
+				return err
+			}
+
+			rr.onPacketRTP(medi, pkt, pts)
+			pts += opus.PacketDuration(packet)
+		}
+
+		return nil
+	})
+}
+
+func (rr *RTPReader) setupMPEG1Audio(track *Track) {
+	forma := &format.MPEG2Audio{}
+	medi := &media.Media{
+		Type:    media.TypeAudio,
+		Formats: []format.Format{forma},
+	}
+	rr.medias = append(rr.medias, medi)
+
+	enc := forma.CreateEncoder()
+
+	rr.r.OnDataMPEG1Audio(track, func(pts time.Duration, data []byte) error {
+		var frames [][]byte
+
+		for len(data) > 0 {
+			var h mpeg1audio.FrameHeader
+			err := h.Unmarshal(data)
+			if err != nil {
+				return err
+			}
+
+			fl := h.FrameLen()
+			if len(data) < fl {
+				return fmt.Errorf("frame is truncated")
+			}
+
+			frames = append(frames, data[:fl])
+			data = data[fl:]
+		}
+
+		pkts, err := enc.Encode(frames, pts)
+		if err != nil {
+			return err
+		}
+
+		rr.writePackets(medi, pkts, pts)
+		return nil
+	})
+}
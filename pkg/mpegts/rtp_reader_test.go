@@ -0,0 +1,119 @@
+package mpegts
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+	"github.com/aler9/gortsplib/v2/pkg/format"
+	"github.com/aler9/gortsplib/v2/pkg/media"
+)
+
+func TestRTPReader(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(&buf, []*Track{
+		{
+			Codec: &CodecH264{},
+		},
+		{
+			Codec: &CodecMPEG4Audio{
+				Config: mpeg4audio.Config{
+					Type:         mpeg4audio.ObjectTypeAACLC,
+					SampleRate:   44100,
+					ChannelCount: 2,
+				},
+			},
+		},
+		{
+			Codec: &CodecOpus{
+				ChannelCount: 2,
+			},
+		},
+		{
+			Codec: &CodecMPEG1Audio{},
+		},
+	})
+	require.NoError(t, err)
+
+	err = w.WriteH264(w.tracks[0], 0, [][]byte{
+		testSPS,
+		{0x68, 0xee, 0x3c, 0x80}, // PPS
+		{0x65, 0x88, 0x84, 0x00, 0x33, 0xff},
+	})
+	require.NoError(t, err)
+
+	err = w.WriteMPEG4Audio(w.tracks[1], 0, [][]byte{{1, 2, 3, 4}})
+	require.NoError(t, err)
+
+	err = w.WriteOpus(w.tracks[2], 0, [][]byte{{0xfc, 1}, {0xfc, 2}})
+	require.NoError(t, err)
+
+	mp3Frame := append([]byte{0xff, 0xff, 0x14, 0x00}, bytes.Repeat([]byte{0x01}, 28)...)
+	err = w.WriteMPEG1Audio(w.tracks[3], 0, [][]byte{mp3Frame, mp3Frame})
+	require.NoError(t, err)
+
+	r, err := NewRTPReader(&buf)
+	require.NoError(t, err)
+
+	medias := r.Medias()
+	require.Equal(t, 4, len(medias))
+	require.Equal(t, media.TypeVideo, medias[0].Type)
+	require.Equal(t, &format.MPEG4Audio{
+		PayloadTyp: 96,
+		Config: &mpeg4audio.Config{
+			Type:         mpeg4audio.ObjectTypeAACLC,
+			SampleRate:   44100,
+			ChannelCount: 2,
+		},
+		SizeLength:       13,
+		IndexLength:      3,
+		IndexDeltaLength: 3,
+	}, medias[1].Formats[0])
+	require.Equal(t, &format.Opus{
+		PayloadTyp:   96,
+		SampleRate:   48000,
+		ChannelCount: 2,
+	}, medias[2].Formats[0])
+	require.Equal(t, &format.MPEG2Audio{}, medias[3].Formats[0])
+
+	pkts := make(map[*media.Media][]*rtp.Packet)
+
+	r.OnPacketRTP(func(medi *media.Media, pkt *rtp.Packet, pts time.Duration) {
+		pkts[medi] = append(pkts[medi], pkt)
+	})
+
+	for {
+		err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	// SPS and PPS are extracted from the stream
+	forma := medias[0].Formats[0].(*format.H264)
+	require.Equal(t, testSPS, forma.SafeSPS())
+	require.Equal(t, []byte{0x68, 0xee, 0x3c, 0x80}, forma.SafePPS())
+
+	// SPS, PPS and IDR are aggregated into a STAP-A packet
+	require.Equal(t, 1, len(pkts[medias[0]]))
+	require.Equal(t, uint8(24), pkts[medias[0]][0].Payload[0]&0x1F)
+
+	require.Equal(t, 1, len(pkts[medias[1]]))
+
+	// Opus packets are spaced by their duration
+	require.Equal(t, 2, len(pkts[medias[2]]))
+	require.Equal(t, []byte{0xfc, 1}, pkts[medias[2]][0].Payload)
+	require.Equal(t, []byte{0xfc, 2}, pkts[medias[2]][1].Payload)
+	require.Equal(t, uint32(960), pkts[medias[2]][1].Timestamp-pkts[medias[2]][0].Timestamp)
+
+	// MPEG-1/2 audio frames are aggregated
+	require.Equal(t, 1, len(pkts[medias[3]]))
+	require.Equal(t, append([]byte{0, 0, 0, 0}, append(mp3Frame, mp3Frame...)...), pkts[medias[3]][0].Payload)
+}
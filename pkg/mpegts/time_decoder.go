@@ -0,0 +1,44 @@
+package mpegts
+
+import (
+	"time"
+)
+
+const (
+	maxTimestamp      = 0x1FFFFFFFF
+	negativeThreshold = maxTimestamp / 2
+)
+
+// timeDecoder converts 33-bit MPEG-TS timestamps into durations,
+// relative to the first decoded timestamp, handling wrap-arounds.
+type timeDecoder struct {
+	initialized bool
+	prev        int64
+	overall     int64
+}
+
+func (d *timeDecoder) decode(ts int64) time.Duration {
+	if !d.initialized {
+		d.initialized = true
+		d.prev = ts
+		return 0
+	}
+
+	diff := (ts - d.prev) & maxTimestamp
+
+	// negative difference
+	if diff > negativeThreshold {
+		diff = (d.prev - ts) & maxTimestamp
+		d.overall -= diff
+	} else {
+		d.overall += diff
+	}
+
+	d.prev = ts
+
+	// avoid an int64 overflow and keep resolution by splitting division into two parts:
+	// first add the integer part, then the decimal part.
+	secs := d.overall / 90000
+	dec := d.overall % 90000
+	return time.Duration(secs)*time.Second + time.Duration(dec)*time.Second/90000
+}
@@ -0,0 +1,37 @@
+package mpegts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeDecoderNegativeDiff(t *testing.T) {
+	var d timeDecoder
+
+	ts := d.decode(64523434)
+	require.Equal(t, time.Duration(0), ts)
+
+	ts = d.decode(64523434 - 90000)
+	require.Equal(t, -1*time.Second, ts)
+
+	ts = d.decode(64523434)
+	require.Equal(t, time.Duration(0), ts)
+
+	ts = d.decode(64523434 + 90000*2)
+	require.Equal(t, 2*time.Second, ts)
+}
+
+func TestTimeDecoderOverflow(t *testing.T) {
+	var d timeDecoder
+
+	ts := d.decode(0x1FFFFFFFF - 44999)
+	require.Equal(t, time.Duration(0), ts)
+
+	ts = d.decode(45000)
+	require.Equal(t, time.Second, ts)
+
+	ts = d.decode(0x1FFFFFFFF - 44999)
+	require.Equal(t, time.Duration(0), ts)
+}
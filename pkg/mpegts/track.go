@@ -0,0 +1,11 @@
+package mpegts
+
+// Track is a MPEG-TS track.
+type Track struct {
+	// PID of the track.
+	// If zero, it is filled automatically by NewWriter.
+	PID uint16
+
+	// codec of the track.
+	Codec Codec
+}
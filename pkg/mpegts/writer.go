@@ -0,0 +1,496 @@
+package mpegts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/asticode/go-astits"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h264"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h265"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+)
+
+type writerTrack struct {
+	// H264 / H265 specific
+	vps              []byte
+	sps              []byte
+	pps              []byte
+	h264DTSExtractor *h264.DTSExtractor
+	h265DTSExtractor *h265.DTSExtractor
+	randomAccessRecv bool
+}
+
+// Writer is a MPEG-TS writer.
+type Writer struct {
+	tracks   []*Track
+	mux      *astits.Muxer
+	pcrTrack *Track
+	states   map[*Track]*writerTrack
+}
+
+// NewWriter allocates a Writer that writes MPEG-TS into bw.
+// Tracks with a zero PID are assigned a PID automatically.
+func NewWriter(bw io.Writer, tracks []*Track) (*Writer, error) {
+	w := &Writer{
+		tracks: tracks,
+		mux:    astits.NewMuxer(context.Background(), bw),
+		states: make(map[*Track]*writerTrack),
+	}
+
+	nextPID := uint16(firstAutoPID)
+
+	for _, track := range tracks {
+		if track.PID == 0 {
+			track.PID = nextPID
+			nextPID++
+		}
+
+		es, err := track.Codec.marshal(track.PID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = w.mux.AddElementaryStream(*es)
+		if err != nil {
+			return nil, err
+		}
+
+		w.states[track] = &writerTrack{}
+	}
+
+	// use the first video track as PCR track, or the first track.
+	for _, track := range tracks {
+		if track.Codec.isVideo() {
+			w.pcrTrack = track
+			break
+		}
+	}
+	if w.pcrTrack == nil && len(tracks) != 0 {
+		w.pcrTrack = tracks[0]
+	}
+
+	if w.pcrTrack != nil {
+		w.mux.SetPCRPID(w.pcrTrack.PID)
+	}
+
+	return w, nil
+}
+
+func (w *Writer) state(track *Track) (*writerTrack, error) {
+	ts, ok := w.states[track]
+	if !ok {
+		return nil, fmt.Errorf("track not found")
+	}
+	return ts, nil
+}
+
+// WriteH264 writes a H264 access unit.
+// Access units are discarded until one containing an IDR is received,
+// after SPS and PPS have been received.
+// SPS and PPS are inserted before every IDR, if they are missing.
+func (w *Writer) WriteH264(track *Track, pts time.Duration, au [][]byte) error {
+	if _, ok := track.Codec.(*CodecH264); !ok {
+		return fmt.Errorf("track is not a H264 track")
+	}
+
+	ts, err := w.state(track)
+	if err != nil {
+		return err
+	}
+
+	// prepend an AUD. This is required by some players
+	filteredAU := [][]byte{
+		{byte(h264.NALUTypeAccessUnitDelimiter), 240},
+	}
+
+	nonIDRPresent := false
+	idrPresent := false
+
+	for _, nalu := range au {
+		// empty NALUs are skipped
+		if len(nalu) == 0 {
+			continue
+		}
+
+		typ := h264.NALUType(nalu[0] & 0x1F)
+		switch typ {
+		case h264.NALUTypeSPS:
+			ts.sps = append([]byte(nil), nalu...)
+			continue
+
+		case h264.NALUTypePPS:
+			ts.pps = append([]byte(nil), nalu...)
+			continue
+
+		case h264.NALUTypeAccessUnitDelimiter:
+			continue
+
+		case h264.NALUTypeIDR:
+			idrPresent = true
+
+		case h264.NALUTypeNonIDR:
+			nonIDRPresent = true
+		}
+
+		filteredAU = append(filteredAU, nalu)
+	}
+
+	au = filteredAU
+
+	if !nonIDRPresent && !idrPresent {
+		return nil
+	}
+
+	if !ts.randomAccessRecv {
+		// skip access units silently until we find one with a IDR,
+		// and SPS and PPS are available
+		if !idrPresent || ts.sps == nil || ts.pps == nil {
+			return nil
+		}
+
+		ts.randomAccessRecv = true
+		ts.h264DTSExtractor = h264.NewDTSExtractor()
+	}
+
+	// add SPS and PPS before every group that contains an IDR
+	if idrPresent {
+		au = append([][]byte{au[0], ts.sps, ts.pps}, au[1:]...)
+	}
+
+	dts, err := ts.h264DTSExtractor.Extract(au, pts)
+	if err != nil {
+		return err
+	}
+
+	enc, err := h264.AnnexBMarshal(au)
+	if err != nil {
+		return err
+	}
+
+	return w.writeVideo(track, pts, dts, idrPresent, enc)
+}
+
+// WriteH265 writes a H265 access unit.
+// Access units are discarded until one containing a random access point is received,
+// after VPS, SPS and PPS have been received.
+// VPS, SPS and PPS are inserted before every random access point, if they are missing.
+func (w *Writer) WriteH265(track *Track, pts time.Duration, au [][]byte) error {
+	if _, ok := track.Codec.(*CodecH265); !ok {
+		return fmt.Errorf("track is not a H265 track")
+	}
+
+	ts, err := w.state(track)
+	if err != nil {
+		return err
+	}
+
+	// prepend an AUD. This is required by some players
+	filteredAU := [][]byte{
+		{byte(h265.NALUType_AUD_NUT) << 1, 1, 0x50},
+	}
+
+	randomAccess := false
+
+	for _, nalu := range au {
+		// empty NALUs are skipped
+		if len(nalu) == 0 {
+			continue
+		}
+
+		typ := h265.NALUType((nalu[0] >> 1) & 0b111111)
+		switch typ {
+		case h265.NALUType_VPS_NUT:
+			ts.vps = append([]byte(nil), nalu...)
+			continue
+
+		case h265.NALUType_SPS_NUT:
+			ts.sps = append([]byte(nil), nalu...)
+			continue
+
+		case h265.NALUType_PPS_NUT:
+			ts.pps = append([]byte(nil), nalu...)
+			continue
+
+		case h265.NALUType_AUD_NUT:
+			continue
+
+		case h265.NALUType_IDR_W_RADL, h265.NALUType_IDR_N_LP, h265.NALUType_CRA_NUT:
+			randomAccess = true
+		}
+
+		filteredAU = append(filteredAU, nalu)
+	}
+
+	au = filteredAU
+
+	if len(au) <= 1 {
+		return nil
+	}
+
+	if !ts.randomAccessRecv {
+		// skip access units silently until we find a random access point,
+		// and VPS, SPS and PPS are available
+		if !randomAccess || ts.vps == nil || ts.sps == nil || ts.pps == nil {
+			return nil
+		}
+
+		ts.randomAccessRecv = true
+		ts.h265DTSExtractor = h265.NewDTSExtractor()
+	}
+
+	// add VPS, SPS and PPS before every random access point
+	if randomAccess {
+		au = append([][]byte{au[0], ts.vps, ts.sps, ts.pps}, au[1:]...)
+	}
+
+	dts, err := ts.h265DTSExtractor.Extract(au, pts)
+	if err != nil {
+		return err
+	}
+
+	// H265 uses the same Annex-B format of H264
+	enc, err := h264.AnnexBMarshal(au)
+	if err != nil {
+		return err
+	}
+
+	return w.writeVideo(track, pts, dts, randomAccess, enc)
+}
+
+// WriteMPEG4Audio writes MPEG-4 Audio access units.
+// The first access unit has the given PTS.
+func (w *Writer) WriteMPEG4Audio(track *Track, pts time.Duration, aus [][]byte) error {
+	codec, ok := track.Codec.(*CodecMPEG4Audio)
+	if !ok {
+		return fmt.Errorf("track is not a MPEG-4 Audio track")
+	}
+
+	pkts := make(mpeg4audio.ADTSPackets, len(aus))
+
+	for i, au := range aus {
+		pkts[i] = &mpeg4audio.ADTSPacket{
+			Type:         codec.Config.Type,
+			SampleRate:   codec.Config.SampleRate,
+			ChannelCount: codec.Config.ChannelCount,
+			AU:           au,
+		}
+	}
+
+	enc, err := pkts.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return w.writeAudio(track, pts, enc)
+}
+
+// WriteOpus writes Opus packets.
+// The first packet has the given PTS.
+func (w *Writer) WriteOpus(track *Track, pts time.Duration, packets [][]byte) error {
+	if _, ok := track.Codec.(*CodecOpus); !ok {
+		return fmt.Errorf("track is not a Opus track")
+	}
+
+	n := 0
+	for _, packet := range packets {
+		n += opusControlHeaderSize(packet) + len(packet)
+	}
+
+	enc := make([]byte, n)
+	pos := 0
+
+	for _, packet := range packets {
+		pos += opusControlHeaderMarshal(enc[pos:], packet)
+		pos += copy(enc[pos:], packet)
+	}
+
+	return w.writeAudio(track, pts, enc)
+}
+
+// WriteMPEG1Audio writes MPEG-1 Audio frames.
+// The first frame has the given PTS.
+func (w *Writer) WriteMPEG1Audio(track *Track, pts time.Duration, frames [][]byte) error {
+	if _, ok := track.Codec.(*CodecMPEG1Audio); !ok {
+		return fmt.Errorf("track is not a MPEG-1 Audio track")
+	}
+
+	n := 0
+	for _, frame := range frames {
+		n += len(frame)
+	}
+
+	enc := make([]byte, n)
+	pos := 0
+
+	for _, frame := range frames {
+		pos += copy(enc[pos:], frame)
+	}
+
+	return w.writeAudio(track, pts, enc)
+}
+
+// WriteAC3 writes AC-3 syncframes.
+// The first frame has the given PTS.
+func (w *Writer) WriteAC3(track *Track, pts time.Duration, frames [][]byte) error {
+	if _, ok := track.Codec.(*CodecAC3); !ok {
+		return fmt.Errorf("track is not an AC-3 track")
+	}
+
+	n := 0
+	for _, frame := range frames {
+		n += len(frame)
+	}
+
+	enc := make([]byte, n)
+	pos := 0
+
+	for _, frame := range frames {
+		pos += copy(enc[pos:], frame)
+	}
+
+	return w.writeAudio(track, pts, enc)
+}
+
+// WriteEAC3 writes E-AC-3 syncframes.
+// The first frame has the given PTS.
+func (w *Writer) WriteEAC3(track *Track, pts time.Duration, frames [][]byte) error {
+	if _, ok := track.Codec.(*CodecEAC3); !ok {
+		return fmt.Errorf("track is not an E-AC-3 track")
+	}
+
+	n := 0
+	for _, frame := range frames {
+		n += len(frame)
+	}
+
+	enc := make([]byte, n)
+	pos := 0
+
+	for _, frame := range frames {
+		pos += copy(enc[pos:], frame)
+	}
+
+	return w.writeAudio(track, pts, enc)
+}
+
+// WriteKLV writes KLV units.
+// PTS is written only if the track is synchronous.
+func (w *Writer) WriteKLV(track *Track, pts time.Duration, units [][]byte) error {
+	codec, ok := track.Codec.(*CodecKLV)
+	if !ok {
+		return fmt.Errorf("track is not a KLV track")
+	}
+
+	n := 0
+	for _, unit := range units {
+		n += len(unit)
+	}
+
+	enc := make([]byte, n)
+	pos := 0
+
+	for _, unit := range units {
+		pos += copy(enc[pos:], unit)
+	}
+
+	var oh *astits.PESOptionalHeader
+	if codec.Synchronous {
+		oh = &astits.PESOptionalHeader{
+			MarkerBits:      2,
+			PTSDTSIndicator: astits.PTSDTSIndicatorOnlyPTS,
+			PTS:             &astits.ClockReference{Base: durationGoToMPEGTS(pts + pcrOffset)},
+		}
+	} else {
+		oh = &astits.PESOptionalHeader{
+			MarkerBits:      2,
+			PTSDTSIndicator: astits.PTSDTSIndicatorNoPTSOrDTS,
+		}
+	}
+
+	return w.writeData(track, pts, false, oh, streamIDPrivate1, enc)
+}
+
+func (w *Writer) writeVideo(
+	track *Track,
+	pts time.Duration,
+	dts time.Duration,
+	randomAccess bool,
+	data []byte,
+) error {
+	oh := &astits.PESOptionalHeader{
+		MarkerBits: 2,
+	}
+
+	if dts == pts {
+		oh.PTSDTSIndicator = astits.PTSDTSIndicatorOnlyPTS
+		oh.PTS = &astits.ClockReference{Base: durationGoToMPEGTS(pts + pcrOffset)}
+	} else {
+		oh.PTSDTSIndicator = astits.PTSDTSIndicatorBothPresent
+		oh.DTS = &astits.ClockReference{Base: durationGoToMPEGTS(dts + pcrOffset)}
+		oh.PTS = &astits.ClockReference{Base: durationGoToMPEGTS(pts + pcrOffset)}
+	}
+
+	return w.writeData(track, dts, randomAccess, oh, 0xe0, data)
+}
+
+func (w *Writer) writeAudio(track *Track, pts time.Duration, data []byte) error {
+	if _, err := w.state(track); err != nil {
+		return err
+	}
+
+	oh := &astits.PESOptionalHeader{
+		MarkerBits:      2,
+		PTSDTSIndicator: astits.PTSDTSIndicatorOnlyPTS,
+		PTS:             &astits.ClockReference{Base: durationGoToMPEGTS(pts + pcrOffset)},
+	}
+
+	streamID := uint8(0xc0)
+	switch track.Codec.(type) {
+	case *CodecOpus, *CodecAC3, *CodecEAC3:
+		streamID = streamIDPrivate1
+	}
+
+	return w.writeData(track, pts, true, oh, streamID, data)
+}
+
+func (w *Writer) writeData(
+	track *Track,
+	dts time.Duration,
+	randomAccess bool,
+	oh *astits.PESOptionalHeader,
+	streamID uint8,
+	data []byte,
+) error {
+	if _, err := w.state(track); err != nil {
+		return err
+	}
+
+	var af *astits.PacketAdaptationField
+
+	if randomAccess || track == w.pcrTrack {
+		af = &astits.PacketAdaptationField{
+			RandomAccessIndicator: randomAccess,
+		}
+
+		if track == w.pcrTrack {
+			af.HasPCR = true
+			af.PCR = &astits.ClockReference{Base: durationGoToMPEGTS(dts)}
+		}
+	}
+
+	_, err := w.mux.WriteData(&astits.MuxerData{
+		PID:             track.PID,
+		AdaptationField: af,
+		PES: &astits.PESData{
+			Header: &astits.PESHeader{
+				OptionalHeader: oh,
+				StreamID:       streamID,
+			},
+			Data: data,
+		},
+	})
+	return err
+}
@@ -0,0 +1,186 @@
+package mpegts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/asticode/go-astits"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/mpeg4audio"
+)
+
+var testSPS = []byte{
+	0x67, 0x64, 0x00, 0x28, 0xac, 0xd9, 0x40, 0x78,
+	0x02, 0x27, 0xe5, 0x84, 0x00, 0x00, 0x03, 0x00,
+	0x04, 0x00, 0x00, 0x03, 0x00, 0xf0, 0x3c, 0x60,
+	0xc6, 0x58,
+}
+
+func readAllData(t *testing.T, byts []byte) []*astits.DemuxerData {
+	dem := astits.NewDemuxer(context.Background(), bytes.NewReader(byts), astits.DemuxerOptPacketSize(188))
+	var ret []*astits.DemuxerData
+
+	for {
+		data, err := dem.NextData()
+		if errors.Is(err, astits.ErrNoMorePackets) {
+			return ret
+		}
+		require.NoError(t, err)
+		ret = append(ret, data)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	h264Track := &Track{
+		Codec: &CodecH264{},
+	}
+
+	mpeg4AudioTrack := &Track{
+		Codec: &CodecMPEG4Audio{
+			Config: mpeg4audio.Config{
+				Type:         mpeg4audio.ObjectTypeAACLC,
+				SampleRate:   44100,
+				ChannelCount: 2,
+			},
+		},
+	}
+
+	opusTrack := &Track{
+		Codec: &CodecOpus{
+			ChannelCount: 2,
+		},
+	}
+
+	mpeg1AudioTrack := &Track{
+		Codec: &CodecMPEG1Audio{},
+	}
+
+	klvTrack := &Track{
+		PID:   300,
+		Codec: &CodecKLV{},
+	}
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, []*Track{h264Track, mpeg4AudioTrack, opusTrack, mpeg1AudioTrack, klvTrack})
+	require.NoError(t, err)
+
+	require.Equal(t, uint16(256), h264Track.PID)
+	require.Equal(t, uint16(257), mpeg4AudioTrack.PID)
+	require.Equal(t, uint16(258), opusTrack.PID)
+	require.Equal(t, uint16(259), mpeg1AudioTrack.PID)
+	require.Equal(t, uint16(300), klvTrack.PID)
+
+	// empty NALU, discarded
+	err = w.WriteH264(h264Track, 0, [][]byte{{}})
+	require.NoError(t, err)
+
+	// non-IDR, discarded
+	err = w.WriteH264(h264Track, 0, [][]byte{{0x41, 0x9a, 0x21}})
+	require.NoError(t, err)
+
+	// IDR without SPS and PPS, discarded
+	err = w.WriteH264(h264Track, 1*time.Second, [][]byte{{0x65, 0x88, 0x84, 0x00, 0x33, 0xff}})
+	require.NoError(t, err)
+
+	err = w.WriteH264(h264Track, 2*time.Second, [][]byte{
+		testSPS,
+		{},                       // empty NALU, skipped
+		{0x68, 0xee, 0x3c, 0x80}, // PPS
+		{0x65, 0x88, 0x84, 0x00, 0x33, 0xff},
+	})
+	require.NoError(t, err)
+
+	err = w.WriteMPEG4Audio(mpeg4AudioTrack, 2*time.Second, [][]byte{{1, 2, 3, 4}})
+	require.NoError(t, err)
+
+	err = w.WriteOpus(opusTrack, 2*time.Second, [][]byte{{5, 6, 7}})
+	require.NoError(t, err)
+
+	err = w.WriteMPEG1Audio(mpeg1AudioTrack, 2*time.Second, [][]byte{{0xff, 0xfb, 8, 9}})
+	require.NoError(t, err)
+
+	err = w.WriteKLV(klvTrack, 2*time.Second, [][]byte{{10, 11, 12}})
+	require.NoError(t, err)
+
+	err = w.WriteH264(h264Track, 3*time.Second, [][]byte{{0x41, 0x9a, 0x21, 0x6c, 0x45, 0xff}})
+	require.NoError(t, err)
+
+	err = w.WriteOpus(h264Track, 2*time.Second, [][]byte{{5, 6, 7}})
+	require.EqualError(t, err, "track is not a Opus track")
+
+	err = w.WriteH264(opusTrack, 2*time.Second, [][]byte{{0x65}})
+	require.EqualError(t, err, "track is not a H264 track")
+
+	err = w.WriteH265(h264Track, 2*time.Second, [][]byte{{0x26, 0x01}})
+	require.EqualError(t, err, "track is not a H265 track")
+
+	err = w.WriteH264(&Track{Codec: &CodecH264{}}, 0, [][]byte{{0x65}})
+	require.EqualError(t, err, "track not found")
+
+	data := readAllData(t, buf.Bytes())
+
+	var pmt *astits.PMTData
+	pes := make(map[uint16][]*astits.DemuxerData)
+
+	for _, d := range data {
+		switch {
+		case d.PMT != nil:
+			pmt = d.PMT
+
+		case d.PES != nil:
+			pes[d.PID] = append(pes[d.PID], d)
+		}
+	}
+
+	require.NotNil(t, pmt)
+	require.Equal(t, uint16(256), pmt.PCRPID)
+	require.Equal(t, 5, len(pmt.ElementaryStreams))
+	require.Equal(t, astits.StreamTypeH264Video, pmt.ElementaryStreams[0].StreamType)
+	require.Equal(t, astits.StreamTypeAACAudio, pmt.ElementaryStreams[1].StreamType)
+	require.Equal(t, astits.StreamTypePrivateData, pmt.ElementaryStreams[2].StreamType)
+	require.Equal(t, uint32(registrationOpus),
+		pmt.ElementaryStreams[2].ElementaryStreamDescriptors[0].Registration.FormatIdentifier)
+	require.Equal(t, astits.StreamTypeMPEG1Audio, pmt.ElementaryStreams[3].StreamType)
+	require.Equal(t, astits.StreamTypePrivateData, pmt.ElementaryStreams[4].StreamType)
+	require.Equal(t, uint32(registrationKLVA),
+		pmt.ElementaryStreams[4].ElementaryStreamDescriptors[0].Registration.FormatIdentifier)
+
+	require.Equal(t, 2, len(pes[256]))
+
+	d := pes[256][0]
+	require.Equal(t, true, d.FirstPacket.AdaptationField.RandomAccessIndicator)
+	require.Equal(t, true, d.FirstPacket.AdaptationField.HasPCR)
+	require.Equal(t, int64(180000), d.FirstPacket.AdaptationField.PCR.Base)
+	require.Equal(t, int64(216000), d.PES.Header.OptionalHeader.PTS.Base)
+	require.Equal(t, append([]byte{
+		0, 0, 0, 1, 0x09, 0xf0,
+		0, 0, 0, 1,
+	}, append(testSPS, []byte{
+		0, 0, 0, 1, 0x68, 0xee, 0x3c, 0x80,
+		0, 0, 0, 1, 0x65, 0x88, 0x84, 0x00, 0x33, 0xff,
+	}...)...), d.PES.Data)
+
+	d = pes[256][1]
+	require.Equal(t, false, d.FirstPacket.AdaptationField.RandomAccessIndicator)
+	require.Equal(t, int64(270000), d.FirstPacket.AdaptationField.PCR.Base)
+
+	d = pes[257][0]
+	require.Equal(t, true, d.FirstPacket.AdaptationField.RandomAccessIndicator)
+	require.Equal(t, int64(216000), d.PES.Header.OptionalHeader.PTS.Base)
+	require.Equal(t, []byte{0xff, 0xf1, 0x50, 0x80, 0x01, 0x7f, 0xfc, 1, 2, 3, 4}, d.PES.Data)
+
+	d = pes[258][0]
+	require.Equal(t, uint8(streamIDPrivate1), d.PES.Header.StreamID)
+	require.Equal(t, []byte{0x7f, 0xe0, 3, 5, 6, 7}, d.PES.Data)
+
+	d = pes[259][0]
+	require.Equal(t, []byte{0xff, 0xfb, 8, 9}, d.PES.Data)
+
+	d = pes[300][0]
+	require.Equal(t, uint8(astits.PTSDTSIndicatorNoPTSOrDTS), d.PES.Header.OptionalHeader.PTSDTSIndicator)
+	require.Equal(t, []byte{10, 11, 12}, d.PES.Data)
+}
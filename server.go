@@ -7,12 +7,15 @@ import (
 	"net"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/aler9/gortsplib/v2/pkg/auth"
 	"github.com/aler9/gortsplib/v2/pkg/base"
 	"github.com/aler9/gortsplib/v2/pkg/liberrors"
+	"github.com/aler9/gortsplib/v2/pkg/url"
 )
 
 func extractPort(address string) (int, error) {
@@ -38,6 +41,7 @@ type sessionRequestRes struct {
 type sessionRequestReq struct {
 	sc     *ServerConn
 	req    *base.Request
+	claims auth.TokenClaims
 	id     string
 	create bool
 	res    chan sessionRequestRes
@@ -47,6 +51,10 @@ type streamMulticastIPReq struct {
 	res chan net.IP
 }
 
+type shutdownReq struct {
+	res chan []*ServerSession
+}
+
 // Server is a RTSP server.
 type Server struct {
 	//
@@ -94,6 +102,59 @@ type Server struct {
 	WriteBufferCount int
 	// disable automatic RTCP sender reports.
 	DisableRTCPSenderReports bool
+	// a function that is called for each session when the server is shut down
+	// with Shutdown(). If it returns an URL, a REDIRECT request pointing to the
+	// URL is sent to the client.
+	ShutdownRedirect func(*ServerSession) *url.URL
+	// a function that validates Bearer tokens.
+	// If set, all requests except OPTIONS must contain a valid Bearer token,
+	// otherwise they are rejected with 401.
+	// Claims of the token are passed to handlers through the TokenClaims field
+	// of their contexts.
+	ValidateToken func(token string) (auth.TokenClaims, error)
+	// realm of the WWW-Authenticate header that is sent when a Bearer token
+	// is missing or invalid.
+	// It defaults to "IPCAM".
+	AuthRealm string
+
+	//
+	// limits (all optional)
+	//
+	// maximum number of open connections.
+	// Connections that exceed the limit receive 503 and are closed,
+	// and are not counted.
+	// It defaults to 0 (unlimited).
+	MaxConnections int
+	// maximum number of open connections from a single IP.
+	// It defaults to 0 (unlimited).
+	MaxConnectionsPerIP int
+	// maximum number of sessions.
+	// Requests that would create a session that exceeds the limit receive 453.
+	// It defaults to 0 (unlimited).
+	MaxSessions int
+	// maximum number of sessions created from a single IP.
+	// It defaults to 0 (unlimited).
+	MaxSessionsPerIP int
+	// maximum number of requests per second that can be sent through a connection.
+	// Connections that exceed the limit receive 503 and are closed.
+	// It defaults to 0 (unlimited).
+	MaxRequestsPerSecond int
+	// maximum number of header entries of a request.
+	// Requests that exceed the limit receive 431 and the connection is closed.
+	// It defaults to 255.
+	MaxRequestHeaderCount int
+	// maximum size of the header of a request, in bytes.
+	// Requests that exceed the limit receive 431 and the connection is closed.
+	// It defaults to 0 (only single entries are limited).
+	MaxRequestHeaderSize int
+	// maximum size of the body of a request (i.e. a SDP), in bytes.
+	// Requests that exceed the limit receive 413 and the connection is closed.
+	// It defaults to 128 KiB.
+	MaxRequestBodySize int
+	// maximum time that can pass between the opening of a connection and
+	// the reception of the first request.
+	// It defaults to 0 (unlimited).
+	FirstRequestTimeout time.Duration
 
 	//
 	// handler (optional)
@@ -131,12 +192,14 @@ type Server struct {
 	sessions        map[string]*ServerSession
 	conns           map[*ServerConn]struct{}
 	closeError      error
+	shuttingDown    int32
 
 	// in
 	connClose         chan *ServerConn
 	sessionRequest    chan sessionRequestReq
 	sessionClose      chan *ServerSession
 	streamMulticastIP chan streamMulticastIPReq
+	shutdown          chan shutdownReq
 }
 
 // Start starts the server.
@@ -154,6 +217,9 @@ func (s *Server) Start() error {
 	if s.WriteBufferCount == 0 {
 		s.WriteBufferCount = 256
 	}
+	if s.AuthRealm == "" {
+		s.AuthRealm = "IPCAM"
+	}
 	if (s.WriteBufferCount & (s.WriteBufferCount - 1)) != 0 {
 		return fmt.Errorf("WriteBufferCount must be a power of two")
 	}
@@ -302,6 +368,14 @@ func (s *Server) Start() error {
 
 	s.ctx, s.ctxCancel = context.WithCancel(context.Background())
 
+	s.sessions = make(map[string]*ServerSession)
+	s.conns = make(map[*ServerConn]struct{})
+	s.connClose = make(chan *ServerConn)
+	s.sessionRequest = make(chan sessionRequestReq)
+	s.sessionClose = make(chan *ServerSession)
+	s.streamMulticastIP = make(chan streamMulticastIPReq)
+	s.shutdown = make(chan shutdownReq)
+
 	s.wg.Add(1)
 	go s.run()
 
@@ -315,6 +389,72 @@ func (s *Server) Close() error {
 	return s.closeError
 }
 
+// Shutdown gracefully shuts down the server.
+// New connections are not accepted anymore and requests that would start
+// new streams are rejected with 503. Existing sessions are redirected, if
+// ShutdownRedirect is set, and are allowed to terminate until ctx is done.
+// Then, remaining sessions are closed forcibly, after sending a RTCP BYE
+// packet to readers, and the server is closed.
+// It returns the sessions that were closed forcibly.
+// If the server has not been started, it does nothing.
+func (s *Server) Shutdown(ctx context.Context) []*ServerSession {
+	if s.ctx == nil {
+		return nil
+	}
+
+	res := make(chan []*ServerSession)
+
+	select {
+	case s.shutdown <- shutdownReq{res: res}:
+	case <-s.ctx.Done():
+		s.wg.Wait()
+		return nil
+	}
+
+	sessions := <-res
+
+	if s.ShutdownRedirect != nil {
+		for _, ss := range sessions {
+			if u := s.ShutdownRedirect(ss); u != nil {
+				select {
+				case ss.redirect <- u:
+				case <-ss.ctx.Done():
+				}
+			}
+		}
+	}
+
+	var forceClosed []*ServerSession
+
+	for _, ss := range sessions {
+		select {
+		case <-ss.ctx.Done():
+		case <-ctx.Done():
+			select {
+			case <-ss.ctx.Done():
+			default:
+				forceClosed = append(forceClosed, ss)
+			}
+		}
+	}
+
+	for _, ss := range forceClosed {
+		select {
+		case ss.forceClose <- struct{}{}:
+		case <-ss.ctx.Done():
+		}
+	}
+
+	// wait until RTCP BYE packets are sent
+	for _, ss := range forceClosed {
+		<-ss.done
+	}
+
+	s.Close()
+
+	return forceClosed
+}
+
 // Wait waits until all server resources are closed.
 // This can happen when a fatal error occurs or when Close() is called.
 func (s *Server) Wait() error {
@@ -325,13 +465,6 @@ func (s *Server) Wait() error {
 func (s *Server) run() {
 	defer s.wg.Done()
 
-	s.sessions = make(map[string]*ServerSession)
-	s.conns = make(map[*ServerConn]struct{})
-	s.connClose = make(chan *ServerConn)
-	s.sessionRequest = make(chan sessionRequestReq)
-	s.sessionClose = make(chan *ServerSession)
-	s.streamMulticastIP = make(chan streamMulticastIPReq)
-
 	s.wg.Add(1)
 	connNew := make(chan net.Conn)
 	acceptErr := make(chan error)
@@ -362,10 +495,14 @@ func (s *Server) run() {
 		for {
 			select {
 			case err := <-acceptErr:
+				// the listener is closed on purpose during a shutdown
+				if atomic.LoadInt32(&s.shuttingDown) == 1 {
+					continue
+				}
 				return err
 
 			case nconn := <-connNew:
-				sc := newServerConn(s, nconn)
+				sc := newServerConn(s, nconn, s.checkConnLimits(nconn))
 				s.conns[sc] = struct{}{}
 
 			case sc := <-s.connClose:
@@ -409,6 +546,27 @@ func (s *Server) run() {
 						continue
 					}
 
+					if atomic.LoadInt32(&s.shuttingDown) == 1 {
+						req.res <- sessionRequestRes{
+							res: &base.Response{
+								StatusCode: base.StatusServiceUnavailable,
+							},
+							err: liberrors.ErrServerShutdown{},
+						}
+						continue
+					}
+
+					err := s.checkSessionLimits(req.sc)
+					if err != nil {
+						req.res <- sessionRequestRes{
+							res: &base.Response{
+								StatusCode: base.StatusNotEnoughBandwidth,
+							},
+							err: err,
+						}
+						continue
+					}
+
 					secretID := uuid.New().String()
 					ss := newServerSession(s, secretID, req.sc)
 					s.sessions[secretID] = ss
@@ -446,6 +604,17 @@ func (s *Server) run() {
 				s.multicastNextIP = ip
 				req.res <- ip
 
+			case req := <-s.shutdown:
+				if atomic.CompareAndSwapInt32(&s.shuttingDown, 0, 1) {
+					s.tcpListener.Close()
+				}
+
+				sessions := make([]*ServerSession, 0, len(s.sessions))
+				for _, ss := range s.sessions {
+					sessions = append(sessions, ss)
+				}
+				req.res <- sessions
+
 			case <-s.ctx.Done():
 				return liberrors.ErrServerTerminated{}
 			}
@@ -465,6 +634,58 @@ func (s *Server) run() {
 	s.tcpListener.Close()
 }
 
+func (s *Server) checkConnLimits(nconn net.Conn) error {
+	if s.MaxConnections == 0 && s.MaxConnectionsPerIP == 0 {
+		return nil
+	}
+
+	ip := nconn.RemoteAddr().(*net.TCPAddr).IP
+	count := 0
+	countIP := 0
+
+	// rejected connections are closed after the response is sent,
+	// and are not taken into account.
+	for sc := range s.conns {
+		if sc.rejectErr != nil {
+			continue
+		}
+		count++
+		if sc.ip().Equal(ip) {
+			countIP++
+		}
+	}
+
+	if s.MaxConnections != 0 && count >= s.MaxConnections {
+		return liberrors.ErrServerTooManyConnections{}
+	}
+
+	if s.MaxConnectionsPerIP != 0 && countIP >= s.MaxConnectionsPerIP {
+		return liberrors.ErrServerTooManyConnectionsPerIP{IP: ip}
+	}
+
+	return nil
+}
+
+func (s *Server) checkSessionLimits(sc *ServerConn) error {
+	if s.MaxSessions != 0 && len(s.sessions) >= s.MaxSessions {
+		return liberrors.ErrServerTooManySessions{}
+	}
+
+	if s.MaxSessionsPerIP != 0 {
+		count := 0
+		for _, ss := range s.sessions {
+			if ss.author.ip().Equal(sc.ip()) {
+				count++
+			}
+		}
+		if count >= s.MaxSessionsPerIP {
+			return liberrors.ErrServerTooManySessionsPerIP{IP: sc.ip()}
+		}
+	}
+
+	return nil
+}
+
 // StartAndWait starts the server and waits until a fatal error.
 func (s *Server) StartAndWait() error {
 	err := s.Start()
@@ -4,15 +4,23 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
+	"io"
 	"net"
 	gourl "net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	psdp "github.com/pion/sdp/v3"
+
+	"github.com/aler9/gortsplib/v2/pkg/auth"
 	"github.com/aler9/gortsplib/v2/pkg/base"
 	"github.com/aler9/gortsplib/v2/pkg/bytecounter"
 	"github.com/aler9/gortsplib/v2/pkg/conn"
+	"github.com/aler9/gortsplib/v2/pkg/headers"
 	"github.com/aler9/gortsplib/v2/pkg/liberrors"
 	"github.com/aler9/gortsplib/v2/pkg/media"
 	"github.com/aler9/gortsplib/v2/pkg/url"
@@ -56,6 +64,16 @@ type ServerConn struct {
 	conn       *conn.Conn
 	session    *ServerSession
 	readFunc   func(readRequest chan readReq) error
+	rejectErr  error
+	firstReqOK bool
+	rateStart  time.Time
+	rateCount  int
+	writeMutex sync.Mutex
+	nextCSeq   int
+
+	// CSeqs of requests initiated by the server that are waiting for a response.
+	// It is protected by writeMutex.
+	pendingCSeqs map[int]struct{}
 
 	// in
 	sessionRemove chan *ServerSession
@@ -67,6 +85,7 @@ type ServerConn struct {
 func newServerConn(
 	s *Server,
 	nconn net.Conn,
+	rejectErr error,
 ) *ServerConn {
 	ctx, ctxCancel := context.WithCancel(s.ctx)
 
@@ -81,6 +100,8 @@ func newServerConn(
 		ctx:           ctx,
 		ctxCancel:     ctxCancel,
 		remoteAddr:    nconn.RemoteAddr().(*net.TCPAddr),
+		rejectErr:     rejectErr,
+		pendingCSeqs:  make(map[int]struct{}),
 		sessionRemove: make(chan *ServerSession),
 		done:          make(chan struct{}),
 	}
@@ -124,6 +145,15 @@ func (sc *ServerConn) UserData() interface{} {
 	return sc.userData
 }
 
+func (sc *ServerConn) notifyLimitExceeded(err error) {
+	if h, ok := sc.s.Handler.(ServerHandlerOnLimitExceeded); ok {
+		h.OnLimitExceeded(&ServerHandlerOnLimitExceededCtx{
+			Conn:  sc,
+			Error: err,
+		})
+	}
+}
+
 func (sc *ServerConn) ip() net.IP {
 	return sc.remoteAddr.IP
 }
@@ -136,13 +166,22 @@ func (sc *ServerConn) run() {
 	defer sc.s.wg.Done()
 	defer close(sc.done)
 
-	if h, ok := sc.s.Handler.(ServerHandlerOnConnOpen); ok {
+	// connections that exceed limits are not notified as opened,
+	// they are kept open until the first request is answered with an error.
+	if sc.rejectErr != nil {
+		sc.notifyLimitExceeded(sc.rejectErr)
+	} else if h, ok := sc.s.Handler.(ServerHandlerOnConnOpen); ok {
 		h.OnConnOpen(&ServerHandlerOnConnOpenCtx{
 			Conn: sc,
 		})
 	}
 
 	sc.conn = conn.NewConn(sc.bc)
+	sc.conn.SetReadLimits(base.ReadLimits{
+		MaxHeaderCount: sc.s.MaxRequestHeaderCount,
+		MaxHeaderSize:  sc.s.MaxRequestHeaderSize,
+		MaxBodySize:    sc.s.MaxRequestBodySize,
+	})
 
 	readRequest := make(chan readReq)
 	readErr := make(chan error)
@@ -151,6 +190,10 @@ func (sc *ServerConn) run() {
 
 	err := sc.runInner(readRequest, readErr)
 
+	if _, ok := err.(liberrors.ErrServerFirstRequestTimedOut); ok && sc.rejectErr == nil {
+		sc.notifyLimitExceeded(err)
+	}
+
 	sc.ctxCancel()
 
 	sc.nconn.Close()
@@ -168,6 +211,10 @@ func (sc *ServerConn) run() {
 	case <-sc.s.ctx.Done():
 	}
 
+	if sc.rejectErr != nil {
+		return
+	}
+
 	if h, ok := sc.s.Handler.(ServerHandlerOnConnClose); ok {
 		h.OnConnClose(&ServerHandlerOnConnCloseCtx{
 			Conn:  sc,
@@ -216,16 +263,41 @@ func (sc *ServerConn) runReader(readRequest chan readReq, readErr chan error, re
 	}
 }
 
+func (sc *ServerConn) firstRequestTimeout() time.Duration {
+	// rejected connections must not be kept open indefinitely
+	if sc.rejectErr != nil &&
+		(sc.s.FirstRequestTimeout == 0 || sc.s.FirstRequestTimeout > sc.s.ReadTimeout) {
+		return sc.s.ReadTimeout
+	}
+	return sc.s.FirstRequestTimeout
+}
+
 func (sc *ServerConn) readFuncStandard(readRequest chan readReq) error {
 	// reset deadline
-	sc.nconn.SetReadDeadline(time.Time{})
+	if !sc.firstReqOK && sc.firstRequestTimeout() != 0 {
+		sc.nconn.SetReadDeadline(time.Now().Add(sc.firstRequestTimeout()))
+	} else {
+		sc.nconn.SetReadDeadline(time.Time{})
+	}
 
 	for {
-		any, err := sc.conn.ReadInterleavedFrameOrRequest()
+		any, err := sc.conn.ReadInterleavedFrameOrRequestOrResponse()
 		if err != nil {
+			if !sc.firstReqOK && sc.firstRequestTimeout() != 0 {
+				var terr net.Error
+				if errors.As(err, &terr) && terr.Timeout() {
+					return liberrors.ErrServerFirstRequestTimedOut{}
+				}
+			}
+			sc.writeReadError(err)
 			return err
 		}
 
+		if !sc.firstReqOK {
+			sc.firstReqOK = true
+			sc.nconn.SetReadDeadline(time.Time{})
+		}
+
 		switch what := any.(type) {
 		case *base.Request:
 			cres := make(chan error)
@@ -240,6 +312,12 @@ func (sc *ServerConn) readFuncStandard(readRequest chan readReq) error {
 				return liberrors.ErrServerTerminated{}
 			}
 
+		case *base.Response:
+			err := sc.handleResponse(what)
+			if err != nil {
+				return err
+			}
+
 		default:
 			return liberrors.ErrServerUnexpectedFrame{}
 		}
@@ -260,7 +338,7 @@ func (sc *ServerConn) readFuncTCP(readRequest chan readReq) error {
 			sc.nconn.SetReadDeadline(time.Now().Add(sc.s.ReadTimeout))
 		}
 
-		what, err := sc.conn.ReadInterleavedFrameOrRequest()
+		what, err := sc.conn.ReadInterleavedFrameOrRequestOrResponse()
 		if err != nil {
 			return err
 		}
@@ -296,10 +374,39 @@ func (sc *ServerConn) readFuncTCP(readRequest chan readReq) error {
 			case <-sc.ctx.Done():
 				return liberrors.ErrServerTerminated{}
 			}
+
+		case *base.Response:
+			err := sc.handleResponse(twhat)
+			if err != nil {
+				return err
+			}
 		}
 	}
 }
 
+// handleResponse discards responses to requests initiated by the server.
+func (sc *ServerConn) handleResponse(res *base.Response) error {
+	cseq, ok := res.Header["CSeq"]
+	if !ok || len(cseq) != 1 {
+		return liberrors.ErrServerUnexpectedResponse{}
+	}
+
+	v, err := strconv.ParseInt(cseq[0], 10, 64)
+	if err != nil {
+		return liberrors.ErrServerUnexpectedResponse{}
+	}
+
+	sc.writeMutex.Lock()
+	defer sc.writeMutex.Unlock()
+
+	if _, ok := sc.pendingCSeqs[int(v)]; !ok {
+		return liberrors.ErrServerUnexpectedResponse{}
+	}
+
+	delete(sc.pendingCSeqs, int(v))
+	return nil
+}
+
 func (sc *ServerConn) handleRequest(req *base.Request) (*base.Response, error) {
 	if cseq, ok := req.Header["CSeq"]; !ok || len(cseq) != 1 {
 		return &base.Response{
@@ -308,6 +415,53 @@ func (sc *ServerConn) handleRequest(req *base.Request) (*base.Response, error) {
 		}, liberrors.ErrServerCSeqMissing{}
 	}
 
+	if sc.rejectErr != nil {
+		return &base.Response{
+			StatusCode: base.StatusServiceUnavailable,
+		}, sc.rejectErr
+	}
+
+	// reject requests that would start new streams
+	if atomic.LoadInt32(&sc.s.shuttingDown) == 1 &&
+		(req.Method == base.Describe || req.Method == base.Announce || req.Method == base.Setup) {
+		return &base.Response{
+			StatusCode: base.StatusServiceUnavailable,
+		}, nil
+	}
+
+	if sc.s.MaxRequestsPerSecond != 0 {
+		now := time.Now()
+		if now.Sub(sc.rateStart) >= time.Second {
+			sc.rateStart = now
+			sc.rateCount = 0
+		}
+
+		sc.rateCount++
+		if sc.rateCount > sc.s.MaxRequestsPerSecond {
+			return &base.Response{
+				StatusCode: base.StatusServiceUnavailable,
+			}, liberrors.ErrServerRequestRateExceeded{}
+		}
+	}
+
+	var claims auth.TokenClaims
+
+	if sc.s.ValidateToken != nil && req.Method != base.Options {
+		var err error
+		claims, err = sc.validateToken(req)
+		if err != nil {
+			return &base.Response{
+				StatusCode: base.StatusUnauthorized,
+				Header: base.Header{
+					"WWW-Authenticate": headers.Authenticate{
+						Method: headers.AuthBearer,
+						Realm:  &sc.s.AuthRealm,
+					}.Marshal(),
+				},
+			}, nil
+		}
+	}
+
 	sxID := getSessionID(req.Header)
 
 	var path string
@@ -327,7 +481,7 @@ func (sc *ServerConn) handleRequest(req *base.Request) (*base.Response, error) {
 	switch req.Method {
 	case base.Options:
 		if sxID != "" {
-			return sc.handleRequestInSession(sxID, req, false)
+			return sc.handleRequestInSession(sxID, req, claims, false)
 		}
 
 		var methods []string
@@ -365,10 +519,11 @@ func (sc *ServerConn) handleRequest(req *base.Request) (*base.Response, error) {
 	case base.Describe:
 		if h, ok := sc.s.Handler.(ServerHandlerOnDescribe); ok {
 			res, stream, err := h.OnDescribe(&ServerHandlerOnDescribeCtx{
-				Conn:    sc,
-				Request: req,
-				Path:    path,
-				Query:   query,
+				Conn:        sc,
+				Request:     req,
+				Path:        path,
+				Query:       query,
+				TokenClaims: claims,
 			})
 
 			if res.StatusCode == base.StatusOK {
@@ -392,7 +547,22 @@ func (sc *ServerConn) handleRequest(req *base.Request) (*base.Response, error) {
 				}
 
 				if stream != nil {
-					byts, _ := filterMedias(stream.medias, stream.streamMedias).Marshal(multicast).Marshal()
+					desc := filterMedias(stream.medias, stream.streamMedias).Marshal(multicast)
+
+					// on-demand streams have a known duration
+					if stream.duration != nil {
+						desc.Attributes = append(desc.Attributes, psdp.Attribute{
+							Key: "range",
+							Value: headers.Range{
+								Value: &headers.RangeNPT{
+									Start: 0,
+									End:   stream.duration,
+								},
+							}.Marshal()[0],
+						})
+					}
+
+					byts, _ := desc.Marshal()
 					res.Body = byts
 				}
 			}
@@ -402,65 +572,67 @@ func (sc *ServerConn) handleRequest(req *base.Request) (*base.Response, error) {
 
 	case base.Announce:
 		if _, ok := sc.s.Handler.(ServerHandlerOnAnnounce); ok {
-			return sc.handleRequestInSession(sxID, req, true)
+			return sc.handleRequestInSession(sxID, req, claims, true)
 		}
 
 	case base.Setup:
 		if _, ok := sc.s.Handler.(ServerHandlerOnSetup); ok {
-			return sc.handleRequestInSession(sxID, req, true)
+			return sc.handleRequestInSession(sxID, req, claims, true)
 		}
 
 	case base.Play:
 		if sxID != "" {
 			if _, ok := sc.s.Handler.(ServerHandlerOnPlay); ok {
-				return sc.handleRequestInSession(sxID, req, false)
+				return sc.handleRequestInSession(sxID, req, claims, false)
 			}
 		}
 
 	case base.Record:
 		if sxID != "" {
 			if _, ok := sc.s.Handler.(ServerHandlerOnRecord); ok {
-				return sc.handleRequestInSession(sxID, req, false)
+				return sc.handleRequestInSession(sxID, req, claims, false)
 			}
 		}
 
 	case base.Pause:
 		if sxID != "" {
 			if _, ok := sc.s.Handler.(ServerHandlerOnPause); ok {
-				return sc.handleRequestInSession(sxID, req, false)
+				return sc.handleRequestInSession(sxID, req, claims, false)
 			}
 		}
 
 	case base.Teardown:
 		if sxID != "" {
-			return sc.handleRequestInSession(sxID, req, false)
+			return sc.handleRequestInSession(sxID, req, claims, false)
 		}
 
 	case base.GetParameter:
 		if sxID != "" {
-			return sc.handleRequestInSession(sxID, req, false)
+			return sc.handleRequestInSession(sxID, req, claims, false)
 		}
 
 		if h, ok := sc.s.Handler.(ServerHandlerOnGetParameter); ok {
 			return h.OnGetParameter(&ServerHandlerOnGetParameterCtx{
-				Conn:    sc,
-				Request: req,
-				Path:    path,
-				Query:   query,
+				Conn:        sc,
+				Request:     req,
+				Path:        path,
+				Query:       query,
+				TokenClaims: claims,
 			})
 		}
 
 	case base.SetParameter:
 		if sxID != "" {
-			return sc.handleRequestInSession(sxID, req, false)
+			return sc.handleRequestInSession(sxID, req, claims, false)
 		}
 
 		if h, ok := sc.s.Handler.(ServerHandlerOnSetParameter); ok {
 			return h.OnSetParameter(&ServerHandlerOnSetParameterCtx{
-				Conn:    sc,
-				Request: req,
-				Path:    path,
-				Query:   query,
+				Conn:        sc,
+				Request:     req,
+				Path:        path,
+				Query:       query,
+				TokenClaims: claims,
 			})
 		}
 	}
@@ -470,6 +642,20 @@ func (sc *ServerConn) handleRequest(req *base.Request) (*base.Response, error) {
 	}, nil
 }
 
+func (sc *ServerConn) validateToken(req *base.Request) (auth.TokenClaims, error) {
+	var h headers.Authorization
+	err := h.Unmarshal(req.Header["Authorization"])
+	if err != nil {
+		return nil, err
+	}
+
+	if h.Method != headers.AuthBearer {
+		return nil, fmt.Errorf("unsupported authentication method")
+	}
+
+	return sc.s.ValidateToken(h.BearerToken)
+}
+
 func (sc *ServerConn) handleRequestOuter(req *base.Request) error {
 	if h, ok := sc.s.Handler.(ServerHandlerOnRequest); ok {
 		h.OnRequest(sc, req)
@@ -477,6 +663,16 @@ func (sc *ServerConn) handleRequestOuter(req *base.Request) error {
 
 	res, err := sc.handleRequest(req)
 
+	switch err.(type) {
+	case liberrors.ErrServerTooManySessions, liberrors.ErrServerTooManySessionsPerIP:
+		// the connection can still be used.
+		sc.notifyLimitExceeded(err)
+		err = nil
+
+	case liberrors.ErrServerRequestRateExceeded:
+		sc.notifyLimitExceeded(err)
+	}
+
 	if res.Header == nil {
 		res.Header = make(base.Header)
 	}
@@ -493,15 +689,70 @@ func (sc *ServerConn) handleRequestOuter(req *base.Request) error {
 		h.OnResponse(sc, res)
 	}
 
+	sc.writeResponse(res)
+
+	return err
+}
+
+// writes can be performed by the connection (responses), by the session writer
+// (interleaved frames) and by the session (requests), therefore they are serialized.
+
+func (sc *ServerConn) writeResponse(res *base.Response) {
+	sc.writeMutex.Lock()
+	defer sc.writeMutex.Unlock()
+
 	sc.nconn.SetWriteDeadline(time.Now().Add(sc.s.WriteTimeout))
 	sc.conn.WriteResponse(res)
+}
 
-	return err
+// writeReadError replies to a request that can't be read,
+// before the connection is closed.
+func (sc *ServerConn) writeReadError(err error) {
+	var lerr base.ErrReadLimitExceeded
+	if errors.As(err, &lerr) {
+		sc.writeResponse(&base.Response{
+			StatusCode: lerr.StatusCode,
+		})
+		return
+	}
+
+	// the connection has been closed or has timed out
+	var nerr net.Error
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.As(err, &nerr) {
+		return
+	}
+
+	sc.writeResponse(&base.Response{
+		StatusCode: base.StatusBadRequest,
+	})
+}
+
+// writeRequest writes a request initiated by the server.
+// Its CSeq is generated by the server, independently from the ones of the client.
+func (sc *ServerConn) writeRequest(req *base.Request) {
+	sc.writeMutex.Lock()
+	defer sc.writeMutex.Unlock()
+
+	sc.nextCSeq++
+	req.Header["CSeq"] = base.HeaderValue{strconv.FormatInt(int64(sc.nextCSeq), 10)}
+	sc.pendingCSeqs[sc.nextCSeq] = struct{}{}
+
+	sc.nconn.SetWriteDeadline(time.Now().Add(sc.s.WriteTimeout))
+	sc.conn.WriteRequest(req)
+}
+
+func (sc *ServerConn) writeInterleavedFrame(fr *base.InterleavedFrame, buf []byte) {
+	sc.writeMutex.Lock()
+	defer sc.writeMutex.Unlock()
+
+	sc.nconn.SetWriteDeadline(time.Now().Add(sc.s.WriteTimeout))
+	sc.conn.WriteInterleavedFrame(fr, buf)
 }
 
 func (sc *ServerConn) handleRequestInSession(
 	sxID string,
 	req *base.Request,
+	claims auth.TokenClaims,
 	create bool,
 ) (*base.Response, error) {
 	// handle directly in Session
@@ -523,6 +774,7 @@ func (sc *ServerConn) handleRequestInSession(
 		sreq := sessionRequestReq{
 			sc:     sc,
 			req:    req,
+			claims: claims,
 			id:     sxID,
 			create: create,
 			res:    cres,
@@ -546,6 +798,7 @@ func (sc *ServerConn) handleRequestInSession(
 	sreq := sessionRequestReq{
 		sc:     sc,
 		req:    req,
+		claims: claims,
 		id:     sxID,
 		create: create,
 		res:    cres,
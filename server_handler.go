@@ -1,6 +1,7 @@
 package gortsplib
 
 import (
+	"github.com/aler9/gortsplib/v2/pkg/auth"
 	"github.com/aler9/gortsplib/v2/pkg/base"
 	"github.com/aler9/gortsplib/v2/pkg/media"
 )
@@ -69,10 +70,11 @@ type ServerHandlerOnResponse interface {
 
 // ServerHandlerOnDescribeCtx is the context of OnDescribe.
 type ServerHandlerOnDescribeCtx struct {
-	Conn    *ServerConn
-	Request *base.Request
-	Path    string
-	Query   string
+	Conn        *ServerConn
+	Request     *base.Request
+	Path        string
+	Query       string
+	TokenClaims auth.TokenClaims
 }
 
 // ServerHandlerOnDescribe can be implemented by a ServerHandler.
@@ -83,13 +85,14 @@ type ServerHandlerOnDescribe interface {
 
 // ServerHandlerOnAnnounceCtx is the context of OnAnnounce.
 type ServerHandlerOnAnnounceCtx struct {
-	Server  *Server
-	Session *ServerSession
-	Conn    *ServerConn
-	Request *base.Request
-	Path    string
-	Query   string
-	Medias  media.Medias
+	Server      *Server
+	Session     *ServerSession
+	Conn        *ServerConn
+	Request     *base.Request
+	Path        string
+	Query       string
+	Medias      media.Medias
+	TokenClaims auth.TokenClaims
 }
 
 // ServerHandlerOnAnnounce can be implemented by a ServerHandler.
@@ -100,13 +103,14 @@ type ServerHandlerOnAnnounce interface {
 
 // ServerHandlerOnSetupCtx is the context of OnSetup.
 type ServerHandlerOnSetupCtx struct {
-	Server    *Server
-	Session   *ServerSession
-	Conn      *ServerConn
-	Request   *base.Request
-	Path      string
-	Query     string
-	Transport Transport
+	Server      *Server
+	Session     *ServerSession
+	Conn        *ServerConn
+	Request     *base.Request
+	Path        string
+	Query       string
+	Transport   Transport
+	TokenClaims auth.TokenClaims
 }
 
 // ServerHandlerOnSetup can be implemented by a ServerHandler.
@@ -121,11 +125,12 @@ type ServerHandlerOnSetup interface {
 
 // ServerHandlerOnPlayCtx is the context of OnPlay.
 type ServerHandlerOnPlayCtx struct {
-	Session *ServerSession
-	Conn    *ServerConn
-	Request *base.Request
-	Path    string
-	Query   string
+	Session     *ServerSession
+	Conn        *ServerConn
+	Request     *base.Request
+	Path        string
+	Query       string
+	TokenClaims auth.TokenClaims
 }
 
 // ServerHandlerOnPlay can be implemented by a ServerHandler.
@@ -136,11 +141,12 @@ type ServerHandlerOnPlay interface {
 
 // ServerHandlerOnRecordCtx is the context of OnRecord.
 type ServerHandlerOnRecordCtx struct {
-	Session *ServerSession
-	Conn    *ServerConn
-	Request *base.Request
-	Path    string
-	Query   string
+	Session     *ServerSession
+	Conn        *ServerConn
+	Request     *base.Request
+	Path        string
+	Query       string
+	TokenClaims auth.TokenClaims
 }
 
 // ServerHandlerOnRecord can be implemented by a ServerHandler.
@@ -151,11 +157,12 @@ type ServerHandlerOnRecord interface {
 
 // ServerHandlerOnPauseCtx is the context of OnPause.
 type ServerHandlerOnPauseCtx struct {
-	Session *ServerSession
-	Conn    *ServerConn
-	Request *base.Request
-	Path    string
-	Query   string
+	Session     *ServerSession
+	Conn        *ServerConn
+	Request     *base.Request
+	Path        string
+	Query       string
+	TokenClaims auth.TokenClaims
 }
 
 // ServerHandlerOnPause can be implemented by a ServerHandler.
@@ -166,11 +173,12 @@ type ServerHandlerOnPause interface {
 
 // ServerHandlerOnGetParameterCtx is the context of OnGetParameter.
 type ServerHandlerOnGetParameterCtx struct {
-	Session *ServerSession
-	Conn    *ServerConn
-	Request *base.Request
-	Path    string
-	Query   string
+	Session     *ServerSession
+	Conn        *ServerConn
+	Request     *base.Request
+	Path        string
+	Query       string
+	TokenClaims auth.TokenClaims
 }
 
 // ServerHandlerOnGetParameter can be implemented by a ServerHandler.
@@ -181,11 +189,12 @@ type ServerHandlerOnGetParameter interface {
 
 // ServerHandlerOnSetParameterCtx is the context of OnSetParameter.
 type ServerHandlerOnSetParameterCtx struct {
-	Session *ServerSession
-	Conn    *ServerConn
-	Request *base.Request
-	Path    string
-	Query   string
+	Session     *ServerSession
+	Conn        *ServerConn
+	Request     *base.Request
+	Path        string
+	Query       string
+	TokenClaims auth.TokenClaims
 }
 
 // ServerHandlerOnSetParameter can be implemented by a ServerHandler.
@@ -206,6 +215,18 @@ type ServerHandlerOnWarning interface {
 	OnWarning(*ServerHandlerOnWarningCtx)
 }
 
+// ServerHandlerOnLimitExceededCtx is the context of OnLimitExceeded.
+type ServerHandlerOnLimitExceededCtx struct {
+	Conn  *ServerConn
+	Error error
+}
+
+// ServerHandlerOnLimitExceeded can be implemented by a ServerHandler.
+type ServerHandlerOnLimitExceeded interface {
+	// called when a connection or a client exceeds a limit of the server.
+	OnLimitExceeded(*ServerHandlerOnLimitExceededCtx)
+}
+
 // ServerHandlerOnDecodeErrorCtx is the context of OnDecodeError.
 //
 // Deprecated. Replaced by ServerHandlerOnWarningCtx.
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"crypto/tls"
 	"errors"
+	"fmt"
+	"io"
 	"net"
 	"strconv"
 	"strings"
@@ -2254,3 +2256,681 @@ func TestServerPlayAdditionalInfos(t *testing.T) {
 		}(),
 	}, ssrcs)
 }
+
+type testServerSessionSource struct {
+	medi   *media.Media
+	pos    time.Duration
+	seeks  chan time.Duration
+	closed chan struct{}
+}
+
+// Seek moves the source to the previous multiple of 200ms.
+func (s *testServerSessionSource) Seek(pos time.Duration) (time.Duration, error) {
+	if pos > 1*time.Second {
+		return 0, fmt.Errorf("out of range")
+	}
+
+	s.seeks <- pos
+	s.pos = (pos / (200 * time.Millisecond)) * (200 * time.Millisecond)
+	return s.pos, nil
+}
+
+// ReadPacket returns a packet every 100ms.
+func (s *testServerSessionSource) ReadPacket() (*media.Media, *rtp.Packet, time.Duration, error) {
+	if s.pos >= 1*time.Second {
+		return nil, nil, 0, io.EOF
+	}
+
+	pos := s.pos
+	s.pos += 100 * time.Millisecond
+
+	return s.medi, &rtp.Packet{
+		Header: rtp.Header{
+			Version:     2,
+			Marker:      true,
+			PayloadType: 96,
+			Timestamp:   uint32(pos.Seconds() * 90000),
+		},
+		Payload: []byte{0x05, byte(pos / (100 * time.Millisecond))},
+	}, pos, nil
+}
+
+func (s *testServerSessionSource) Close() error {
+	close(s.closed)
+	return nil
+}
+
+func TestServerPlayOnDemand(t *testing.T) {
+	source := &testServerSessionSource{
+		medi:   testH264Media,
+		seeks:  make(chan time.Duration, 10),
+		closed: make(chan struct{}),
+	}
+
+	stream := NewServerStreamOnDemand(media.Medias{testH264Media}, 1*time.Second,
+		func() (ServerSessionSource, error) {
+			return source, nil
+		})
+	defer stream.Close()
+
+	s := &Server{
+		Handler: &testServerHandler{
+			onDescribe: func(ctx *ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+			onSetup: func(ctx *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+			onPlay: func(ctx *ServerHandlerOnPlayCtx) (*base.Response, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+			onPause: func(ctx *ServerHandlerOnPauseCtx) (*base.Response, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+		},
+		RTSPAddress:       "localhost:8554",
+		MulticastIPRange:  "224.1.0.0/16",
+		MulticastRTPPort:  8000,
+		MulticastRTCPPort: 8001,
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+	conn := conn.NewConn(nconn)
+
+	desc, err := doDescribe(conn)
+	require.NoError(t, err)
+
+	v, ok := desc.Attribute("range")
+	require.Equal(t, true, ok)
+	require.Equal(t, "npt=0-1", v)
+
+	res, err := writeReqReadRes(conn, base.Request{
+		Method: base.Setup,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream/" + controlAttribute(desc.MediaDescriptions[0])),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"2"},
+			"Transport": headers.Transport{
+				Protocol: headers.TransportProtocolUDP,
+				Delivery: func() *headers.TransportDelivery {
+					v := headers.TransportDeliveryMulticast
+					return &v
+				}(),
+			}.Marshal(),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusUnsupportedTransport, res.StatusCode)
+
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Setup,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream/" + controlAttribute(desc.MediaDescriptions[0])),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"3"},
+			"Transport": headers.Transport{
+				Protocol: headers.TransportProtocolTCP,
+				Delivery: func() *headers.TransportDelivery {
+					v := headers.TransportDeliveryUnicast
+					return &v
+				}(),
+				Mode: func() *headers.TransportMode {
+					v := headers.TransportModePlay
+					return &v
+				}(),
+				InterleavedIDs: &[2]int{0, 1},
+			}.Marshal(),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	var th headers.Transport
+	err = th.Unmarshal(res.Header["Transport"])
+	require.NoError(t, err)
+	require.NotNil(t, th.SSRC)
+
+	var sx headers.Session
+	err = sx.Unmarshal(res.Header["Session"])
+	require.NoError(t, err)
+
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Play,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"4"},
+			"Session": base.HeaderValue{sx.Session},
+			"Range":   base.HeaderValue{"npt=0.5-"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+	require.Equal(t, 500*time.Millisecond, <-source.seeks)
+	require.Equal(t, base.HeaderValue{"npt=0.4-1"}, res.Header["Range"])
+
+	seqNum1, ts1 := readRTPInfo(t, res)
+
+	pkt := readPacketWithSeqNum(t, conn, nil, seqNum1)
+	require.Equal(t, ts1, pkt.Timestamp)
+	require.Equal(t, *th.SSRC, pkt.SSRC)
+	require.Equal(t, []byte{0x05, 4}, pkt.Payload)
+
+	pkt = readPacketWithSeqNum(t, conn, nil, seqNum1+1)
+	require.Equal(t, ts1+9000, pkt.Timestamp)
+	require.Equal(t, []byte{0x05, 5}, pkt.Payload)
+
+	err = conn.WriteRequest(&base.Request{
+		Method: base.Pause,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"5"},
+			"Session": base.HeaderValue{sx.Session},
+		},
+	})
+	require.NoError(t, err)
+
+	res, recv := readResponse(t, conn)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	lastSeqNum := seqNum1 + 1
+	if len(recv) != 0 {
+		lastSeqNum = recv[len(recv)-1].SequenceNumber
+	}
+
+	// resume with continuous sequence numbers
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Play,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"6"},
+			"Session": base.HeaderValue{sx.Session},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	seqNum2, ts2 := readRTPInfo(t, res)
+	require.Equal(t, lastSeqNum+1, seqNum2)
+
+	pkt = readPacketWithSeqNum(t, conn, nil, seqNum2)
+	require.Equal(t, ts2, pkt.Timestamp)
+	require.Equal(t, ts1+uint32(pkt.Payload[1]-4)*9000, pkt.Timestamp)
+
+	err = conn.WriteRequest(&base.Request{
+		Method: base.Play,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"7"},
+			"Session": base.HeaderValue{sx.Session},
+			"Range":   base.HeaderValue{"npt=2-"},
+		},
+	})
+	require.NoError(t, err)
+
+	res, _ = readResponse(t, conn)
+	require.Equal(t, base.StatusInvalidRange, res.StatusCode)
+
+	err = conn.WriteRequest(&base.Request{
+		Method: base.Teardown,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"8"},
+			"Session": base.HeaderValue{sx.Session},
+		},
+	})
+	require.NoError(t, err)
+
+	res, _ = readResponse(t, conn)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	<-source.closed
+}
+
+func TestServerPlayTimeShift(t *testing.T) {
+	stream := NewServerStreamWithTimeShift(media.Medias{testH264Media}, 10*time.Second)
+	defer stream.Close()
+
+	// 2 seconds of packets, with a key frame every 500ms
+	base0 := time.Now().Truncate(time.Second).Add(-3 * time.Second)
+
+	writePacket := func(i int, ntp time.Time) {
+		typ := byte(0x01)
+		if (i % 5) == 0 {
+			typ = 0x05
+		}
+
+		stream.WritePacketRTPWithNTP(testH264Media, &rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				PayloadType:    96,
+				SequenceNumber: uint16(i),
+				Timestamp:      uint32(i) * 9000,
+				SSRC:           0x38F27A2F,
+			},
+			Payload: []byte{typ, byte(i)},
+		}, ntp)
+	}
+
+	for i := 0; i < 20; i++ {
+		writePacket(i, base0.Add(time.Duration(i)*100*time.Millisecond))
+	}
+
+	s := &Server{
+		Handler: &testServerHandler{
+			onSetup: func(ctx *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, stream, nil
+			},
+			onPlay: func(ctx *ServerHandlerOnPlayCtx) (*base.Response, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+			onPause: func(ctx *ServerHandlerOnPauseCtx) (*base.Response, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+		},
+		RTSPAddress: "localhost:8554",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+	conn := conn.NewConn(nconn)
+
+	res, err := writeReqReadRes(conn, base.Request{
+		Method: base.Setup,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream/mediaUUID=" + stream.streamMedias[testH264Media].uuid.String()),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"1"},
+			"Transport": headers.Transport{
+				Protocol: headers.TransportProtocolTCP,
+				Delivery: func() *headers.TransportDelivery {
+					v := headers.TransportDeliveryUnicast
+					return &v
+				}(),
+				Mode: func() *headers.TransportMode {
+					v := headers.TransportModePlay
+					return &v
+				}(),
+				InterleavedIDs: &[2]int{0, 1},
+			}.Marshal(),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	var sx headers.Session
+	err = sx.Unmarshal(res.Header["Session"])
+	require.NoError(t, err)
+
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Play,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"2"},
+			"Session": base.HeaderValue{sx.Session},
+			"Range":   base.HeaderValue{"npt=1.25-"},
+			"Scale":   base.HeaderValue{"0.5"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusNotImplemented, res.StatusCode)
+
+	// "npt=0-" means live playback
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Play,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"3"},
+			"Session": base.HeaderValue{sx.Session},
+			"Range":   base.HeaderValue{"npt=0-"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+	require.Equal(t, base.HeaderValue(nil), res.Header["Range"])
+
+	seqNum, _ := readRTPInfo(t, res)
+	require.Equal(t, uint16(20), seqNum)
+
+	// play from the key frame that precedes 1.25s before the live edge,
+	// and catch up at double speed
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Play,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"4"},
+			"Session": base.HeaderValue{sx.Session},
+			"Range":   base.HeaderValue{"npt=1.25-"},
+			"Scale":   base.HeaderValue{"2"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+	require.Equal(t, base.HeaderValue{"npt=1.4-"}, res.Header["Range"])
+	require.Equal(t, base.HeaderValue{"2"}, res.Header["Scale"])
+
+	var ts uint32
+	seqNum, ts = readRTPInfo(t, res)
+	require.Equal(t, uint16(5), seqNum)
+	require.Equal(t, uint32(5*9000), ts)
+
+	for i := 5; i < 20; i++ {
+		pkt := readPacketWithSeqNum(t, conn, nil, uint16(i))
+		require.Equal(t, []byte{pkt.Payload[0], byte(i)}, pkt.Payload)
+	}
+
+	// after catching up, live packets are received
+	writePacket(20, time.Now())
+
+	pkt := readPacketWithSeqNum(t, conn, nil, 20)
+	require.Equal(t, []byte{0x05, 20}, pkt.Payload)
+
+	// seek to an absolute time during the playback
+	err = conn.WriteRequest(&base.Request{
+		Method: base.Play,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"5"},
+			"Session": base.HeaderValue{sx.Session},
+			"Range": headers.Range{
+				Value: &headers.RangeUTC{
+					Start: base0.Add(1200 * time.Millisecond),
+				},
+			}.Marshal(),
+		},
+	})
+	require.NoError(t, err)
+
+	res, recv := readResponse(t, conn)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+	require.Equal(t, headers.Range{
+		Value: &headers.RangeUTC{
+			Start: base0.Add(1 * time.Second),
+		},
+	}.Marshal(), res.Header["Range"])
+
+	seqNum, ts = readRTPInfo(t, res)
+	require.Equal(t, uint16(10), seqNum)
+	require.Equal(t, uint32(10*9000), ts)
+
+	pkt = readPacketWithSeqNum(t, conn, recv, 10)
+	require.Equal(t, []byte{0x05, 10}, pkt.Payload)
+
+	err = conn.WriteRequest(&base.Request{
+		Method: base.Pause,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"6"},
+			"Session": base.HeaderValue{sx.Session},
+		},
+	})
+	require.NoError(t, err)
+
+	res, recv = readResponse(t, conn)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	lastSeqNum := uint16(10)
+	if len(recv) != 0 {
+		lastSeqNum = recv[len(recv)-1].SequenceNumber
+	}
+
+	// resume from the time-shifted position
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Play,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"7"},
+			"Session": base.HeaderValue{sx.Session},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	seqNum, _ = readRTPInfo(t, res)
+	require.Equal(t, lastSeqNum+1, seqNum)
+
+	pkt = readPacketWithSeqNum(t, conn, nil, seqNum)
+	require.Equal(t, byte(seqNum), pkt.Payload[1])
+
+	// go back to live playback
+	err = conn.WriteRequest(&base.Request{
+		Method: base.Play,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"8"},
+			"Session": base.HeaderValue{sx.Session},
+			"Range":   base.HeaderValue{"npt=0-"},
+		},
+	})
+	require.NoError(t, err)
+
+	res, _ = readResponse(t, conn)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+	require.Equal(t, base.HeaderValue(nil), res.Header["Range"])
+
+	seqNum, _ = readRTPInfo(t, res)
+	require.Equal(t, uint16(21), seqNum)
+
+	writePacket(21, time.Now())
+
+	pkt = readPacketWithSeqNum(t, conn, nil, 21)
+	require.Equal(t, []byte{0x01, 21}, pkt.Payload)
+}
+
+func TestServerPlayGOPCache(t *testing.T) {
+	for _, ca := range []struct {
+		name       string
+		mode       ServerStreamGOPCache
+		gopPackets int
+		replayed   bool
+	}{
+		{"replay", ServerStreamGOPCacheReplay, 2, true},
+		{"wait key frame", ServerStreamGOPCacheWaitKeyFrame, 2, false},
+		// with the default WriteBufferCount, these packets can't be replayed
+		{"replay too many packets", ServerStreamGOPCacheReplay, 200, false},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			stream := NewServerStream(media.Medias{testH264Media})
+			stream.SetGOPCache(ca.mode)
+			defer stream.Close()
+
+			writePacketWithTimestamp := func(i int, ts uint32, typ byte) {
+				stream.WritePacketRTP(testH264Media, &rtp.Packet{
+					Header: rtp.Header{
+						Version:        2,
+						PayloadType:    96,
+						SequenceNumber: uint16(i),
+						Timestamp:      ts,
+						SSRC:           0x38F27A2F,
+					},
+					Payload: []byte{typ, byte(i)},
+				})
+			}
+
+			writePacket := func(i int, typ byte) {
+				writePacketWithTimestamp(i, uint32(i)*9000, typ)
+			}
+
+			writePacket(0, 0x01)
+			writePacket(1, 0x05)
+			for i := 0; i < ca.gopPackets; i++ {
+				writePacket(2+i, 0x01)
+			}
+			n := 2 + ca.gopPackets
+
+			s := &Server{
+				Handler: &testServerHandler{
+					onSetup: func(ctx *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, stream, nil
+					},
+					onPlay: func(ctx *ServerHandlerOnPlayCtx) (*base.Response, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, nil
+					},
+					onPause: func(ctx *ServerHandlerOnPauseCtx) (*base.Response, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, nil
+					},
+				},
+				RTSPAddress: "localhost:8554",
+			}
+
+			err := s.Start()
+			require.NoError(t, err)
+			defer s.Close()
+
+			nconn, err := net.Dial("tcp", "localhost:8554")
+			require.NoError(t, err)
+			defer nconn.Close()
+			conn := conn.NewConn(nconn)
+
+			res, err := writeReqReadRes(conn, base.Request{
+				Method: base.Setup,
+				URL:    mustParseURL("rtsp://localhost:8554/teststream/mediaUUID=" + stream.streamMedias[testH264Media].uuid.String()),
+				Header: base.Header{
+					"CSeq": base.HeaderValue{"1"},
+					"Transport": headers.Transport{
+						Protocol: headers.TransportProtocolTCP,
+						Delivery: func() *headers.TransportDelivery {
+							v := headers.TransportDeliveryUnicast
+							return &v
+						}(),
+						Mode: func() *headers.TransportMode {
+							v := headers.TransportModePlay
+							return &v
+						}(),
+						InterleavedIDs: &[2]int{0, 1},
+					}.Marshal(),
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, base.StatusOK, res.StatusCode)
+
+			var sx headers.Session
+			err = sx.Unmarshal(res.Header["Session"])
+			require.NoError(t, err)
+
+			res, err = writeReqReadRes(conn, base.Request{
+				Method: base.Play,
+				URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+				Header: base.Header{
+					"CSeq":    base.HeaderValue{"2"},
+					"Session": base.HeaderValue{sx.Session},
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, base.StatusOK, res.StatusCode)
+
+			seqNum, ts := readRTPInfo(t, res)
+
+			writePacket(n, 0x01)
+			writePacketWithTimestamp(n+1, uint32(n+3)*9000, 0x07) // SPS
+			writePacketWithTimestamp(n+2, uint32(n+3)*9000, 0x08) // PPS
+			writePacket(n+3, 0x05)
+
+			readRTP := func() *rtp.Packet {
+				f, err := conn.ReadInterleavedFrame()
+				require.NoError(t, err)
+
+				// skip RTCP packets
+				for f.Channel != 0 {
+					f, err = conn.ReadInterleavedFrame()
+					require.NoError(t, err)
+				}
+
+				var pkt rtp.Packet
+				err = pkt.Unmarshal(f.Payload)
+				require.NoError(t, err)
+				return &pkt
+			}
+
+			var expected []int
+			if ca.replayed {
+				// timestamps of replayed access units are compressed before the live edge
+				liveTS := uint32(n-1) * 9000
+				require.Equal(t, uint16(1), seqNum)
+				require.Equal(t, liveTS-uint32(n-2), ts)
+
+				for i := 1; i < n; i++ {
+					pkt := readRTP()
+					require.Equal(t, uint16(i), pkt.SequenceNumber)
+					require.Equal(t, liveTS-uint32(n-1-i), pkt.Timestamp)
+				}
+
+				expected = []int{n, n + 1, n + 2, n + 3}
+			} else {
+				require.Equal(t, uint16(n), seqNum)
+				expected = []int{n + 1, n + 2, n + 3}
+			}
+
+			for _, i := range expected {
+				pkt := readRTP()
+				require.Equal(t, uint16(i), pkt.SequenceNumber)
+				if i == n {
+					require.Equal(t, uint32(n)*9000, pkt.Timestamp)
+				} else {
+					require.Equal(t, uint32(n+3)*9000, pkt.Timestamp)
+				}
+			}
+
+			res, err = writeReqReadRes(conn, base.Request{
+				Method: base.Pause,
+				URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+				Header: base.Header{
+					"CSeq":    base.HeaderValue{"3"},
+					"Session": base.HeaderValue{sx.Session},
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, base.StatusOK, res.StatusCode)
+
+			writePacket(n+4, 0x01)
+
+			res, err = writeReqReadRes(conn, base.Request{
+				Method: base.Play,
+				URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+				Header: base.Header{
+					"CSeq":    base.HeaderValue{"4"},
+					"Session": base.HeaderValue{sx.Session},
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, base.StatusOK, res.StatusCode)
+
+			// the cache is not replayed again after a PAUSE
+			writePacket(n+5, 0x01)
+			writePacket(n+6, 0x05)
+
+			if ca.mode == ServerStreamGOPCacheWaitKeyFrame {
+				require.Equal(t, uint16(n+6), readRTP().SequenceNumber)
+			} else {
+				require.Equal(t, uint16(n+5), readRTP().SequenceNumber)
+			}
+		})
+	}
+}
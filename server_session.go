@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/pion/rtcp"
 	"github.com/pion/rtp"
 
+	"github.com/aler9/gortsplib/v2/pkg/auth"
 	"github.com/aler9/gortsplib/v2/pkg/base"
 	"github.com/aler9/gortsplib/v2/pkg/format"
 	"github.com/aler9/gortsplib/v2/pkg/headers"
@@ -168,7 +170,9 @@ type ServerSession struct {
 	setuppedMediasOrdered []*serverSessionMedia
 	tcpMediasByChannel    map[int]*serverSessionMedia
 	setuppedTransport     *Transport
-	setuppedStream        *ServerStream // read
+	setuppedStream        *ServerStream           // read
+	source                *serverSessionSource    // read on-demand
+	timeShift             *serverSessionTimeShift // read time-shifted
 	setuppedPath          *string
 	setuppedQuery         string
 	lastRequestTime       time.Time
@@ -182,6 +186,11 @@ type ServerSession struct {
 	request     chan sessionRequestReq
 	connRemove  chan *ServerConn
 	startWriter chan struct{}
+	redirect    chan *url.URL
+	forceClose  chan struct{}
+
+	// out
+	done chan struct{}
 }
 
 func newServerSession(
@@ -205,6 +214,9 @@ func newServerSession(
 		request:             make(chan sessionRequestReq),
 		connRemove:          make(chan *ServerConn),
 		startWriter:         make(chan struct{}),
+		redirect:            make(chan *url.URL),
+		forceClose:          make(chan struct{}),
+		done:                make(chan struct{}),
 	}
 
 	s.wg.Add(1)
@@ -279,6 +291,7 @@ func (ss *ServerSession) checkState(allowed map[ServerSessionState]struct{}) err
 
 func (ss *ServerSession) run() {
 	defer ss.s.wg.Done()
+	defer close(ss.done)
 
 	if h, ok := ss.s.Handler.(ServerHandlerOnSessionOpen); ok {
 		h.OnSessionOpen(&ServerHandlerOnSessionOpenCtx{
@@ -291,6 +304,14 @@ func (ss *ServerSession) run() {
 
 	ss.ctxCancel()
 
+	if ss.source != nil {
+		ss.source.close()
+	}
+
+	if ss.timeShift != nil {
+		ss.timeShift.stop()
+	}
+
 	if ss.setuppedStream != nil {
 		ss.setuppedStream.readerSetInactive(ss)
 		ss.setuppedStream.readerRemove(ss)
@@ -340,7 +361,7 @@ func (ss *ServerSession) runInner() error {
 				ss.conns[req.sc] = struct{}{}
 			}
 
-			res, err := ss.handleRequest(req.sc, req.req)
+			res, err := ss.handleRequest(req.sc, req.req, req.claims)
 
 			returnedSession := ss
 
@@ -428,13 +449,287 @@ func (ss *ServerSession) runInner() error {
 
 			ss.udpCheckStreamTimer = time.NewTimer(ss.s.checkStreamPeriod)
 
+		case u := <-ss.redirect:
+			ss.writeRedirect(u)
+
+		case <-ss.forceClose:
+			ss.writeGoodbye()
+			return liberrors.ErrServerShutdown{}
+
 		case <-ss.ctx.Done():
 			return liberrors.ErrServerTerminated{}
 		}
 	}
 }
 
-func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base.Response, error) {
+func (ss *ServerSession) writeRedirect(u *url.URL) {
+	sc := ss.tcpConn
+	if sc == nil {
+		for c := range ss.conns {
+			sc = c
+			break
+		}
+		if sc == nil {
+			return
+		}
+	}
+
+	sc.writeRequest(&base.Request{
+		Method: base.Redirect,
+		URL:    u,
+		Header: base.Header{
+			"Session":  base.HeaderValue{ss.secretID},
+			"Location": base.HeaderValue{u.String()},
+		},
+	})
+}
+
+// writeGoodbye sends a RTCP BYE packet to readers.
+// It must be called before the writer is stopped.
+func (ss *ServerSession) writeGoodbye() {
+	if ss.state != ServerSessionStatePlay ||
+		*ss.setuppedTransport == TransportUDPMulticast {
+		return
+	}
+
+	for _, sm := range ss.setuppedMediasOrdered {
+		var ssrcs []uint32
+
+		// sessions of on-demand streams send packets with their own SSRC
+		if ss.source != nil {
+			ssrcs = append(ssrcs, ss.source.lastSSRC(sm.media))
+		} else {
+			for _, forma := range sm.media.Formats {
+				ssrc, ok := ss.setuppedStream.streamMedias[sm.media].formats[forma.PayloadType()].rtcpSender.LastSSRC()
+				if ok {
+					ssrcs = append(ssrcs, ssrc)
+				}
+			}
+		}
+
+		if len(ssrcs) != 0 {
+			ss.WritePacketRTCP(sm.media, &rtcp.Goodbye{Sources: ssrcs})
+		}
+	}
+}
+
+func (ss *ServerSession) addRTPInfo(req *base.Request, res *base.Response) {
+	var ri headers.RTPInfo
+	now := time.Now()
+
+	for _, sm := range ss.setuppedMediasOrdered {
+		var entry *headers.RTPInfoEntry
+		if ss.source != nil {
+			entry = ss.source.rtpInfoEntry(sm.media)
+		} else if ss.timeShift != nil && !ss.timeShift.isLive() {
+			entry = ss.timeShift.rtpInfoEntry(sm.media)
+		} else {
+			entry = ss.setuppedStream.rtpInfoEntry(ss, sm.media, now)
+		}
+
+		if entry != nil {
+			entry.URL = (&url.URL{
+				Scheme: req.URL.Scheme,
+				Host:   req.URL.Host,
+				Path:   *ss.setuppedPath + "/mediaUUID=" + ss.setuppedStream.streamMedias[sm.media].uuid.String(),
+			}).String()
+			ri = append(ri, entry)
+		}
+	}
+	if len(ri) > 0 {
+		if res.Header == nil {
+			res.Header = make(base.Header)
+		}
+		res.Header["RTP-Info"] = ri.Marshal()
+	}
+}
+
+// seekSource stops the source of an on-demand stream and moves it
+// to the position requested by the Range header, if present.
+func (ss *ServerSession) seekSource(req *base.Request, res *base.Response) (*base.Response, error) {
+	var start *time.Duration
+
+	if v, ok := req.Header["Range"]; ok {
+		var ra headers.Range
+		err := ra.Unmarshal(v)
+		if err != nil {
+			return &base.Response{
+				StatusCode: base.StatusBadRequest,
+			}, liberrors.ErrServerRangeHeaderInvalid{Err: err}
+		}
+
+		npt, ok := ra.Value.(*headers.RangeNPT)
+		if !ok {
+			return &base.Response{
+				StatusCode: base.StatusNotImplemented,
+			}, nil
+		}
+
+		start = &npt.Start
+	}
+
+	ss.source.stop()
+
+	if start != nil {
+		err := ss.source.seek(*start)
+		if err != nil {
+			// resume the playback from the previous position
+			if ss.state == ServerSessionStatePlay {
+				ss.source.start()
+			}
+
+			return &base.Response{
+				StatusCode: base.StatusInvalidRange,
+			}, nil
+		}
+	}
+
+	if res.Header == nil {
+		res.Header = make(base.Header)
+	}
+
+	res.Header["Range"] = headers.Range{
+		Value: &headers.RangeNPT{
+			Start: ss.source.position,
+			End:   &ss.source.duration,
+		},
+	}.Marshal()
+
+	return res, nil
+}
+
+// seekTimeShift moves the playback of a live stream into its time-shift window,
+// if the Range header is present and doesn't point to the live edge.
+func (ss *ServerSession) seekTimeShift(req *base.Request, res *base.Response) (*base.Response, error) {
+	v, ok := req.Header["Range"]
+	if !ok {
+		return res, nil
+	}
+
+	var ra headers.Range
+	err := ra.Unmarshal(v)
+	if err != nil {
+		return &base.Response{
+			StatusCode: base.StatusBadRequest,
+		}, liberrors.ErrServerRangeHeaderInvalid{Err: err}
+	}
+
+	scale := float64(1)
+
+	if v, ok := req.Header["Scale"]; ok {
+		if len(v) != 1 {
+			return &base.Response{
+				StatusCode: base.StatusBadRequest,
+			}, liberrors.ErrServerScaleHeaderInvalid{Err: fmt.Errorf("invalid value (%v)", v)}
+		}
+
+		scale, err = strconv.ParseFloat(v[0], 64)
+		if err != nil {
+			return &base.Response{
+				StatusCode: base.StatusBadRequest,
+			}, liberrors.ErrServerScaleHeaderInvalid{Err: err}
+		}
+
+		// slow motion and reverse playback are not supported
+		if scale < 1 {
+			return &base.Response{
+				StatusCode: base.StatusNotImplemented,
+			}, nil
+		}
+	}
+
+	if *ss.setuppedTransport == TransportUDPMulticast {
+		return &base.Response{
+			StatusCode: base.StatusNotImplemented,
+		}, nil
+	}
+
+	stream := ss.setuppedStream
+
+	// "npt=0-" is sent by most clients when starting the playback,
+	// and means live playback.
+	if rv, ok := ra.Value.(*headers.RangeNPT); ok && rv.Start == 0 && rv.End == nil {
+		if ss.timeShift != nil {
+			ss.timeShift.stop()
+			live := ss.timeShift.isLive()
+			ss.timeShift = nil
+
+			if ss.state == ServerSessionStatePlay && !live {
+				stream.readerSetActive(ss)
+			}
+		}
+		return res, nil
+	}
+
+	liveEdge, ok := stream.timeShift.liveEdge()
+	if !ok {
+		return &base.Response{
+			StatusCode: base.StatusInvalidRange,
+		}, nil
+	}
+
+	var target time.Time
+
+	switch rv := ra.Value.(type) {
+	case *headers.RangeNPT:
+		target = liveEdge.Add(-rv.Start)
+
+	case *headers.RangeUTC:
+		target = rv.Start
+
+	default:
+		return &base.Response{
+			StatusCode: base.StatusNotImplemented,
+		}, nil
+	}
+
+	start, ok := stream.timeShift.seek(target)
+	if !ok {
+		return &base.Response{
+			StatusCode: base.StatusInvalidRange,
+		}, nil
+	}
+
+	// stop the current playback, that can be live or time-shifted
+	if ss.timeShift != nil {
+		ss.timeShift.stop()
+	}
+	if ss.state == ServerSessionStatePlay {
+		stream.readerSetInactive(ss)
+	}
+
+	ss.timeShift = newServerSessionTimeShift(ss, stream, start, scale)
+
+	if res.Header == nil {
+		res.Header = make(base.Header)
+	}
+
+	switch ra.Value.(type) {
+	case *headers.RangeNPT:
+		res.Header["Range"] = headers.Range{
+			Value: &headers.RangeNPT{
+				Start: liveEdge.Sub(start.ntp),
+			},
+		}.Marshal()
+
+	default:
+		res.Header["Range"] = headers.Range{
+			Value: &headers.RangeUTC{
+				Start: start.ntp,
+			},
+		}.Marshal()
+	}
+
+	res.Header["Scale"] = base.HeaderValue{strconv.FormatFloat(scale, 'f', -1, 64)}
+
+	return res, nil
+}
+
+func (ss *ServerSession) handleRequest(
+	sc *ServerConn,
+	req *base.Request,
+	claims auth.TokenClaims,
+) (*base.Response, error) {
 	if ss.tcpConn != nil && sc != ss.tcpConn {
 		return &base.Response{
 			StatusCode: base.StatusBadRequest,
@@ -526,7 +821,7 @@ func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base
 		}
 
 		var medias media.Medias
-		err = medias.Unmarshal(sd.MediaDescriptions)
+		err = medias.UnmarshalSessionDescription(&sd)
 		if err != nil {
 			return &base.Response{
 				StatusCode: base.StatusBadRequest,
@@ -557,13 +852,14 @@ func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base
 		}
 
 		res, err := ss.s.Handler.(ServerHandlerOnAnnounce).OnAnnounce(&ServerHandlerOnAnnounceCtx{
-			Server:  ss.s,
-			Session: ss,
-			Conn:    sc,
-			Request: req,
-			Path:    path,
-			Query:   query,
-			Medias:  medias,
+			Server:      ss.s,
+			Session:     ss,
+			Conn:        sc,
+			Request:     req,
+			Path:        path,
+			Query:       query,
+			Medias:      medias,
+			TokenClaims: claims,
 		})
 
 		if res.StatusCode != base.StatusOK {
@@ -664,13 +960,14 @@ func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base
 		}
 
 		res, stream, err := ss.s.Handler.(ServerHandlerOnSetup).OnSetup(&ServerHandlerOnSetupCtx{
-			Server:    ss.s,
-			Session:   ss,
-			Conn:      sc,
-			Request:   req,
-			Path:      path,
-			Query:     query,
-			Transport: transport,
+			Server:      ss.s,
+			Session:     ss,
+			Conn:        sc,
+			Request:     req,
+			Path:        path,
+			Query:       query,
+			Transport:   transport,
+			TokenClaims: claims,
 		})
 
 		// workaround to prevent a bug in rtspclientsink
@@ -722,6 +1019,13 @@ func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base
 			}, liberrors.ErrServerMediaAlreadySetup{}
 		}
 
+		// on-demand streams are dedicated to a single session
+		if stream != nil && stream.newSource != nil && transport == TransportUDPMulticast {
+			return &base.Response{
+				StatusCode: base.StatusUnsupportedTransport,
+			}, nil
+		}
+
 		if ss.state == ServerSessionStateInitial {
 			err := stream.readerAdd(ss,
 				transport,
@@ -733,6 +1037,18 @@ func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base
 				}, err
 			}
 
+			if stream.newSource != nil {
+				source, err := stream.newSource()
+				if err != nil {
+					stream.readerRemove(ss)
+					return &base.Response{
+						StatusCode: base.StatusInternalServerError,
+					}, err
+				}
+
+				ss.source = newServerSessionSource(ss, source, *stream.duration, stream.medias)
+			}
+
 			ss.state = ServerSessionStatePrePlay
 			ss.setuppedPath = &path
 			ss.setuppedStream = stream
@@ -741,9 +1057,14 @@ func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base
 		th := headers.Transport{}
 
 		if ss.state == ServerSessionStatePrePlay {
-			ssrc, ok := stream.lastSSRC(medi)
-			if ok {
+			if ss.source != nil {
+				ssrc := ss.source.lastSSRC(medi)
 				th.SSRC = &ssrc
+			} else {
+				ssrc, ok := stream.lastSSRC(medi)
+				if ok {
+					th.SSRC = &ssrc
+				}
 			}
 		}
 
@@ -840,13 +1161,22 @@ func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base
 		}
 
 		res, err := sc.s.Handler.(ServerHandlerOnPlay).OnPlay(&ServerHandlerOnPlayCtx{
-			Session: ss,
-			Conn:    sc,
-			Request: req,
-			Path:    path,
-			Query:   query,
+			Session:     ss,
+			Conn:        sc,
+			Request:     req,
+			Path:        path,
+			Query:       query,
+			TokenClaims: claims,
 		})
 
+		if res.StatusCode == base.StatusOK {
+			if ss.source != nil {
+				res, err = ss.seekSource(req, res)
+			} else if ss.setuppedStream.timeShift != nil {
+				res, err = ss.seekTimeShift(req, res)
+			}
+		}
+
 		if res.StatusCode != base.StatusOK {
 			if ss.state != ServerSessionStatePlay {
 				ss.writer.buffer = nil
@@ -855,6 +1185,14 @@ func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base
 		}
 
 		if ss.state == ServerSessionStatePlay {
+			// PLAY can be used to seek on-demand streams
+			if ss.source != nil {
+				ss.source.start()
+			}
+			if ss.timeShift != nil && !ss.timeShift.running() {
+				ss.timeShift.start()
+			}
+			ss.addRTPInfo(req, res)
 			return res, err
 		}
 
@@ -867,7 +1205,10 @@ func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base
 			sm.start()
 		}
 
-		ss.setuppedStream.readerSetActive(ss)
+		// time-shifted readers are activated when they reach the live edge
+		if ss.timeShift == nil {
+			ss.setuppedStream.readerSetActive(ss)
+		}
 
 		switch *ss.setuppedTransport {
 		case TransportUDP:
@@ -884,27 +1225,16 @@ func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base
 			// writer.start() is called by ServerConn after the response has been sent
 		}
 
-		var ri headers.RTPInfo
-		now := time.Now()
-
-		for _, sm := range ss.setuppedMediasOrdered {
-			entry := ss.setuppedStream.rtpInfoEntry(sm.media, now)
-			if entry != nil {
-				entry.URL = (&url.URL{
-					Scheme: req.URL.Scheme,
-					Host:   req.URL.Host,
-					Path:   *ss.setuppedPath + "/mediaUUID=" + ss.setuppedStream.streamMedias[sm.media].uuid.String(),
-				}).String()
-				ri = append(ri, entry)
-			}
+		if ss.source != nil {
+			ss.source.start()
 		}
-		if len(ri) > 0 {
-			if res.Header == nil {
-				res.Header = make(base.Header)
-			}
-			res.Header["RTP-Info"] = ri.Marshal()
+
+		if ss.timeShift != nil {
+			ss.timeShift.start()
 		}
 
+		ss.addRTPInfo(req, res)
+
 		return res, err
 
 	case base.Record:
@@ -938,11 +1268,12 @@ func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base
 		ss.writer.allocateBuffer(8)
 
 		res, err := ss.s.Handler.(ServerHandlerOnRecord).OnRecord(&ServerHandlerOnRecordCtx{
-			Session: ss,
-			Conn:    sc,
-			Request: req,
-			Path:    path,
-			Query:   query,
+			Session:     ss,
+			Conn:        sc,
+			Request:     req,
+			Path:        path,
+			Query:       query,
+			TokenClaims: claims,
 		})
 
 		if res.StatusCode != base.StatusOK {
@@ -987,17 +1318,30 @@ func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base
 		}
 
 		res, err := ss.s.Handler.(ServerHandlerOnPause).OnPause(&ServerHandlerOnPauseCtx{
-			Session: ss,
-			Conn:    sc,
-			Request: req,
-			Path:    path,
-			Query:   query,
+			Session:     ss,
+			Conn:        sc,
+			Request:     req,
+			Path:        path,
+			Query:       query,
+			TokenClaims: claims,
 		})
 
 		if res.StatusCode != base.StatusOK {
 			return res, err
 		}
 
+		if ss.source != nil {
+			ss.source.stop()
+		}
+
+		// time-shifted playback is resumed from the current position
+		if ss.timeShift != nil {
+			ss.timeShift.stop()
+			if ss.timeShift.isLive() {
+				ss.timeShift = nil
+			}
+		}
+
 		ss.writer.stop()
 
 		if ss.setuppedStream != nil {
@@ -1056,11 +1400,12 @@ func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base
 	case base.GetParameter:
 		if h, ok := sc.s.Handler.(ServerHandlerOnGetParameter); ok {
 			return h.OnGetParameter(&ServerHandlerOnGetParameterCtx{
-				Session: ss,
-				Conn:    sc,
-				Request: req,
-				Path:    path,
-				Query:   query,
+				Session:     ss,
+				Conn:        sc,
+				Request:     req,
+				Path:        path,
+				Query:       query,
+				TokenClaims: claims,
 			})
 		}
 
@@ -1077,11 +1422,12 @@ func (ss *ServerSession) handleRequest(sc *ServerConn, req *base.Request) (*base
 	case base.SetParameter:
 		if h, ok := sc.s.Handler.(ServerHandlerOnSetParameter); ok {
 			return h.OnSetParameter(&ServerHandlerOnSetParameterCtx{
-				Session: ss,
-				Conn:    sc,
-				Request: req,
-				Path:    path,
-				Query:   query,
+				Session:     ss,
+				Conn:        sc,
+				Request:     req,
+				Path:        path,
+				Query:       query,
+				TokenClaims: claims,
 			})
 		}
 	}
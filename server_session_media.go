@@ -126,15 +126,13 @@ func (sm *serverSessionMedia) writePacketRTCPInQueueUDP(payload []byte) {
 func (sm *serverSessionMedia) writePacketRTPInQueueTCP(payload []byte) {
 	atomic.AddUint64(sm.ss.bytesSent, uint64(len(payload)))
 	sm.tcpRTPFrame.Payload = payload
-	sm.ss.tcpConn.nconn.SetWriteDeadline(time.Now().Add(sm.ss.s.WriteTimeout))
-	sm.ss.tcpConn.conn.WriteInterleavedFrame(sm.tcpRTPFrame, sm.tcpBuffer)
+	sm.ss.tcpConn.writeInterleavedFrame(sm.tcpRTPFrame, sm.tcpBuffer)
 }
 
 func (sm *serverSessionMedia) writePacketRTCPInQueueTCP(payload []byte) {
 	atomic.AddUint64(sm.ss.bytesSent, uint64(len(payload)))
 	sm.tcpRTCPFrame.Payload = payload
-	sm.ss.tcpConn.nconn.SetWriteDeadline(time.Now().Add(sm.ss.s.WriteTimeout))
-	sm.ss.tcpConn.conn.WriteInterleavedFrame(sm.tcpRTCPFrame, sm.tcpBuffer)
+	sm.ss.tcpConn.writeInterleavedFrame(sm.tcpRTCPFrame, sm.tcpBuffer)
 }
 
 func (sm *serverSessionMedia) writePacketRTP(payload []byte) {
@@ -0,0 +1,274 @@
+package gortsplib
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/format"
+	"github.com/aler9/gortsplib/v2/pkg/headers"
+	"github.com/aler9/gortsplib/v2/pkg/media"
+	"github.com/aler9/gortsplib/v2/pkg/rtcpsender"
+)
+
+// ServerSessionSource is a source of packets dedicated to a single session.
+// It is used by on-demand streams, in which every reader has its own timeline.
+type ServerSessionSource interface {
+	// Seek moves the source to the given position.
+	// It returns the position from which packets are read, that can precede
+	// the requested one (i.e. in case of a previous random access point).
+	Seek(pos time.Duration) (time.Duration, error)
+
+	// ReadPacket reads the next packet, in sending order.
+	// It returns the media of the packet, the packet and the position in which
+	// the packet has to be sent.
+	// The timestamp of the packet must be relative to the beginning of the source,
+	// i.e. position zero corresponds to timestamp zero.
+	// SSRC and sequence number are filled by the server.
+	// It returns io.EOF when the source is over.
+	ReadPacket() (*media.Media, *rtp.Packet, time.Duration, error)
+
+	// Close closes the source.
+	Close() error
+}
+
+type serverSessionSourceMedia struct {
+	media      *media.Media
+	ssrc       uint32
+	initialTS  uint32
+	nextSeqNum uint16
+	rtcpSender *rtcpsender.RTCPSender
+
+	// RTP-Info of the current playback
+	startSeqNum uint16
+	startTS     uint32
+}
+
+func (sm *serverSessionSourceMedia) format(payloadType uint8) format.Format {
+	for _, forma := range sm.media.Formats {
+		if forma.PayloadType() == payloadType {
+			return forma
+		}
+	}
+	return nil
+}
+
+// serverSessionSource paces the packets of a ServerSessionSource
+// and writes them to a session.
+type serverSessionSource struct {
+	ss       *ServerSession
+	source   ServerSessionSource
+	duration time.Duration
+	medias   map[*media.Media]*serverSessionSourceMedia
+
+	// playback state
+	position     time.Duration
+	pendingMedia *media.Media
+	pendingPkt   *rtp.Packet
+	pendingPos   time.Duration
+
+	mutex  sync.RWMutex
+	active bool
+
+	terminate chan struct{}
+	done      chan struct{}
+}
+
+func newServerSessionSource(
+	ss *ServerSession,
+	source ServerSessionSource,
+	duration time.Duration,
+	medias media.Medias,
+) *serverSessionSource {
+	src := &serverSessionSource{
+		ss:       ss,
+		source:   source,
+		duration: duration,
+		medias:   make(map[*media.Media]*serverSessionSourceMedia),
+	}
+
+	for _, medi := range medias {
+		sm := &serverSessionSourceMedia{
+			media:      medi,
+			ssrc:       uint32(randInRange(0xFFFFFFFF)),
+			initialTS:  uint32(randInRange(0xFFFFFFFF)),
+			nextSeqNum: uint16(randInRange(0xFFFF)),
+		}
+
+		cmedia := medi
+		sm.rtcpSender = rtcpsender.New(
+			medi.Formats[0].ClockRate(),
+			func(pkt rtcp.Packet) {
+				src.writePacketRTCP(cmedia, pkt)
+			},
+		)
+
+		if !ss.s.DisableRTCPSenderReports {
+			sm.rtcpSender.Start(ss.s.senderReportPeriod)
+		}
+
+		src.medias[medi] = sm
+	}
+
+	return src
+}
+
+func (src *serverSessionSource) close() {
+	src.stop()
+
+	for _, sm := range src.medias {
+		sm.rtcpSender.Close()
+	}
+
+	src.source.Close()
+}
+
+func (src *serverSessionSource) lastSSRC(medi *media.Media) uint32 {
+	return src.medias[medi].ssrc
+}
+
+func (src *serverSessionSource) rtpInfoEntry(medi *media.Media) *headers.RTPInfoEntry {
+	sm := src.medias[medi]
+
+	// RTP-Info doesn't support multiple timestamps.
+	if len(sm.media.Formats) > 1 {
+		return nil
+	}
+
+	seqNum := sm.startSeqNum
+	ts := sm.startTS
+
+	return &headers.RTPInfoEntry{
+		SequenceNumber: &seqNum,
+		Timestamp:      &ts,
+	}
+}
+
+func (src *serverSessionSource) seek(pos time.Duration) error {
+	start, err := src.source.Seek(pos)
+	if err != nil {
+		return err
+	}
+
+	src.position = start
+	src.pendingPkt = nil
+	return nil
+}
+
+// start starts the playback from the current position.
+func (src *serverSessionSource) start() {
+	for _, sm := range src.medias {
+		sm.startSeqNum = sm.nextSeqNum
+		sm.startTS = sm.initialTS +
+			uint32(src.position.Seconds()*float64(sm.media.Formats[0].ClockRate()))
+	}
+
+	src.mutex.Lock()
+	src.active = true
+	src.mutex.Unlock()
+
+	src.terminate = make(chan struct{})
+	src.done = make(chan struct{})
+	go src.run(src.position, time.Now())
+}
+
+// stop stops the playback and saves the current position.
+func (src *serverSessionSource) stop() {
+	if src.terminate == nil {
+		return
+	}
+
+	close(src.terminate)
+	<-src.done
+	src.terminate = nil
+
+	src.mutex.Lock()
+	src.active = false
+	src.mutex.Unlock()
+}
+
+func (src *serverSessionSource) run(start time.Duration, startTime time.Time) {
+	defer close(src.done)
+
+	defer func() {
+		src.position = start + time.Since(startTime)
+		if src.position > src.duration {
+			src.position = src.duration
+		}
+		if src.pendingPkt != nil && src.pendingPos > src.position {
+			src.position = src.pendingPos
+		}
+	}()
+
+	for {
+		medi, pkt, pos := src.pendingMedia, src.pendingPkt, src.pendingPos
+		if pkt == nil {
+			var err error
+			medi, pkt, pos, err = src.source.ReadPacket()
+			if err != nil {
+				// the source is over, or cannot be read anymore
+				return
+			}
+		}
+
+		wait := pos - start - time.Since(startTime)
+		if wait > 0 {
+			t := time.NewTimer(wait)
+
+			select {
+			case <-t.C:
+
+			case <-src.terminate:
+				t.Stop()
+				src.pendingMedia, src.pendingPkt, src.pendingPos = medi, pkt, pos
+				return
+			}
+		}
+
+		src.pendingPkt = nil
+
+		src.writePacketRTP(medi, pkt)
+
+		select {
+		case <-src.terminate:
+			return
+		default:
+		}
+	}
+}
+
+func (src *serverSessionSource) writePacketRTP(medi *media.Media, pkt *rtp.Packet) {
+	sm, ok := src.medias[medi]
+	if !ok {
+		return
+	}
+
+	pkt.SSRC = sm.ssrc
+	pkt.SequenceNumber = sm.nextSeqNum
+	pkt.Timestamp += sm.initialTS
+	sm.nextSeqNum++
+
+	if forma := sm.format(pkt.PayloadType); forma != nil {
+		sm.rtcpSender.ProcessPacket(pkt, time.Now(), forma.PTSEqualsDTS(pkt))
+	}
+
+	// the media may have not been setupped
+	if _, ok := src.ss.setuppedMedias[medi]; ok {
+		src.ss.WritePacketRTP(medi, pkt)
+	}
+}
+
+func (src *serverSessionSource) writePacketRTCP(medi *media.Media, pkt rtcp.Packet) {
+	src.mutex.RLock()
+	defer src.mutex.RUnlock()
+
+	if !src.active {
+		return
+	}
+
+	if _, ok := src.ss.setuppedMedias[medi]; ok {
+		src.ss.WritePacketRTCP(medi, pkt)
+	}
+}
@@ -0,0 +1,139 @@
+package gortsplib
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/aler9/gortsplib/v2/pkg/headers"
+	"github.com/aler9/gortsplib/v2/pkg/media"
+)
+
+// serverSessionTimeShift reads the time-shift window of a stream
+// and writes its packets to a session, until the live edge is reached.
+type serverSessionTimeShift struct {
+	ss    *ServerSession
+	st    *ServerStream
+	scale float64
+
+	// playback state
+	nextIndex uint64
+	live      int32 // written by run(), read by the session
+	rtpInfo   map[*media.Media]*headers.RTPInfoEntry
+
+	terminate chan struct{}
+	done      chan struct{}
+}
+
+func newServerSessionTimeShift(
+	ss *ServerSession,
+	st *ServerStream,
+	start *serverStreamTimeShiftEntry,
+	scale float64,
+) *serverSessionTimeShift {
+	return &serverSessionTimeShift{
+		ss:        ss,
+		st:        st,
+		scale:     scale,
+		nextIndex: start.index,
+	}
+}
+
+func (sts *serverSessionTimeShift) rtpInfoEntry(medi *media.Media) *headers.RTPInfoEntry {
+	return sts.rtpInfo[medi]
+}
+
+// isLive returns whether the playback has reached the live edge.
+func (sts *serverSessionTimeShift) isLive() bool {
+	return atomic.LoadInt32(&sts.live) == 1
+}
+
+func (sts *serverSessionTimeShift) running() bool {
+	return sts.terminate != nil
+}
+
+// start starts the playback from the current position.
+func (sts *serverSessionTimeShift) start() {
+	sts.rtpInfo = make(map[*media.Media]*headers.RTPInfoEntry)
+
+	for medi := range sts.ss.setuppedMedias {
+		e, ok := sts.st.timeShift.firstOfMedia(medi, sts.nextIndex)
+		if ok {
+			seqNum := e.seqNum
+			ts := e.timestamp
+			sts.rtpInfo[medi] = &headers.RTPInfoEntry{
+				SequenceNumber: &seqNum,
+				Timestamp:      &ts,
+			}
+		}
+	}
+
+	sts.terminate = make(chan struct{})
+	sts.done = make(chan struct{})
+	go sts.run()
+}
+
+// stop stops the playback and saves the current position.
+func (sts *serverSessionTimeShift) stop() {
+	if sts.terminate == nil {
+		return
+	}
+
+	close(sts.terminate)
+	<-sts.done
+	sts.terminate = nil
+}
+
+func (sts *serverSessionTimeShift) run() {
+	defer close(sts.done)
+
+	var startTime time.Time
+	var startNTP time.Time
+
+	for {
+		e := sts.st.timeShift.next(sts.nextIndex)
+		if e == nil {
+			if sts.st.readerCatchUp(sts.ss, sts.nextIndex) {
+				atomic.StoreInt32(&sts.live, 1)
+				return
+			}
+
+			select {
+			case <-sts.st.timeShift.waitEntry(sts.nextIndex):
+			case <-sts.terminate:
+				return
+			}
+			continue
+		}
+
+		if startTime.IsZero() {
+			startTime = time.Now()
+			startNTP = e.ntp
+		}
+
+		wait := time.Duration(float64(e.ntp.Sub(startNTP))/sts.scale) - time.Since(startTime)
+		if wait > 0 {
+			t := time.NewTimer(wait)
+
+			select {
+			case <-t.C:
+
+			case <-sts.terminate:
+				t.Stop()
+				return
+			}
+		}
+
+		// the media may have not been setupped
+		if _, ok := sts.ss.setuppedMedias[e.media]; ok {
+			sts.ss.writePacketRTP(e.media, e.byts)
+		}
+
+		sts.nextIndex = e.index + 1
+
+		select {
+		case <-sts.terminate:
+			return
+		default:
+		}
+	}
+}
@@ -27,6 +27,16 @@ type ServerStream struct {
 	readers              map[*ServerSession]struct{}
 	streamMedias         map[*media.Media]*serverStreamMedia
 	closed               bool
+
+	// on-demand streams
+	duration  *time.Duration
+	newSource func() (ServerSessionSource, error)
+
+	// called when the last reader is removed
+	onNoReaders func()
+
+	// live streams with a time-shift window
+	timeShift *serverStreamTimeShift
 }
 
 // NewServerStream allocates a ServerStream.
@@ -45,8 +55,59 @@ func NewServerStream(medias media.Medias) *ServerStream {
 	return st
 }
 
+// NewServerStreamOnDemand allocates a ServerStream that serves an on-demand stream.
+// Instead of distributing the same packets to all readers, each session reads
+// packets from a dedicated source, created by newSource when the session is set up.
+// The server paces packets, seeks the source when a PLAY request contains a Range header,
+// stops it when receiving a PAUSE request, and provides RTP-Info accordingly.
+// On-demand streams can't be read with the UDP-multicast transport protocol,
+// and packets written with WritePacket*() are discarded.
+func NewServerStreamOnDemand(
+	medias media.Medias,
+	duration time.Duration,
+	newSource func() (ServerSessionSource, error),
+) *ServerStream {
+	st := NewServerStream(medias)
+	st.duration = &duration
+	st.newSource = newSource
+	return st
+}
+
+// NewServerStreamWithTimeShift allocates a ServerStream that retains
+// the packets of the last window of time.
+// Readers can play the past by sending a PLAY request with a Range header,
+// expressed in NPT units (time before the live edge) or in UTC units
+// (absolute time of packets), and can catch up with the live edge faster
+// by using a Scale header greater than 1.
+// Playback starts from the random access point that precedes the requested time,
+// that is a key frame of H264, H265, VP8 or VP9 video formats, if present.
+func NewServerStreamWithTimeShift(medias media.Medias, window time.Duration) *ServerStream {
+	st := NewServerStream(medias)
+	st.timeShift = newServerStreamTimeShift(medias, window)
+	return st
+}
+
+// SetGOPCache sets the way the stream handles readers that start reading
+// video medias between two key frames.
+// It is applied to video medias whose key frames can be detected,
+// that are medias with H264, H265, VP8 or VP9 formats.
+// It must be called before writing packets.
+func (st *ServerStream) SetGOPCache(mode ServerStreamGOPCache) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	for _, sm := range st.streamMedias {
+		if mode != ServerStreamGOPCacheDisabled && sm.media.Type == media.TypeVideo && sm.canDetectKeyFrames() {
+			sm.gopCache = newServerStreamGOPCache(mode)
+		} else {
+			sm.gopCache = nil
+		}
+	}
+}
+
 func (st *ServerStream) initializeServerDependentPart() {
-	if !st.s.DisableRTCPSenderReports {
+	// sessions of on-demand streams generate their own reports
+	if !st.s.DisableRTCPSenderReports && st.newSource == nil {
 		for _, ssm := range st.streamMedias {
 			for _, tr := range ssm.formats {
 				tr.rtcpSender.Start(st.s.senderReportPeriod)
@@ -72,6 +133,23 @@ func (st *ServerStream) Close() error {
 	return nil
 }
 
+// closeIfNoReaders closes the stream if it doesn't have readers.
+func (st *ServerStream) closeIfNoReaders() bool {
+	st.mutex.Lock()
+	if st.closed || len(st.readers) != 0 {
+		st.mutex.Unlock()
+		return false
+	}
+	st.closed = true
+	st.mutex.Unlock()
+
+	for _, sm := range st.streamMedias {
+		sm.close()
+	}
+
+	return true
+}
+
 // Medias returns the medias of the stream.
 func (st *ServerStream) Medias() media.Medias {
 	return st.medias
@@ -99,7 +177,7 @@ func (st *ServerStream) lastSSRC(medi *media.Media) (uint32, bool) {
 	return sm.formats[firstKey].rtcpSender.LastSSRC()
 }
 
-func (st *ServerStream) rtpInfoEntry(medi *media.Media, now time.Time) *headers.RTPInfoEntry {
+func (st *ServerStream) rtpInfoEntry(ss *ServerSession, medi *media.Media, now time.Time) *headers.RTPInfoEntry {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
 
@@ -118,6 +196,13 @@ func (st *ServerStream) rtpInfoEntry(medi *media.Media, now time.Time) *headers.
 		break
 	}
 
+	// packets of the GOP cache are sent before the others
+	if sm.gopCache != nil {
+		if entry := sm.gopCache.rtpInfoEntry(ss); entry != nil {
+			return entry
+		}
+	}
+
 	format := sm.formats[firstKey]
 
 	lastSeqNum, lastTimeRTP, lastTimeNTP, ok := format.rtcpSender.LastPacketData()
@@ -194,15 +279,27 @@ func (st *ServerStream) readerAdd(
 }
 
 func (st *ServerStream) readerRemove(ss *ServerSession) {
+	if st.readerRemoveInner(ss) && st.onNoReaders != nil {
+		st.onNoReaders()
+	}
+}
+
+func (st *ServerStream) readerRemoveInner(ss *ServerSession) bool {
 	st.mutex.Lock()
 	defer st.mutex.Unlock()
 
 	if st.closed {
-		return
+		return false
 	}
 
 	delete(st.readers, ss)
 
+	for _, media := range st.streamMedias {
+		if media.gopCache != nil {
+			media.gopCache.readerRemove(ss)
+		}
+	}
+
 	if len(st.readers) == 0 {
 		for _, media := range st.streamMedias {
 			if media.multicastWriter != nil {
@@ -210,7 +307,10 @@ func (st *ServerStream) readerRemove(ss *ServerSession) {
 				media.multicastWriter = nil
 			}
 		}
+		return true
 	}
+
+	return false
 }
 
 func (st *ServerStream) readerSetActive(ss *ServerSession) {
@@ -229,7 +329,33 @@ func (st *ServerStream) readerSetActive(ss *ServerSession) {
 		}
 	} else {
 		st.activeUnicastReaders[ss] = struct{}{}
+
+		for medi, sm := range ss.setuppedMedias {
+			streamMedia := st.streamMedias[medi]
+			if streamMedia.gopCache != nil {
+				streamMedia.gopCache.readerSetActive(sm)
+			}
+		}
+	}
+}
+
+// readerCatchUp activates a time-shifted reader, if it has reached the live edge.
+func (st *ServerStream) readerCatchUp(ss *ServerSession, nextIndex uint64) bool {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	if st.closed {
+		return true
+	}
+
+	// packets are written while holding the read lock,
+	// therefore no packet can be added in the meanwhile.
+	if st.timeShift.nextIndex != nextIndex {
+		return false
 	}
+
+	st.activeUnicastReaders[ss] = struct{}{}
+	return true
 }
 
 func (st *ServerStream) readerSetInactive(ss *ServerSession) {
@@ -247,6 +373,13 @@ func (st *ServerStream) readerSetInactive(ss *ServerSession) {
 		}
 	} else {
 		delete(st.activeUnicastReaders, ss)
+
+		for medi := range ss.setuppedMedias {
+			streamMedia := st.streamMedias[medi]
+			if streamMedia.gopCache != nil {
+				streamMedia.gopCache.readerSetInactive(ss)
+			}
+		}
 	}
 }
 
@@ -262,7 +395,7 @@ func (st *ServerStream) WritePacketRTPWithNTP(medi *media.Media, pkt *rtp.Packet
 	st.mutex.RLock()
 	defer st.mutex.RUnlock()
 
-	if st.closed {
+	if st.closed || st.newSource != nil {
 		return
 	}
 
@@ -275,7 +408,7 @@ func (st *ServerStream) WritePacketRTCP(medi *media.Media, pkt rtcp.Packet) {
 	st.mutex.RLock()
 	defer st.mutex.RUnlock()
 
-	if st.closed {
+	if st.closed || st.newSource != nil {
 		return
 	}
 
@@ -1,6 +1,8 @@
 package gortsplib
 
 import (
+	"github.com/pion/rtp"
+
 	"github.com/aler9/gortsplib/v2/pkg/format"
 	"github.com/aler9/gortsplib/v2/pkg/rtcpsender"
 )
@@ -8,4 +10,7 @@ import (
 type serverStreamFormat struct {
 	format     format.Format
 	rtcpSender *rtcpsender.RTCPSender
+
+	// nil if key frames of the format can't be detected
+	isKeyFrame func(*rtp.Packet) bool
 }
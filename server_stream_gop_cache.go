@@ -0,0 +1,236 @@
+package gortsplib
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/headers"
+)
+
+// maximum number of packets that can be stored by a GOP cache.
+// If the stream doesn't contain key frames in this amount of packets,
+// the cache is emptied until the next key frame.
+const serverStreamGOPCacheMaxPackets = 4096
+
+// ServerStreamGOPCache is the way a ServerStream handles readers that start
+// reading a video media between two key frames.
+type ServerStreamGOPCache int
+
+// GOP cache modes.
+const (
+	// readers receive packets immediately, and are not able to decode
+	// them until the next key frame.
+	ServerStreamGOPCacheDisabled ServerStreamGOPCache = iota
+
+	// packets since the last key frame are sent to readers right after the first PLAY,
+	// with rewritten sequence numbers and timestamps, therefore decoding can start
+	// immediately from the live edge.
+	// Packets are replayed only if they fit into half of Server.WriteBufferCount
+	// (divided by the number of medias of the session), otherwise readers
+	// receive packets starting from the next key frame.
+	ServerStreamGOPCacheReplay
+
+	// readers receive packets of video medias starting from the next key frame.
+	ServerStreamGOPCacheWaitKeyFrame
+)
+
+type serverStreamGOPCacheEntry struct {
+	byts      []byte
+	seqNum    uint16
+	timestamp uint32
+}
+
+// maximum number of cached packets that can be replayed to a session.
+// Cached packets are queued before the writer of the session is started,
+// together with packets that are written in the meanwhile, and the write buffer
+// is shared among medias, therefore they can fill only a portion of it.
+func serverStreamGOPCacheMaxReplay(ss *ServerSession) int {
+	return ss.s.WriteBufferCount / 2 / len(ss.setuppedMedias)
+}
+
+// serverStreamGOPCache is the GOP cache of a video media.
+type serverStreamGOPCache struct {
+	mode ServerStreamGOPCache
+
+	mutex sync.Mutex
+
+	// in replay mode, packets since the last key frame.
+	// in wait key frame mode, packets of the last access unit.
+	entries []*serverStreamGOPCacheEntry
+
+	waiting map[*ServerSession]*serverSessionMedia
+
+	// sessions that already started reading the media, associated with
+	// the RTP-Info entry of the first replayed packet, until it is used.
+	started map[*ServerSession]*headers.RTPInfoEntry
+}
+
+func newServerStreamGOPCache(mode ServerStreamGOPCache) *serverStreamGOPCache {
+	return &serverStreamGOPCache{
+		mode:    mode,
+		waiting: make(map[*ServerSession]*serverSessionMedia),
+		started: make(map[*ServerSession]*headers.RTPInfoEntry),
+	}
+}
+
+// writePacketRTP processes a packet before it is sent to readers.
+// It returns the readers that are still waiting for a key frame.
+func (gc *serverStreamGOPCache) writePacketRTP(
+	pkt *rtp.Packet,
+	byts []byte,
+	keyFrame bool,
+) map[*ServerSession]*serverSessionMedia {
+	gc.mutex.Lock()
+	defer gc.mutex.Unlock()
+
+	if keyFrame {
+		// a key frame may be preceded by other packets of the same access unit
+		// (i.e. parameters), that must be kept.
+		n := len(gc.entries)
+		for n > 0 && gc.entries[n-1].timestamp == pkt.Timestamp {
+			n--
+		}
+		gc.entries = gc.entries[n:]
+
+		if len(gc.waiting) != 0 {
+			// readers that are waiting for a key frame
+			// need these packets in order to decode it.
+			for _, sm := range gc.waiting {
+				for _, e := range gc.entries {
+					sm.writePacketRTP(e.byts)
+				}
+			}
+
+			gc.waiting = make(map[*ServerSession]*serverSessionMedia)
+		}
+	} else if len(gc.entries) >= serverStreamGOPCacheMaxPackets {
+		gc.entries = nil
+	}
+
+	if gc.mode == ServerStreamGOPCacheWaitKeyFrame {
+		// cache the last access unit only
+		if len(gc.entries) != 0 && gc.entries[len(gc.entries)-1].timestamp != pkt.Timestamp {
+			gc.entries = nil
+		}
+	} else if len(gc.entries) == 0 && !keyFrame {
+		// start caching from the first key frame
+		return gc.waiting
+	}
+
+	gc.entries = append(gc.entries, &serverStreamGOPCacheEntry{
+		byts:      append([]byte(nil), byts...),
+		seqNum:    pkt.SequenceNumber,
+		timestamp: pkt.Timestamp,
+	})
+
+	return gc.waiting
+}
+
+func (gc *serverStreamGOPCache) canReplay(maxReplay int) bool {
+	return gc.mode == ServerStreamGOPCacheReplay &&
+		len(gc.entries) != 0 && len(gc.entries) <= maxReplay
+}
+
+// replayEntries returns the cached packets, rewritten in order to be sent
+// right before the next live packets. Sequence numbers are made contiguous with
+// the one of the last cached packet, while timestamps of access units are
+// compressed before the one of the last cached access unit, therefore readers
+// decode the key frame and reach the live edge immediately, without introducing
+// a delay with respect to other medias.
+func (gc *serverStreamGOPCache) replayEntries() []*serverStreamGOPCacheEntry {
+	last := gc.entries[len(gc.entries)-1]
+	seqNum := last.seqNum - uint16(len(gc.entries)-1)
+	ts := last.timestamp
+
+	for i := len(gc.entries) - 2; i >= 0; i-- {
+		if gc.entries[i].timestamp != gc.entries[i+1].timestamp {
+			ts--
+		}
+	}
+
+	ret := make([]*serverStreamGOPCacheEntry, len(gc.entries))
+
+	for i, e := range gc.entries {
+		if i > 0 && e.timestamp != gc.entries[i-1].timestamp {
+			ts++
+		}
+
+		byts := append([]byte(nil), e.byts...)
+		binary.BigEndian.PutUint16(byts[2:], seqNum)
+		binary.BigEndian.PutUint32(byts[4:], ts)
+
+		ret[i] = &serverStreamGOPCacheEntry{
+			byts:      byts,
+			seqNum:    seqNum,
+			timestamp: ts,
+		}
+		seqNum++
+	}
+
+	return ret
+}
+
+// readerSetActive is called when a reader starts or resumes reading the media.
+// Cached packets are replayed to the first PLAY request of a session only.
+func (gc *serverStreamGOPCache) readerSetActive(sm *serverSessionMedia) {
+	gc.mutex.Lock()
+	defer gc.mutex.Unlock()
+
+	if _, ok := gc.started[sm.ss]; ok {
+		if gc.mode == ServerStreamGOPCacheWaitKeyFrame {
+			gc.waiting[sm.ss] = sm
+		}
+		return
+	}
+
+	if gc.canReplay(serverStreamGOPCacheMaxReplay(sm.ss)) {
+		entries := gc.replayEntries()
+		for _, e := range entries {
+			sm.writePacketRTP(e.byts)
+		}
+
+		seqNum := entries[0].seqNum
+		ts := entries[0].timestamp
+		gc.started[sm.ss] = &headers.RTPInfoEntry{
+			SequenceNumber: &seqNum,
+			Timestamp:      &ts,
+		}
+		return
+	}
+
+	// replaying the cache would overflow the write buffer of the session,
+	// wait for the next key frame instead.
+	gc.started[sm.ss] = nil
+	gc.waiting[sm.ss] = sm
+}
+
+func (gc *serverStreamGOPCache) readerSetInactive(ss *ServerSession) {
+	gc.mutex.Lock()
+	defer gc.mutex.Unlock()
+
+	delete(gc.waiting, ss)
+}
+
+func (gc *serverStreamGOPCache) readerRemove(ss *ServerSession) {
+	gc.mutex.Lock()
+	defer gc.mutex.Unlock()
+
+	delete(gc.waiting, ss)
+	delete(gc.started, ss)
+}
+
+// rtpInfoEntry returns a RTP-Info entry that points to the first replayed packet.
+func (gc *serverStreamGOPCache) rtpInfoEntry(ss *ServerSession) *headers.RTPInfoEntry {
+	gc.mutex.Lock()
+	defer gc.mutex.Unlock()
+
+	entry, ok := gc.started[ss]
+	if !ok || entry == nil {
+		return nil
+	}
+
+	gc.started[ss] = nil
+	return entry
+}
@@ -0,0 +1,119 @@
+package gortsplib
+
+import (
+	"github.com/pion/rtp"
+	"github.com/pion/rtp/codecs"
+
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h264"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h265"
+	"github.com/aler9/gortsplib/v2/pkg/format"
+)
+
+// split the NALUs of an aggregation packet, without decoding the packet.
+func rtpSplitAggregationUnit(payload []byte) [][]byte {
+	var nalus [][]byte
+
+	for len(payload) > 0 {
+		if len(payload) < 2 {
+			return nalus
+		}
+
+		size := uint16(payload[0])<<8 | uint16(payload[1])
+		payload = payload[2:]
+
+		if size == 0 || int(size) > len(payload) {
+			return nalus
+		}
+
+		nalus = append(nalus, payload[:size])
+		payload = payload[size:]
+	}
+
+	return nalus
+}
+
+// check whether a RTP/H264 packet contains the beginning of an IDR.
+func rtpH264IsKeyFrame(pkt *rtp.Packet) bool {
+	if len(pkt.Payload) == 0 {
+		return false
+	}
+
+	switch pkt.Payload[0] & 0x1F {
+	case 24: // STAP-A
+		return h264.IDRPresent(rtpSplitAggregationUnit(pkt.Payload[1:]))
+
+	case 28: // FU-A
+		if len(pkt.Payload) < 2 || (pkt.Payload[1]>>7) != 1 {
+			return false
+		}
+		return h264.IDRPresent([][]byte{{pkt.Payload[1] & 0x1F}})
+
+	default:
+		return h264.IDRPresent([][]byte{pkt.Payload})
+	}
+}
+
+// check whether a RTP/H265 packet contains the beginning of an IDR or CRA.
+func rtpH265IsKeyFrame(pkt *rtp.Packet) bool {
+	if len(pkt.Payload) < 2 {
+		return false
+	}
+
+	switch h265.NALUType((pkt.Payload[0] >> 1) & 0b111111) {
+	case h265.NALUType_AggregationUnit:
+		return h265.IsRandomAccess(rtpSplitAggregationUnit(pkt.Payload[2:]))
+
+	case h265.NALUType_FragmentationUnit:
+		if len(pkt.Payload) < 3 || (pkt.Payload[2]>>7) != 1 {
+			return false
+		}
+		return h265.IsRandomAccess([][]byte{{(pkt.Payload[2] & 0b111111) << 1}})
+
+	default:
+		return h265.IsRandomAccess([][]byte{pkt.Payload})
+	}
+}
+
+// check whether a RTP/VP8 packet contains the beginning of a key frame.
+func rtpVP8IsKeyFrame(pkt *rtp.Packet) bool {
+	var vpkt codecs.VP8Packet
+	_, err := vpkt.Unmarshal(pkt.Payload)
+	if err != nil {
+		return false
+	}
+
+	return vpkt.S == 1 && vpkt.PID == 0 &&
+		len(vpkt.Payload) != 0 && (vpkt.Payload[0]&0x01) == 0
+}
+
+// check whether a RTP/VP9 packet contains the beginning of a key frame.
+func rtpVP9IsKeyFrame(pkt *rtp.Packet) bool {
+	var vpkt codecs.VP9Packet
+	_, err := vpkt.Unmarshal(pkt.Payload)
+	if err != nil {
+		return false
+	}
+
+	return vpkt.B && !vpkt.P
+}
+
+// serverStreamKeyFrameDetector returns a function that checks whether a packet
+// contains the beginning of a key frame, or nil if key frames of the format
+// can't be detected.
+func serverStreamKeyFrameDetector(forma format.Format) func(*rtp.Packet) bool {
+	switch forma.(type) {
+	case *format.H264:
+		return rtpH264IsKeyFrame
+
+	case *format.H265:
+		return rtpH265IsKeyFrame
+
+	case *format.VP8:
+		return rtpVP8IsKeyFrame
+
+	case *format.VP9:
+		return rtpVP9IsKeyFrame
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package gortsplib
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gortsplib/v2/pkg/format"
+)
+
+func TestServerStreamKeyFrameDetector(t *testing.T) {
+	for _, ca := range []struct {
+		name    string
+		format  format.Format
+		payload []byte
+		res     bool
+	}{
+		{
+			"h264 idr",
+			&format.H264{},
+			[]byte{0x05, 0x01},
+			true,
+		},
+		{
+			"h264 non-idr",
+			&format.H264{},
+			[]byte{0x01, 0x01},
+			false,
+		},
+		{
+			"h264 stap-a with idr",
+			&format.H264{},
+			[]byte{0x18, 0x00, 0x02, 0x07, 0x01, 0x00, 0x02, 0x05, 0x01},
+			true,
+		},
+		{
+			"h264 fu-a, start of idr",
+			&format.H264{},
+			[]byte{0x1c, 0x85, 0x01},
+			true,
+		},
+		{
+			"h264 fu-a, continuation of idr",
+			&format.H264{},
+			[]byte{0x1c, 0x05, 0x01},
+			false,
+		},
+		{
+			"h264 empty",
+			&format.H264{},
+			[]byte{},
+			false,
+		},
+		{
+			"h265 idr",
+			&format.H265{},
+			[]byte{0x26, 0x01, 0xaf},
+			true,
+		},
+		{
+			"h265 cra",
+			&format.H265{},
+			[]byte{0x2a, 0x01, 0xaf},
+			true,
+		},
+		{
+			"h265 non-idr",
+			&format.H265{},
+			[]byte{0x02, 0x01, 0xaf},
+			false,
+		},
+		{
+			"h265 aggregation unit with idr",
+			&format.H265{},
+			[]byte{0x60, 0x01, 0x00, 0x03, 0x40, 0x01, 0x0c, 0x00, 0x03, 0x26, 0x01, 0xaf},
+			true,
+		},
+		{
+			"h265 aggregation unit without idr",
+			&format.H265{},
+			[]byte{0x60, 0x01, 0x00, 0x03, 0x40, 0x01, 0x0c, 0x00, 0x03, 0x02, 0x01, 0xaf},
+			false,
+		},
+		{
+			"h265 fragmentation unit, start of idr",
+			&format.H265{},
+			[]byte{0x62, 0x01, 0x93, 0xaf},
+			true,
+		},
+		{
+			"h265 fragmentation unit, continuation of idr",
+			&format.H265{},
+			[]byte{0x62, 0x01, 0x13, 0xaf},
+			false,
+		},
+		{
+			"h265 empty",
+			&format.H265{},
+			[]byte{},
+			false,
+		},
+		{
+			"vp8 key frame",
+			&format.VP8{},
+			[]byte{0x10, 0x00, 0x01, 0x02},
+			true,
+		},
+		{
+			"vp8 inter frame",
+			&format.VP8{},
+			[]byte{0x10, 0x01, 0x01, 0x02},
+			false,
+		},
+		{
+			"vp9 key frame",
+			&format.VP9{},
+			[]byte{0x08, 0x01},
+			true,
+		},
+		{
+			"vp9 inter frame",
+			&format.VP9{},
+			[]byte{0x48, 0x01},
+			false,
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			isKeyFrame := serverStreamKeyFrameDetector(ca.format)
+			require.Equal(t, ca.res, isKeyFrame(&rtp.Packet{
+				Payload: ca.payload,
+			}))
+		})
+	}
+
+	require.Nil(t, serverStreamKeyFrameDetector(&format.MJPEG{}))
+}
@@ -16,6 +16,7 @@ type serverStreamMedia struct {
 	media           *media.Media
 	formats         map[uint8]*serverStreamFormat
 	multicastWriter *serverMulticastWriter
+	gopCache        *serverStreamGOPCache
 }
 
 func newServerStreamMedia(st *ServerStream, medi *media.Media) *serverStreamMedia {
@@ -27,7 +28,8 @@ func newServerStreamMedia(st *ServerStream, medi *media.Media) *serverStreamMedi
 	sm.formats = make(map[uint8]*serverStreamFormat)
 	for _, forma := range medi.Formats {
 		tr := &serverStreamFormat{
-			format: forma,
+			format:     forma,
+			isKeyFrame: serverStreamKeyFrameDetector(forma),
 		}
 
 		cmedia := medi
@@ -56,6 +58,15 @@ func (sm *serverStreamMedia) close() {
 	}
 }
 
+func (sm *serverStreamMedia) canDetectKeyFrames() bool {
+	for _, tr := range sm.formats {
+		if tr.isKeyFrame == nil {
+			return false
+		}
+	}
+	return true
+}
+
 func (sm *serverStreamMedia) allocateMulticastHandler(s *Server) error {
 	if sm.multicastWriter == nil {
 		mh, err := newServerMulticastWriter(s)
@@ -80,8 +91,21 @@ func (sm *serverStreamMedia) WritePacketRTPWithNTP(ss *ServerStream, pkt *rtp.Pa
 
 	forma.rtcpSender.ProcessPacket(pkt, ntp, forma.format.PTSEqualsDTS(pkt))
 
+	if ss.timeShift != nil {
+		ss.timeShift.writePacketRTP(sm.media, forma.isKeyFrame, pkt, byts, ntp)
+	}
+
+	var waiting map[*ServerSession]*serverSessionMedia
+	if sm.gopCache != nil {
+		waiting = sm.gopCache.writePacketRTP(pkt, byts, forma.isKeyFrame(pkt))
+	}
+
 	// send unicast
 	for r := range ss.activeUnicastReaders {
+		if _, ok := waiting[r]; ok {
+			continue
+		}
+
 		sm, ok := r.setuppedMedias[sm.media]
 		if ok {
 			sm.writePacketRTP(byts)
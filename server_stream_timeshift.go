@@ -0,0 +1,211 @@
+package gortsplib
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/media"
+)
+
+type serverStreamTimeShiftEntry struct {
+	index     uint64
+	media     *media.Media
+	byts      []byte
+	seqNum    uint16
+	timestamp uint32
+	ntp       time.Time
+
+	// whether the entry is the first packet of a random access point
+	randomAccess bool
+}
+
+// serverStreamTimeShift retains the packets of a live stream
+// for a given window, in order to allow readers to play the past.
+type serverStreamTimeShift struct {
+	window time.Duration
+
+	// if the stream contains video formats whose key frames can be detected,
+	// random access points are placed on their key frames;
+	// otherwise, every packet is a random access point.
+	hasVideo bool
+
+	mutex     sync.RWMutex
+	entries   []*serverStreamTimeShiftEntry
+	nextIndex uint64
+
+	// closed when an entry is added
+	added chan struct{}
+}
+
+func newServerStreamTimeShift(medias media.Medias, window time.Duration) *serverStreamTimeShift {
+	ts := &serverStreamTimeShift{
+		window: window,
+	}
+
+	for _, medi := range medias {
+		if medi.Type == media.TypeVideo {
+			for _, forma := range medi.Formats {
+				if serverStreamKeyFrameDetector(forma) != nil {
+					ts.hasVideo = true
+				}
+			}
+		}
+	}
+
+	return ts
+}
+
+func (ts *serverStreamTimeShift) writePacketRTP(
+	medi *media.Media,
+	isKeyFrame func(*rtp.Packet) bool,
+	pkt *rtp.Packet,
+	byts []byte,
+	ntp time.Time,
+) {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	e := &serverStreamTimeShiftEntry{
+		index:     ts.nextIndex,
+		media:     medi,
+		byts:      append([]byte(nil), byts...),
+		seqNum:    pkt.SequenceNumber,
+		timestamp: pkt.Timestamp,
+		ntp:       ntp,
+	}
+	ts.nextIndex++
+
+	if !ts.hasVideo {
+		e.randomAccess = true
+	} else if medi.Type == media.TypeVideo && isKeyFrame != nil && isKeyFrame(pkt) {
+		// a key frame may be preceded by other packets of the same access unit
+		// (i.e. parameters), that must be sent too.
+		first := e
+		for i := len(ts.entries) - 1; i >= 0; i-- {
+			prev := ts.entries[i]
+			if prev.media != medi {
+				continue
+			}
+			if prev.timestamp != pkt.Timestamp {
+				break
+			}
+			first = prev
+		}
+		first.randomAccess = true
+	}
+
+	ts.entries = append(ts.entries, e)
+
+	if ts.added != nil {
+		close(ts.added)
+		ts.added = nil
+	}
+
+	// remove entries that are outside the window
+	n := 0
+	for n < len(ts.entries) && ntp.Sub(ts.entries[n].ntp) > ts.window {
+		n++
+	}
+	if n > 0 {
+		ts.entries = ts.entries[n:]
+	}
+}
+
+// liveEdge returns the time of the most recent entry.
+func (ts *serverStreamTimeShift) liveEdge() (time.Time, bool) {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+
+	if len(ts.entries) == 0 {
+		return time.Time{}, false
+	}
+
+	return ts.entries[len(ts.entries)-1].ntp, true
+}
+
+// seek returns the last random access point that precedes the given time,
+// or the first random access point if the time precedes the window.
+func (ts *serverStreamTimeShift) seek(t time.Time) (*serverStreamTimeShiftEntry, bool) {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+
+	var ret *serverStreamTimeShiftEntry
+
+	for _, e := range ts.entries {
+		if !e.randomAccess {
+			continue
+		}
+
+		if ret != nil && e.ntp.After(t) {
+			break
+		}
+
+		ret = e
+	}
+
+	return ret, ret != nil
+}
+
+// next returns the entry with the given index.
+// If the entry has been removed from the window, it returns the first
+// random access point.
+func (ts *serverStreamTimeShift) next(index uint64) *serverStreamTimeShiftEntry {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+
+	if len(ts.entries) == 0 {
+		return nil
+	}
+
+	first := ts.entries[0].index
+
+	if index < first {
+		for _, e := range ts.entries {
+			if e.randomAccess {
+				return e
+			}
+		}
+		return ts.entries[0]
+	}
+
+	i := index - first
+	if i >= uint64(len(ts.entries)) {
+		return nil
+	}
+
+	return ts.entries[i]
+}
+
+// waitEntry returns a channel that is closed when the entry
+// with the given index is available.
+func (ts *serverStreamTimeShift) waitEntry(index uint64) <-chan struct{} {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	if ts.nextIndex > index {
+		ch := make(chan struct{})
+		close(ch)
+		return ch
+	}
+
+	if ts.added == nil {
+		ts.added = make(chan struct{})
+	}
+	return ts.added
+}
+
+// firstOfMedia returns the first entry of a media, starting from the given index.
+func (ts *serverStreamTimeShift) firstOfMedia(medi *media.Media, index uint64) (*serverStreamTimeShiftEntry, bool) {
+	ts.mutex.RLock()
+	defer ts.mutex.RUnlock()
+
+	for _, e := range ts.entries {
+		if e.index >= index && e.media == medi {
+			return e, true
+		}
+	}
+
+	return nil, false
+}
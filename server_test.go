@@ -1,17 +1,23 @@
 package gortsplib
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"testing"
+	"time"
 
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
 	"github.com/stretchr/testify/require"
 
 	"github.com/aler9/gortsplib/v2/pkg/auth"
 	"github.com/aler9/gortsplib/v2/pkg/base"
 	"github.com/aler9/gortsplib/v2/pkg/conn"
 	"github.com/aler9/gortsplib/v2/pkg/headers"
+	"github.com/aler9/gortsplib/v2/pkg/liberrors"
 	"github.com/aler9/gortsplib/v2/pkg/media"
+	"github.com/aler9/gortsplib/v2/pkg/url"
 )
 
 var serverCert = []byte(`-----BEGIN CERTIFICATE-----
@@ -81,19 +87,20 @@ func writeReqReadRes(
 }
 
 type testServerHandler struct {
-	onConnOpen     func(*ServerHandlerOnConnOpenCtx)
-	onConnClose    func(*ServerHandlerOnConnCloseCtx)
-	onSessionOpen  func(*ServerHandlerOnSessionOpenCtx)
-	onSessionClose func(*ServerHandlerOnSessionCloseCtx)
-	onDescribe     func(*ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error)
-	onAnnounce     func(*ServerHandlerOnAnnounceCtx) (*base.Response, error)
-	onSetup        func(*ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error)
-	onPlay         func(*ServerHandlerOnPlayCtx) (*base.Response, error)
-	onRecord       func(*ServerHandlerOnRecordCtx) (*base.Response, error)
-	onPause        func(*ServerHandlerOnPauseCtx) (*base.Response, error)
-	onSetParameter func(*ServerHandlerOnSetParameterCtx) (*base.Response, error)
-	onGetParameter func(*ServerHandlerOnGetParameterCtx) (*base.Response, error)
-	onWarning      func(*ServerHandlerOnWarningCtx)
+	onConnOpen      func(*ServerHandlerOnConnOpenCtx)
+	onConnClose     func(*ServerHandlerOnConnCloseCtx)
+	onSessionOpen   func(*ServerHandlerOnSessionOpenCtx)
+	onSessionClose  func(*ServerHandlerOnSessionCloseCtx)
+	onDescribe      func(*ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error)
+	onAnnounce      func(*ServerHandlerOnAnnounceCtx) (*base.Response, error)
+	onSetup         func(*ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error)
+	onPlay          func(*ServerHandlerOnPlayCtx) (*base.Response, error)
+	onRecord        func(*ServerHandlerOnRecordCtx) (*base.Response, error)
+	onPause         func(*ServerHandlerOnPauseCtx) (*base.Response, error)
+	onSetParameter  func(*ServerHandlerOnSetParameterCtx) (*base.Response, error)
+	onGetParameter  func(*ServerHandlerOnGetParameterCtx) (*base.Response, error)
+	onWarning       func(*ServerHandlerOnWarningCtx)
+	onLimitExceeded func(*ServerHandlerOnLimitExceededCtx)
 }
 
 func (sh *testServerHandler) OnConnOpen(ctx *ServerHandlerOnConnOpenCtx) {
@@ -182,6 +189,12 @@ func (sh *testServerHandler) OnWarning(ctx *ServerHandlerOnWarningCtx) {
 	}
 }
 
+func (sh *testServerHandler) OnLimitExceeded(ctx *ServerHandlerOnLimitExceededCtx) {
+	if sh.onLimitExceeded != nil {
+		sh.onLimitExceeded(ctx)
+	}
+}
+
 func TestServerClose(t *testing.T) {
 	s := &Server{
 		Handler:     &testServerHandler{},
@@ -1171,3 +1184,501 @@ func TestServerAuth(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, base.StatusOK, res.StatusCode)
 }
+
+func TestServerAuthBearer(t *testing.T) {
+	va := auth.NewTokenValidator([]byte("mykey"))
+
+	// {"alg":"HS256","typ":"JWT"} {"sub":"myuser"}
+	token := "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJzdWIiOiJteXVzZXIifQ." +
+		"SuG3KQICzKiNLYfs1TB0SKgA0xUh9G__JHUvWcHh3n0"
+
+	s := &Server{
+		ValidateToken: va.ValidateToken,
+		AuthRealm:     "myrealm",
+		Handler: &testServerHandler{
+			onAnnounce: func(ctx *ServerHandlerOnAnnounceCtx) (*base.Response, error) {
+				require.Equal(t, "myuser", ctx.TokenClaims["sub"])
+
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+		},
+		RTSPAddress: "localhost:8554",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+	conn := conn.NewConn(nconn)
+
+	medias := media.Medias{testH264Media}
+
+	req := base.Request{
+		Method: base.Announce,
+		URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+		Header: base.Header{
+			"CSeq":         base.HeaderValue{"1"},
+			"Content-Type": base.HeaderValue{"application/sdp"},
+		},
+		Body: mustMarshalSDP(medias.Marshal(false)),
+	}
+
+	res, err := writeReqReadRes(conn, req)
+	require.NoError(t, err)
+	require.Equal(t, base.StatusUnauthorized, res.StatusCode)
+	require.Equal(t, base.HeaderValue{`Bearer realm="myrealm"`}, res.Header["WWW-Authenticate"])
+
+	sender, err := auth.NewBearerSender(res.Header["WWW-Authenticate"], "invalid")
+	require.NoError(t, err)
+
+	sender.AddAuthorization(&req)
+	res, err = writeReqReadRes(conn, req)
+	require.NoError(t, err)
+	require.Equal(t, base.StatusUnauthorized, res.StatusCode)
+
+	sender, err = auth.NewBearerSender(res.Header["WWW-Authenticate"], token)
+	require.NoError(t, err)
+
+	sender.AddAuthorization(&req)
+	res, err = writeReqReadRes(conn, req)
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+}
+
+func TestServerLimitConnections(t *testing.T) {
+	limitExceeded := make(chan error, 1)
+	connClosed := make(chan struct{}, 1)
+
+	s := &Server{
+		Handler: &testServerHandler{
+			onConnClose: func(ctx *ServerHandlerOnConnCloseCtx) {
+				connClosed <- struct{}{}
+			},
+			onLimitExceeded: func(ctx *ServerHandlerOnLimitExceededCtx) {
+				limitExceeded <- ctx.Error
+			},
+		},
+		RTSPAddress:    "localhost:8554",
+		MaxConnections: 1,
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	nconn1, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn1.Close()
+	conn1 := conn.NewConn(nconn1)
+
+	res, err := writeReqReadRes(conn1, base.Request{
+		Method: base.Options,
+		URL:    mustParseURL("rtsp://localhost:8554/"),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"1"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	nconn2, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn2.Close()
+	conn2 := conn.NewConn(nconn2)
+
+	require.Equal(t, liberrors.ErrServerTooManyConnections{}, <-limitExceeded)
+
+	// rejected connections are not counted
+	nconn1.Close()
+	<-connClosed
+
+	nconn3, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn3.Close()
+	conn3 := conn.NewConn(nconn3)
+
+	res, err = writeReqReadRes(conn3, base.Request{
+		Method: base.Options,
+		URL:    mustParseURL("rtsp://localhost:8554/"),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"1"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	res, err = writeReqReadRes(conn2, base.Request{
+		Method: base.Options,
+		URL:    mustParseURL("rtsp://localhost:8554/"),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"1"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusServiceUnavailable, res.StatusCode)
+
+	_, err = conn2.ReadResponse()
+	require.Error(t, err)
+}
+
+func TestServerLimitSessions(t *testing.T) {
+	limitExceeded := make(chan error, 1)
+
+	s := &Server{
+		Handler: &testServerHandler{
+			onAnnounce: func(ctx *ServerHandlerOnAnnounceCtx) (*base.Response, error) {
+				return &base.Response{
+					StatusCode: base.StatusOK,
+				}, nil
+			},
+			onLimitExceeded: func(ctx *ServerHandlerOnLimitExceededCtx) {
+				limitExceeded <- ctx.Error
+			},
+		},
+		RTSPAddress: "localhost:8554",
+		MaxSessions: 1,
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	medias := media.Medias{testH264Media}
+
+	for i := 0; i < 2; i++ {
+		nconn, err := net.Dial("tcp", "localhost:8554")
+		require.NoError(t, err)
+		defer nconn.Close()
+		conn := conn.NewConn(nconn)
+
+		res, err := writeReqReadRes(conn, base.Request{
+			Method: base.Announce,
+			URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+			Header: base.Header{
+				"CSeq":         base.HeaderValue{"1"},
+				"Content-Type": base.HeaderValue{"application/sdp"},
+			},
+			Body: mustMarshalSDP(medias.Marshal(false)),
+		})
+		require.NoError(t, err)
+
+		if i == 0 {
+			require.Equal(t, base.StatusOK, res.StatusCode)
+		} else {
+			require.Equal(t, base.StatusNotEnoughBandwidth, res.StatusCode)
+			require.Equal(t, liberrors.ErrServerTooManySessions{}, <-limitExceeded)
+
+			// connection is still usable
+			res, err = writeReqReadRes(conn, base.Request{
+				Method: base.Options,
+				URL:    mustParseURL("rtsp://localhost:8554/"),
+				Header: base.Header{
+					"CSeq": base.HeaderValue{"2"},
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, base.StatusOK, res.StatusCode)
+		}
+	}
+}
+
+func TestServerLimitRequestRate(t *testing.T) {
+	s := &Server{
+		Handler:              &testServerHandler{},
+		RTSPAddress:          "localhost:8554",
+		MaxRequestsPerSecond: 2,
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+	conn := conn.NewConn(nconn)
+
+	for i := 0; i < 3; i++ {
+		res, err := writeReqReadRes(conn, base.Request{
+			Method: base.Options,
+			URL:    mustParseURL("rtsp://localhost:8554/"),
+			Header: base.Header{
+				"CSeq": base.HeaderValue{"1"},
+			},
+		})
+		require.NoError(t, err)
+
+		if i < 2 {
+			require.Equal(t, base.StatusOK, res.StatusCode)
+		} else {
+			require.Equal(t, base.StatusServiceUnavailable, res.StatusCode)
+		}
+	}
+}
+
+func TestServerLimitRequestSize(t *testing.T) {
+	for _, ca := range []struct {
+		name       string
+		req        base.Request
+		statusCode base.StatusCode
+		err        string
+	}{
+		{
+			"body size",
+			base.Request{
+				Method: base.Announce,
+				URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+				Header: base.Header{
+					"CSeq":         base.HeaderValue{"1"},
+					"Content-Type": base.HeaderValue{"application/sdp"},
+				},
+				Body: mustMarshalSDP(media.Medias{testH264Media}.Marshal(false)),
+			},
+			base.StatusRequestEntityTooLarge,
+			"Content-Length exceeds 10",
+		},
+		{
+			"header count",
+			base.Request{
+				Method: base.Options,
+				URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+				Header: base.Header{
+					"CSeq": base.HeaderValue{"1"},
+					"A":    base.HeaderValue{"1"},
+					"B":    base.HeaderValue{"2"},
+					"C":    base.HeaderValue{"3"},
+				},
+			},
+			base.StatusRequestHeaderFieldsTooLarge,
+			"headers count exceeds 3",
+		},
+	} {
+		t.Run(ca.name, func(t *testing.T) {
+			connClosed := make(chan error, 1)
+
+			s := &Server{
+				Handler: &testServerHandler{
+					onConnClose: func(ctx *ServerHandlerOnConnCloseCtx) {
+						connClosed <- ctx.Error
+					},
+				},
+				RTSPAddress:           "localhost:8554",
+				MaxRequestHeaderCount: 3,
+				MaxRequestBodySize:    10,
+			}
+
+			err := s.Start()
+			require.NoError(t, err)
+			defer s.Close()
+
+			nconn, err := net.Dial("tcp", "localhost:8554")
+			require.NoError(t, err)
+			defer nconn.Close()
+			conn := conn.NewConn(nconn)
+
+			res, err := writeReqReadRes(conn, ca.req)
+			require.NoError(t, err)
+			require.Equal(t, ca.statusCode, res.StatusCode)
+
+			err = <-connClosed
+			require.Contains(t, err.Error(), ca.err)
+		})
+	}
+}
+
+func TestServerMalformedRequest(t *testing.T) {
+	s := &Server{
+		Handler:     &testServerHandler{},
+		RTSPAddress: "localhost:8554",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+	conn := conn.NewConn(nconn)
+
+	_, err = nconn.Write([]byte("OPTIONS rtsp://localhost:8554/ RTSP/2.0\r\n\r\n"))
+	require.NoError(t, err)
+
+	res, err := conn.ReadResponse()
+	require.NoError(t, err)
+	require.Equal(t, base.StatusBadRequest, res.StatusCode)
+}
+
+func TestServerFirstRequestTimeout(t *testing.T) {
+	limitExceeded := make(chan error, 1)
+
+	s := &Server{
+		Handler: &testServerHandler{
+			onLimitExceeded: func(ctx *ServerHandlerOnLimitExceededCtx) {
+				limitExceeded <- ctx.Error
+			},
+		},
+		RTSPAddress:         "localhost:8554",
+		FirstRequestTimeout: 500 * time.Millisecond,
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+
+	require.Equal(t, liberrors.ErrServerFirstRequestTimedOut{}, <-limitExceeded)
+}
+
+func TestServerShutdown(t *testing.T) {
+	for _, ca := range []string{"force close", "redirect"} {
+		t.Run(ca, func(t *testing.T) {
+			stream := NewServerStream(media.Medias{testH264Media})
+			defer stream.Close()
+
+			s := &Server{
+				Handler: &testServerHandler{
+					onDescribe: func(ctx *ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, stream, nil
+					},
+					onSetup: func(ctx *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, stream, nil
+					},
+					onPlay: func(ctx *ServerHandlerOnPlayCtx) (*base.Response, error) {
+						return &base.Response{
+							StatusCode: base.StatusOK,
+						}, nil
+					},
+				},
+				RTSPAddress: "localhost:8554",
+			}
+
+			if ca == "redirect" {
+				s.ShutdownRedirect = func(ss *ServerSession) *url.URL {
+					return mustParseURL("rtsp://otherhost:8554/teststream")
+				}
+			}
+
+			err := s.Start()
+			require.NoError(t, err)
+			defer s.Close()
+
+			nconn, err := net.Dial("tcp", "localhost:8554")
+			require.NoError(t, err)
+			defer nconn.Close()
+			conn := conn.NewConn(nconn)
+
+			desc, err := doDescribe(conn)
+			require.NoError(t, err)
+
+			res, err := writeReqReadRes(conn, base.Request{
+				Method: base.Setup,
+				URL:    mustParseURL("rtsp://localhost:8554/teststream/" + controlAttribute(desc.MediaDescriptions[0])),
+				Header: base.Header{
+					"CSeq": base.HeaderValue{"2"},
+					"Transport": headers.Transport{
+						Protocol: headers.TransportProtocolTCP,
+						Delivery: func() *headers.TransportDelivery {
+							v := headers.TransportDeliveryUnicast
+							return &v
+						}(),
+						Mode: func() *headers.TransportMode {
+							v := headers.TransportModePlay
+							return &v
+						}(),
+						InterleavedIDs: &[2]int{0, 1},
+					}.Marshal(),
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, base.StatusOK, res.StatusCode)
+
+			var sx headers.Session
+			err = sx.Unmarshal(res.Header["Session"])
+			require.NoError(t, err)
+
+			res, err = writeReqReadRes(conn, base.Request{
+				Method: base.Play,
+				URL:    mustParseURL("rtsp://localhost:8554/teststream"),
+				Header: base.Header{
+					"CSeq":    base.HeaderValue{"3"},
+					"Session": base.HeaderValue{sx.Session},
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, base.StatusOK, res.StatusCode)
+
+			stream.WritePacketRTP(testH264Media, &rtp.Packet{
+				Header: rtp.Header{
+					Version:     2,
+					PayloadType: 96,
+					SSRC:        0x38F27A2F,
+				},
+				Payload: []byte{0x05},
+			})
+
+			_, err = conn.ReadInterleavedFrame()
+			require.NoError(t, err)
+
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer ctxCancel()
+
+			shutdownDone := make(chan []*ServerSession)
+			go func() {
+				shutdownDone <- s.Shutdown(ctx)
+			}()
+
+			if ca == "redirect" {
+				req, err := conn.ReadRequestIgnoreFrames()
+				require.NoError(t, err)
+				require.Equal(t, base.Redirect, req.Method)
+				require.Equal(t, base.HeaderValue{"1"}, req.Header["CSeq"])
+				require.Equal(t, base.HeaderValue{"rtsp://otherhost:8554/teststream"}, req.Header["Location"])
+
+				// the response is discarded and the connection is kept open
+				err = conn.WriteResponse(&base.Response{
+					StatusCode: base.StatusOK,
+					Header: base.Header{
+						"CSeq": base.HeaderValue{"1"},
+					},
+				})
+				require.NoError(t, err)
+			}
+
+			f, err := conn.ReadInterleavedFrame()
+			require.NoError(t, err)
+			require.Equal(t, 1, f.Channel)
+
+			pkts, err := rtcp.Unmarshal(f.Payload)
+			require.NoError(t, err)
+			require.Equal(t, []rtcp.Packet{&rtcp.Goodbye{Sources: []uint32{0x38F27A2F}}}, pkts)
+
+			forceClosed := <-shutdownDone
+			require.Equal(t, 1, len(forceClosed))
+
+			_, err = net.Dial("tcp", "localhost:8554")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestServerShutdownNotStarted(t *testing.T) {
+	s := &Server{
+		Handler:     &testServerHandler{},
+		RTSPAddress: "localhost:8554",
+	}
+
+	require.Nil(t, s.Shutdown(context.Background()))
+}
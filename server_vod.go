@@ -0,0 +1,321 @@
+package gortsplib
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pion/rtp"
+
+	"github.com/aler9/gortsplib/v2/pkg/base"
+	"github.com/aler9/gortsplib/v2/pkg/codecs/h264"
+	"github.com/aler9/gortsplib/v2/pkg/format"
+	"github.com/aler9/gortsplib/v2/pkg/media"
+	"github.com/aler9/gortsplib/v2/pkg/mp4"
+)
+
+type serverVODTrack struct {
+	media  *media.Media
+	encode func(*mp4.Sample) ([]*rtp.Packet, error)
+}
+
+func newServerVODTrack(medi *media.Media) (*serverVODTrack, error) {
+	t := &serverVODTrack{
+		media: medi,
+	}
+
+	// timestamps of packets must be relative to the beginning of the file.
+	zero := uint32(0)
+
+	switch forma := medi.Formats[0].(type) {
+	case *format.H264:
+		enc := forma.CreateEncoder()
+		enc.InitialTimestamp = &zero
+		t.encode = func(sample *mp4.Sample) ([]*rtp.Packet, error) {
+			au, err := h264.AVCCUnmarshal(sample.Payload)
+			if err != nil {
+				return nil, err
+			}
+			return enc.Encode(au, sample.PTS)
+		}
+
+	case *format.H265:
+		enc := forma.CreateEncoder()
+		enc.InitialTimestamp = &zero
+		t.encode = func(sample *mp4.Sample) ([]*rtp.Packet, error) {
+			// H265 uses the same AVCC format of H264
+			au, err := h264.AVCCUnmarshal(sample.Payload)
+			if err != nil {
+				return nil, err
+			}
+			return enc.Encode(au, sample.PTS)
+		}
+
+	case *format.VP9:
+		enc := forma.CreateEncoder()
+		enc.InitialTimestamp = &zero
+		t.encode = func(sample *mp4.Sample) ([]*rtp.Packet, error) {
+			return enc.Encode(sample.Payload, sample.PTS)
+		}
+
+	case *format.MPEG4Audio:
+		enc := forma.CreateEncoder()
+		enc.InitialTimestamp = &zero
+		t.encode = func(sample *mp4.Sample) ([]*rtp.Packet, error) {
+			return enc.Encode([][]byte{sample.Payload}, sample.PTS)
+		}
+
+	case *format.Opus:
+		enc := forma.CreateEncoder()
+		enc.InitialTimestamp = &zero
+		t.encode = func(sample *mp4.Sample) ([]*rtp.Packet, error) {
+			pkt, err := enc.Encode(sample.Payload, sample.PTS)
+			if err != nil {
+				return nil, err
+			}
+			return []*rtp.Packet{pkt}, nil
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", forma)
+	}
+
+	return t, nil
+}
+
+// serverVODStream is a stream of ServerVOD.
+type serverVODStream struct {
+	stream    *ServerStream
+	idleTimer *time.Timer
+}
+
+// serverVODSource is a ServerSessionSource that reads a MP4 file.
+type serverVODSource struct {
+	file   io.ReadSeekCloser
+	reader *mp4.Reader
+	tracks map[*mp4.Track]*serverVODTrack
+
+	// packets of the current sample
+	queue      []*rtp.Packet
+	queueMedia *media.Media
+	queuePos   time.Duration
+}
+
+// Seek implements ServerSessionSource.
+func (s *serverVODSource) Seek(pos time.Duration) (time.Duration, error) {
+	s.queue = nil
+	return s.reader.Seek(pos)
+}
+
+// ReadPacket implements ServerSessionSource.
+func (s *serverVODSource) ReadPacket() (*media.Media, *rtp.Packet, time.Duration, error) {
+	for len(s.queue) == 0 {
+		track, sample, err := s.reader.Read()
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		vt := s.tracks[track]
+
+		pkts, err := vt.encode(sample)
+		if err != nil {
+			// skip invalid samples
+			continue
+		}
+
+		s.queue = pkts
+		s.queueMedia = vt.media
+		s.queuePos = sample.DTS
+	}
+
+	pkt := s.queue[0]
+	s.queue = s.queue[1:]
+
+	return s.queueMedia, pkt, s.queuePos, nil
+}
+
+// Close implements ServerSessionSource.
+func (s *serverVODSource) Close() error {
+	return s.file.Close()
+}
+
+// ServerVOD is a ServerHandler that serves MP4 files on demand.
+// Each session has its own playback, whose position can be changed
+// through the Range header of PLAY requests, and that can be paused.
+//
+// It implements ServerHandlerOnDescribe, ServerHandlerOnSetup, ServerHandlerOnPlay
+// and ServerHandlerOnPause, and can be embedded into another ServerHandler.
+// Files are supposed not to change while they are served.
+type ServerVOD struct {
+	// function that opens the file associated with a path.
+	// It must return an error if the file doesn't exist.
+	OpenFile func(path string) (io.ReadSeekCloser, error)
+
+	// time after which a stream without readers is closed.
+	// It must be long enough to allow clients to perform a SETUP after a DESCRIBE,
+	// since media UUIDs change when a stream is recreated.
+	// It defaults to 60 seconds.
+	StreamIdleTimeout time.Duration
+
+	mutex   sync.Mutex
+	streams map[string]*serverVODStream
+}
+
+func (sv *ServerVOD) streamIdleTimeout() time.Duration {
+	if sv.StreamIdleTimeout == 0 {
+		return 60 * time.Second
+	}
+	return sv.StreamIdleTimeout
+}
+
+// openReader opens a file and reads its metadata.
+func (sv *ServerVOD) openReader(path string) (io.ReadSeekCloser, *mp4.Reader, error) {
+	f, err := sv.OpenFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := mp4.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	return f, r, nil
+}
+
+func (sv *ServerVOD) newSource(path string, medias media.Medias) (ServerSessionSource, error) {
+	f, r, err := sv.openReader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tracks := r.Tracks()
+	if len(tracks) != len(medias) {
+		f.Close()
+		return nil, fmt.Errorf("file has changed")
+	}
+
+	s := &serverVODSource{
+		file:   f,
+		reader: r,
+		tracks: make(map[*mp4.Track]*serverVODTrack),
+	}
+
+	// packets are associated with the medias of the stream
+	for i, track := range tracks {
+		vt, err := newServerVODTrack(medias[i])
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		s.tracks[track] = vt
+	}
+
+	return s, nil
+}
+
+// stream returns the on-demand stream associated with a path.
+// The same stream is returned to DESCRIBE and SETUP requests, therefore
+// media UUIDs are preserved.
+func (sv *ServerVOD) stream(path string) (*ServerStream, error) {
+	sv.mutex.Lock()
+	defer sv.mutex.Unlock()
+
+	if vs, ok := sv.streams[path]; ok {
+		return vs.stream, nil
+	}
+
+	f, r, err := sv.openReader(path)
+	if err != nil {
+		return nil, err
+	}
+	f.Close()
+
+	medias := r.Medias()
+
+	st := NewServerStreamOnDemand(medias, r.Duration(), func() (ServerSessionSource, error) {
+		return sv.newSource(path, medias)
+	})
+
+	vs := &serverVODStream{
+		stream: st,
+	}
+
+	st.onNoReaders = func() {
+		sv.mutex.Lock()
+		defer sv.mutex.Unlock()
+		sv.startIdleTimer(path, vs)
+	}
+
+	if sv.streams == nil {
+		sv.streams = make(map[string]*serverVODStream)
+	}
+	sv.streams[path] = vs
+
+	// the stream may never be read
+	sv.startIdleTimer(path, vs)
+
+	return st, nil
+}
+
+// startIdleTimer closes and removes a stream if it doesn't have readers
+// after the idle timeout.
+func (sv *ServerVOD) startIdleTimer(path string, vs *serverVODStream) {
+	if vs.idleTimer != nil {
+		vs.idleTimer.Stop()
+	}
+
+	vs.idleTimer = time.AfterFunc(sv.streamIdleTimeout(), func() {
+		sv.mutex.Lock()
+		defer sv.mutex.Unlock()
+
+		if sv.streams[path] == vs && vs.stream.closeIfNoReaders() {
+			delete(sv.streams, path)
+		}
+	})
+}
+
+// OnDescribe implements ServerHandlerOnDescribe.
+func (sv *ServerVOD) OnDescribe(ctx *ServerHandlerOnDescribeCtx) (*base.Response, *ServerStream, error) {
+	st, err := sv.stream(ctx.Path)
+	if err != nil {
+		return &base.Response{
+			StatusCode: base.StatusNotFound,
+		}, nil, nil
+	}
+
+	return &base.Response{
+		StatusCode: base.StatusOK,
+	}, st, nil
+}
+
+// OnSetup implements ServerHandlerOnSetup.
+func (sv *ServerVOD) OnSetup(ctx *ServerHandlerOnSetupCtx) (*base.Response, *ServerStream, error) {
+	st, err := sv.stream(ctx.Path)
+	if err != nil {
+		return &base.Response{
+			StatusCode: base.StatusNotFound,
+		}, nil, nil
+	}
+
+	return &base.Response{
+		StatusCode: base.StatusOK,
+	}, st, nil
+}
+
+// OnPlay implements ServerHandlerOnPlay.
+// Seeking is performed by the server.
+func (sv *ServerVOD) OnPlay(ctx *ServerHandlerOnPlayCtx) (*base.Response, error) {
+	return &base.Response{
+		StatusCode: base.StatusOK,
+	}, nil
+}
+
+// OnPause implements ServerHandlerOnPause.
+func (sv *ServerVOD) OnPause(ctx *ServerHandlerOnPauseCtx) (*base.Response, error) {
+	return &base.Response{
+		StatusCode: base.StatusOK,
+	}, nil
+}
@@ -0,0 +1,514 @@
+package gortsplib
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aler9/gortsplib/v2/pkg/base"
+	"github.com/aler9/gortsplib/v2/pkg/conn"
+	"github.com/aler9/gortsplib/v2/pkg/fmp4"
+	"github.com/aler9/gortsplib/v2/pkg/format"
+	"github.com/aler9/gortsplib/v2/pkg/headers"
+	"github.com/aler9/gortsplib/v2/pkg/sdp"
+)
+
+type testVODFile struct {
+	*bytes.Reader
+}
+
+func (testVODFile) Close() error {
+	return nil
+}
+
+func testVODFileContent(t *testing.T) []byte {
+	track := &fmp4.Track{
+		Format: &format.H264{
+			PayloadTyp:        96,
+			PacketizationMode: 1,
+		},
+	}
+
+	var buf bytes.Buffer
+	m, err := fmp4.NewMuxer(&buf, 1*time.Second, []*fmp4.Track{track})
+	require.NoError(t, err)
+
+	// 2 seconds, with a random access point every 200ms
+	for i := 0; i < 20; i++ {
+		var au [][]byte
+		if (i % 2) == 0 {
+			au = [][]byte{
+				{
+					0x67, 0x64, 0x00, 0x28, 0xac, 0xd9, 0x40, 0x78,
+					0x02, 0x27, 0xe5, 0x84, 0x00, 0x00, 0x03, 0x00,
+					0x04, 0x00, 0x00, 0x03, 0x00, 0xf0, 0x3c, 0x60,
+					0xc6, 0x58,
+				},
+				{0x68, 0xee, 0x3c, 0x80},
+				{0x65, 0x88, 0x84, 0x00, 0x33, 0xff},
+			}
+		} else {
+			au = [][]byte{{0x41, 0x9a, 0x21, 0x6c, 0x45, 0xff}}
+		}
+
+		err = m.WriteH264(track, time.Duration(i)*100*time.Millisecond, au)
+		require.NoError(t, err)
+	}
+
+	err = m.Flush()
+	require.NoError(t, err)
+
+	return buf.Bytes()
+}
+
+func readRTPInfo(t *testing.T, res *base.Response) (uint16, uint32) {
+	var ri headers.RTPInfo
+	err := ri.Unmarshal(res.Header["RTP-Info"])
+	require.NoError(t, err)
+	require.Equal(t, 1, len(ri))
+	return *ri[0].SequenceNumber, *ri[0].Timestamp
+}
+
+// readResponse reads a response and returns the RTP packets that precede it.
+func readResponse(t *testing.T, conn *conn.Conn) (*base.Response, []*rtp.Packet) {
+	var pkts []*rtp.Packet
+
+	for {
+		recv, err := conn.ReadInterleavedFrameOrResponse()
+		require.NoError(t, err)
+
+		switch recv := recv.(type) {
+		case *base.Response:
+			return recv, pkts
+
+		case *base.InterleavedFrame:
+			if recv.Channel == 0 {
+				var pkt rtp.Packet
+				err = pkt.Unmarshal(recv.Payload)
+				require.NoError(t, err)
+				pkts = append(pkts, &pkt)
+			}
+		}
+	}
+}
+
+// readPacketWithSeqNum returns the packet with the given sequence number,
+// searching it among already received packets or reading new packets.
+func readPacketWithSeqNum(t *testing.T, conn *conn.Conn, recv []*rtp.Packet, seqNum uint16) *rtp.Packet {
+	for _, pkt := range recv {
+		if pkt.SequenceNumber == seqNum {
+			return pkt
+		}
+	}
+
+	for {
+		f, err := conn.ReadInterleavedFrame()
+		require.NoError(t, err)
+
+		if f.Channel != 0 {
+			continue
+		}
+
+		var pkt rtp.Packet
+		err = pkt.Unmarshal(f.Payload)
+		require.NoError(t, err)
+
+		if pkt.SequenceNumber == seqNum {
+			return &pkt
+		}
+	}
+}
+
+func TestServerVOD(t *testing.T) {
+	content := testVODFileContent(t)
+
+	s := &Server{
+		Handler: &ServerVOD{
+			OpenFile: func(path string) (io.ReadSeekCloser, error) {
+				if path != "/test.mp4" {
+					return nil, fmt.Errorf("file not found")
+				}
+				return testVODFile{bytes.NewReader(content)}, nil
+			},
+		},
+		RTSPAddress: "localhost:8554",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+	conn := conn.NewConn(nconn)
+
+	res, err := writeReqReadRes(conn, base.Request{
+		Method: base.Describe,
+		URL:    mustParseURL("rtsp://localhost:8554/missing.mp4"),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"1"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusNotFound, res.StatusCode)
+
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Describe,
+		URL:    mustParseURL("rtsp://localhost:8554/test.mp4"),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"2"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	var desc sdp.SessionDescription
+	err = desc.Unmarshal(res.Body)
+	require.NoError(t, err)
+
+	v, ok := desc.Attribute("range")
+	require.Equal(t, true, ok)
+	require.Equal(t, "npt=0-2", v)
+
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Setup,
+		URL:    mustParseURL("rtsp://localhost:8554/test.mp4/" + controlAttribute(desc.MediaDescriptions[0])),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"3"},
+			"Transport": headers.Transport{
+				Protocol: headers.TransportProtocolTCP,
+				Delivery: func() *headers.TransportDelivery {
+					v := headers.TransportDeliveryUnicast
+					return &v
+				}(),
+				Mode: func() *headers.TransportMode {
+					v := headers.TransportModePlay
+					return &v
+				}(),
+				InterleavedIDs: &[2]int{0, 1},
+			}.Marshal(),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	var sx headers.Session
+	err = sx.Unmarshal(res.Header["Session"])
+	require.NoError(t, err)
+
+	// play from the random access point that precedes 1.3s
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Play,
+		URL:    mustParseURL("rtsp://localhost:8554/test.mp4"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"4"},
+			"Session": base.HeaderValue{sx.Session},
+			"Range":   base.HeaderValue{"npt=1.3-"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+	require.Equal(t, base.HeaderValue{"npt=1.2-2"}, res.Header["Range"])
+
+	seqNum1, ts1 := readRTPInfo(t, res)
+
+	pkt := readPacketWithSeqNum(t, conn, nil, seqNum1)
+	require.Equal(t, ts1, pkt.Timestamp)
+	require.Equal(t, []byte{0x65, 0x88, 0x84, 0x00, 0x33, 0xff}, pkt.Payload[len(pkt.Payload)-6:])
+
+	// seek during the playback
+	var recv []*rtp.Packet
+
+	err = conn.WriteRequest(&base.Request{
+		Method: base.Play,
+		URL:    mustParseURL("rtsp://localhost:8554/test.mp4"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"5"},
+			"Session": base.HeaderValue{sx.Session},
+			"Range":   base.HeaderValue{"npt=0-"},
+		},
+	})
+	require.NoError(t, err)
+
+	res, recv = readResponse(t, conn)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+	require.Equal(t, base.HeaderValue{"npt=0-2"}, res.Header["Range"])
+
+	seqNum2, ts2 := readRTPInfo(t, res)
+	require.Equal(t, ts1-108000, ts2)
+
+	pkt = readPacketWithSeqNum(t, conn, recv, seqNum2)
+	require.Equal(t, ts2, pkt.Timestamp)
+
+	err = conn.WriteRequest(&base.Request{
+		Method: base.Pause,
+		URL:    mustParseURL("rtsp://localhost:8554/test.mp4"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"6"},
+			"Session": base.HeaderValue{sx.Session},
+		},
+	})
+	require.NoError(t, err)
+
+	res, recv = readResponse(t, conn)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	// resume from the current position
+	err = conn.WriteRequest(&base.Request{
+		Method: base.Play,
+		URL:    mustParseURL("rtsp://localhost:8554/test.mp4"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"7"},
+			"Session": base.HeaderValue{sx.Session},
+		},
+	})
+	require.NoError(t, err)
+
+	res, recv = readResponse(t, conn)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	var ra headers.Range
+	err = ra.Unmarshal(res.Header["Range"])
+	require.NoError(t, err)
+	start := ra.Value.(*headers.RangeNPT).Start
+	require.Greater(t, start, time.Duration(0))
+
+	seqNum3, ts3 := readRTPInfo(t, res)
+	require.Equal(t, ts2+uint32(start.Seconds()*90000), ts3)
+
+	pkt = readPacketWithSeqNum(t, conn, recv, seqNum3)
+	require.Less(t, pkt.Timestamp-ts3, uint32(90000))
+
+	err = conn.WriteRequest(&base.Request{
+		Method: base.Play,
+		URL:    mustParseURL("rtsp://localhost:8554/test.mp4"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"8"},
+			"Session": base.HeaderValue{sx.Session},
+			"Range":   base.HeaderValue{"npt=3-"},
+		},
+	})
+	require.NoError(t, err)
+
+	res, recv = readResponse(t, conn)
+	require.Equal(t, base.StatusInvalidRange, res.StatusCode)
+}
+
+func TestServerVODStreamIdle(t *testing.T) {
+	content := testVODFileContent(t)
+
+	sv := &ServerVOD{
+		OpenFile: func(path string) (io.ReadSeekCloser, error) {
+			return testVODFile{bytes.NewReader(content)}, nil
+		},
+		StreamIdleTimeout: 200 * time.Millisecond,
+	}
+
+	streamCount := func() int {
+		sv.mutex.Lock()
+		defer sv.mutex.Unlock()
+		return len(sv.streams)
+	}
+
+	s := &Server{
+		Handler:     sv,
+		RTSPAddress: "localhost:8554",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+	conn := conn.NewConn(nconn)
+
+	// streams that are never read are closed
+	res, err := writeReqReadRes(conn, base.Request{
+		Method: base.Describe,
+		URL:    mustParseURL("rtsp://localhost:8554/test1.mp4"),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"1"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+	require.Equal(t, 1, streamCount())
+
+	time.Sleep(400 * time.Millisecond)
+	require.Equal(t, 0, streamCount())
+
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Describe,
+		URL:    mustParseURL("rtsp://localhost:8554/test2.mp4"),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"2"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	var desc sdp.SessionDescription
+	err = desc.Unmarshal(res.Body)
+	require.NoError(t, err)
+
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Setup,
+		URL:    mustParseURL("rtsp://localhost:8554/test2.mp4/" + controlAttribute(desc.MediaDescriptions[0])),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"3"},
+			"Transport": headers.Transport{
+				Protocol: headers.TransportProtocolTCP,
+				Delivery: func() *headers.TransportDelivery {
+					v := headers.TransportDeliveryUnicast
+					return &v
+				}(),
+				Mode: func() *headers.TransportMode {
+					v := headers.TransportModePlay
+					return &v
+				}(),
+				InterleavedIDs: &[2]int{0, 1},
+			}.Marshal(),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	var sx headers.Session
+	err = sx.Unmarshal(res.Header["Session"])
+	require.NoError(t, err)
+
+	// streams with readers are kept
+	time.Sleep(400 * time.Millisecond)
+	require.Equal(t, 1, streamCount())
+
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Teardown,
+		URL:    mustParseURL("rtsp://localhost:8554/test2.mp4"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"4"},
+			"Session": base.HeaderValue{sx.Session},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	// streams are closed when the last reader leaves
+	time.Sleep(400 * time.Millisecond)
+	require.Equal(t, 0, streamCount())
+}
+
+func TestServerVODShutdown(t *testing.T) {
+	content := testVODFileContent(t)
+
+	s := &Server{
+		Handler: &ServerVOD{
+			OpenFile: func(path string) (io.ReadSeekCloser, error) {
+				return testVODFile{bytes.NewReader(content)}, nil
+			},
+		},
+		RTSPAddress: "localhost:8554",
+	}
+
+	err := s.Start()
+	require.NoError(t, err)
+	defer s.Close()
+
+	nconn, err := net.Dial("tcp", "localhost:8554")
+	require.NoError(t, err)
+	defer nconn.Close()
+	conn := conn.NewConn(nconn)
+
+	res, err := writeReqReadRes(conn, base.Request{
+		Method: base.Describe,
+		URL:    mustParseURL("rtsp://localhost:8554/test.mp4"),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"1"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	var desc sdp.SessionDescription
+	err = desc.Unmarshal(res.Body)
+	require.NoError(t, err)
+
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Setup,
+		URL:    mustParseURL("rtsp://localhost:8554/test.mp4/" + controlAttribute(desc.MediaDescriptions[0])),
+		Header: base.Header{
+			"CSeq": base.HeaderValue{"2"},
+			"Transport": headers.Transport{
+				Protocol: headers.TransportProtocolTCP,
+				Delivery: func() *headers.TransportDelivery {
+					v := headers.TransportDeliveryUnicast
+					return &v
+				}(),
+				Mode: func() *headers.TransportMode {
+					v := headers.TransportModePlay
+					return &v
+				}(),
+				InterleavedIDs: &[2]int{0, 1},
+			}.Marshal(),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	var sx headers.Session
+	err = sx.Unmarshal(res.Header["Session"])
+	require.NoError(t, err)
+
+	res, err = writeReqReadRes(conn, base.Request{
+		Method: base.Play,
+		URL:    mustParseURL("rtsp://localhost:8554/test.mp4"),
+		Header: base.Header{
+			"CSeq":    base.HeaderValue{"3"},
+			"Session": base.HeaderValue{sx.Session},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, base.StatusOK, res.StatusCode)
+
+	seqNum, _ := readRTPInfo(t, res)
+	pkt := readPacketWithSeqNum(t, conn, nil, seqNum)
+
+	ctx, ctxCancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer ctxCancel()
+
+	shutdownDone := make(chan []*ServerSession)
+	go func() {
+		shutdownDone <- s.Shutdown(ctx)
+	}()
+
+	// the RTCP BYE packet contains the SSRC of the session
+	for {
+		f, err := conn.ReadInterleavedFrame()
+		require.NoError(t, err)
+
+		if f.Channel != 1 {
+			continue
+		}
+
+		pkts, err := rtcp.Unmarshal(f.Payload)
+		require.NoError(t, err)
+
+		if bye, ok := pkts[0].(*rtcp.Goodbye); ok {
+			require.Equal(t, []uint32{pkt.SSRC}, bye.Sources)
+			break
+		}
+	}
+
+	forceClosed := <-shutdownDone
+	require.Equal(t, 1, len(forceClosed))
+}